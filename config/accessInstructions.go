@@ -0,0 +1,36 @@
+package config
+
+import (
+	"encoding/base64"
+	"fmt"
+	"handworks-api/types"
+	"os"
+	"time"
+)
+
+const (
+	defaultAccessInstructionsWindowHours = 2
+	accessInstructionsKeyLength          = 32
+)
+
+// NewAccessInstructionsPolicy reads the base64 encoded 32-byte ACCESS_INSTRUCTIONS_KEY.
+// Without a key access instructions are disabled and cannot be saved or read.
+func NewAccessInstructionsPolicy() (types.AccessInstructionsPolicy, error) {
+	policy := types.AccessInstructionsPolicy{
+		CleanerWindow: time.Duration(envFloat("ACCESS_INSTRUCTIONS_CLEANER_WINDOW_HOURS", defaultAccessInstructionsWindowHours) * float64(time.Hour)),
+	}
+
+	raw := os.Getenv("ACCESS_INSTRUCTIONS_KEY")
+	if raw == "" {
+		return policy, nil
+	}
+	key, err := base64.StdEncoding.DecodeString(raw)
+	if err != nil {
+		return policy, fmt.Errorf("ACCESS_INSTRUCTIONS_KEY is not valid base64: %w", err)
+	}
+	if len(key) != accessInstructionsKeyLength {
+		return policy, fmt.Errorf("ACCESS_INSTRUCTIONS_KEY must be %d bytes, got %d", accessInstructionsKeyLength, len(key))
+	}
+	policy.Key = key
+	return policy, nil
+}
@@ -0,0 +1,51 @@
+package config
+
+import (
+	"fmt"
+	"handworks-api/types"
+	"os"
+	"time"
+	_ "time/tzdata"
+)
+
+const (
+	defaultBusinessOpenHour  = 8
+	defaultBusinessCloseHour = 20
+	defaultSlotMinutes       = 30
+	defaultBusinessTimeZone  = "Asia/Manila"
+)
+
+func NewBusinessHours() (types.BusinessHours, error) {
+	loc, err := newBusinessLocation()
+	if err != nil {
+		return types.BusinessHours{}, err
+	}
+	hours := types.BusinessHours{
+		OpenHour:    int(envFloat("BUSINESS_OPEN_HOUR", defaultBusinessOpenHour)),
+		CloseHour:   int(envFloat("BUSINESS_CLOSE_HOUR", defaultBusinessCloseHour)),
+		SlotMinutes: int(envFloat("AVAILABILITY_SLOT_MINUTES", defaultSlotMinutes)),
+		TimeZone:    loc.String(),
+		Location:    loc,
+	}
+	if hours.CloseHour <= hours.OpenHour || hours.CloseHour > 24 {
+		hours.OpenHour, hours.CloseHour = defaultBusinessOpenHour, defaultBusinessCloseHour
+	}
+	if hours.SlotMinutes <= 0 {
+		hours.SlotMinutes = defaultSlotMinutes
+	}
+	return hours, nil
+}
+
+// newBusinessLocation loads the time zone the business works in, so schedules and
+// calendars mean the same wall-clock time whatever zone the server runs in.
+func newBusinessLocation() (*time.Location, error) {
+	name := os.Getenv("BUSINESS_TIMEZONE")
+	if name == "" {
+		name = defaultBusinessTimeZone
+	}
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		return nil, fmt.Errorf("invalid BUSINESS_TIMEZONE %q: %w", name, err)
+	}
+	return loc, nil
+}
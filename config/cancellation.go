@@ -0,0 +1,31 @@
+package config
+
+import (
+	"handworks-api/types"
+	"os"
+	"strconv"
+)
+
+const (
+	defaultFullRefundHours         = 48
+	defaultDownpaymentForfeitHours = 24
+)
+
+func NewCancellationPolicy() types.CancellationPolicy {
+	return types.CancellationPolicy{
+		FullRefundHours:         envFloat("CANCELLATION_FULL_REFUND_HOURS", defaultFullRefundHours),
+		DownpaymentForfeitHours: envFloat("CANCELLATION_DOWNPAYMENT_FORFEIT_HOURS", defaultDownpaymentForfeitHours),
+	}
+}
+
+func envFloat(key string, fallback float64) float64 {
+	raw := os.Getenv(key)
+	if raw == "" {
+		return fallback
+	}
+	parsed, err := strconv.ParseFloat(raw, 64)
+	if err != nil || parsed < 0 {
+		return fallback
+	}
+	return parsed
+}
@@ -0,0 +1,40 @@
+package config
+
+import (
+	"handworks-api/types"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// Levels 1 and 2 are the usual state of a home; heavier levels cost and take longer.
+var (
+	defaultDirtyScalePriceMultipliers = []float64{1, 1, 1.15, 1.3, 1.5}
+	defaultDirtyScaleHourMultipliers  = []float64{1, 1, 1.1, 1.25, 1.5}
+)
+
+func NewDirtyScalePolicy() types.DirtyScalePolicy {
+	return types.DirtyScalePolicy{
+		PriceMultipliers: envMultipliers("DIRTY_SCALE_PRICE_MULTIPLIERS", defaultDirtyScalePriceMultipliers),
+		HourMultipliers:  envMultipliers("DIRTY_SCALE_HOUR_MULTIPLIERS", defaultDirtyScaleHourMultipliers),
+	}
+}
+
+// envMultipliers reads a comma-separated list such as "1,1,1.15,1.3,1.5". Lists with an
+// entry below 1 fall back to the default, so a dirtier home never costs less.
+func envMultipliers(key string, fallback []float64) []float64 {
+	raw := os.Getenv(key)
+	if raw == "" {
+		return fallback
+	}
+	parts := strings.Split(raw, ",")
+	multipliers := make([]float64, 0, len(parts))
+	for _, part := range parts {
+		parsed, err := strconv.ParseFloat(strings.TrimSpace(part), 64)
+		if err != nil || parsed < 1 {
+			return fallback
+		}
+		multipliers = append(multipliers, parsed)
+	}
+	return multipliers
+}
@@ -0,0 +1,15 @@
+package config
+
+import "handworks-api/types"
+
+const defaultGeofenceRadiusMeters = 200
+
+func NewGeofencePolicy() types.GeofencePolicy {
+	policy := types.GeofencePolicy{
+		RadiusMeters: envFloat("GEOFENCE_RADIUS_METERS", defaultGeofenceRadiusMeters),
+	}
+	if policy.RadiusMeters <= 0 {
+		policy.RadiusMeters = defaultGeofenceRadiusMeters
+	}
+	return policy
+}
@@ -0,0 +1,7 @@
+package config
+
+// NewLargeAreaRate returns the price per square metre above 100 SQM for general cleaning.
+// Zero, the default, means the business has not set one and such areas cannot be quoted.
+func NewLargeAreaRate() float32 {
+	return float32(envFloat("GENERAL_CLEANING_LARGE_AREA_RATE_PER_SQM", 0))
+}
@@ -0,0 +1,28 @@
+package config
+
+import (
+	"handworks-api/types"
+	"time"
+)
+
+const (
+	defaultLocationRetentionHours       = 12
+	defaultLocationMinPingSeconds       = 5
+	defaultLocationEnRouteWindowMinutes = 180
+)
+
+func NewLocationPolicy() types.LocationPolicy {
+	policy := types.LocationPolicy{
+		Retention:       time.Duration(envFloat("LOCATION_RETENTION_HOURS", defaultLocationRetentionHours) * float64(time.Hour)),
+		MinPingInterval: time.Duration(envFloat("LOCATION_MIN_PING_SECONDS", defaultLocationMinPingSeconds) * float64(time.Second)),
+		EnRouteWindow:   time.Duration(envFloat("LOCATION_EN_ROUTE_WINDOW_MINUTES", defaultLocationEnRouteWindowMinutes) * float64(time.Minute)),
+		AverageSpeedKPH: envFloat("TRAVEL_AVERAGE_SPEED_KPH", defaultTravelAverageSpeedKPH),
+	}
+	if policy.Retention <= 0 {
+		policy.Retention = defaultLocationRetentionHours * time.Hour
+	}
+	if policy.AverageSpeedKPH <= 0 {
+		policy.AverageSpeedKPH = defaultTravelAverageSpeedKPH
+	}
+	return policy
+}
@@ -0,0 +1,50 @@
+package config
+
+import (
+	"crypto/rand"
+	"handworks-api/types"
+	"os"
+	"strings"
+	"time"
+)
+
+const (
+	defaultMediaStorageDir     = "data/media"
+	defaultMediaMaxUploadMB    = 10
+	defaultMediaThumbnailSize  = 320
+	defaultMediaURLTTLMinutes  = 15
+	mediaGeneratedSecretLength = 32
+)
+
+// NewMediaPolicy reads where uploads are stored, their limits and the URL signing
+// settings. Without MEDIA_URL_SECRET a random secret is used, so signed URLs stop working
+// when the server restarts.
+func NewMediaPolicy() types.MediaPolicy {
+	secret := []byte(os.Getenv("MEDIA_URL_SECRET"))
+	if len(secret) == 0 {
+		secret = make([]byte, mediaGeneratedSecretLength)
+		_, _ = rand.Read(secret)
+	}
+
+	policy := types.MediaPolicy{
+		StorageDir:     os.Getenv("MEDIA_STORAGE_DIR"),
+		MaxUploadBytes: int64(envFloat("MEDIA_MAX_UPLOAD_MB", defaultMediaMaxUploadMB) * (1 << 20)),
+		ThumbnailSize:  int(envFloat("MEDIA_THUMBNAIL_SIZE", defaultMediaThumbnailSize)),
+		URLTTL:         time.Duration(envFloat("MEDIA_URL_TTL_MINUTES", defaultMediaURLTTLMinutes) * float64(time.Minute)),
+		URLSecret:      secret,
+		BaseURL:        strings.TrimRight(os.Getenv("MEDIA_PUBLIC_BASE_URL"), "/"),
+	}
+	if policy.StorageDir == "" {
+		policy.StorageDir = defaultMediaStorageDir
+	}
+	if policy.MaxUploadBytes <= 0 {
+		policy.MaxUploadBytes = defaultMediaMaxUploadMB << 20
+	}
+	if policy.ThumbnailSize <= 0 {
+		policy.ThumbnailSize = defaultMediaThumbnailSize
+	}
+	if policy.URLTTL <= 0 {
+		policy.URLTTL = defaultMediaURLTTLMinutes * time.Minute
+	}
+	return policy
+}
@@ -0,0 +1,30 @@
+package config
+
+import (
+	"handworks-api/types"
+	"time"
+)
+
+const (
+	defaultLateAfterMinutes    = 15
+	defaultNoShowAfterMinutes  = 60
+	defaultCustomerWaitMinutes = 20
+)
+
+func NewNoShowPolicy() types.NoShowPolicy {
+	policy := types.NoShowPolicy{
+		LateAfter:    time.Duration(envFloat("LATE_START_AFTER_MINUTES", defaultLateAfterMinutes) * float64(time.Minute)),
+		NoShowAfter:  time.Duration(envFloat("NO_SHOW_AFTER_MINUTES", defaultNoShowAfterMinutes) * float64(time.Minute)),
+		CustomerWait: time.Duration(envFloat("CUSTOMER_NO_SHOW_WAIT_MINUTES", defaultCustomerWaitMinutes) * float64(time.Minute)),
+	}
+	if policy.NoShowAfter <= policy.LateAfter {
+		policy.NoShowAfter = policy.LateAfter + (defaultNoShowAfterMinutes-defaultLateAfterMinutes)*time.Minute
+	}
+	// Long enough for a late booking to be classified, short enough that bookings from
+	// before the monitor ran are never picked up.
+	policy.Lookback = time.Duration(envFloat("NO_SHOW_LOOKBACK_MINUTES", 0) * float64(time.Minute))
+	if policy.Lookback <= policy.NoShowAfter {
+		policy.Lookback = 2 * policy.NoShowAfter
+	}
+	return policy
+}
@@ -0,0 +1,19 @@
+package config
+
+import "handworks-api/types"
+
+const (
+	defaultOvertimeToleranceMinutes = 15
+	defaultOvertimeIncrementMinutes = 30
+)
+
+func NewOvertimePolicy() types.OvertimePolicy {
+	policy := types.OvertimePolicy{
+		ToleranceMinutes: envFloat("OVERTIME_TOLERANCE_MINUTES", defaultOvertimeToleranceMinutes),
+		IncrementMinutes: envFloat("OVERTIME_INCREMENT_MINUTES", defaultOvertimeIncrementMinutes),
+	}
+	if policy.IncrementMinutes <= 0 {
+		policy.IncrementMinutes = defaultOvertimeIncrementMinutes
+	}
+	return policy
+}
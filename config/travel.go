@@ -0,0 +1,19 @@
+package config
+
+import (
+	"handworks-api/types"
+	"time"
+)
+
+const (
+	defaultTravelAverageSpeedKPH = 25
+	defaultTravelMinBufferMins   = 15
+)
+
+// NewTravelPolicy reads the straight-line travel model used by cleaner allocation.
+func NewTravelPolicy() types.TravelPolicy {
+	return types.TravelPolicy{
+		AverageSpeedKPH: envFloat("TRAVEL_AVERAGE_SPEED_KPH", defaultTravelAverageSpeedKPH),
+		MinBuffer:       time.Duration(envFloat("TRAVEL_MIN_BUFFER_MINUTES", defaultTravelMinBufferMins) * float64(time.Minute)),
+	}
+}
@@ -0,0 +1,11 @@
+package config
+
+import "time"
+
+const defaultWaitlistExpiryHours = 24
+
+// NewWaitlistExpiry returns how long a waitlist entry stays open before it expires.
+// Entries never outlive the slot they are waiting for.
+func NewWaitlistExpiry() time.Duration {
+	return time.Duration(envFloat("WAITLIST_EXPIRY_HOURS", defaultWaitlistExpiryHours) * float64(time.Hour))
+}
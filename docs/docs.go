@@ -73,7 +73,7 @@ const docTemplate = `{
                         "BearerAuth": []
                     }
                 ],
-                "description": "Save an address to an account for easier future bookings",
+                "description": "Save an address to an account for easier future bookings. Addresses outside every active service zone are refused",
                 "consumes": [
                     "application/json"
                 ],
@@ -170,7 +170,7 @@ const docTemplate = `{
                         "BearerAuth": []
                     }
                 ],
-                "description": "Update a saved account address by ID",
+                "description": "Update a saved account address by ID. Addresses outside every active service zone are refused",
                 "consumes": [
                     "application/json"
                 ],
@@ -745,7 +745,7 @@ const docTemplate = `{
                         "BearerAuth": []
                     }
                 ],
-                "description": "Record employee time-in for the current day",
+                "description": "Record employee time-in for the current day, along with the device location it was recorded from",
                 "consumes": [
                     "application/json"
                 ],
@@ -886,6 +886,110 @@ const docTemplate = `{
                 }
             }
         },
+        "/account/employee/{id}/availability": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Sends the recurring weekly windows an employee can work for admin approval. Once approved, cleaner allocation only uses the employee for jobs that fit inside one window. Weekday 0 is Sunday; times are HH:MM local time",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Account"
+                ],
+                "summary": "Submit a weekly availability schedule",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Employee ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Weekly windows",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/types.SubmitWeeklyAvailabilityRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/types.WeeklyAvailability"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/types.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/types.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/account/employee/{id}/calendar": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Returns the approved and pending weekly schedules and upcoming time off",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Account"
+                ],
+                "summary": "Get an employee's availability calendar",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Employee ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/types.EmployeeCalendar"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/types.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/types.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
         "/account/employee/{id}/performance": {
             "patch": {
                 "security": [
@@ -1002,6 +1106,123 @@ const docTemplate = `{
                 }
             }
         },
+        "/account/employee/{id}/time-off": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Asks for leave or another one-off stretch of unavailability. Approved time off keeps the employee out of cleaner allocation for that window",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Account"
+                ],
+                "summary": "Request time off",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Employee ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Time off window",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/types.RequestTimeOffRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/types.TimeOff"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/types.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/types.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/account/employee/{id}/time-off/{timeOffId}": {
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Withdraws a pending time-off request, or approved time off that has not ended yet",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Account"
+                ],
+                "summary": "Cancel time off",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Employee ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Time off ID",
+                        "name": "timeOffId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/types.TimeOff"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/types.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/types.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/types.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
         "/account/employee/{id}/{empId}": {
             "delete": {
                 "security": [
@@ -1267,6 +1488,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/types.ErrorResponse"
                         }
                     },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/types.ErrorResponse"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -1325,14 +1552,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/admin/dashboard": {
+        "/admin/booking/series/conflicts": {
             "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Fetch data for admin dashboard",
+                "description": "Returns upcoming series occurrences left in CONFLICT because not enough cleaners were free. They are retried on every generator run",
                 "consumes": [
                     "application/json"
                 ],
@@ -1342,33 +1569,12 @@ const docTemplate = `{
                 "tags": [
                     "Admin"
                 ],
-                "summary": "Fetch data for admin dashboard",
-                "parameters": [
-                    {
-                        "type": "string",
-                        "description": "Admin ID",
-                        "name": "adminId",
-                        "in": "query",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "Date filter (year, month, week)",
-                        "name": "dateFilter",
-                        "in": "query"
-                    }
-                ],
+                "summary": "Fetch recurring booking occurrences that could not be staffed",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/types.AdminDashboardResponse"
-                        }
-                    },
-                    "400": {
-                        "description": "Bad Request",
-                        "schema": {
-                            "$ref": "#/definitions/types.ErrorResponse"
+                            "$ref": "#/definitions/types.SeriesConflictsResponse"
                         }
                     },
                     "500": {
@@ -1380,56 +1586,42 @@ const docTemplate = `{
                 }
             }
         },
-        "/admin/employee/assign": {
-            "post": {
+        "/admin/booking/{id}/access-log": {
+            "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Adds or removes a cleaner from a booking after schedule conflict checks",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Lists every read, refused read and change of the booking's access instructions, newest first",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
                     "Admin"
                 ],
-                "summary": "Assign or unassign a cleaner to a booking",
+                "summary": "Get a booking's access instructions log",
                 "parameters": [
                     {
-                        "description": "Assign employee data",
-                        "name": "input",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/types.AssignEmployeeToBookingRequest"
-                        }
+                        "type": "string",
+                        "description": "Booking ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/types.AssignEmployeeToBookingResponse"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/types.AccessLogEntry"
+                            }
                         }
                     },
-                    "400": {
-                        "description": "Bad Request",
-                        "schema": {
-                            "$ref": "#/definitions/types.ErrorResponse"
-                        }
-                    },
-                    "409": {
-                        "description": "Conflict",
-                        "schema": {
-                            "$ref": "#/definitions/types.ErrorResponse"
-                        }
-                    },
-                    "500": {
-                        "description": "Internal Server Error",
+                    "500": {
+                        "description": "Internal Server Error",
                         "schema": {
                             "$ref": "#/definitions/types.ErrorResponse"
                         }
@@ -1437,44 +1629,37 @@ const docTemplate = `{
                 }
             }
         },
-        "/admin/employee/available": {
+        "/admin/checklists": {
             "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Returns cleaners with no overlapping non-cancelled responsibilities for the booking schedule",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Returns the active checklist templates, optionally for one service type",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
                     "Admin"
                 ],
-                "summary": "List available cleaners for a booking",
+                "summary": "List checklist templates",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Booking ID",
-                        "name": "bookingId",
-                        "in": "query",
-                        "required": true
+                        "description": "Service type",
+                        "name": "serviceType",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/types.AvailableCleanersResponse"
-                        }
-                    },
-                    "400": {
-                        "description": "Bad Request",
-                        "schema": {
-                            "$ref": "#/definitions/types.ErrorResponse"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/types.ChecklistTemplate"
+                            }
                         }
                     },
                     "500": {
@@ -1484,16 +1669,14 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/admin/employee/onboard": {
+            },
             "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Create a new employee account",
+                "description": "Adds an item to the checklist of a service type, either as the main service (MAIN) or as an add-on (ADDON). It is copied onto bookings whose session starts afterwards",
                 "consumes": [
                     "application/json"
                 ],
@@ -1503,23 +1686,23 @@ const docTemplate = `{
                 "tags": [
                     "Admin"
                 ],
-                "summary": "Onboard a new employee",
+                "summary": "Create a checklist template",
                 "parameters": [
                     {
-                        "description": "Employee onboard data",
+                        "description": "Checklist template",
                         "name": "input",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/types.OnboardEmployeeRequest"
+                            "$ref": "#/definitions/types.ChecklistTemplateRequest"
                         }
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "OK",
+                    "201": {
+                        "description": "Created",
                         "schema": {
-                            "$ref": "#/definitions/types.SignUpEmployeeResponse"
+                            "$ref": "#/definitions/types.ChecklistTemplate"
                         }
                     },
                     "400": {
@@ -1537,14 +1720,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/admin/inventory/assign-equipment": {
-            "post": {
+        "/admin/checklists/{id}": {
+            "put": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Admin override to manually assign equipment to a booking",
+                "description": "Changes apply to sessions started afterwards; existing booking checklists are not touched",
                 "consumes": [
                     "application/json"
                 ],
@@ -1554,15 +1737,22 @@ const docTemplate = `{
                 "tags": [
                     "Admin"
                 ],
-                "summary": "Assign equipment to a booking",
+                "summary": "Update a checklist template",
                 "parameters": [
                     {
-                        "description": "Assign equipment data",
+                        "type": "string",
+                        "description": "Template ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Checklist template",
                         "name": "input",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/types.AssignEquipmentToBookingRequest"
+                            "$ref": "#/definitions/types.ChecklistTemplateRequest"
                         }
                     }
                 ],
@@ -1570,7 +1760,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/types.AssignInventoryResponse"
+                            "$ref": "#/definitions/types.ChecklistTemplate"
                         }
                     },
                     "400": {
@@ -1586,42 +1776,38 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/admin/inventory/assign-resources": {
-            "post": {
+            },
+            "delete": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Admin override to manually assign resources (supplies) to a booking",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Stops the template from being added to new sessions",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
                     "Admin"
                 ],
-                "summary": "Assign resources to a booking",
+                "summary": "Delete a checklist template",
                 "parameters": [
                     {
-                        "description": "Assign resources data",
-                        "name": "input",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/types.AssignResourcesToBookingRequest"
-                        }
+                        "type": "string",
+                        "description": "Template ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/types.AssignInventoryResponse"
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
                         }
                     },
                     "400": {
@@ -1639,14 +1825,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/booking": {
+        "/admin/dashboard": {
             "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Retrieve booking",
+                "description": "Fetch data for admin dashboard",
                 "consumes": [
                     "application/json"
                 ],
@@ -1654,23 +1840,29 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Booking"
+                    "Admin"
                 ],
-                "summary": "Get booking with specific id",
+                "summary": "Fetch data for admin dashboard",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Booking ID",
-                        "name": "bookingId",
+                        "description": "Admin ID",
+                        "name": "adminId",
                         "in": "query",
                         "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Date filter (year, month, week)",
+                        "name": "dateFilter",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/types.Booking"
+                            "$ref": "#/definitions/types.AdminDashboardResponse"
                         }
                     },
                     "400": {
@@ -1686,14 +1878,16 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
+            }
+        },
+        "/admin/employee/assign": {
             "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Creates a booking record",
+                "description": "Adds or removes a cleaner from a booking after qualification, availability calendar and schedule conflict checks",
                 "consumes": [
                     "application/json"
                 ],
@@ -1701,17 +1895,17 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Booking"
+                    "Admin"
                 ],
-                "summary": "Create a new booking",
+                "summary": "Assign or unassign a cleaner to a booking",
                 "parameters": [
                     {
-                        "description": "Booking info",
+                        "description": "Assign employee data",
                         "name": "input",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/types.CreateBookingRequest"
+                            "$ref": "#/definitions/types.AssignEmployeeToBookingRequest"
                         }
                     }
                 ],
@@ -1719,7 +1913,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/types.Booking"
+                            "$ref": "#/definitions/types.AssignEmployeeToBookingResponse"
                         }
                     },
                     "400": {
@@ -1728,6 +1922,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/types.ErrorResponse"
                         }
                     },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/types.ErrorResponse"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -1737,9 +1937,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/booking/active": {
+        "/admin/employee/available": {
             "get": {
-                "description": "Returns ongoing bookings for a customer",
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Returns cleaners qualified for the booking's services whose approved availability calendar allows the booking schedule and who have no overlapping non-cancelled responsibilities",
                 "consumes": [
                     "application/json"
                 ],
@@ -1747,14 +1952,14 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Booking"
+                    "Admin"
                 ],
-                "summary": "Get customer active bookings",
+                "summary": "List available cleaners for a booking",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Customer ID",
-                        "name": "id",
+                        "description": "Booking ID",
+                        "name": "bookingId",
                         "in": "query",
                         "required": true
                     }
@@ -1763,16 +1968,13 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/types.FetchActiveBookingsResponse"
+                            "$ref": "#/definitions/types.AvailableCleanersResponse"
                         }
                     },
                     "400": {
                         "description": "Bad Request",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/types.ErrorResponse"
                         }
                     },
                     "500": {
@@ -1784,14 +1986,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/booking/bookings": {
-            "get": {
+        "/admin/employee/calendar/availability/{id}/review": {
+            "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Retrieve all bookings with optional date filtering and pagination",
+                "description": "Approving replaces the employee's current schedule for cleaner allocation",
                 "consumes": [
                     "application/json"
                 ],
@@ -1799,42 +2001,32 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Booking"
+                    "Admin"
                 ],
-                "summary": "Get all bookings with filters",
+                "summary": "Approve or reject a weekly availability schedule",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Start date (YYYY-MM-DD)",
-                        "name": "startDate",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "End date (YYYY-MM-DD)",
-                        "name": "endDate",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "default": 0,
-                        "description": "Page number",
-                        "name": "page",
-                        "in": "query"
+                        "description": "Schedule ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
                     },
                     {
-                        "type": "integer",
-                        "default": 10,
-                        "description": "Items per page",
-                        "name": "limit",
-                        "in": "query"
+                        "description": "Review decision",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/types.ReviewCalendarRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/types.FetchAllBookingsResponse"
+                            "$ref": "#/definitions/types.WeeklyAvailability"
                         }
                     },
                     "400": {
@@ -1843,6 +2035,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/types.ErrorResponse"
                         }
                     },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/types.ErrorResponse"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -1852,83 +2050,26 @@ const docTemplate = `{
                 }
             }
         },
-        "/booking/customer": {
+        "/admin/employee/calendar/pending": {
             "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Get bookings for a specific customer using query parameters",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Returns submitted weekly availability schedules and time-off requests that have not been approved or rejected yet",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Booking"
-                ],
-                "summary": "Get customer bookings",
-                "parameters": [
-                    {
-                        "type": "string",
-                        "description": "Customer ID",
-                        "name": "customerId",
-                        "in": "query",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "Booking Status",
-                        "name": "status",
-                        "in": "query",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "Start date (YYYY-MM-DD)",
-                        "name": "startDate",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "End date (YYYY-MM-DD)",
-                        "name": "endDate",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "default": 0,
-                        "description": "Page number",
-                        "name": "page",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "default": 10,
-                        "description": "Items per page",
-                        "name": "limit",
-                        "in": "query"
-                    }
+                    "Admin"
                 ],
+                "summary": "List calendar requests awaiting review",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/types.FetchAllBookingsResponse"
-                        }
-                    },
-                    "400": {
-                        "description": "Bad Request",
-                        "schema": {
-                            "$ref": "#/definitions/types.ErrorResponse"
-                        }
-                    },
-                    "401": {
-                        "description": "Unauthorized",
-                        "schema": {
-                            "$ref": "#/definitions/types.ErrorResponse"
+                            "$ref": "#/definitions/types.PendingCalendarRequestsResponse"
                         }
                     },
                     "500": {
@@ -1940,14 +2081,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/booking/employee": {
-            "get": {
+        "/admin/employee/calendar/time-off/{id}/review": {
+            "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Get bookings for a specific Employee using query parameters",
+                "description": "Approved time off keeps the employee out of cleaner allocation. Bookings they are already assigned to in that window are listed for reassignment",
                 "consumes": [
                     "application/json"
                 ],
@@ -1955,49 +2096,32 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Booking"
+                    "Admin"
                 ],
-                "summary": "Get Employee bookings",
+                "summary": "Approve or reject a time-off request",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Employee ID",
-                        "name": "employeeId",
-                        "in": "query",
+                        "description": "Time off ID",
+                        "name": "id",
+                        "in": "path",
                         "required": true
                     },
                     {
-                        "type": "string",
-                        "description": "Start date (YYYY-MM-DD)",
-                        "name": "startDate",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "End date (YYYY-MM-DD)",
-                        "name": "endDate",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "default": 0,
-                        "description": "Page number",
-                        "name": "page",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "default": 10,
-                        "description": "Items per page",
-                        "name": "limit",
-                        "in": "query"
+                        "description": "Review decision",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/types.ReviewCalendarRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/types.FetchAllBookingsResponse"
+                            "$ref": "#/definitions/types.ReviewTimeOffResponse"
                         }
                     },
                     "400": {
@@ -2006,8 +2130,8 @@ const docTemplate = `{
                             "$ref": "#/definitions/types.ErrorResponse"
                         }
                     },
-                    "401": {
-                        "description": "Unauthorized",
+                    "409": {
+                        "description": "Conflict",
                         "schema": {
                             "$ref": "#/definitions/types.ErrorResponse"
                         }
@@ -2021,14 +2145,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/booking/inventory-used": {
-            "get": {
+        "/admin/employee/onboard": {
+            "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Returns used inventory entries filtered by RESOURCE or EQUIPMENT type",
+                "description": "Create a new employee account",
                 "consumes": [
                     "application/json"
                 ],
@@ -2036,33 +2160,25 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Booking"
+                    "Admin"
                 ],
-                "summary": "Get used inventory items in a booking",
+                "summary": "Onboard a new employee",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "Booking ID",
-                        "name": "bookingId",
-                        "in": "query",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "Inventory type (RESOURCE or EQUIPMENT)",
-                        "name": "type",
-                        "in": "query",
-                        "required": true
+                        "description": "Employee onboard data",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/types.OnboardEmployeeRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/types.UsedInventoryItem"
-                            }
+                            "$ref": "#/definitions/types.SignUpEmployeeResponse"
                         }
                     },
                     "400": {
@@ -2080,9 +2196,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/booking/session/end": {
-            "post": {
-                "description": "Marks a booking as COMPLETED",
+        "/admin/employee/{id}/qualifications": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Returns the service types the employee can be allocated to. GENERAL_CLEANING is always included",
                 "consumes": [
                     "application/json"
                 ],
@@ -2090,28 +2211,23 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Booking"
+                    "Admin"
                 ],
-                "summary": "End a booking session",
+                "summary": "Get an employee's service qualifications",
                 "parameters": [
                     {
-                        "description": "End session payload",
-                        "name": "input",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/types.EndSessionRequest"
-                        }
+                        "type": "string",
+                        "description": "Employee ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/types.EmployeeQualificationsResponse"
                         }
                     },
                     "400": {
@@ -2127,11 +2243,14 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/booking/session/start": {
-            "post": {
-                "description": "Marks a booking as ONGOING",
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Sets the service types the employee is trained for. Cleaner allocation and manual assignment only use qualified cleaners for a booking's main service and addons",
                 "consumes": [
                     "application/json"
                 ],
@@ -2139,17 +2258,24 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Booking"
+                    "Admin"
                 ],
-                "summary": "Start a booking session",
+                "summary": "Replace an employee's service qualifications",
                 "parameters": [
                     {
-                        "description": "Start session payload",
+                        "type": "string",
+                        "description": "Employee ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Qualified service types",
                         "name": "input",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/types.StartSessionRequest"
+                            "$ref": "#/definitions/types.UpdateEmployeeQualificationsRequest"
                         }
                     }
                 ],
@@ -2157,10 +2283,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/types.EmployeeQualificationsResponse"
                         }
                     },
                     "400": {
@@ -2178,14 +2301,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/booking/slots": {
-            "get": {
+        "/admin/inventory/assign-equipment": {
+            "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Returns all occupied booking slots for the specified date. If no date is provided, defaults to today.",
+                "description": "Admin override to manually assign equipment to a booking",
                 "consumes": [
                     "application/json"
                 ],
@@ -2193,38 +2316,35 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Booking"
+                    "Admin"
                 ],
-                "summary": "Get booked slots by date",
+                "summary": "Assign equipment to a booking",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "Date in YYYY-MM-DD format (defaults to today)",
-                        "name": "date",
-                        "in": "query"
+                        "description": "Assign equipment data",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/types.AssignEquipmentToBookingRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/types.FetchSlotsResponse"
+                            "$ref": "#/definitions/types.AssignInventoryResponse"
                         }
                     },
                     "400": {
-                        "description": "Invalid date format",
-                        "schema": {
-                            "$ref": "#/definitions/types.ErrorResponse"
-                        }
-                    },
-                    "401": {
-                        "description": "Unauthorized",
+                        "description": "Bad Request",
                         "schema": {
                             "$ref": "#/definitions/types.ErrorResponse"
                         }
                     },
                     "500": {
-                        "description": "Internal server error",
+                        "description": "Internal Server Error",
                         "schema": {
                             "$ref": "#/definitions/types.ErrorResponse"
                         }
@@ -2232,14 +2352,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/booking/today": {
-            "get": {
+        "/admin/inventory/assign-resources": {
+            "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Retrieve all bookings scheduled for the current server date",
+                "description": "Admin override to manually assign resources (supplies) to a booking",
                 "consumes": [
                     "application/json"
                 ],
@@ -2247,14 +2367,31 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Booking"
+                    "Admin"
+                ],
+                "summary": "Assign resources to a booking",
+                "parameters": [
+                    {
+                        "description": "Assign resources data",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/types.AssignResourcesToBookingRequest"
+                        }
+                    }
                 ],
-                "summary": "Get all bookings for today",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/types.FetchBookingsTodayResponse"
+                            "$ref": "#/definitions/types.AssignInventoryResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/types.ErrorResponse"
                         }
                     },
                     "500": {
@@ -2266,62 +2403,73 @@ const docTemplate = `{
                 }
             }
         },
-        "/booking/{id}": {
-            "put": {
+        "/admin/reviews": {
+            "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Update booking information",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Returns reviews newest first, optionally only those with the given moderation status",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Booking"
+                    "Admin"
                 ],
-                "summary": "Update a booking",
+                "summary": "List booking reviews",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Booking ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "description": "PUBLISHED, HIDDEN or REJECTED",
+                        "name": "status",
+                        "in": "query"
                     },
                     {
-                        "description": "Updated booking info",
-                        "name": "input",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "type": "object",
-                            "additionalProperties": true
-                        }
+                        "type": "integer",
+                        "default": 0,
+                        "description": "Page number",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 10,
+                        "description": "Items per page",
+                        "name": "limit",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/types.FetchReviewsResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/types.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/types.ErrorResponse"
                         }
                     }
                 }
-            },
-            "delete": {
+            }
+        },
+        "/admin/reviews/{id}/moderate": {
+            "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Remove booking by ID",
+                "description": "HIDDEN keeps the review out of public listings but its ratings still count. REJECTED also removes its ratings from the cleaners' performance scores; moving it back restores them",
                 "consumes": [
                     "application/json"
                 ],
@@ -2329,65 +2477,32 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Booking"
+                    "Admin"
                 ],
-                "summary": "Delete a booking",
+                "summary": "Moderate a booking review",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Booking ID",
+                        "description": "Review ID",
                         "name": "id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "description": "Moderation decision",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/types.ModerateReviewRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
-                        }
-                    }
-                }
-            }
-        },
-        "/inventory": {
-            "post": {
-                "security": [
-                    {
-                        "BearerAuth": []
-                    }
-                ],
-                "description": "Adds a new item to inventory",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "Inventory"
-                ],
-                "summary": "Create a new inventory item",
-                "parameters": [
-                    {
-                        "description": "Item info",
-                        "name": "input",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/types.CreateItemRequest"
-                        }
-                    }
-                ],
-                "responses": {
-                    "200": {
-                        "description": "OK",
-                        "schema": {
-                            "$ref": "#/definitions/types.InventoryItem"
+                            "$ref": "#/definitions/types.BookingReview"
                         }
                     },
                     "400": {
@@ -2405,52 +2520,46 @@ const docTemplate = `{
                 }
             }
         },
-        "/inventory/": {
+        "/admin/zones": {
             "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Retrieve a single inventory item",
+                "description": "Returns every service zone, active ones first",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Inventory"
-                ],
-                "summary": "Get an item by ID",
-                "parameters": [
-                    {
-                        "type": "string",
-                        "description": "Item ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    }
+                    "Admin"
                 ],
+                "summary": "List service zones",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/types.InventoryItem"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/types.ServiceZone"
+                            }
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
+                    "500": {
+                        "description": "Internal Server Error",
                         "schema": {
                             "$ref": "#/definitions/types.ErrorResponse"
                         }
                     }
                 }
             },
-            "put": {
+            "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Modify fields of an existing inventory item",
+                "description": "Adds an area bookings are taken in. The boundary is a GeoJSON Polygon or MultiPolygon geometry with [longitude, latitude] positions. Quotes for addresses in the zone include its travel fee and must reach its minimum order value. Zones are active unless active is false",
                 "consumes": [
                     "application/json"
                 ],
@@ -2458,25 +2567,25 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Inventory"
+                    "Admin"
                 ],
-                "summary": "Update an inventory item",
+                "summary": "Create a service zone",
                 "parameters": [
                     {
-                        "description": "Updated item info",
+                        "description": "Service zone",
                         "name": "input",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/types.UpdateItemRequest"
+                            "$ref": "#/definitions/types.ServiceZoneRequest"
                         }
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "OK",
+                    "201": {
+                        "description": "Created",
                         "schema": {
-                            "$ref": "#/definitions/types.InventoryItem"
+                            "$ref": "#/definitions/types.ServiceZone"
                         }
                     },
                     "400": {
@@ -2494,60 +2603,47 @@ const docTemplate = `{
                 }
             }
         },
-        "/inventory/items": {
-            "get": {
+        "/admin/zones/{id}": {
+            "put": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Retrieve inventory items with optional filters and pagination",
+                "description": "Changes apply to new quotes and bookings. Set active to reactivate or deactivate the zone; it is left as is when omitted",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Inventory"
+                    "Admin"
                 ],
-                "summary": "Get inventory items",
+                "summary": "Update a service zone",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Item type",
-                        "name": "type",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "Item status",
-                        "name": "status",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "Item category",
-                        "name": "category",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "default": 0,
-                        "description": "Page number (zero-based)",
-                        "name": "page",
-                        "in": "query"
+                        "description": "Zone ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
                     },
                     {
-                        "type": "integer",
-                        "default": 10,
-                        "description": "Number of items per page",
-                        "name": "limit",
-                        "in": "query"
+                        "description": "Service zone",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/types.ServiceZoneRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/types.InventoryListResponse"
+                            "$ref": "#/definitions/types.ServiceZone"
                         }
                     },
                     "400": {
@@ -2556,40 +2652,32 @@ const docTemplate = `{
                             "$ref": "#/definitions/types.ErrorResponse"
                         }
                     },
-                    "401": {
-                        "description": "Unauthorized",
-                        "schema": {
-                            "$ref": "#/definitions/types.ErrorResponse"
-                        }
-                    },
-                    "404": {
-                        "description": "Not Found",
+                    "500": {
+                        "description": "Internal Server Error",
                         "schema": {
                             "$ref": "#/definitions/types.ErrorResponse"
                         }
                     }
                 }
-            }
-        },
-        "/inventory/{id}": {
+            },
             "delete": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Remove inventory item by ID",
+                "description": "Deactivates the zone so new addresses, quotes and bookings in it are refused. Existing bookings are kept",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Inventory"
+                    "Admin"
                 ],
-                "summary": "Delete an item",
+                "summary": "Delete a service zone",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Item ID",
+                        "description": "Zone ID",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -2599,12 +2687,18 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/types.InventoryItem"
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
                             }
                         }
                     },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/types.ErrorResponse"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -2614,14 +2708,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/notifications/subscribe": {
-            "post": {
+        "/booking": {
+            "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Subscribes an FCM token to admin or employee topic based on role",
+                "description": "Retrieve booking",
                 "consumes": [
                     "application/json"
                 ],
@@ -2629,25 +2723,23 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Notifications"
+                    "Booking"
                 ],
-                "summary": "Subscribe a device token to notification topics",
+                "summary": "Get booking with specific id",
                 "parameters": [
                     {
-                        "description": "Subscription request",
-                        "name": "input",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/types.SubscribeNotificationRequest"
-                        }
+                        "type": "string",
+                        "description": "Booking ID",
+                        "name": "bookingId",
+                        "in": "query",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/types.SubscribeNotificationResponse"
+                            "$ref": "#/definitions/types.Booking"
                         }
                     },
                     "400": {
@@ -2663,16 +2755,14 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/notifications/unsubscribe": {
+            },
             "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Deactivates token persistence and removes topic subscription for admin or employee role",
+                "description": "Creates a booking record. When the quote has a multi-day plan, one linked booking is created per consecutive day under the same order and returned in linkedBookings. The dirtyScale must be the one the quote was priced at and the address must be in the service zone it was quoted for. Returns 409 when the slot has no free cleaners; the order can then join the waitlist. Also returns 409 when the customer, or anyone at the same address, already has a booking overlapping the schedule, or the order is already booked at another time. Resubmitting the same order for the same schedule returns the booking already made for it",
                 "consumes": [
                     "application/json"
                 ],
@@ -2680,17 +2770,17 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Notifications"
+                    "Booking"
                 ],
-                "summary": "Unsubscribe a device token from notification topics",
+                "summary": "Create a new booking",
                 "parameters": [
                     {
-                        "description": "Unsubscription request",
+                        "description": "Booking info",
                         "name": "input",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/types.UnsubscribeNotificationRequest"
+                            "$ref": "#/definitions/types.CreateBookingRequest"
                         }
                     }
                 ],
@@ -2698,7 +2788,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/types.UnsubscribeNotificationResponse"
+                            "$ref": "#/definitions/types.Booking"
                         }
                     },
                     "400": {
@@ -2707,23 +2797,30 @@ const docTemplate = `{
                             "$ref": "#/definitions/types.ErrorResponse"
                         }
                     },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/types.ErrorResponse"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
                             "$ref": "#/definitions/types.ErrorResponse"
                         }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/types.ErrorResponse"
+                        }
                     }
                 }
             }
         },
-        "/payment/customer": {
+        "/booking/active": {
             "get": {
-                "security": [
-                    {
-                        "BearerAuth": []
-                    }
-                ],
-                "description": "Retrieve all payments for a specific customer with pagination",
+                "description": "Returns ongoing bookings for a customer",
                 "consumes": [
                     "application/json"
                 ],
@@ -2731,43 +2828,32 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Payment"
+                    "Booking"
                 ],
-                "summary": "Get payments by customer ID",
+                "summary": "Get customer active bookings",
                 "parameters": [
                     {
                         "type": "string",
                         "description": "Customer ID",
                         "name": "id",
-                        "in": "path",
+                        "in": "query",
                         "required": true
-                    },
-                    {
-                        "type": "integer",
-                        "default": 0,
-                        "description": "Page number (starting at 0)",
-                        "name": "page",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "default": 10,
-                        "description": "Number of payments per page",
-                        "name": "limit",
-                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/types.GetPaymentsResponse"
+                            "$ref": "#/definitions/types.FetchActiveBookingsResponse"
                         }
                     },
                     "400": {
                         "description": "Bad Request",
                         "schema": {
-                            "$ref": "#/definitions/types.ErrorResponse"
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
                         }
                     },
                     "500": {
@@ -2779,14 +2865,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/payment/customer/quotes": {
-            "get": {
+        "/booking/availability": {
+            "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Retrieve all quotations associated with a specific customer with optional date filtering and pagination.",
+                "description": "Returns the start times between startDate and endDate (inclusive, at most 14 days) where enough qualified cleaners are free and on their availability calendar for the requested services. Duration comes from the service pricing rules and every window stays inside business hours",
                 "consumes": [
                     "application/json"
                 ],
@@ -2794,23 +2880,67 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Payment"
+                    "Booking"
                 ],
-                "summary": "Get all quotations for a customer",
+                "summary": "Search bookable start times",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "Customer ID",
-                        "name": "customerId",
-                        "in": "query",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "Start date (YYYY-MM-DD)",
-                        "name": "startDate",
-                        "in": "query"
-                    },
+                        "description": "Services and date range",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/types.AvailabilityRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/types.AvailabilityResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/types.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/types.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/booking/bookings": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retrieve all bookings with optional date filtering and pagination",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Booking"
+                ],
+                "summary": "Get all bookings with filters",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Start date (YYYY-MM-DD)",
+                        "name": "startDate",
+                        "in": "query"
+                    },
                     {
                         "type": "string",
                         "description": "End date (YYYY-MM-DD)",
@@ -2820,14 +2950,14 @@ const docTemplate = `{
                     {
                         "type": "integer",
                         "default": 0,
-                        "description": "Page number (starting at 0)",
+                        "description": "Page number",
                         "name": "page",
                         "in": "query"
                     },
                     {
                         "type": "integer",
                         "default": 10,
-                        "description": "Number of quotes per page",
+                        "description": "Items per page",
                         "name": "limit",
                         "in": "query"
                     }
@@ -2836,7 +2966,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/types.FetchAllQuotesResponse"
+                            "$ref": "#/definitions/types.FetchAllBookingsResponse"
                         }
                     },
                     "400": {
@@ -2854,30 +2984,34 @@ const docTemplate = `{
                 }
             }
         },
-        "/payment/order": {
+        "/booking/calendar/feeds": {
             "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Retrieve a single order by its ID",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Lists an employee's or customer's calendar feeds, including revoked ones",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Payment"
+                    "Booking"
                 ],
-                "summary": "Get order by ID",
+                "summary": "List calendar feeds",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Order ID",
-                        "name": "id",
-                        "in": "path",
+                        "description": "EMPLOYEE or CUSTOMER",
+                        "name": "ownerType",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Employee or customer ID",
+                        "name": "ownerId",
+                        "in": "query",
                         "required": true
                     }
                 ],
@@ -2885,7 +3019,10 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/types.Order"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/types.CalendarFeed"
+                            }
                         }
                     },
                     "400": {
@@ -2894,8 +3031,8 @@ const docTemplate = `{
                             "$ref": "#/definitions/types.ErrorResponse"
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
+                    "403": {
+                        "description": "Forbidden",
                         "schema": {
                             "$ref": "#/definitions/types.ErrorResponse"
                         }
@@ -2914,7 +3051,7 @@ const docTemplate = `{
                         "BearerAuth": []
                     }
                 ],
-                "description": "Create a new order from an accepted quotation",
+                "description": "Issues a private ICS feed URL for an employee's assignments or a customer's bookings. Employees and customers can create feeds for themselves; admins for anyone. The URL is only shown once",
                 "consumes": [
                     "application/json"
                 ],
@@ -2922,17 +3059,17 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Payment"
+                    "Booking"
                 ],
-                "summary": "Create an order",
+                "summary": "Create a calendar feed",
                 "parameters": [
                     {
-                        "description": "Order details",
+                        "description": "Feed owner",
                         "name": "input",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/types.CreateOrderRequest"
+                            "$ref": "#/definitions/types.CreateCalendarFeedRequest"
                         }
                     }
                 ],
@@ -2940,7 +3077,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/types.CreateOrderResponse"
+                            "$ref": "#/definitions/types.CreateCalendarFeedResponse"
                         }
                     },
                     "400": {
@@ -2949,6 +3086,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/types.ErrorResponse"
                         }
                     },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/types.ErrorResponse"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -2958,52 +3101,38 @@ const docTemplate = `{
                 }
             }
         },
-        "/payment/order/customer": {
-            "get": {
+        "/booking/calendar/feeds/{id}": {
+            "delete": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Retrieve all orders for a specific customer with pagination",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Stops the feed's URL from working. Calendar apps subscribed to it stop receiving updates",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Payment"
+                    "Booking"
                 ],
-                "summary": "Get orders by customer ID",
+                "summary": "Revoke a calendar feed",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Customer ID",
+                        "description": "Feed ID",
                         "name": "id",
                         "in": "path",
                         "required": true
-                    },
-                    {
-                        "type": "integer",
-                        "default": 0,
-                        "description": "Page number (starting at 0)",
-                        "name": "page",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "default": 10,
-                        "description": "Number of orders per page",
-                        "name": "limit",
-                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/types.GetOrdersResponse"
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
                         }
                     },
                     "400": {
@@ -3012,6 +3141,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/types.ErrorResponse"
                         }
                     },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/types.ErrorResponse"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -3021,14 +3156,55 @@ const docTemplate = `{
                 }
             }
         },
-        "/payment/order/orders": {
+        "/booking/calendar/ics/{token}": {
+            "get": {
+                "description": "Public ICS feed of the owner's bookings. The token in the URL is the only credential",
+                "produces": [
+                    "text/calendar"
+                ],
+                "tags": [
+                    "Booking"
+                ],
+                "summary": "Calendar feed",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Feed token, optionally ending in .ics",
+                        "name": "token",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "ICS calendar",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/types.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/types.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/booking/customer": {
             "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Retrieve all orders with pagination",
+                "description": "Get bookings for a specific customer using query parameters",
                 "consumes": [
                     "application/json"
                 ],
@@ -3036,21 +3212,47 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Payment"
+                    "Booking"
                 ],
-                "summary": "Get all orders",
+                "summary": "Get customer bookings",
                 "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Customer ID",
+                        "name": "customerId",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Booking Status",
+                        "name": "status",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Start date (YYYY-MM-DD)",
+                        "name": "startDate",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "End date (YYYY-MM-DD)",
+                        "name": "endDate",
+                        "in": "query"
+                    },
                     {
                         "type": "integer",
                         "default": 0,
-                        "description": "Page number (starting at 0)",
+                        "description": "Page number",
                         "name": "page",
                         "in": "query"
                     },
                     {
                         "type": "integer",
                         "default": 10,
-                        "description": "Number of orders per page",
+                        "description": "Items per page",
                         "name": "limit",
                         "in": "query"
                     }
@@ -3059,7 +3261,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/types.GetOrdersResponse"
+                            "$ref": "#/definitions/types.FetchAllBookingsResponse"
                         }
                     },
                     "400": {
@@ -3068,6 +3270,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/types.ErrorResponse"
                         }
                     },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/types.ErrorResponse"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -3077,14 +3285,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/payment/payments/existing-downpayment": {
+        "/booking/employee": {
             "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Check whether an order already has an existing downpayment",
+                "description": "Get bookings for a specific Employee using query parameters",
                 "consumes": [
                     "application/json"
                 ],
@@ -3092,23 +3300,49 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Payment"
+                    "Booking"
                 ],
-                "summary": "Check existing downpayment",
+                "summary": "Get Employee bookings",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Order ID",
-                        "name": "orderId",
+                        "description": "Employee ID",
+                        "name": "employeeId",
                         "in": "query",
                         "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Start date (YYYY-MM-DD)",
+                        "name": "startDate",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "End date (YYYY-MM-DD)",
+                        "name": "endDate",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 0,
+                        "description": "Page number",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 10,
+                        "description": "Items per page",
+                        "name": "limit",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/types.ExistingDownpaymentResponse"
+                            "$ref": "#/definitions/types.FetchAllBookingsResponse"
                         }
                     },
                     "400": {
@@ -3117,6 +3351,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/types.ErrorResponse"
                         }
                     },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/types.ErrorResponse"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -3126,41 +3366,44 @@ const docTemplate = `{
                 }
             }
         },
-        "/payment/payments/intent/cash/{id}": {
+        "/booking/import": {
             "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Record a full cash payment for an order and update payment status in the database",
+                "description": "Admins only. Each row is a booking for an existing customer. Columns, matched by header name in any order: customerId, address, lat, lng, serviceType, serviceDetails (JSON details of the service, as stored on bookings), windowStart and windowEnd (RFC3339 preferred window), and optionally unit, addons (JSON array of {serviceType, details}), dirtyScale and paymentMethod. Every row is priced like a quote and given the first available slot that fits inside its window without overlapping other bookings of the same unit for the same customer or at the same coordinates, including earlier rows of the file. This is a dry run: nothing is booked, and the rows with their slots are kept as an import job to confirm with POST /booking/import/{id}/confirm",
                 "consumes": [
-                    "application/json"
+                    "multipart/form-data"
                 ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Payment"
+                    "Booking"
                 ],
-                "summary": "Record full cash payment",
+                "summary": "Import bookings from a CSV file",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "Order ID",
-                        "name": "id",
-                        "in": "path",
+                        "type": "file",
+                        "description": "CSV file",
+                        "name": "file",
+                        "in": "formData",
                         "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Payment method for rows that do not set one",
+                        "name": "paymentMethod",
+                        "in": "formData"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/types.BookingImportResponse"
                         }
                     },
                     "400": {
@@ -3169,6 +3412,18 @@ const docTemplate = `{
                             "$ref": "#/definitions/types.ErrorResponse"
                         }
                     },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/types.ErrorResponse"
+                        }
+                    },
+                    "413": {
+                        "description": "Request Entity Too Large",
+                        "schema": {
+                            "$ref": "#/definitions/types.ErrorResponse"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -3178,14 +3433,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/payment/payments/intent/downpayment/{id}": {
-            "post": {
+        "/booking/import/{id}": {
+            "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Create a PayMongo payment intent for the order's downpayment amount",
+                "description": "Admins only. Returns the report of an import: the slot, quote, order and booking of every row as far as the import has got",
                 "consumes": [
                     "application/json"
                 ],
@@ -3193,13 +3448,13 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Payment"
+                    "Booking"
                 ],
-                "summary": "Create downpayment payment intent",
+                "summary": "Get a booking import",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Order ID",
+                        "description": "Import ID",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -3209,11 +3464,17 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/types.PaymentIntentResponse"
+                            "$ref": "#/definitions/types.BookingImportResponse"
                         }
                     },
-                    "400": {
-                        "description": "Bad Request",
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/types.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
                         "schema": {
                             "$ref": "#/definitions/types.ErrorResponse"
                         }
@@ -3227,14 +3488,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/payment/payments/intent/fullpayment/{id}": {
+        "/booking/import/{id}/confirm": {
             "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Create a PayMongo payment intent for the order's full remaining balance",
+                "description": "Admins only. Starts booking the valid rows of an import in the background, in the slots the dry run gave them, and returns at once. Poll GET /booking/import/{id} for progress. Rows whose slot has been taken or has passed since the dry run fail. An import that stopped without finishing can be confirmed again after a few minutes; rows it was booking when it stopped are reported as failed and should be checked by hand",
                 "consumes": [
                     "application/json"
                 ],
@@ -3242,27 +3503,39 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Payment"
+                    "Booking"
                 ],
-                "summary": "Create full payment payment intent",
+                "summary": "Create the bookings of a validated import",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Order ID",
+                        "description": "Import ID",
                         "name": "id",
                         "in": "path",
                         "required": true
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "OK",
+                    "202": {
+                        "description": "Accepted",
                         "schema": {
-                            "$ref": "#/definitions/types.PaymentIntentResponse"
+                            "$ref": "#/definitions/types.BookingImportResponse"
                         }
                     },
-                    "400": {
-                        "description": "Bad Request",
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/types.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/types.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
                         "schema": {
                             "$ref": "#/definitions/types.ErrorResponse"
                         }
@@ -3276,14 +3549,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/payment/payments/intent/qrph-static": {
-            "post": {
+        "/booking/inventory-used": {
+            "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Create a PayMongo QRPH static code and return the generated QR image payload",
+                "description": "Returns used inventory entries filtered by RESOURCE or EQUIPMENT type",
                 "consumes": [
                     "application/json"
                 ],
@@ -3291,25 +3564,33 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Payment"
+                    "Booking"
                 ],
-                "summary": "Create QRPH static code",
+                "summary": "Get used inventory items in a booking",
                 "parameters": [
                     {
-                        "description": "QRPH code request",
-                        "name": "input",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/types.CreateQRPHCodeRequest"
-                        }
+                        "type": "string",
+                        "description": "Booking ID",
+                        "name": "bookingId",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Inventory type (RESOURCE or EQUIPMENT)",
+                        "name": "type",
+                        "in": "query",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/types.QRPHCodeResponse"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/types.UsedInventoryItem"
+                            }
                         }
                     },
                     "400": {
@@ -3327,52 +3608,34 @@ const docTemplate = `{
                 }
             }
         },
-        "/payment/payments/order": {
+        "/booking/review": {
             "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Retrieve all payments for a specific order with pagination",
-                "consumes": [
-                    "application/json"
-                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Payment"
+                    "Booking"
                 ],
-                "summary": "Get payments by order ID",
+                "summary": "Get a booking's review",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Order ID",
-                        "name": "id",
-                        "in": "path",
+                        "description": "Booking ID",
+                        "name": "bookingId",
+                        "in": "query",
                         "required": true
-                    },
-                    {
-                        "type": "integer",
-                        "default": 0,
-                        "description": "Page number (starting at 0)",
-                        "name": "page",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "default": 10,
-                        "description": "Number of payments per page",
-                        "name": "limit",
-                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/types.GetPaymentsResponse"
+                            "$ref": "#/definitions/types.BookingReview"
                         }
                     },
                     "400": {
@@ -3388,16 +3651,14 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/payment/quote": {
-            "get": {
+            },
+            "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Retrieves a quote by ID that belongs to a specific customer",
+                "description": "Rates a completed booking overall and, optionally, per cleaner. Assigned cleaners without their own rating get the overall one. Each rating is added to the cleaner's performance score. Only the booking's customer can review it",
                 "consumes": [
                     "application/json"
                 ],
@@ -3405,30 +3666,25 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Payment"
+                    "Booking"
                 ],
-                "summary": "Get quote by ID for a specific customer",
+                "summary": "Review a completed booking",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "Quote ID",
-                        "name": "quoteId",
-                        "in": "query",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "Customer ID",
-                        "name": "customerId",
-                        "in": "query",
-                        "required": true
+                        "description": "Review",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/types.SubmitReviewRequest"
+                        }
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "OK",
+                    "201": {
+                        "description": "Created",
                         "schema": {
-                            "$ref": "#/definitions/types.Quote"
+                            "$ref": "#/definitions/types.BookingReview"
                         }
                     },
                     "400": {
@@ -3437,8 +3693,14 @@ const docTemplate = `{
                             "$ref": "#/definitions/types.ErrorResponse"
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/types.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
                         "schema": {
                             "$ref": "#/definitions/types.ErrorResponse"
                         }
@@ -3450,14 +3712,16 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
+            }
+        },
+        "/booking/series": {
             "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Generate a new quotation for a customer",
+                "description": "Creates a weekly, bi-weekly or monthly series from a booking template. The first occurrence uses the given order, later occurrences get their own orders and are booked up to four weeks ahead. Access instructions in the template are kept encrypted and given to every booking of the series",
                 "consumes": [
                     "application/json"
                 ],
@@ -3465,17 +3729,17 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Payment"
+                    "Booking"
                 ],
-                "summary": "Create a quotation",
+                "summary": "Create a recurring booking series",
                 "parameters": [
                     {
-                        "description": "Quote details",
+                        "description": "Series info",
                         "name": "input",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/types.QuoteRequest"
+                            "$ref": "#/definitions/types.CreateBookingSeriesRequest"
                         }
                     }
                 ],
@@ -3483,7 +3747,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/types.QuoteResponse"
+                            "$ref": "#/definitions/types.BookingSeries"
                         }
                     },
                     "400": {
@@ -3497,13 +3761,24 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/types.ErrorResponse"
                         }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/types.ErrorResponse"
+                        }
                     }
                 }
             }
         },
-        "/payment/quote/preview": {
-            "post": {
-                "description": "Generate a new quotation",
+        "/booking/series/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Returns a series with every generated occurrence and its status",
                 "consumes": [
                     "application/json"
                 ],
@@ -3511,25 +3786,23 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Payment"
+                    "Booking"
                 ],
-                "summary": "Create a quotation",
+                "summary": "Get a recurring booking series",
                 "parameters": [
                     {
-                        "description": "Quote details",
-                        "name": "input",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/types.QuoteRequest"
-                        }
+                        "type": "string",
+                        "description": "Series ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/types.QuoteResponse"
+                            "$ref": "#/definitions/types.BookingSeries"
                         }
                     },
                     "400": {
@@ -3547,14 +3820,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/payment/quote/quotes": {
-            "get": {
+        "/booking/series/{id}/end": {
+            "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Retrieve all quotations with optional date filtering and pagination.",
+                "description": "Stops generating occurrences and cancels every future occurrence of the series",
                 "consumes": [
                     "application/json"
                 ],
@@ -3562,42 +3835,23 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Payment"
+                    "Booking"
                 ],
-                "summary": "Get all quotations",
+                "summary": "End a recurring booking series",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Start date (YYYY-MM-DD)",
-                        "name": "startDate",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "End date (YYYY-MM-DD)",
-                        "name": "endDate",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "default": 0,
-                        "description": "Page number (starting at 0)",
-                        "name": "page",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "default": 10,
-                        "description": "Number of quotes per page",
-                        "name": "limit",
-                        "in": "query"
+                        "description": "Series ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/types.FetchAllQuotesResponse"
+                            "$ref": "#/definitions/types.BookingSeries"
                         }
                     },
                     "400": {
@@ -3606,6 +3860,18 @@ const docTemplate = `{
                             "$ref": "#/definitions/types.ErrorResponse"
                         }
                     },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/types.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/types.ErrorResponse"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -3615,9 +3881,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/payment/webhooks/paymongo": {
+        "/booking/series/{id}/skip": {
             "post": {
-                "description": "Receives PayMongo webhook events and updates payment state based on event type",
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Skips the occurrence on the given date. A booking already created for it is cancelled under the cancellation policy",
                 "consumes": [
                     "application/json"
                 ],
@@ -3625,14 +3896,2859 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Payment"
+                    "Booking"
                 ],
-                "summary": "Handle PayMongo webhook events",
+                "summary": "Skip one occurrence of a series",
                 "parameters": [
                     {
-                        "description": "PayMongo webhook payload",
-                        "name": "payload",
-                        "in": "body",
+                        "type": "string",
+                        "description": "Series ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Occurrence date",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/types.SkipSeriesOccurrenceRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/types.BookingSeries"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/types.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/types.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/types.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/types.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/booking/session/end": {
+            "post": {
+                "description": "Marks a booking as COMPLETED and returns the time actually worked. Time beyond the quoted hours, past the overtime tolerance, is billed to the order at the extra-hour rate per cleaner. Refused with 409 while any required checklist item is neither done nor skipped",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Booking"
+                ],
+                "summary": "End a booking session",
+                "parameters": [
+                    {
+                        "description": "End session payload",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/types.EndSessionRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/types.SessionSummary"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/types.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/types.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/types.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/booking/session/no-show": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Closes a booking as NO_SHOW because the customer was not there. Cleaners on the booking can report it once the wait time past the start has passed and must send a location inside the booking's geofence; admins can report it at any time. It is settled like a cancellation at the start time and any remaining days of a multi-day job are cancelled",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Booking"
+                ],
+                "summary": "Report a customer no-show",
+                "parameters": [
+                    {
+                        "description": "Customer no-show payload",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/types.ReportCustomerNoShowRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/types.CancelBookingResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/types.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/types.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/types.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/types.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/booking/session/pause": {
+            "post": {
+                "description": "Stops the worked-time clock of an ongoing session until it is resumed",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Booking"
+                ],
+                "summary": "Pause a booking session",
+                "parameters": [
+                    {
+                        "description": "Pause session payload",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/types.PauseSessionRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/types.SessionSummary"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/types.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/types.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/types.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/booking/session/resume": {
+            "post": {
+                "description": "Restarts the worked-time clock of a paused session",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Booking"
+                ],
+                "summary": "Resume a paused booking session",
+                "parameters": [
+                    {
+                        "description": "Resume session payload",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/types.ResumeSessionRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/types.SessionSummary"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/types.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/types.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/types.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/booking/session/start": {
+            "post": {
+                "description": "Marks a booking as ONGOING and creates its checklist from the templates for its services. The device location is required and must be within the geofence radius of the booking address; admins can override this with overrideGeofence",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Booking"
+                ],
+                "summary": "Start a booking session",
+                "parameters": [
+                    {
+                        "description": "Start session payload",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/types.StartSessionRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/types.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/types.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/types.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/types.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/booking/session/{id}/checklist": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Returns the checklist items created for the booking when its session started, with the number of required items still outstanding",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Booking"
+                ],
+                "summary": "Get a booking's session checklist",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Booking ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/types.BookingChecklist"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/types.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/types.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/booking/session/{id}/checklist/{itemId}": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Updates an item of an ongoing session. Only the booking's cleaners and admins can update items. Skipping requires a reason",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Booking"
+                ],
+                "summary": "Tick, skip or reopen a checklist item",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Booking ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Checklist item ID",
+                        "name": "itemId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "New item status",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/types.UpdateChecklistItemRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/types.BookingChecklistItem"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/types.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/types.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/types.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/types.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/booking/session/{id}/summary": {
+            "get": {
+                "description": "Returns the session events, worked and paused time, and overtime against the quoted hours. For a running session the overtime is what ending it now would bill",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Booking"
+                ],
+                "summary": "Get a booking session's worked time",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Booking ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/types.SessionSummary"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/types.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/types.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/booking/slots": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Returns all occupied booking slots for the specified date. If no date is provided, defaults to today.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Booking"
+                ],
+                "summary": "Get booked slots by date",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Date in YYYY-MM-DD format (defaults to today)",
+                        "name": "date",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/types.FetchSlotsResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid date format",
+                        "schema": {
+                            "$ref": "#/definitions/types.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/types.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/types.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/booking/today": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retrieve all bookings scheduled for the current server date",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Booking"
+                ],
+                "summary": "Get all bookings for today",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/types.FetchBookingsTodayResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/types.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/booking/waitlist": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Queues a booking request whose slot has no free cleaners. The entry is booked automatically when a conflicting booking is cancelled or a cleaner becomes active, and expires after the configured waitlist time or at the slot's start. Access instructions are kept encrypted and given to the booking the entry becomes",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Booking"
+                ],
+                "summary": "Join the waitlist for a fully booked slot",
+                "parameters": [
+                    {
+                        "description": "Booking info",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/types.CreateBookingRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/types.WaitlistEntry"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/types.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/types.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/types.ErrorResponse"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/types.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/booking/waitlist/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Returns a waitlist entry with its status and, once promoted, the booking it became",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Booking"
+                ],
+                "summary": "Get a waitlist entry",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Waitlist entry ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/types.WaitlistEntry"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/types.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/types.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Cancels a waitlist entry that has not been promoted yet",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Booking"
+                ],
+                "summary": "Leave the waitlist",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Waitlist entry ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/types.WaitlistEntry"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/types.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/types.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/types.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/booking/{id}": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Moves a booking to a new schedule window. The current crew is kept when free, otherwise cleaners are reallocated and an approved booking returns to PENDING review. Only admins and the booking's customer can reschedule it",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Booking"
+                ],
+                "summary": "Reschedule a booking",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Booking ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "New schedule window",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/types.RescheduleBookingRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/types.RescheduleBookingResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/types.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/types.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/types.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/types.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Cancels a booking that has not started, together with the remaining days of a multi-day job. The refund follows the cancellation policy based on hours before startSched and covers only the cancelled days' share of the day plan; days already started stay on the order. Cleaners are freed and reserved inventory is restocked. Only admins and the booking's customer can cancel it",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Booking"
+                ],
+                "summary": "Cancel a booking",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Booking ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Cancellation reason",
+                        "name": "reason",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/types.CancelBookingResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/types.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/types.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/types.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/types.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/booking/{id}/access-instructions": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Admins can always read them. Cleaners assigned to the booking can read them from a configured window before the start until the booking ends. Every read and refused attempt is logged",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Booking"
+                ],
+                "summary": "Read a booking's access instructions",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Booking ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/types.AccessInstructionsResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/types.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/types.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/types.ErrorResponse"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/types.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Stores gate codes, lobby instructions and key locations encrypted. Only admins and the booking's customer can change them, and they apply to every day of a multi-day job",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Booking"
+                ],
+                "summary": "Set a booking's access instructions",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Booking ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Access instructions",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/types.AccessInstructions"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/types.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/types.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/types.ErrorResponse"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/types.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/booking/{id}/book-again": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Re-prices the main service and add-ons of a past booking at current rates and creates a new quote and an unpaid order for them. The returned booking request only needs startSched and endSched before it is sent to POST /booking. The payment method defaults to the one used for the past booking. Only admins and the booking's customer can book it again",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Booking"
+                ],
+                "summary": "Book a past booking again",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Booking ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Payment method for the new order",
+                        "name": "paymentMethod",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/types.BookAgainResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/types.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/types.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/types.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/booking/{id}/locations": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Returns the latest position, distance and ETA of each cleaner on the booking who is en route or on duty. Live updates are sent as cleaner.location events. Only admins and the booking's customer can see them",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Booking"
+                ],
+                "summary": "Get cleaner locations for a booking",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Booking ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/types.CleanerLocation"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/types.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/types.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/types.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/inventory": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Adds a new item to inventory",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Inventory"
+                ],
+                "summary": "Create a new inventory item",
+                "parameters": [
+                    {
+                        "description": "Item info",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/types.CreateItemRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/types.InventoryItem"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/types.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/types.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/inventory/": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retrieve a single inventory item",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Inventory"
+                ],
+                "summary": "Get an item by ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Item ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/types.InventoryItem"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/types.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Modify fields of an existing inventory item",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Inventory"
+                ],
+                "summary": "Update an inventory item",
+                "parameters": [
+                    {
+                        "description": "Updated item info",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/types.UpdateItemRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/types.InventoryItem"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/types.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/types.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/inventory/items": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retrieve inventory items with optional filters and pagination",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Inventory"
+                ],
+                "summary": "Get inventory items",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Item type",
+                        "name": "type",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Item status",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Item category",
+                        "name": "category",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 0,
+                        "description": "Page number (zero-based)",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 10,
+                        "description": "Number of items per page",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/types.InventoryListResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/types.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/types.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/types.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/inventory/rules": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retrieve allocation rules, optionally for a single service type",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Inventory"
+                ],
+                "summary": "Get inventory allocation rules",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Service type (GENERAL_CLEANING, COUCH, MATTRESS, CAR, POST)",
+                        "name": "serviceType",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/types.AllocationRulesResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/types.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Defines how much of an inventory item a service type needs. Bookings are allocated equipment and resources from these rules",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Inventory"
+                ],
+                "summary": "Create an inventory allocation rule",
+                "parameters": [
+                    {
+                        "description": "Rule info",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/types.CreateAllocationRuleRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/types.AllocationRule"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/types.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/types.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/inventory/rules/{id}": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Change the quantities of an allocation rule. Omitted fields are left unchanged",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Inventory"
+                ],
+                "summary": "Update an inventory allocation rule",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Rule ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Updated quantities",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/types.UpdateAllocationRuleRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/types.AllocationRule"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/types.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/types.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/types.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Remove an allocation rule by ID",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Inventory"
+                ],
+                "summary": "Delete an inventory allocation rule",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Rule ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/types.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/types.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/inventory/{id}": {
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Remove inventory item by ID",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Inventory"
+                ],
+                "summary": "Delete an item",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Item ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/types.InventoryItem"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/types.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/media": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Uploads a JPEG or PNG for use as a booking photo, a session photo or a profile picture. A profile picture belongs to the employee or customer uploading it. The file type is checked from its contents, EXIF/XMP metadata such as GPS location is removed and a thumbnail is generated. Use the returned ID in bookings and sessions; the URLs are signed and expire",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Media"
+                ],
+                "summary": "Upload a photo",
+                "parameters": [
+                    {
+                        "type": "file",
+                        "description": "Image file",
+                        "name": "file",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "BOOKING, SESSION or PROFILE",
+                        "name": "purpose",
+                        "in": "formData",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/types.Media"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/types.ErrorResponse"
+                        }
+                    },
+                    "413": {
+                        "description": "Request Entity Too Large",
+                        "schema": {
+                            "$ref": "#/definitions/types.ErrorResponse"
+                        }
+                    },
+                    "415": {
+                        "description": "Unsupported Media Type",
+                        "schema": {
+                            "$ref": "#/definitions/types.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/types.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/media/files/{id}": {
+            "get": {
+                "description": "Serves the file behind a signed media URL. Needs no bearer token; the signature and expiry in the URL grant access",
+                "produces": [
+                    "image/jpeg",
+                    "image/png"
+                ],
+                "tags": [
+                    "Media"
+                ],
+                "summary": "Download a photo",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Media ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "original or thumbnail",
+                        "name": "variant",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Expiry as a Unix timestamp",
+                        "name": "expires",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "URL signature",
+                        "name": "signature",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "file"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/types.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/types.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/types.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/media/resolve": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Resolves the media IDs stored on bookings and sessions, e.g. a booking's photos, to signed URLs in one call. Fails unless the caller may get every one of them, as for GET /media/{id}",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Media"
+                ],
+                "summary": "Get signed URLs for several photos",
+                "parameters": [
+                    {
+                        "description": "Media IDs",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/types.ResolveMediaRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/types.ResolveMediaResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/types.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/types.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/types.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/media/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Returns the media with freshly signed URLs. Admins can get any media; others only what they uploaded, their profile picture and the photos of bookings they are the customer or a cleaner of",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Media"
+                ],
+                "summary": "Get a photo",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Media ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/types.Media"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/types.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/types.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/types.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/notifications/subscribe": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Subscribes an FCM token to admin or employee topic based on role",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Notifications"
+                ],
+                "summary": "Subscribe a device token to notification topics",
+                "parameters": [
+                    {
+                        "description": "Subscription request",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/types.SubscribeNotificationRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/types.SubscribeNotificationResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/types.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/types.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/notifications/unsubscribe": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Deactivates token persistence and removes topic subscription for admin or employee role",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Notifications"
+                ],
+                "summary": "Unsubscribe a device token from notification topics",
+                "parameters": [
+                    {
+                        "description": "Unsubscription request",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/types.UnsubscribeNotificationRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/types.UnsubscribeNotificationResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/types.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/types.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/payment/customer": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retrieve all payments for a specific customer with pagination",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Payment"
+                ],
+                "summary": "Get payments by customer ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Customer ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "default": 0,
+                        "description": "Page number (starting at 0)",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 10,
+                        "description": "Number of payments per page",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/types.GetPaymentsResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/types.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/types.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/payment/customer/quotes": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retrieve all quotations associated with a specific customer with optional date filtering and pagination.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Payment"
+                ],
+                "summary": "Get all quotations for a customer",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Customer ID",
+                        "name": "customerId",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Start date (YYYY-MM-DD)",
+                        "name": "startDate",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "End date (YYYY-MM-DD)",
+                        "name": "endDate",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 0,
+                        "description": "Page number (starting at 0)",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 10,
+                        "description": "Number of quotes per page",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/types.FetchAllQuotesResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/types.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/types.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/payment/order": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retrieve a single order by its ID",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Payment"
+                ],
+                "summary": "Get order by ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Order ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/types.Order"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/types.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/types.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/types.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Create a new order from an accepted quotation",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Payment"
+                ],
+                "summary": "Create an order",
+                "parameters": [
+                    {
+                        "description": "Order details",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/types.CreateOrderRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/types.CreateOrderResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/types.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/types.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/payment/order/customer": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retrieve all orders for a specific customer with pagination",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Payment"
+                ],
+                "summary": "Get orders by customer ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Customer ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "default": 0,
+                        "description": "Page number (starting at 0)",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 10,
+                        "description": "Number of orders per page",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/types.GetOrdersResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/types.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/types.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/payment/order/orders": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retrieve all orders with pagination",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Payment"
+                ],
+                "summary": "Get all orders",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "default": 0,
+                        "description": "Page number (starting at 0)",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 10,
+                        "description": "Number of orders per page",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/types.GetOrdersResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/types.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/types.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/payment/payments/existing-downpayment": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Check whether an order already has an existing downpayment",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Payment"
+                ],
+                "summary": "Check existing downpayment",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Order ID",
+                        "name": "orderId",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/types.ExistingDownpaymentResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/types.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/types.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/payment/payments/intent/cash/{id}": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Record a full cash payment for an order and update payment status in the database",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Payment"
+                ],
+                "summary": "Record full cash payment",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Order ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/types.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/types.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/payment/payments/intent/downpayment/{id}": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Create a PayMongo payment intent for the order's downpayment amount",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Payment"
+                ],
+                "summary": "Create downpayment payment intent",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Order ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/types.PaymentIntentResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/types.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/types.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/payment/payments/intent/fullpayment/{id}": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Create a PayMongo payment intent for the order's full remaining balance",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Payment"
+                ],
+                "summary": "Create full payment payment intent",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Order ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/types.PaymentIntentResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/types.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/types.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/payment/payments/intent/qrph-static": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Create a PayMongo QRPH static code and return the generated QR image payload",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Payment"
+                ],
+                "summary": "Create QRPH static code",
+                "parameters": [
+                    {
+                        "description": "QRPH code request",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/types.CreateQRPHCodeRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/types.QRPHCodeResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/types.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/types.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/payment/payments/order": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retrieve all payments for a specific order with pagination",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Payment"
+                ],
+                "summary": "Get payments by order ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Order ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "default": 0,
+                        "description": "Page number (starting at 0)",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 10,
+                        "description": "Number of payments per page",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/types.GetPaymentsResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/types.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/types.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/payment/quote": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retrieves a quote by ID that belongs to a specific customer",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Payment"
+                ],
+                "summary": "Get quote by ID for a specific customer",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Quote ID",
+                        "name": "quoteId",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Customer ID",
+                        "name": "customerId",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/types.Quote"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/types.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/types.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/types.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Generate a new quotation for a customer. Jobs longer than the daily limit get a dayPlan splitting hours and price across consecutive days. A dirtyScale above the base levels adds a dirtyScaleSurcharge and dirtyScaleHours to the totals. The address must be inside an active service zone, whose travelFee is included in the total",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Payment"
+                ],
+                "summary": "Create a quotation",
+                "parameters": [
+                    {
+                        "description": "Quote details",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/types.QuoteRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/types.QuoteResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/types.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/types.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/payment/quote/preview": {
+            "post": {
+                "description": "Generate a new quotation. Jobs longer than the daily limit get a dayPlan splitting hours and price across consecutive days. A dirtyScale above the base levels adds a dirtyScaleSurcharge and dirtyScaleHours to the totals. The address must be inside an active service zone, whose travelFee is included in the total",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Payment"
+                ],
+                "summary": "Create a quotation",
+                "parameters": [
+                    {
+                        "description": "Quote details",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/types.QuoteRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/types.QuoteResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/types.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/types.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/payment/quote/quotes": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retrieve all quotations with optional date filtering and pagination.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Payment"
+                ],
+                "summary": "Get all quotations",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Start date (YYYY-MM-DD)",
+                        "name": "startDate",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "End date (YYYY-MM-DD)",
+                        "name": "endDate",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 0,
+                        "description": "Page number (starting at 0)",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 10,
+                        "description": "Number of quotes per page",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/types.FetchAllQuotesResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/types.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/types.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/payment/webhooks/paymongo": {
+            "post": {
+                "description": "Receives PayMongo webhook events and updates payment state based on event type",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Payment"
+                ],
+                "summary": "Handle PayMongo webhook events",
+                "parameters": [
+                    {
+                        "description": "PayMongo webhook payload",
+                        "name": "payload",
+                        "in": "body",
                         "required": true,
                         "schema": {
                             "$ref": "#/definitions/types.WebhookEvent"
@@ -3683,6 +6799,82 @@ const docTemplate = `{
                 }
             }
         },
+        "types.AccessInstructions": {
+            "type": "object",
+            "properties": {
+                "gateCode": {
+                    "type": "string"
+                },
+                "keyLocation": {
+                    "type": "string"
+                },
+                "lobbyInstructions": {
+                    "type": "string"
+                },
+                "notes": {
+                    "type": "string"
+                },
+                "parkingNotes": {
+                    "type": "string"
+                }
+            }
+        },
+        "types.AccessInstructionsResponse": {
+            "type": "object",
+            "properties": {
+                "bookingId": {
+                    "type": "string"
+                },
+                "instructions": {
+                    "$ref": "#/definitions/types.AccessInstructions"
+                },
+                "updatedAt": {
+                    "type": "string"
+                },
+                "updatedBy": {
+                    "type": "string"
+                }
+            }
+        },
+        "types.AccessLogAction": {
+            "type": "string",
+            "enum": [
+                "READ",
+                "WRITE",
+                "DENIED"
+            ],
+            "x-enum-varnames": [
+                "AccessLogRead",
+                "AccessLogWrite",
+                "AccessLogDenied"
+            ]
+        },
+        "types.AccessLogEntry": {
+            "type": "object",
+            "properties": {
+                "action": {
+                    "$ref": "#/definitions/types.AccessLogAction"
+                },
+                "actor": {
+                    "type": "string"
+                },
+                "at": {
+                    "type": "string"
+                },
+                "bookingId": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "reason": {
+                    "type": "string"
+                },
+                "viewerRole": {
+                    "type": "string"
+                }
+            }
+        },
         "types.Account": {
             "type": "object",
             "properties": {
@@ -3794,6 +6986,9 @@ const docTemplate = `{
                 },
                 "addressLng": {
                     "type": "number"
+                },
+                "unit": {
+                    "type": "string"
                 }
             }
         },
@@ -3883,6 +7078,55 @@ const docTemplate = `{
                 }
             }
         },
+        "types.AllocationRule": {
+            "type": "object",
+            "properties": {
+                "baseQuantity": {
+                    "type": "number"
+                },
+                "createdAt": {
+                    "type": "string"
+                },
+                "dirtyScaleFactor": {
+                    "type": "number"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "itemId": {
+                    "type": "string"
+                },
+                "itemName": {
+                    "type": "string"
+                },
+                "itemType": {
+                    "$ref": "#/definitions/types.ItemType"
+                },
+                "perSqm": {
+                    "type": "number"
+                },
+                "perUnit": {
+                    "type": "number"
+                },
+                "serviceType": {
+                    "$ref": "#/definitions/types.MainServiceType"
+                },
+                "updatedAt": {
+                    "type": "string"
+                }
+            }
+        },
+        "types.AllocationRulesResponse": {
+            "type": "object",
+            "properties": {
+                "rules": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/types.AllocationRule"
+                    }
+                }
+            }
+        },
         "types.AssignEmployeeAction": {
             "type": "string",
             "enum": [
@@ -3933,55 +7177,131 @@ const docTemplate = `{
                 "employeeId": {
                     "type": "string"
                 },
-                "message": {
+                "message": {
+                    "type": "string"
+                }
+            }
+        },
+        "types.AssignEquipmentToBookingRequest": {
+            "type": "object",
+            "required": [
+                "bookingId",
+                "equipment"
+            ],
+            "properties": {
+                "bookingId": {
+                    "type": "string"
+                },
+                "equipment": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/types.ItemQuantity"
+                    }
+                }
+            }
+        },
+        "types.AssignInventoryResponse": {
+            "type": "object",
+            "properties": {
+                "bookingId": {
+                    "type": "string"
+                },
+                "message": {
+                    "type": "string"
+                }
+            }
+        },
+        "types.AssignResourcesToBookingRequest": {
+            "type": "object",
+            "required": [
+                "bookingId",
+                "resources"
+            ],
+            "properties": {
+                "bookingId": {
+                    "type": "string"
+                },
+                "resources": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/types.ItemQuantity"
+                    }
+                }
+            }
+        },
+        "types.AvailabilityRequest": {
+            "type": "object",
+            "required": [
+                "endDate",
+                "service",
+                "startDate"
+            ],
+            "properties": {
+                "addons": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/types.AddOnRequest"
+                    }
+                },
+                "address": {
+                    "$ref": "#/definitions/types.Address"
+                },
+                "dirtyScale": {
+                    "type": "integer"
+                },
+                "endDate": {
+                    "description": "YYYY-MM-DD, inclusive",
+                    "type": "string"
+                },
+                "service": {
+                    "$ref": "#/definitions/types.ServicesRequest"
+                },
+                "startDate": {
+                    "description": "YYYY-MM-DD",
                     "type": "string"
                 }
             }
         },
-        "types.AssignEquipmentToBookingRequest": {
+        "types.AvailabilityResponse": {
             "type": "object",
-            "required": [
-                "bookingId",
-                "equipment"
-            ],
             "properties": {
-                "bookingId": {
-                    "type": "string"
+                "businessHours": {
+                    "$ref": "#/definitions/types.BusinessHours"
                 },
-                "equipment": {
+                "cleanersNeeded": {
+                    "type": "integer"
+                },
+                "serviceDays": {
+                    "type": "integer"
+                },
+                "serviceHours": {
+                    "type": "integer"
+                },
+                "slots": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/types.ItemQuantity"
+                        "$ref": "#/definitions/types.AvailableSlot"
                     }
                 }
             }
         },
-        "types.AssignInventoryResponse": {
-            "type": "object",
-            "properties": {
-                "bookingId": {
-                    "type": "string"
-                },
-                "message": {
-                    "type": "string"
-                }
-            }
-        },
-        "types.AssignResourcesToBookingRequest": {
+        "types.AvailabilityWindow": {
             "type": "object",
             "required": [
-                "bookingId",
-                "resources"
+                "endTime",
+                "startTime"
             ],
             "properties": {
-                "bookingId": {
+                "endTime": {
                     "type": "string"
                 },
-                "resources": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/types.ItemQuantity"
-                    }
+                "startTime": {
+                    "type": "string"
+                },
+                "weekday": {
+                    "type": "integer",
+                    "maximum": 6,
+                    "minimum": 0
                 }
             }
         },
@@ -4025,6 +7345,17 @@ const docTemplate = `{
                 }
             }
         },
+        "types.AvailableSlot": {
+            "type": "object",
+            "properties": {
+                "endSched": {
+                    "type": "string"
+                },
+                "startSched": {
+                    "type": "string"
+                }
+            }
+        },
         "types.BaseBookingDetails": {
             "type": "object",
             "properties": {
@@ -4090,6 +7421,9 @@ const docTemplate = `{
         "types.BaseBookingDetailsRequest": {
             "type": "object",
             "properties": {
+                "accessInstructions": {
+                    "$ref": "#/definitions/types.AccessInstructions"
+                },
                 "address": {
                     "$ref": "#/definitions/types.Address"
                 },
@@ -4140,103 +7474,397 @@ const docTemplate = `{
                 }
             }
         },
-        "types.BillingAddress": {
+        "types.BillingAddress": {
+            "type": "object",
+            "properties": {
+                "city": {
+                    "type": "string"
+                },
+                "country": {
+                    "type": "string"
+                },
+                "line1": {
+                    "type": "string"
+                },
+                "line2": {
+                    "type": "string"
+                },
+                "postal_code": {
+                    "type": "string"
+                },
+                "state": {
+                    "type": "string"
+                }
+            }
+        },
+        "types.BillingInfo": {
+            "type": "object",
+            "properties": {
+                "address": {
+                    "$ref": "#/definitions/types.BillingAddress"
+                },
+                "email": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "phone": {
+                    "type": "string"
+                }
+            }
+        },
+        "types.BookAgainResponse": {
+            "type": "object",
+            "properties": {
+                "booking": {
+                    "$ref": "#/definitions/types.CreateBookingRequest"
+                },
+                "order": {
+                    "$ref": "#/definitions/types.Order"
+                },
+                "quote": {
+                    "$ref": "#/definitions/types.QuoteResponse"
+                },
+                "sourceBookingId": {
+                    "type": "string"
+                }
+            }
+        },
+        "types.BookedSlot": {
+            "type": "object",
+            "properties": {
+                "bookingID": {
+                    "type": "string"
+                },
+                "durationHours": {
+                    "type": "number"
+                },
+                "endSched": {
+                    "type": "string"
+                },
+                "startSched": {
+                    "type": "string"
+                }
+            }
+        },
+        "types.Booking": {
+            "type": "object",
+            "properties": {
+                "addons": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/types.AddOns"
+                    }
+                },
+                "base": {
+                    "$ref": "#/definitions/types.BaseBookingDetails"
+                },
+                "cleaners": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/types.CleanerAssigned"
+                    }
+                },
+                "equipments": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/types.CleaningEquipment"
+                    }
+                },
+                "extraHourCost": {
+                    "type": "number"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "inventoryWarnings": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "linkedBookings": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/types.LinkedBookingDay"
+                    }
+                },
+                "mainService": {
+                    "$ref": "#/definitions/types.ServiceDetails"
+                },
+                "resources": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/types.CleaningResources"
+                    }
+                },
+                "totalPrice": {
+                    "type": "number"
+                }
+            }
+        },
+        "types.BookingChecklist": {
+            "type": "object",
+            "properties": {
+                "bookingId": {
+                    "type": "string"
+                },
+                "complete": {
+                    "type": "boolean"
+                },
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/types.BookingChecklistItem"
+                    }
+                },
+                "outstanding": {
+                    "description": "Outstanding counts required items that are neither done nor skipped.",
+                    "type": "integer"
+                }
+            }
+        },
+        "types.BookingChecklistItem": {
+            "type": "object",
+            "properties": {
+                "bookingId": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "position": {
+                    "type": "integer"
+                },
+                "required": {
+                    "type": "boolean"
+                },
+                "scope": {
+                    "$ref": "#/definitions/types.ChecklistScope"
+                },
+                "serviceType": {
+                    "$ref": "#/definitions/types.MainServiceType"
+                },
+                "skipReason": {
+                    "type": "string"
+                },
+                "status": {
+                    "$ref": "#/definitions/types.ChecklistItemStatus"
+                },
+                "templateId": {
+                    "type": "string"
+                },
+                "title": {
+                    "type": "string"
+                },
+                "updatedAt": {
+                    "type": "string"
+                },
+                "updatedBy": {
+                    "type": "string"
+                }
+            }
+        },
+        "types.BookingImportJobStatus": {
+            "type": "string",
+            "enum": [
+                "VALIDATED",
+                "RUNNING",
+                "COMPLETED"
+            ],
+            "x-enum-varnames": [
+                "BookingImportJobValidated",
+                "BookingImportJobRunning",
+                "BookingImportJobCompleted"
+            ]
+        },
+        "types.BookingImportResponse": {
+            "type": "object",
+            "properties": {
+                "created": {
+                    "type": "integer"
+                },
+                "createdAt": {
+                    "type": "string"
+                },
+                "creating": {
+                    "type": "integer"
+                },
+                "failed": {
+                    "type": "integer"
+                },
+                "invalid": {
+                    "type": "integer"
+                },
+                "jobId": {
+                    "type": "string"
+                },
+                "rows": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/types.BookingImportRowResult"
+                    }
+                },
+                "status": {
+                    "$ref": "#/definitions/types.BookingImportJobStatus"
+                },
+                "total": {
+                    "type": "integer"
+                },
+                "updatedAt": {
+                    "type": "string"
+                },
+                "valid": {
+                    "type": "integer"
+                }
+            }
+        },
+        "types.BookingImportRowResult": {
             "type": "object",
             "properties": {
-                "city": {
+                "bookingId": {
                     "type": "string"
                 },
-                "country": {
+                "customerId": {
                     "type": "string"
                 },
-                "line1": {
+                "endSched": {
                     "type": "string"
                 },
-                "line2": {
+                "error": {
                     "type": "string"
                 },
-                "postal_code": {
+                "line": {
+                    "type": "integer"
+                },
+                "orderId": {
                     "type": "string"
                 },
-                "state": {
+                "quote": {
+                    "$ref": "#/definitions/types.QuoteResponse"
+                },
+                "startSched": {
                     "type": "string"
+                },
+                "status": {
+                    "$ref": "#/definitions/types.BookingImportStatus"
                 }
             }
         },
-        "types.BillingInfo": {
+        "types.BookingImportStatus": {
+            "type": "string",
+            "enum": [
+                "VALID",
+                "INVALID",
+                "CREATING",
+                "CREATED",
+                "FAILED"
+            ],
+            "x-enum-varnames": [
+                "BookingImportValid",
+                "BookingImportInvalid",
+                "BookingImportCreating",
+                "BookingImportCreated",
+                "BookingImportFailed"
+            ]
+        },
+        "types.BookingReview": {
             "type": "object",
             "properties": {
-                "address": {
-                    "$ref": "#/definitions/types.BillingAddress"
+                "bookingId": {
+                    "type": "string"
                 },
-                "email": {
+                "cleanerRatings": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/types.CleanerRating"
+                    }
+                },
+                "comment": {
                     "type": "string"
                 },
-                "name": {
+                "createdAt": {
                     "type": "string"
                 },
-                "phone": {
+                "customerId": {
                     "type": "string"
-                }
-            }
-        },
-        "types.BookedSlot": {
-            "type": "object",
-            "properties": {
-                "bookingID": {
+                },
+                "id": {
                     "type": "string"
                 },
-                "durationHours": {
-                    "type": "number"
+                "moderatedAt": {
+                    "type": "string"
                 },
-                "endSched": {
+                "moderatedBy": {
                     "type": "string"
                 },
-                "startSched": {
+                "moderationNote": {
                     "type": "string"
+                },
+                "overallRating": {
+                    "type": "integer"
+                },
+                "status": {
+                    "$ref": "#/definitions/types.ReviewStatus"
                 }
             }
         },
-        "types.Booking": {
+        "types.BookingSeries": {
             "type": "object",
             "properties": {
-                "addons": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/types.AddOns"
-                    }
+                "addonTotal": {
+                    "type": "number"
                 },
-                "base": {
-                    "$ref": "#/definitions/types.BaseBookingDetails"
+                "anchorEnd": {
+                    "type": "string"
                 },
-                "cleaners": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/types.CleanerAssigned"
-                    }
+                "anchorStart": {
+                    "type": "string"
                 },
-                "equipments": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/types.CleaningEquipment"
-                    }
+                "createdAt": {
+                    "type": "string"
                 },
-                "extraHourCost": {
-                    "type": "number"
+                "customerId": {
+                    "type": "string"
+                },
+                "endDate": {
+                    "type": "string"
+                },
+                "endedAt": {
+                    "type": "string"
+                },
+                "frequency": {
+                    "$ref": "#/definitions/types.RecurrenceFrequency"
                 },
                 "id": {
                     "type": "string"
                 },
-                "mainService": {
-                    "$ref": "#/definitions/types.ServiceDetails"
+                "maxOccurrences": {
+                    "type": "integer"
                 },
-                "resources": {
+                "occurrences": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/types.CleaningResources"
+                        "$ref": "#/definitions/types.SeriesOccurrence"
                     }
                 },
-                "totalPrice": {
+                "paymentMethod": {
+                    "type": "string"
+                },
+                "quoteId": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "subtotal": {
+                    "type": "number"
+                },
+                "totalAmount": {
                     "type": "number"
                 }
             }
@@ -4269,6 +7897,23 @@ const docTemplate = `{
                 }
             }
         },
+        "types.BusinessHours": {
+            "type": "object",
+            "properties": {
+                "closeHour": {
+                    "type": "integer"
+                },
+                "openHour": {
+                    "type": "integer"
+                },
+                "slotMinutes": {
+                    "type": "integer"
+                },
+                "timeZone": {
+                    "type": "string"
+                }
+            }
+        },
         "types.CalendarBooking": {
             "type": "object",
             "required": [
@@ -4325,45 +7970,279 @@ const docTemplate = `{
                 }
             }
         },
+        "types.CalendarFeed": {
+            "type": "object",
+            "properties": {
+                "createdAt": {
+                    "type": "string"
+                },
+                "createdBy": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "lastAccessedAt": {
+                    "type": "string"
+                },
+                "ownerId": {
+                    "type": "string"
+                },
+                "ownerType": {
+                    "$ref": "#/definitions/types.CalendarFeedOwner"
+                },
+                "revokedAt": {
+                    "type": "string"
+                }
+            }
+        },
+        "types.CalendarFeedOwner": {
+            "type": "string",
+            "enum": [
+                "EMPLOYEE",
+                "CUSTOMER"
+            ],
+            "x-enum-varnames": [
+                "CalendarFeedEmployee",
+                "CalendarFeedCustomer"
+            ]
+        },
+        "types.CalendarRequestStatus": {
+            "type": "string",
+            "enum": [
+                "PENDING",
+                "APPROVED",
+                "REJECTED",
+                "CANCELLED",
+                "SUPERSEDED"
+            ],
+            "x-enum-varnames": [
+                "CalendarRequestPending",
+                "CalendarRequestApproved",
+                "CalendarRequestRejected",
+                "CalendarRequestCancelled",
+                "CalendarRequestSuperseded"
+            ]
+        },
+        "types.CancelBookingResponse": {
+            "type": "object",
+            "properties": {
+                "amountPaid": {
+                    "type": "number"
+                },
+                "bookingId": {
+                    "type": "string"
+                },
+                "cancellationFee": {
+                    "type": "number"
+                },
+                "hoursBeforeStart": {
+                    "type": "number"
+                },
+                "outcome": {
+                    "$ref": "#/definitions/types.CancellationOutcome"
+                },
+                "refundAmount": {
+                    "type": "number"
+                },
+                "status": {
+                    "type": "string"
+                }
+            }
+        },
+        "types.CancellationOutcome": {
+            "type": "string",
+            "enum": [
+                "FULL_REFUND",
+                "DOWNPAYMENT_FORFEITED",
+                "NO_REFUND"
+            ],
+            "x-enum-varnames": [
+                "CancellationFullRefund",
+                "CancellationDownpaymentForfeited",
+                "CancellationNoRefund"
+            ]
+        },
         "types.CarCleaningDetails": {
             "type": "object",
             "properties": {
                 "childSeats": {
                     "type": "integer"
                 },
-                "cleaningSpecs": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/types.CarCleaningSpecifications"
-                    }
+                "cleaningSpecs": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/types.CarCleaningSpecifications"
+                    }
+                }
+            }
+        },
+        "types.CarCleaningSpecifications": {
+            "type": "object",
+            "properties": {
+                "carType": {
+                    "type": "string"
+                },
+                "quantity": {
+                    "type": "integer"
+                }
+            }
+        },
+        "types.ChecklistItemStatus": {
+            "type": "string",
+            "enum": [
+                "PENDING",
+                "DONE",
+                "SKIPPED"
+            ],
+            "x-enum-varnames": [
+                "ChecklistItemPending",
+                "ChecklistItemDone",
+                "ChecklistItemSkipped"
+            ]
+        },
+        "types.ChecklistScope": {
+            "type": "string",
+            "enum": [
+                "MAIN",
+                "ADDON"
+            ],
+            "x-enum-varnames": [
+                "ChecklistScopeMain",
+                "ChecklistScopeAddon"
+            ]
+        },
+        "types.ChecklistTemplate": {
+            "type": "object",
+            "properties": {
+                "active": {
+                    "type": "boolean"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "position": {
+                    "type": "integer"
+                },
+                "required": {
+                    "type": "boolean"
+                },
+                "scope": {
+                    "$ref": "#/definitions/types.ChecklistScope"
+                },
+                "serviceType": {
+                    "$ref": "#/definitions/types.MainServiceType"
+                },
+                "title": {
+                    "type": "string"
+                }
+            }
+        },
+        "types.ChecklistTemplateRequest": {
+            "type": "object",
+            "required": [
+                "scope",
+                "serviceType",
+                "title"
+            ],
+            "properties": {
+                "description": {
+                    "type": "string"
+                },
+                "position": {
+                    "type": "integer"
+                },
+                "required": {
+                    "type": "boolean"
+                },
+                "scope": {
+                    "enum": [
+                        "MAIN",
+                        "ADDON"
+                    ],
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/types.ChecklistScope"
+                        }
+                    ]
+                },
+                "serviceType": {
+                    "$ref": "#/definitions/types.MainServiceType"
+                },
+                "title": {
+                    "type": "string"
                 }
             }
         },
-        "types.CarCleaningSpecifications": {
+        "types.CleanerAssigned": {
             "type": "object",
             "properties": {
-                "carType": {
+                "cleanerFirstName": {
                     "type": "string"
                 },
-                "quantity": {
-                    "type": "integer"
+                "cleanerLastName": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "pfpUrl": {
+                    "type": "string"
                 }
             }
         },
-        "types.CleanerAssigned": {
+        "types.CleanerLocation": {
             "type": "object",
             "properties": {
-                "cleanerFirstName": {
+                "accuracyMeters": {
+                    "type": "number"
+                },
+                "arrived": {
+                    "type": "boolean"
+                },
+                "bookingId": {
                     "type": "string"
                 },
-                "cleanerLastName": {
+                "customerId": {
                     "type": "string"
                 },
-                "id": {
+                "distanceMeters": {
+                    "type": "number"
+                },
+                "employeeId": {
                     "type": "string"
                 },
-                "pfpUrl": {
+                "etaSeconds": {
+                    "type": "integer"
+                },
+                "latitude": {
+                    "type": "number"
+                },
+                "longitude": {
+                    "type": "number"
+                },
+                "recordedAt": {
+                    "type": "string"
+                }
+            }
+        },
+        "types.CleanerRating": {
+            "type": "object",
+            "required": [
+                "employeeId",
+                "rating"
+            ],
+            "properties": {
+                "employeeId": {
                     "type": "string"
+                },
+                "rating": {
+                    "type": "integer",
+                    "maximum": 5,
+                    "minimum": 1
                 }
             }
         },
@@ -4470,6 +8349,33 @@ const docTemplate = `{
                 }
             }
         },
+        "types.CreateAllocationRuleRequest": {
+            "type": "object",
+            "required": [
+                "itemId",
+                "serviceType"
+            ],
+            "properties": {
+                "baseQuantity": {
+                    "type": "number"
+                },
+                "dirtyScaleFactor": {
+                    "type": "number"
+                },
+                "itemId": {
+                    "type": "string"
+                },
+                "perSqm": {
+                    "type": "number"
+                },
+                "perUnit": {
+                    "type": "number"
+                },
+                "serviceType": {
+                    "$ref": "#/definitions/types.MainServiceType"
+                }
+            }
+        },
         "types.CreateBookingRequest": {
             "type": "object",
             "properties": {
@@ -4493,6 +8399,61 @@ const docTemplate = `{
                 }
             }
         },
+        "types.CreateBookingSeriesRequest": {
+            "type": "object",
+            "required": [
+                "booking",
+                "frequency"
+            ],
+            "properties": {
+                "booking": {
+                    "$ref": "#/definitions/types.CreateBookingRequest"
+                },
+                "endDate": {
+                    "type": "string"
+                },
+                "frequency": {
+                    "$ref": "#/definitions/types.RecurrenceFrequency"
+                },
+                "maxOccurrences": {
+                    "type": "integer"
+                }
+            }
+        },
+        "types.CreateCalendarFeedRequest": {
+            "type": "object",
+            "required": [
+                "ownerId",
+                "ownerType"
+            ],
+            "properties": {
+                "ownerId": {
+                    "type": "string"
+                },
+                "ownerType": {
+                    "enum": [
+                        "EMPLOYEE",
+                        "CUSTOMER"
+                    ],
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/types.CalendarFeedOwner"
+                        }
+                    ]
+                }
+            }
+        },
+        "types.CreateCalendarFeedResponse": {
+            "type": "object",
+            "properties": {
+                "feed": {
+                    "$ref": "#/definitions/types.CalendarFeed"
+                },
+                "url": {
+                    "type": "string"
+                }
+            }
+        },
         "types.CreateItemRequest": {
             "type": "object",
             "required": [
@@ -4676,6 +8637,28 @@ const docTemplate = `{
                 }
             }
         },
+        "types.DeviceLocation": {
+            "type": "object",
+            "required": [
+                "latitude",
+                "longitude"
+            ],
+            "properties": {
+                "accuracyMeters": {
+                    "type": "number"
+                },
+                "latitude": {
+                    "type": "number",
+                    "maximum": 90,
+                    "minimum": -90
+                },
+                "longitude": {
+                    "type": "number",
+                    "maximum": 180,
+                    "minimum": -180
+                }
+            }
+        },
         "types.Employee": {
             "type": "object",
             "properties": {
@@ -4703,6 +8686,40 @@ const docTemplate = `{
                 }
             }
         },
+        "types.EmployeeCalendar": {
+            "type": "object",
+            "properties": {
+                "employeeId": {
+                    "type": "string"
+                },
+                "pendingSchedule": {
+                    "$ref": "#/definitions/types.WeeklyAvailability"
+                },
+                "upcomingTimeOff": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/types.TimeOff"
+                    }
+                },
+                "weeklySchedule": {
+                    "$ref": "#/definitions/types.WeeklyAvailability"
+                }
+            }
+        },
+        "types.EmployeeQualificationsResponse": {
+            "type": "object",
+            "properties": {
+                "employeeId": {
+                    "type": "string"
+                },
+                "qualifications": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/types.MainServiceType"
+                    }
+                }
+            }
+        },
         "types.EmployeeTimesheet": {
             "type": "object",
             "properties": {
@@ -4840,6 +8857,20 @@ const docTemplate = `{
                 }
             }
         },
+        "types.FetchReviewsResponse": {
+            "type": "object",
+            "properties": {
+                "reviews": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/types.BookingReview"
+                    }
+                },
+                "totalReviews": {
+                    "type": "integer"
+                }
+            }
+        },
         "types.FetchSlotsResponse": {
             "type": "object",
             "properties": {
@@ -5102,6 +9133,35 @@ const docTemplate = `{
                 "ItemTypeEquipment"
             ]
         },
+        "types.LinkedBookingDay": {
+            "type": "object",
+            "properties": {
+                "bookingId": {
+                    "type": "string"
+                },
+                "cleaners": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/types.CleanerAssigned"
+                    }
+                },
+                "dayIndex": {
+                    "type": "integer"
+                },
+                "endSched": {
+                    "type": "string"
+                },
+                "hours": {
+                    "type": "integer"
+                },
+                "price": {
+                    "type": "number"
+                },
+                "startSched": {
+                    "type": "string"
+                }
+            }
+        },
         "types.MainServiceType": {
             "type": "string",
             "enum": [
@@ -5152,6 +9212,102 @@ const docTemplate = `{
                 }
             }
         },
+        "types.Media": {
+            "type": "object",
+            "properties": {
+                "contentType": {
+                    "type": "string"
+                },
+                "createdAt": {
+                    "type": "string"
+                },
+                "height": {
+                    "type": "integer"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "ownerId": {
+                    "type": "string"
+                },
+                "purpose": {
+                    "$ref": "#/definitions/types.MediaPurpose"
+                },
+                "sizeBytes": {
+                    "type": "integer"
+                },
+                "thumbnailUrl": {
+                    "type": "string"
+                },
+                "uploadedBy": {
+                    "type": "string"
+                },
+                "url": {
+                    "type": "string"
+                },
+                "urlExpiresAt": {
+                    "type": "string"
+                },
+                "width": {
+                    "type": "integer"
+                }
+            }
+        },
+        "types.MediaPurpose": {
+            "type": "string",
+            "enum": [
+                "BOOKING",
+                "SESSION",
+                "PROFILE"
+            ],
+            "x-enum-varnames": [
+                "MediaPurposeBooking",
+                "MediaPurposeSession",
+                "MediaPurposeProfile"
+            ]
+        },
+        "types.ModerateReviewRequest": {
+            "type": "object",
+            "required": [
+                "status"
+            ],
+            "properties": {
+                "note": {
+                    "type": "string"
+                },
+                "status": {
+                    "enum": [
+                        "PUBLISHED",
+                        "HIDDEN",
+                        "REJECTED"
+                    ],
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/types.ReviewStatus"
+                        }
+                    ]
+                }
+            }
+        },
+        "types.OccurrenceStatus": {
+            "type": "string",
+            "enum": [
+                "PENDING",
+                "SCHEDULED",
+                "CONFLICT",
+                "FAILED",
+                "SKIPPED",
+                "CANCELLED"
+            ],
+            "x-enum-varnames": [
+                "OccurrencePending",
+                "OccurrenceScheduled",
+                "OccurrenceConflict",
+                "OccurrenceFailed",
+                "OccurrenceSkipped",
+                "OccurrenceCancelled"
+            ]
+        },
         "types.OnboardEmployeeRequest": {
             "type": "object",
             "required": [
@@ -5220,16 +9376,30 @@ const docTemplate = `{
                 "quote_id": {
                     "type": "string"
                 },
-                "remaining_balance": {
-                    "type": "number"
-                },
-                "subtotal": {
-                    "type": "number"
-                },
-                "total_amount": {
-                    "type": "number"
-                },
-                "updated_at": {
+                "remaining_balance": {
+                    "type": "number"
+                },
+                "subtotal": {
+                    "type": "number"
+                },
+                "total_amount": {
+                    "type": "number"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "types.PauseSessionRequest": {
+            "type": "object",
+            "required": [
+                "bookingId"
+            ],
+            "properties": {
+                "bookingId": {
+                    "type": "string"
+                },
+                "reason": {
                     "type": "string"
                 }
             }
@@ -5546,6 +9716,23 @@ const docTemplate = `{
                 }
             }
         },
+        "types.PendingCalendarRequestsResponse": {
+            "type": "object",
+            "properties": {
+                "schedules": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/types.WeeklyAvailability"
+                    }
+                },
+                "timeOff": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/types.TimeOff"
+                    }
+                }
+            }
+        },
         "types.PostConstructionDetails": {
             "type": "object",
             "properties": {
@@ -5634,6 +9821,21 @@ const docTemplate = `{
                 "customerId": {
                     "type": "string"
                 },
+                "dayPlan": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/types.ServiceDay"
+                    }
+                },
+                "dirtyScale": {
+                    "type": "integer"
+                },
+                "dirtyScaleHours": {
+                    "type": "integer"
+                },
+                "dirtyScaleSurcharge": {
+                    "type": "number"
+                },
                 "id": {
                     "type": "string"
                 },
@@ -5652,6 +9854,12 @@ const docTemplate = `{
                     "description": "added",
                     "type": "integer"
                 },
+                "serviceDays": {
+                    "type": "integer"
+                },
+                "serviceZoneId": {
+                    "type": "string"
+                },
                 "subtotal": {
                     "type": "number"
                 },
@@ -5662,6 +9870,9 @@ const docTemplate = `{
                     "description": "added",
                     "type": "integer"
                 },
+                "travelFee": {
+                    "type": "number"
+                },
                 "updatedAt": {
                     "type": "string"
                 }
@@ -5704,9 +9915,15 @@ const docTemplate = `{
                         "$ref": "#/definitions/types.AddOnRequest"
                     }
                 },
+                "address": {
+                    "$ref": "#/definitions/types.Address"
+                },
                 "customerId": {
                     "type": "string"
                 },
+                "dirtyScale": {
+                    "type": "integer"
+                },
                 "service": {
                     "description": "nested structs usually don't need db tags",
                     "allOf": [
@@ -5729,6 +9946,21 @@ const docTemplate = `{
                         "$ref": "#/definitions/types.AddOnBreakdown"
                     }
                 },
+                "dayPlan": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/types.ServiceDay"
+                    }
+                },
+                "dirtyScale": {
+                    "type": "integer"
+                },
+                "dirtyScaleHours": {
+                    "type": "integer"
+                },
+                "dirtyScaleSurcharge": {
+                    "type": "number"
+                },
                 "mainServiceDetail": {
                     "type": "object"
                 },
@@ -5739,16 +9971,26 @@ const docTemplate = `{
                     "type": "string"
                 },
                 "mainServiceTotal": {
+                    "description": "Price of the main service alone, before addons, the dirty scale surcharge and the travel fee. Saved quotes used to report the whole quote here; use totalPrice for that",
                     "type": "number"
                 },
                 "quoteId": {
                     "type": "string"
                 },
+                "serviceDays": {
+                    "type": "integer"
+                },
+                "serviceZoneId": {
+                    "type": "string"
+                },
                 "totalPrice": {
                     "type": "number"
                 },
                 "totalServiceHours": {
                     "type": "integer"
+                },
+                "travelFee": {
+                    "type": "number"
                 }
             }
         },
@@ -5769,6 +10011,168 @@ const docTemplate = `{
                 }
             }
         },
+        "types.RecurrenceFrequency": {
+            "type": "string",
+            "enum": [
+                "WEEKLY",
+                "BIWEEKLY",
+                "MONTHLY"
+            ],
+            "x-enum-varnames": [
+                "RecurrenceWeekly",
+                "RecurrenceBiWeekly",
+                "RecurrenceMonthly"
+            ]
+        },
+        "types.ReportCustomerNoShowRequest": {
+            "type": "object",
+            "required": [
+                "bookingId"
+            ],
+            "properties": {
+                "bookingId": {
+                    "type": "string"
+                },
+                "location": {
+                    "$ref": "#/definitions/types.DeviceLocation"
+                },
+                "notes": {
+                    "type": "string"
+                }
+            }
+        },
+        "types.RequestTimeOffRequest": {
+            "type": "object",
+            "required": [
+                "endAt",
+                "startAt"
+            ],
+            "properties": {
+                "endAt": {
+                    "type": "string"
+                },
+                "reason": {
+                    "type": "string"
+                },
+                "startAt": {
+                    "type": "string"
+                }
+            }
+        },
+        "types.RescheduleBookingRequest": {
+            "type": "object",
+            "required": [
+                "endSched",
+                "startSched"
+            ],
+            "properties": {
+                "endSched": {
+                    "type": "string"
+                },
+                "startSched": {
+                    "type": "string"
+                }
+            }
+        },
+        "types.RescheduleBookingResponse": {
+            "type": "object",
+            "properties": {
+                "bookingId": {
+                    "type": "string"
+                },
+                "cleanerIds": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "cleanersReassigned": {
+                    "type": "boolean"
+                },
+                "endSched": {
+                    "type": "string"
+                },
+                "reviewStatus": {
+                    "type": "string"
+                },
+                "startSched": {
+                    "type": "string"
+                }
+            }
+        },
+        "types.ResolveMediaRequest": {
+            "type": "object",
+            "required": [
+                "ids"
+            ],
+            "properties": {
+                "ids": {
+                    "type": "array",
+                    "maxItems": 50,
+                    "minItems": 1,
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "types.ResolveMediaResponse": {
+            "type": "object",
+            "properties": {
+                "media": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/types.Media"
+                    }
+                }
+            }
+        },
+        "types.ResumeSessionRequest": {
+            "type": "object",
+            "required": [
+                "bookingId"
+            ],
+            "properties": {
+                "bookingId": {
+                    "type": "string"
+                }
+            }
+        },
+        "types.ReviewCalendarRequest": {
+            "type": "object",
+            "properties": {
+                "approve": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "types.ReviewStatus": {
+            "type": "string",
+            "enum": [
+                "PUBLISHED",
+                "HIDDEN",
+                "REJECTED"
+            ],
+            "x-enum-varnames": [
+                "ReviewPublished",
+                "ReviewHidden",
+                "ReviewRejected"
+            ]
+        },
+        "types.ReviewTimeOffResponse": {
+            "type": "object",
+            "properties": {
+                "affectedBookingIds": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "timeOff": {
+                    "$ref": "#/definitions/types.TimeOff"
+                }
+            }
+        },
         "types.SavedAddress": {
             "type": "object",
             "properties": {
@@ -5789,6 +10193,101 @@ const docTemplate = `{
                 }
             }
         },
+        "types.SeriesConflict": {
+            "type": "object",
+            "properties": {
+                "customerFirstName": {
+                    "type": "string"
+                },
+                "customerId": {
+                    "type": "string"
+                },
+                "customerLastName": {
+                    "type": "string"
+                },
+                "endSched": {
+                    "type": "string"
+                },
+                "occurrenceId": {
+                    "type": "string"
+                },
+                "reason": {
+                    "type": "string"
+                },
+                "seriesId": {
+                    "type": "string"
+                },
+                "startSched": {
+                    "type": "string"
+                },
+                "updatedAt": {
+                    "type": "string"
+                }
+            }
+        },
+        "types.SeriesConflictsResponse": {
+            "type": "object",
+            "properties": {
+                "conflicts": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/types.SeriesConflict"
+                    }
+                },
+                "total": {
+                    "type": "integer"
+                }
+            }
+        },
+        "types.SeriesOccurrence": {
+            "type": "object",
+            "properties": {
+                "bookingId": {
+                    "type": "string"
+                },
+                "conflictReason": {
+                    "type": "string"
+                },
+                "endSched": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "occurrenceIndex": {
+                    "type": "integer"
+                },
+                "orderId": {
+                    "type": "string"
+                },
+                "seriesId": {
+                    "type": "string"
+                },
+                "startSched": {
+                    "type": "string"
+                },
+                "status": {
+                    "$ref": "#/definitions/types.OccurrenceStatus"
+                },
+                "updatedAt": {
+                    "type": "string"
+                }
+            }
+        },
+        "types.ServiceDay": {
+            "type": "object",
+            "properties": {
+                "dayIndex": {
+                    "type": "integer"
+                },
+                "hours": {
+                    "type": "integer"
+                },
+                "price": {
+                    "type": "number"
+                }
+            }
+        },
         "types.ServiceDetail": {
             "type": "object",
             "properties": {
@@ -5821,6 +10320,61 @@ const docTemplate = `{
                 }
             }
         },
+        "types.ServiceZone": {
+            "type": "object",
+            "properties": {
+                "active": {
+                    "type": "boolean"
+                },
+                "boundary": {
+                    "type": "object"
+                },
+                "createdAt": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "minOrderValue": {
+                    "type": "number"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "travelFee": {
+                    "type": "number"
+                },
+                "updatedAt": {
+                    "type": "string"
+                }
+            }
+        },
+        "types.ServiceZoneRequest": {
+            "type": "object",
+            "required": [
+                "boundary",
+                "name"
+            ],
+            "properties": {
+                "active": {
+                    "type": "boolean"
+                },
+                "boundary": {
+                    "type": "object"
+                },
+                "minOrderValue": {
+                    "type": "number",
+                    "minimum": 0
+                },
+                "name": {
+                    "type": "string"
+                },
+                "travelFee": {
+                    "type": "number",
+                    "minimum": 0
+                }
+            }
+        },
         "types.ServicesRequest": {
             "type": "object",
             "properties": {
@@ -5832,6 +10386,80 @@ const docTemplate = `{
                 }
             }
         },
+        "types.SessionEvent": {
+            "type": "object",
+            "properties": {
+                "actor": {
+                    "type": "string"
+                },
+                "at": {
+                    "type": "string"
+                },
+                "reason": {
+                    "type": "string"
+                },
+                "type": {
+                    "$ref": "#/definitions/types.SessionEventType"
+                }
+            }
+        },
+        "types.SessionEventType": {
+            "type": "string",
+            "enum": [
+                "START",
+                "PAUSE",
+                "RESUME",
+                "END"
+            ],
+            "x-enum-varnames": [
+                "SessionEventStart",
+                "SessionEventPause",
+                "SessionEventResume",
+                "SessionEventEnd"
+            ]
+        },
+        "types.SessionSummary": {
+            "type": "object",
+            "properties": {
+                "billedOvertimeHours": {
+                    "type": "number"
+                },
+                "bookingId": {
+                    "type": "string"
+                },
+                "cleaners": {
+                    "type": "integer"
+                },
+                "events": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/types.SessionEvent"
+                    }
+                },
+                "overtimeCharge": {
+                    "type": "number"
+                },
+                "overtimeHours": {
+                    "type": "number"
+                },
+                "paused": {
+                    "type": "boolean"
+                },
+                "pausedMinutes": {
+                    "type": "number"
+                },
+                "quotedHours": {
+                    "description": "Hours on the clock the crew was quoted for: the quote's service hours, which are crew-hours, shared across the cleaners, plus any extra hours",
+                    "type": "number"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "workedMinutes": {
+                    "type": "number"
+                }
+            }
+        },
         "types.SignUpAdminRequest": {
             "type": "object",
             "required": [
@@ -5957,16 +10585,35 @@ const docTemplate = `{
                 }
             }
         },
+        "types.SkipSeriesOccurrenceRequest": {
+            "type": "object",
+            "required": [
+                "occurrenceDate"
+            ],
+            "properties": {
+                "occurrenceDate": {
+                    "description": "YYYY-MM-DD",
+                    "type": "string"
+                }
+            }
+        },
         "types.StartSessionRequest": {
             "type": "object",
             "required": [
                 "bookingId",
+                "location",
                 "startPhotos"
             ],
             "properties": {
                 "bookingId": {
                     "type": "string"
                 },
+                "location": {
+                    "$ref": "#/definitions/types.DeviceLocation"
+                },
+                "overrideGeofence": {
+                    "type": "boolean"
+                },
                 "startPhotos": {
                     "type": "array",
                     "minItems": 1,
@@ -5976,6 +10623,47 @@ const docTemplate = `{
                 }
             }
         },
+        "types.SubmitReviewRequest": {
+            "type": "object",
+            "required": [
+                "bookingId",
+                "overallRating"
+            ],
+            "properties": {
+                "bookingId": {
+                    "type": "string"
+                },
+                "cleanerRatings": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/types.CleanerRating"
+                    }
+                },
+                "comment": {
+                    "type": "string"
+                },
+                "overallRating": {
+                    "type": "integer",
+                    "maximum": 5,
+                    "minimum": 1
+                }
+            }
+        },
+        "types.SubmitWeeklyAvailabilityRequest": {
+            "type": "object",
+            "required": [
+                "windows"
+            ],
+            "properties": {
+                "windows": {
+                    "type": "array",
+                    "minItems": 1,
+                    "items": {
+                        "$ref": "#/definitions/types.AvailabilityWindow"
+                    }
+                }
+            }
+        },
         "types.SubscribeNotificationRequest": {
             "type": "object",
             "required": [
@@ -6022,15 +10710,53 @@ const docTemplate = `{
         },
         "types.TimeInRequest": {
             "type": "object",
+            "required": [
+                "location"
+            ],
             "properties": {
                 "employee_id": {
                     "type": "string"
                 },
+                "location": {
+                    "$ref": "#/definitions/types.DeviceLocation"
+                },
                 "time_in": {
                     "type": "string"
                 }
             }
         },
+        "types.TimeOff": {
+            "type": "object",
+            "properties": {
+                "employeeId": {
+                    "type": "string"
+                },
+                "endAt": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "reason": {
+                    "type": "string"
+                },
+                "requestedAt": {
+                    "type": "string"
+                },
+                "reviewedAt": {
+                    "type": "string"
+                },
+                "reviewedBy": {
+                    "type": "string"
+                },
+                "startAt": {
+                    "type": "string"
+                },
+                "status": {
+                    "$ref": "#/definitions/types.CalendarRequestStatus"
+                }
+            }
+        },
         "types.TimeOutRequest": {
             "type": "object",
             "properties": {
@@ -6123,6 +10849,46 @@ const docTemplate = `{
                 }
             }
         },
+        "types.UpdateAllocationRuleRequest": {
+            "type": "object",
+            "properties": {
+                "baseQuantity": {
+                    "type": "number"
+                },
+                "dirtyScaleFactor": {
+                    "type": "number"
+                },
+                "perSqm": {
+                    "type": "number"
+                },
+                "perUnit": {
+                    "type": "number"
+                }
+            }
+        },
+        "types.UpdateChecklistItemRequest": {
+            "type": "object",
+            "required": [
+                "status"
+            ],
+            "properties": {
+                "skipReason": {
+                    "type": "string"
+                },
+                "status": {
+                    "enum": [
+                        "PENDING",
+                        "DONE",
+                        "SKIPPED"
+                    ],
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/types.ChecklistItemStatus"
+                        }
+                    ]
+                }
+            }
+        },
         "types.UpdateCustomerRequest": {
             "type": "object",
             "required": [
@@ -6154,6 +10920,20 @@ const docTemplate = `{
                 }
             }
         },
+        "types.UpdateEmployeeQualificationsRequest": {
+            "type": "object",
+            "required": [
+                "qualifications"
+            ],
+            "properties": {
+                "qualifications": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/types.MainServiceType"
+                    }
+                }
+            }
+        },
         "types.UpdateEmployeeRequest": {
             "type": "object",
             "required": [
@@ -6283,6 +11063,56 @@ const docTemplate = `{
                 }
             }
         },
+        "types.WaitlistEntry": {
+            "type": "object",
+            "properties": {
+                "bookingId": {
+                    "type": "string"
+                },
+                "createdAt": {
+                    "type": "string"
+                },
+                "customerId": {
+                    "type": "string"
+                },
+                "endSched": {
+                    "type": "string"
+                },
+                "expiresAt": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "orderId": {
+                    "type": "string"
+                },
+                "startSched": {
+                    "type": "string"
+                },
+                "status": {
+                    "$ref": "#/definitions/types.WaitlistStatus"
+                },
+                "updatedAt": {
+                    "type": "string"
+                }
+            }
+        },
+        "types.WaitlistStatus": {
+            "type": "string",
+            "enum": [
+                "WAITING",
+                "PROMOTED",
+                "EXPIRED",
+                "CANCELLED"
+            ],
+            "x-enum-varnames": [
+                "WaitlistWaiting",
+                "WaitlistPromoted",
+                "WaitlistExpired",
+                "WaitlistCancelled"
+            ]
+        },
         "types.WebhookEvent": {
             "type": "object",
             "properties": {
@@ -6336,6 +11166,35 @@ const docTemplate = `{
                     "type": "string"
                 }
             }
+        },
+        "types.WeeklyAvailability": {
+            "type": "object",
+            "properties": {
+                "employeeId": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "reviewedAt": {
+                    "type": "string"
+                },
+                "reviewedBy": {
+                    "type": "string"
+                },
+                "status": {
+                    "$ref": "#/definitions/types.CalendarRequestStatus"
+                },
+                "submittedAt": {
+                    "type": "string"
+                },
+                "windows": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/types.AvailabilityWindow"
+                    }
+                }
+            }
         }
     },
     "securityDefinitions": {
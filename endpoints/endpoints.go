@@ -32,6 +32,10 @@ func AccountEndpoint(r *gin.RouterGroup, h *handlers.AccountHandler) {
 		employee.PUT("/", h.UpdateEmployee)
 		employee.PUT("/:id/performance", h.UpdateEmployeePerformanceScore)
 		employee.PUT("/:id/status", h.UpdateEmployeeStatus)
+		employee.GET("/:id/calendar", h.GetEmployeeCalendar)
+		employee.PUT("/:id/availability", h.SubmitWeeklyAvailability)
+		employee.POST("/:id/time-off", h.RequestTimeOff)
+		employee.DELETE("/:id/time-off/:timeOffId", h.CancelTimeOff)
 		employee.DELETE("/:id/:empId", h.DeleteEmployee)
 	}
 	admin := r.Group("/admin")
@@ -61,6 +65,13 @@ func InventoryEndpoint(r *gin.RouterGroup, h *handlers.InventoryHandler) {
 	r.GET("/items", h.GetItems)
 	r.PUT("/", h.UpdateItem)
 	r.DELETE("/:id", h.DeleteItem)
+	rules := r.Group("/rules")
+	{
+		rules.GET("", h.GetAllocationRules)
+		rules.POST("", h.CreateAllocationRule)
+		rules.PUT("/:id", h.UpdateAllocationRule)
+		rules.DELETE("/:id", h.DeleteAllocationRule)
+	}
 }
 func BookingEndpoint(r *gin.RouterGroup, h *handlers.BookingHandler) {
 	r.GET("/", h.GetBookingById)
@@ -68,10 +79,18 @@ func BookingEndpoint(r *gin.RouterGroup, h *handlers.BookingHandler) {
 	r.GET("/bookings", h.GetBookings)
 	r.GET("/today", h.GetBookingsToday)
 	r.GET("/slots", h.GetBookedSlots)
+	r.POST("/availability", h.SearchAvailability)
+	r.POST("/import", h.ImportBookings)
+	r.GET("/import/:id", h.GetBookingImport)
+	r.POST("/import/:id/confirm", h.ConfirmBookingImport)
 	r.GET("/active", h.GetActiveBooking)
 	r.POST("/", h.CreateBooking)
 	r.PUT("/:id", h.UpdateBooking)
 	r.DELETE("/:id", h.DeleteBooking)
+	r.GET("/:id/access-instructions", h.GetAccessInstructions)
+	r.PUT("/:id/access-instructions", h.SetAccessInstructions)
+	r.GET("/:id/locations", h.GetBookingLocations)
+	r.POST("/:id/book-again", h.BookAgain)
 	customers := r.Group("/customer")
 	{
 		customers.GET("/", h.GetCustomerBookings)
@@ -84,6 +103,37 @@ func BookingEndpoint(r *gin.RouterGroup, h *handlers.BookingHandler) {
 	{
 		session.POST("/start", h.StartSession)
 		session.POST("/end", h.EndSession)
+		session.POST("/pause", h.PauseSession)
+		session.POST("/resume", h.ResumeSession)
+		session.POST("/no-show", h.ReportCustomerNoShow)
+		session.GET("/:id/summary", h.GetSessionSummary)
+		session.GET("/:id/checklist", h.GetBookingChecklist)
+		session.PUT("/:id/checklist/:itemId", h.UpdateChecklistItem)
+	}
+	series := r.Group("/series")
+	{
+		series.POST("/", h.CreateBookingSeries)
+		series.GET("/:id", h.GetBookingSeries)
+		series.POST("/:id/skip", h.SkipSeriesOccurrence)
+		series.POST("/:id/end", h.EndBookingSeries)
+	}
+	waitlist := r.Group("/waitlist")
+	{
+		waitlist.POST("/", h.JoinWaitlist)
+		waitlist.GET("/:id", h.GetWaitlistEntry)
+		waitlist.DELETE("/:id", h.LeaveWaitlist)
+	}
+	review := r.Group("/review")
+	{
+		review.POST("", h.SubmitReview)
+		review.GET("", h.GetBookingReview)
+	}
+	calendar := r.Group("/calendar")
+	{
+		calendar.POST("/feeds", h.CreateCalendarFeed)
+		calendar.GET("/feeds", h.GetCalendarFeeds)
+		calendar.DELETE("/feeds/:id", h.RevokeCalendarFeed)
+		calendar.GET("/ics/:token", h.ServeCalendarFeed)
 	}
 }
 func PaymentEndpoint(r *gin.RouterGroup, h *handlers.PaymentHandler) {
@@ -133,11 +183,40 @@ func AdminEndpoint(r *gin.RouterGroup, h *handlers.AdminHandler) {
 		employees.POST("/onboard", h.OnboardEmployee)
 		employees.POST("/assign", h.AssignEmployeeToBooking)
 		employees.GET("/available", h.ListAvailableCleaners)
+		employees.GET("/:id/qualifications", h.GetEmployeeQualifications)
+		employees.PUT("/:id/qualifications", h.UpdateEmployeeQualifications)
+		calendar := employees.Group("/calendar")
+		{
+			calendar.GET("/pending", h.GetPendingCalendarRequests)
+			calendar.POST("/availability/:id/review", h.ReviewWeeklyAvailability)
+			calendar.POST("/time-off/:id/review", h.ReviewTimeOff)
+		}
 	}
 	bookings := r.Group("/booking")
 	{
 		bookings.GET("/calendar", h.GetCalendarBookings)
 		bookings.POST("/approve/:id", h.AcceptBooking)
+		bookings.GET("/series/conflicts", h.GetSeriesConflicts)
+		bookings.GET("/:id/access-log", h.GetAccessLog)
+	}
+	checklists := r.Group("/checklists")
+	{
+		checklists.GET("", h.GetChecklistTemplates)
+		checklists.POST("", h.CreateChecklistTemplate)
+		checklists.PUT("/:id", h.UpdateChecklistTemplate)
+		checklists.DELETE("/:id", h.DeleteChecklistTemplate)
+	}
+	zones := r.Group("/zones")
+	{
+		zones.GET("", h.GetServiceZones)
+		zones.POST("", h.CreateServiceZone)
+		zones.PUT("/:id", h.UpdateServiceZone)
+		zones.DELETE("/:id", h.DeleteServiceZone)
+	}
+	reviews := r.Group("/reviews")
+	{
+		reviews.GET("", h.GetReviews)
+		reviews.POST("/:id/moderate", h.ModerateReview)
 	}
 	inventory := r.Group("/inventory")
 	{
@@ -146,9 +225,16 @@ func AdminEndpoint(r *gin.RouterGroup, h *handlers.AdminHandler) {
 	}
 }
 
-func RealtimeEndpoint(r *gin.RouterGroup, hubs *realtime.RealtimeHubs) {
+func MediaEndpoint(r *gin.RouterGroup, h *handlers.MediaHandler) {
+	r.POST("", h.UploadMedia)
+	r.POST("/resolve", h.ResolveMedia)
+	r.GET("/:id", h.GetMedia)
+	r.GET("/files/:id", h.ServeMedia)
+}
+
+func RealtimeEndpoint(r *gin.RouterGroup, hubs *realtime.RealtimeHubs, resolveEmployee realtime.EmployeeResolver) {
 	r.GET("/ws/admin", realtime.AdminWS(hubs.AdminHub))
-	r.GET("/ws/employee", realtime.EmployeeWS(hubs.EmployeeHub))
+	r.GET("/ws/employee", realtime.EmployeeWS(hubs.EmployeeHub, resolveEmployee))
 	r.GET("/ws/chat", realtime.ChatWS(hubs.ChatHub))
 }
 
@@ -0,0 +1,115 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"handworks-api/tasks"
+	"handworks-api/types"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// SetAccessInstructions godoc
+// @Summary Set a booking's access instructions
+// @Description Stores gate codes, lobby instructions and key locations encrypted. Only admins and the booking's customer can change them, and they apply to every day of a multi-day job
+// @Tags Booking
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param id path string true "Booking ID"
+// @Param input body types.AccessInstructions true "Access instructions"
+// @Success 200 {object} map[string]string
+// @Failure 400 {object} types.ErrorResponse
+// @Failure 403 {object} types.ErrorResponse
+// @Failure 500 {object} types.ErrorResponse
+// @Failure 503 {object} types.ErrorResponse
+// @Router /booking/{id}/access-instructions [put]
+func (h *BookingHandler) SetAccessInstructions(c *gin.Context) {
+	bookingID := c.Param("id")
+	var req types.AccessInstructions
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, types.NewErrorResponse(err))
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	if err := h.Service.SetAccessInstructions(ctx, bookingID, req, requestActor(c)); err != nil {
+		c.JSON(accessInstructionsErrorStatus(err), types.NewErrorResponse(err))
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"bookingId": bookingID, "status": "saved"})
+}
+
+// GetAccessInstructions godoc
+// @Summary Read a booking's access instructions
+// @Description Admins can always read them. Cleaners assigned to the booking can read them from a configured window before the start until the booking ends. Every read and refused attempt is logged
+// @Tags Booking
+// @Security BearerAuth
+// @Produce json
+// @Param id path string true "Booking ID"
+// @Success 200 {object} types.AccessInstructionsResponse
+// @Failure 400 {object} types.ErrorResponse
+// @Failure 403 {object} types.ErrorResponse
+// @Failure 500 {object} types.ErrorResponse
+// @Failure 503 {object} types.ErrorResponse
+// @Router /booking/{id}/access-instructions [get]
+func (h *BookingHandler) GetAccessInstructions(c *gin.Context) {
+	bookingID := c.Param("id")
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	res, err := h.Service.GetAccessInstructions(ctx, bookingID, requestActor(c))
+	if err != nil {
+		c.JSON(accessInstructionsErrorStatus(err), types.NewErrorResponse(err))
+		return
+	}
+
+	c.JSON(http.StatusOK, res)
+}
+
+// GetAccessLog godoc
+// @Summary Get a booking's access instructions log
+// @Description Lists every read, refused read and change of the booking's access instructions, newest first
+// @Tags Admin
+// @Security BearerAuth
+// @Produce json
+// @Param id path string true "Booking ID"
+// @Success 200 {array} types.AccessLogEntry
+// @Failure 500 {object} types.ErrorResponse
+// @Router /admin/booking/{id}/access-log [get]
+func (h *AdminHandler) GetAccessLog(c *gin.Context) {
+	bookingID := c.Param("id")
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	res, err := h.Service.GetAccessLog(ctx, bookingID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, types.NewErrorResponse(err))
+		return
+	}
+
+	c.JSON(http.StatusOK, res)
+}
+
+func accessInstructionsErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, tasks.ErrBookingNotFound),
+		errors.Is(err, tasks.ErrAccessInstructionsNotFound),
+		errors.Is(err, tasks.ErrInvalidAccessInstructions):
+		return http.StatusBadRequest
+	case errors.Is(err, tasks.ErrAccessInstructionsDenied),
+		errors.Is(err, tasks.ErrAccessInstructionsNotYet):
+		return http.StatusForbidden
+	case errors.Is(err, tasks.ErrAccessInstructionsDisabled):
+		return http.StatusServiceUnavailable
+	default:
+		return http.StatusInternalServerError
+	}
+}
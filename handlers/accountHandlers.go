@@ -392,7 +392,7 @@ func (h *AccountHandler) DeleteEmployee(c *gin.Context) {
 
 // EmployeeTimeIn godoc
 // @Summary Employee time in
-// @Description Record employee time-in for the current day
+// @Description Record employee time-in for the current day, along with the device location it was recorded from
 // @Security BearerAuth
 // @Tags Account
 // @Accept json
@@ -478,7 +478,7 @@ func (h *AccountHandler) TimesheetToday(c *gin.Context) {
 
 // CreateAddress godoc
 // @Summary Save a customer address
-// @Description Save an address to an account for easier future bookings
+// @Description Save an address to an account for easier future bookings. Addresses outside every active service zone are refused
 // @Security BearerAuth
 // @Tags Account
 // @Accept json
@@ -500,6 +500,10 @@ func (h *AccountHandler) CreateAddress(c *gin.Context) {
 
 	resp, err := h.Service.CreateAddress(ctx, req)
 	if err != nil {
+		if isServiceAreaError(err) {
+			c.JSON(http.StatusBadRequest, types.NewErrorResponse(err))
+			return
+		}
 		c.JSON(http.StatusInternalServerError, types.NewErrorResponse(err))
 		return
 	}
@@ -571,7 +575,7 @@ func (h *AccountHandler) GetAddresses(c *gin.Context) {
 
 // UpdateAddress godoc
 // @Summary Update a saved address
-// @Description Update a saved account address by ID
+// @Description Update a saved account address by ID. Addresses outside every active service zone are refused
 // @Security BearerAuth
 // @Tags Account
 // @Accept json
@@ -596,6 +600,10 @@ func (h *AccountHandler) UpdateAddress(c *gin.Context) {
 
 	resp, err := h.Service.UpdateAddress(ctx, req)
 	if err != nil {
+		if isServiceAreaError(err) {
+			c.JSON(http.StatusBadRequest, types.NewErrorResponse(err))
+			return
+		}
 		c.JSON(http.StatusInternalServerError, types.NewErrorResponse(err))
 		return
 	}
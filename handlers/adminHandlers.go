@@ -78,7 +78,7 @@ func (h *AdminHandler) OnboardEmployee(c *gin.Context) {
 
 // AssignEmployeeToBooking godoc
 // @Summary Assign or unassign a cleaner to a booking
-// @Description Adds or removes a cleaner from a booking after schedule conflict checks
+// @Description Adds or removes a cleaner from a booking after qualification, availability calendar and schedule conflict checks
 // @Tags Admin
 // @Security BearerAuth
 // @Accept json
@@ -107,7 +107,11 @@ func (h *AdminHandler) AssignEmployeeToBooking(c *gin.Context) {
 		case errors.Is(err, tasks.ErrBookingNotFound), errors.Is(err, tasks.ErrEmployeeNotFoundOrInactive):
 			c.JSON(http.StatusBadRequest, types.NewErrorResponse(err))
 			return
-		case errors.Is(err, tasks.ErrCleanerAlreadyAssigned), errors.Is(err, tasks.ErrCleanerHasConflict), errors.Is(err, tasks.ErrCleanerNotAssigned):
+		case errors.Is(err, tasks.ErrCleanerAlreadyAssigned),
+			errors.Is(err, tasks.ErrCleanerHasConflict),
+			errors.Is(err, tasks.ErrCleanerNotAssigned),
+			errors.Is(err, tasks.ErrCleanerNotQualified),
+			errors.Is(err, tasks.ErrCleanerUnavailable):
 			c.JSON(http.StatusConflict, types.NewErrorResponse(err))
 			return
 		default:
@@ -121,7 +125,7 @@ func (h *AdminHandler) AssignEmployeeToBooking(c *gin.Context) {
 
 // ListAvailableCleaners godoc
 // @Summary List available cleaners for a booking
-// @Description Returns cleaners with no overlapping non-cancelled responsibilities for the booking schedule
+// @Description Returns cleaners qualified for the booking's services whose approved availability calendar allows the booking schedule and who have no overlapping non-cancelled responsibilities
 // @Tags Admin
 // @Security BearerAuth
 // @Accept json
@@ -154,6 +158,164 @@ func (h *AdminHandler) ListAvailableCleaners(c *gin.Context) {
 	c.JSON(http.StatusOK, res)
 }
 
+// GetEmployeeQualifications godoc
+// @Summary Get an employee's service qualifications
+// @Description Returns the service types the employee can be allocated to. GENERAL_CLEANING is always included
+// @Tags Admin
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param id path string true "Employee ID"
+// @Success 200 {object} types.EmployeeQualificationsResponse
+// @Failure 400 {object} types.ErrorResponse
+// @Failure 500 {object} types.ErrorResponse
+// @Router /admin/employee/{id}/qualifications [get]
+func (h *AdminHandler) GetEmployeeQualifications(c *gin.Context) {
+	employeeID := c.Param("id")
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	res, err := h.Service.GetEmployeeQualifications(ctx, employeeID)
+	if err != nil {
+		if errors.Is(err, tasks.ErrEmployeeNotFoundOrInactive) {
+			c.JSON(http.StatusBadRequest, types.NewErrorResponse(err))
+			return
+		}
+		c.JSON(http.StatusInternalServerError, types.NewErrorResponse(err))
+		return
+	}
+
+	c.JSON(http.StatusOK, res)
+}
+
+// UpdateEmployeeQualifications godoc
+// @Summary Replace an employee's service qualifications
+// @Description Sets the service types the employee is trained for. Cleaner allocation and manual assignment only use qualified cleaners for a booking's main service and addons
+// @Tags Admin
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param id path string true "Employee ID"
+// @Param input body types.UpdateEmployeeQualificationsRequest true "Qualified service types"
+// @Success 200 {object} types.EmployeeQualificationsResponse
+// @Failure 400 {object} types.ErrorResponse
+// @Failure 500 {object} types.ErrorResponse
+// @Router /admin/employee/{id}/qualifications [put]
+func (h *AdminHandler) UpdateEmployeeQualifications(c *gin.Context) {
+	employeeID := c.Param("id")
+	var req types.UpdateEmployeeQualificationsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, types.NewErrorResponse(err))
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	res, err := h.Service.UpdateEmployeeQualifications(ctx, employeeID, &req)
+	if err != nil {
+		if errors.Is(err, tasks.ErrEmployeeNotFoundOrInactive) || errors.Is(err, tasks.ErrInvalidQualification) {
+			c.JSON(http.StatusBadRequest, types.NewErrorResponse(err))
+			return
+		}
+		c.JSON(http.StatusInternalServerError, types.NewErrorResponse(err))
+		return
+	}
+
+	c.JSON(http.StatusOK, res)
+}
+
+// GetPendingCalendarRequests godoc
+// @Summary List calendar requests awaiting review
+// @Description Returns submitted weekly availability schedules and time-off requests that have not been approved or rejected yet
+// @Tags Admin
+// @Security BearerAuth
+// @Produce json
+// @Success 200 {object} types.PendingCalendarRequestsResponse
+// @Failure 500 {object} types.ErrorResponse
+// @Router /admin/employee/calendar/pending [get]
+func (h *AdminHandler) GetPendingCalendarRequests(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	res, err := h.Service.GetPendingCalendarRequests(ctx)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, types.NewErrorResponse(err))
+		return
+	}
+
+	c.JSON(http.StatusOK, res)
+}
+
+// ReviewWeeklyAvailability godoc
+// @Summary Approve or reject a weekly availability schedule
+// @Description Approving replaces the employee's current schedule for cleaner allocation
+// @Tags Admin
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param id path string true "Schedule ID"
+// @Param input body types.ReviewCalendarRequest true "Review decision"
+// @Success 200 {object} types.WeeklyAvailability
+// @Failure 400 {object} types.ErrorResponse
+// @Failure 409 {object} types.ErrorResponse
+// @Failure 500 {object} types.ErrorResponse
+// @Router /admin/employee/calendar/availability/{id}/review [post]
+func (h *AdminHandler) ReviewWeeklyAvailability(c *gin.Context) {
+	scheduleID := c.Param("id")
+	var req types.ReviewCalendarRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, types.NewErrorResponse(err))
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	res, err := h.Service.ReviewWeeklyAvailability(ctx, scheduleID, req.Approve, requestActor(c))
+	if err != nil {
+		c.JSON(calendarErrorStatus(err), types.NewErrorResponse(err))
+		return
+	}
+
+	c.JSON(http.StatusOK, res)
+}
+
+// ReviewTimeOff godoc
+// @Summary Approve or reject a time-off request
+// @Description Approved time off keeps the employee out of cleaner allocation. Bookings they are already assigned to in that window are listed for reassignment
+// @Tags Admin
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param id path string true "Time off ID"
+// @Param input body types.ReviewCalendarRequest true "Review decision"
+// @Success 200 {object} types.ReviewTimeOffResponse
+// @Failure 400 {object} types.ErrorResponse
+// @Failure 409 {object} types.ErrorResponse
+// @Failure 500 {object} types.ErrorResponse
+// @Router /admin/employee/calendar/time-off/{id}/review [post]
+func (h *AdminHandler) ReviewTimeOff(c *gin.Context) {
+	timeOffID := c.Param("id")
+	var req types.ReviewCalendarRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, types.NewErrorResponse(err))
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	res, err := h.Service.ReviewTimeOff(ctx, timeOffID, req.Approve, requestActor(c))
+	if err != nil {
+		c.JSON(calendarErrorStatus(err), types.NewErrorResponse(err))
+		return
+	}
+
+	c.JSON(http.StatusOK, res)
+}
+
 // AcceptBooking godoc
 // @Summary Accept a booking
 // @Description Updates the booking review status to SCHEDULED, triggering a notification to assigned employees
@@ -164,6 +326,7 @@ func (h *AdminHandler) ListAvailableCleaners(c *gin.Context) {
 // @Param id path string true "Booking ID"
 // @Success 200 {object} types.AcceptBookingResponse
 // @Failure 400 {object} types.ErrorResponse
+// @Failure 409 {object} types.ErrorResponse
 // @Failure 500 {object} types.ErrorResponse
 // @Router /admin/booking/approve/{id} [post]
 func (h *AdminHandler) AcceptBooking(c *gin.Context) {
@@ -174,9 +337,16 @@ func (h *AdminHandler) AcceptBooking(c *gin.Context) {
 	}
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
-	res, err := h.Service.AcceptBooking(ctx, bookingId)
+	res, err := h.Service.AcceptBooking(ctx, bookingId, requestActor(c))
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, types.NewErrorResponse(err))
+		switch {
+		case errors.Is(err, tasks.ErrBookingNotFound):
+			c.JSON(http.StatusBadRequest, types.NewErrorResponse(err))
+		case errors.Is(err, tasks.ErrInvalidTransition):
+			c.JSON(http.StatusConflict, types.NewErrorResponse(err))
+		default:
+			c.JSON(http.StatusInternalServerError, types.NewErrorResponse(err))
+		}
 		return
 	}
 	c.JSON(http.StatusOK, res)
@@ -292,3 +462,27 @@ func (h *AdminHandler) GetBookingTrends(c *gin.Context) {
 
 	c.JSON(http.StatusOK, result)
 }
+
+// GetSeriesConflicts godoc
+// @Summary Fetch recurring booking occurrences that could not be staffed
+// @Description Returns upcoming series occurrences left in CONFLICT because not enough cleaners were free. They are retried on every generator run
+// @Tags Admin
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Success 200 {object} types.SeriesConflictsResponse
+// @Failure 500 {object} types.ErrorResponse
+// @Router /admin/booking/series/conflicts [get]
+func (h *AdminHandler) GetSeriesConflicts(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 10*time.Second)
+	defer cancel()
+
+	result, err := h.Service.GetSeriesConflicts(ctx)
+	if err != nil {
+		h.Logger.Error("failed to get series conflicts: %v", err)
+		c.JSON(http.StatusInternalServerError, types.NewErrorResponse(err))
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
@@ -0,0 +1,52 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"handworks-api/tasks"
+	"handworks-api/types"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// BookAgain godoc
+// @Summary Book a past booking again
+// @Description Re-prices the main service and add-ons of a past booking at current rates and creates a new quote and an unpaid order for them. The returned booking request only needs startSched and endSched before it is sent to POST /booking. The payment method defaults to the one used for the past booking. Only admins and the booking's customer can book it again
+// @Tags Booking
+// @Security BearerAuth
+// @Produce json
+// @Param id path string true "Booking ID"
+// @Param paymentMethod query string false "Payment method for the new order"
+// @Success 200 {object} types.BookAgainResponse
+// @Failure 400 {object} types.ErrorResponse
+// @Failure 403 {object} types.ErrorResponse
+// @Failure 500 {object} types.ErrorResponse
+// @Router /booking/{id}/book-again [post]
+func (h *BookingHandler) BookAgain(c *gin.Context) {
+	bookingID := c.Param("id")
+	paymentMethod := strings.TrimSpace(c.Query("paymentMethod"))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	res, err := h.Service.BookAgain(ctx, bookingID, paymentMethod, requestActor(c))
+	if err != nil {
+		switch {
+		case errors.Is(err, tasks.ErrBookingNotFound),
+			errors.Is(err, tasks.ErrInvalidService),
+			errors.Is(err, tasks.ErrBookAgainPaymentMethod),
+			isServiceAreaError(err):
+			c.JSON(http.StatusBadRequest, types.NewErrorResponse(err))
+		case errors.Is(err, tasks.ErrBookAgainDenied):
+			c.JSON(http.StatusForbidden, types.NewErrorResponse(err))
+		default:
+			c.JSON(http.StatusInternalServerError, types.NewErrorResponse(err))
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, res)
+}
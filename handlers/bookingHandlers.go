@@ -3,6 +3,7 @@ package handlers
 import (
 	"context"
 	"errors"
+	"handworks-api/tasks"
 	"handworks-api/types"
 	"net/http"
 	"strconv"
@@ -14,7 +15,7 @@ import (
 
 // CreateBooking godoc
 // @Summary Create a new booking
-// @Description Creates a booking record
+// @Description Creates a booking record. When the quote has a multi-day plan, one linked booking is created per consecutive day under the same order and returned in linkedBookings. The dirtyScale must be the one the quote was priced at and the address must be in the service zone it was quoted for. Returns 409 when the slot has no free cleaners; the order can then join the waitlist. Also returns 409 when the customer, or anyone at the same address, already has a booking overlapping the schedule, or the order is already booked at another time. Resubmitting the same order for the same schedule returns the booking already made for it
 // @Tags Booking
 // @Security BearerAuth
 // @Accept json
@@ -22,7 +23,9 @@ import (
 // @Param input body types.CreateBookingRequest true "Booking info"
 // @Success 200 {object} types.Booking
 // @Failure 400 {object} types.ErrorResponse
+// @Failure 409 {object} types.ErrorResponse
 // @Failure 500 {object} types.ErrorResponse
+// @Failure 503 {object} types.ErrorResponse
 // @Router /booking [post]
 func (h *BookingHandler) CreateBooking(c *gin.Context) {
 	var req types.CreateBookingRequest
@@ -33,8 +36,22 @@ func (h *BookingHandler) CreateBooking(c *gin.Context) {
 	}
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
-	res, err := h.Service.CreateBooking(ctx, req)
+	res, err := h.Service.CreateBooking(ctx, req, requestActor(c))
 	if err != nil {
+		if errors.Is(err, tasks.ErrNoAvailableCleaners) || errors.Is(err, tasks.ErrInsufficientCleaners) ||
+			errors.Is(err, tasks.ErrOverlappingBooking) || errors.Is(err, tasks.ErrOrderAlreadyBooked) {
+			c.JSON(http.StatusConflict, types.NewErrorResponse(err))
+			return
+		}
+		if isMediaReferenceError(err) || errors.Is(err, tasks.ErrInvalidAccessInstructions) ||
+			errors.Is(err, tasks.ErrDirtyScaleMismatch) || isServiceAreaError(err) {
+			c.JSON(http.StatusBadRequest, types.NewErrorResponse(err))
+			return
+		}
+		if errors.Is(err, tasks.ErrAccessInstructionsDisabled) {
+			c.JSON(http.StatusServiceUnavailable, types.NewErrorResponse(err))
+			return
+		}
 		c.JSON(http.StatusInternalServerError, types.NewErrorResponse(err))
 		return
 	}
@@ -387,15 +404,53 @@ func (h *BookingHandler) GetBookedSlots(c *gin.Context) {
 	})
 }
 
+// SearchAvailability godoc
+// @Summary Search bookable start times
+// @Description Returns the start times between startDate and endDate (inclusive, at most 14 days) where enough qualified cleaners are free and on their availability calendar for the requested services. Duration comes from the service pricing rules and every window stays inside business hours
+// @Tags Booking
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param input body types.AvailabilityRequest true "Services and date range"
+// @Success 200 {object} types.AvailabilityResponse
+// @Failure 400 {object} types.ErrorResponse
+// @Failure 500 {object} types.ErrorResponse
+// @Router /booking/availability [post]
+func (h *BookingHandler) SearchAvailability(c *gin.Context) {
+	var req types.AvailabilityRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, types.NewErrorResponse(err))
+		return
+	}
+
+	// Every candidate start runs the cleaner allocation, so allow more time than a single lookup.
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 30*time.Second)
+	defer cancel()
+
+	res, err := h.Service.SearchAvailability(ctx, req)
+	if err != nil {
+		if errors.Is(err, tasks.ErrInvalidSchedule) || errors.Is(err, tasks.ErrInvalidService) {
+			c.JSON(http.StatusBadRequest, types.NewErrorResponse(err))
+			return
+		}
+		c.JSON(http.StatusInternalServerError, types.NewErrorResponse(err))
+		return
+	}
+
+	c.JSON(http.StatusOK, res)
+}
+
 // StartSession godoc
 // @Summary Start a booking session
-// @Description Marks a booking as ONGOING
+// @Description Marks a booking as ONGOING and creates its checklist from the templates for its services. The device location is required and must be within the geofence radius of the booking address; admins can override this with overrideGeofence
 // @Tags Booking
 // @Accept json
 // @Produce json
 // @Param input body types.StartSessionRequest true "Start session payload"
 // @Success 200 {object} map[string]string
 // @Failure 400 {object} types.ErrorResponse
+// @Failure 403 {object} types.ErrorResponse
+// @Failure 409 {object} types.ErrorResponse
 // @Failure 500 {object} types.ErrorResponse
 // @Router /booking/session/start [post]
 func (h *BookingHandler) StartSession(c *gin.Context) {
@@ -407,8 +462,19 @@ func (h *BookingHandler) StartSession(c *gin.Context) {
 	bookingID := req.BookingID
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
-	if err := h.Service.StartSession(ctx, bookingID, req.StartPhotos); err != nil {
-		c.JSON(http.StatusInternalServerError, types.NewErrorResponse(err))
+	if err := h.Service.StartSession(ctx, req, requestActor(c)); err != nil {
+		switch {
+		case errors.Is(err, tasks.ErrBookingNotFound), isMediaReferenceError(err):
+			c.JSON(http.StatusBadRequest, types.NewErrorResponse(err))
+		case errors.Is(err, tasks.ErrOutsideGeofence),
+			errors.Is(err, tasks.ErrGeofenceUnverifiable),
+			errors.Is(err, tasks.ErrGeofenceOverrideDenied):
+			c.JSON(http.StatusForbidden, types.NewErrorResponse(err))
+		case errors.Is(err, tasks.ErrInvalidTransition):
+			c.JSON(http.StatusConflict, types.NewErrorResponse(err))
+		default:
+			c.JSON(http.StatusInternalServerError, types.NewErrorResponse(err))
+		}
 		return
 	}
 	c.JSON(http.StatusOK, gin.H{"status": "ONGOING", "bookingId": bookingID})
@@ -416,13 +482,14 @@ func (h *BookingHandler) StartSession(c *gin.Context) {
 
 // EndSession godoc
 // @Summary End a booking session
-// @Description Marks a booking as COMPLETED
+// @Description Marks a booking as COMPLETED and returns the time actually worked. Time beyond the quoted hours, past the overtime tolerance, is billed to the order at the extra-hour rate per cleaner. Refused with 409 while any required checklist item is neither done nor skipped
 // @Tags Booking
 // @Accept json
 // @Produce json
 // @Param input body types.EndSessionRequest true "End session payload"
-// @Success 200 {object} map[string]string
+// @Success 200 {object} types.SessionSummary
 // @Failure 400 {object} types.ErrorResponse
+// @Failure 409 {object} types.ErrorResponse
 // @Failure 500 {object} types.ErrorResponse
 // @Router /booking/session/end [post]
 func (h *BookingHandler) EndSession(c *gin.Context) {
@@ -434,11 +501,113 @@ func (h *BookingHandler) EndSession(c *gin.Context) {
 	bookingID := req.BookingID
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
-	if err := h.Service.EndSession(ctx, bookingID, req.EndPhotos); err != nil {
-		c.JSON(http.StatusInternalServerError, types.NewErrorResponse(err))
+	summary, err := h.Service.EndSession(ctx, bookingID, req.EndPhotos, requestActor(c))
+	if err != nil {
+		switch {
+		case errors.Is(err, tasks.ErrBookingNotFound), isMediaReferenceError(err):
+			c.JSON(http.StatusBadRequest, types.NewErrorResponse(err))
+		case errors.Is(err, tasks.ErrInvalidTransition), errors.Is(err, tasks.ErrChecklistIncomplete):
+			c.JSON(http.StatusConflict, types.NewErrorResponse(err))
+		default:
+			c.JSON(http.StatusInternalServerError, types.NewErrorResponse(err))
+		}
+		return
+	}
+	c.JSON(http.StatusOK, summary)
+}
+
+// PauseSession godoc
+// @Summary Pause a booking session
+// @Description Stops the worked-time clock of an ongoing session until it is resumed
+// @Tags Booking
+// @Accept json
+// @Produce json
+// @Param input body types.PauseSessionRequest true "Pause session payload"
+// @Success 200 {object} types.SessionSummary
+// @Failure 400 {object} types.ErrorResponse
+// @Failure 409 {object} types.ErrorResponse
+// @Failure 500 {object} types.ErrorResponse
+// @Router /booking/session/pause [post]
+func (h *BookingHandler) PauseSession(c *gin.Context) {
+	var req types.PauseSessionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, types.NewErrorResponse(err))
+		return
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	summary, err := h.Service.PauseSession(ctx, req.BookingID, req.Reason, requestActor(c))
+	if err != nil {
+		c.JSON(sessionErrorStatus(err), types.NewErrorResponse(err))
 		return
 	}
-	c.JSON(http.StatusOK, gin.H{"status": "COMPLETED", "bookingId": bookingID})
+	c.JSON(http.StatusOK, summary)
+}
+
+// ResumeSession godoc
+// @Summary Resume a paused booking session
+// @Description Restarts the worked-time clock of a paused session
+// @Tags Booking
+// @Accept json
+// @Produce json
+// @Param input body types.ResumeSessionRequest true "Resume session payload"
+// @Success 200 {object} types.SessionSummary
+// @Failure 400 {object} types.ErrorResponse
+// @Failure 409 {object} types.ErrorResponse
+// @Failure 500 {object} types.ErrorResponse
+// @Router /booking/session/resume [post]
+func (h *BookingHandler) ResumeSession(c *gin.Context) {
+	var req types.ResumeSessionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, types.NewErrorResponse(err))
+		return
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	summary, err := h.Service.ResumeSession(ctx, req.BookingID, requestActor(c))
+	if err != nil {
+		c.JSON(sessionErrorStatus(err), types.NewErrorResponse(err))
+		return
+	}
+	c.JSON(http.StatusOK, summary)
+}
+
+// GetSessionSummary godoc
+// @Summary Get a booking session's worked time
+// @Description Returns the session events, worked and paused time, and overtime against the quoted hours. For a running session the overtime is what ending it now would bill
+// @Tags Booking
+// @Produce json
+// @Param id path string true "Booking ID"
+// @Success 200 {object} types.SessionSummary
+// @Failure 400 {object} types.ErrorResponse
+// @Failure 500 {object} types.ErrorResponse
+// @Router /booking/session/{id}/summary [get]
+func (h *BookingHandler) GetSessionSummary(c *gin.Context) {
+	bookingID := c.Param("id")
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	summary, err := h.Service.GetSessionSummary(ctx, bookingID)
+	if err != nil {
+		c.JSON(sessionErrorStatus(err), types.NewErrorResponse(err))
+		return
+	}
+	c.JSON(http.StatusOK, summary)
+}
+
+func sessionErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, tasks.ErrBookingNotFound):
+		return http.StatusBadRequest
+	case errors.Is(err, tasks.ErrSessionNotStarted),
+		errors.Is(err, tasks.ErrSessionPaused),
+		errors.Is(err, tasks.ErrSessionNotPaused):
+		return http.StatusConflict
+	default:
+		return http.StatusInternalServerError
+	}
 }
 
 // GetActiveBooking godoc
@@ -475,32 +644,88 @@ func (h *BookingHandler) GetActiveBooking(c *gin.Context) {
 }
 
 // UpdateBooking godoc
-// @Summary Update a booking
-// @Description Update booking information
+// @Summary Reschedule a booking
+// @Description Moves a booking to a new schedule window. The current crew is kept when free, otherwise cleaners are reallocated and an approved booking returns to PENDING review. Only admins and the booking's customer can reschedule it
 // @Tags Booking
 // @Security BearerAuth
 // @Accept json
 // @Produce json
 // @Param id path string true "Booking ID"
-// @Param input body map[string]interface{} true "Updated booking info"
-// @Success 200 {object} map[string]string
+// @Param input body types.RescheduleBookingRequest true "New schedule window"
+// @Success 200 {object} types.RescheduleBookingResponse
+// @Failure 400 {object} types.ErrorResponse
+// @Failure 403 {object} types.ErrorResponse
+// @Failure 409 {object} types.ErrorResponse
+// @Failure 500 {object} types.ErrorResponse
 // @Router /booking/{id} [put]
 func (h *BookingHandler) UpdateBooking(c *gin.Context) {
-	_ = h.Service.UpdateBooking(c.Request.Context())
-	c.JSON(http.StatusOK, gin.H{"status": "success"})
+	bookingID := c.Param("id")
+	var req types.RescheduleBookingRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, types.NewErrorResponse(err))
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 10*time.Second)
+	defer cancel()
+
+	res, err := h.Service.RescheduleBooking(ctx, bookingID, req, requestActor(c))
+	if err != nil {
+		switch {
+		case errors.Is(err, tasks.ErrBookingNotFound), errors.Is(err, tasks.ErrInvalidSchedule):
+			c.JSON(http.StatusBadRequest, types.NewErrorResponse(err))
+		case errors.Is(err, tasks.ErrBookingChangeDenied):
+			c.JSON(http.StatusForbidden, types.NewErrorResponse(err))
+		case errors.Is(err, tasks.ErrInvalidTransition),
+			errors.Is(err, tasks.ErrScheduleSlotUnavailable),
+			errors.Is(err, tasks.ErrNoAvailableCleaners),
+			errors.Is(err, tasks.ErrInsufficientCleaners):
+			c.JSON(http.StatusConflict, types.NewErrorResponse(err))
+		default:
+			c.JSON(http.StatusInternalServerError, types.NewErrorResponse(err))
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, res)
 }
 
 // DeleteBooking godoc
-// @Summary Delete a booking
-// @Description Remove booking by ID
+// @Summary Cancel a booking
+// @Description Cancels a booking that has not started, together with the remaining days of a multi-day job. The refund follows the cancellation policy based on hours before startSched and covers only the cancelled days' share of the day plan; days already started stay on the order. Cleaners are freed and reserved inventory is restocked. Only admins and the booking's customer can cancel it
 // @Tags Booking
 // @Security BearerAuth
 // @Accept json
 // @Produce json
 // @Param id path string true "Booking ID"
-// @Success 200 {object} map[string]string
+// @Param reason query string false "Cancellation reason"
+// @Success 200 {object} types.CancelBookingResponse
+// @Failure 400 {object} types.ErrorResponse
+// @Failure 403 {object} types.ErrorResponse
+// @Failure 409 {object} types.ErrorResponse
+// @Failure 500 {object} types.ErrorResponse
 // @Router /booking/{id} [delete]
 func (h *BookingHandler) DeleteBooking(c *gin.Context) {
-	_ = h.Service.DeleteBooking(c.Request.Context())
-	c.JSON(http.StatusOK, gin.H{"status": "success"})
+	bookingID := c.Param("id")
+	reason := strings.TrimSpace(c.Query("reason"))
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 10*time.Second)
+	defer cancel()
+
+	res, err := h.Service.CancelBooking(ctx, bookingID, reason, requestActor(c))
+	if err != nil {
+		switch {
+		case errors.Is(err, tasks.ErrBookingNotFound):
+			c.JSON(http.StatusBadRequest, types.NewErrorResponse(err))
+		case errors.Is(err, tasks.ErrBookingChangeDenied):
+			c.JSON(http.StatusForbidden, types.NewErrorResponse(err))
+		case errors.Is(err, tasks.ErrInvalidTransition):
+			c.JSON(http.StatusConflict, types.NewErrorResponse(err))
+		default:
+			c.JSON(http.StatusInternalServerError, types.NewErrorResponse(err))
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, res)
 }
@@ -0,0 +1,150 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"handworks-api/tasks"
+	"handworks-api/types"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	maxBookingImportBytes = 1 << 20
+	// bookingImportTimeout covers validating a full file of rows.
+	bookingImportTimeout = 5 * time.Minute
+)
+
+// ImportBookings godoc
+// @Summary Import bookings from a CSV file
+// @Description Admins only. Each row is a booking for an existing customer. Columns, matched by header name in any order: customerId, address, lat, lng, serviceType, serviceDetails (JSON details of the service, as stored on bookings), windowStart and windowEnd (RFC3339 preferred window), and optionally unit, addons (JSON array of {serviceType, details}), dirtyScale and paymentMethod. Every row is priced like a quote and given the first available slot that fits inside its window without overlapping other bookings of the same unit for the same customer or at the same coordinates, including earlier rows of the file. This is a dry run: nothing is booked, and the rows with their slots are kept as an import job to confirm with POST /booking/import/{id}/confirm
+// @Tags Booking
+// @Security BearerAuth
+// @Accept multipart/form-data
+// @Produce json
+// @Param file formData file true "CSV file"
+// @Param paymentMethod formData string false "Payment method for rows that do not set one"
+// @Success 200 {object} types.BookingImportResponse
+// @Failure 400 {object} types.ErrorResponse
+// @Failure 403 {object} types.ErrorResponse
+// @Failure 413 {object} types.ErrorResponse
+// @Failure 500 {object} types.ErrorResponse
+// @Router /booking/import [post]
+func (h *BookingHandler) ImportBookings(c *gin.Context) {
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, maxBookingImportBytes+multipartOverhead)
+
+	header, err := c.FormFile("file")
+	if err != nil {
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			c.JSON(http.StatusRequestEntityTooLarge, types.NewErrorResponse(fmt.Errorf("%w: file is too large", tasks.ErrInvalidBookingImport)))
+			return
+		}
+		c.JSON(http.StatusBadRequest, types.NewErrorResponse(fmt.Errorf("file is required: %w", err)))
+		return
+	}
+	if header.Size > maxBookingImportBytes {
+		c.JSON(http.StatusRequestEntityTooLarge, types.NewErrorResponse(fmt.Errorf("%w: file is too large", tasks.ErrInvalidBookingImport)))
+		return
+	}
+
+	file, err := header.Open()
+	if err != nil {
+		c.JSON(http.StatusBadRequest, types.NewErrorResponse(err))
+		return
+	}
+	defer file.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), bookingImportTimeout)
+	defer cancel()
+
+	paymentMethod := strings.TrimSpace(c.PostForm("paymentMethod"))
+	res, err := h.Service.ImportBookings(ctx, file, paymentMethod, requestActor(c))
+	if err != nil {
+		switch {
+		case errors.Is(err, tasks.ErrInvalidBookingImport):
+			c.JSON(http.StatusBadRequest, types.NewErrorResponse(err))
+		case errors.Is(err, tasks.ErrBookingImportDenied):
+			c.JSON(http.StatusForbidden, types.NewErrorResponse(err))
+		default:
+			c.JSON(http.StatusInternalServerError, types.NewErrorResponse(err))
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, res)
+}
+
+// ConfirmBookingImport godoc
+// @Summary Create the bookings of a validated import
+// @Description Admins only. Starts booking the valid rows of an import in the background, in the slots the dry run gave them, and returns at once. Poll GET /booking/import/{id} for progress. Rows whose slot has been taken or has passed since the dry run fail. An import that stopped without finishing can be confirmed again after a few minutes; rows it was booking when it stopped are reported as failed and should be checked by hand
+// @Tags Booking
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param id path string true "Import ID"
+// @Success 202 {object} types.BookingImportResponse
+// @Failure 403 {object} types.ErrorResponse
+// @Failure 404 {object} types.ErrorResponse
+// @Failure 409 {object} types.ErrorResponse
+// @Failure 500 {object} types.ErrorResponse
+// @Router /booking/import/{id}/confirm [post]
+func (h *BookingHandler) ConfirmBookingImport(c *gin.Context) {
+	jobID := c.Param("id")
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 10*time.Second)
+	defer cancel()
+
+	res, err := h.Service.ConfirmBookingImport(ctx, jobID, requestActor(c))
+	if err != nil {
+		respondBookingImportError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusAccepted, res)
+}
+
+// GetBookingImport godoc
+// @Summary Get a booking import
+// @Description Admins only. Returns the report of an import: the slot, quote, order and booking of every row as far as the import has got
+// @Tags Booking
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param id path string true "Import ID"
+// @Success 200 {object} types.BookingImportResponse
+// @Failure 403 {object} types.ErrorResponse
+// @Failure 404 {object} types.ErrorResponse
+// @Failure 500 {object} types.ErrorResponse
+// @Router /booking/import/{id} [get]
+func (h *BookingHandler) GetBookingImport(c *gin.Context) {
+	jobID := c.Param("id")
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 10*time.Second)
+	defer cancel()
+
+	res, err := h.Service.GetBookingImport(ctx, jobID, requestActor(c))
+	if err != nil {
+		respondBookingImportError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, res)
+}
+
+func respondBookingImportError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, tasks.ErrBookingImportDenied):
+		c.JSON(http.StatusForbidden, types.NewErrorResponse(err))
+	case errors.Is(err, tasks.ErrBookingImportNotFound):
+		c.JSON(http.StatusNotFound, types.NewErrorResponse(err))
+	case errors.Is(err, tasks.ErrBookingImportStarted):
+		c.JSON(http.StatusConflict, types.NewErrorResponse(err))
+	default:
+		c.JSON(http.StatusInternalServerError, types.NewErrorResponse(err))
+	}
+}
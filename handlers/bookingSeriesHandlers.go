@@ -0,0 +1,170 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"handworks-api/tasks"
+	"handworks-api/types"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// CreateBookingSeries godoc
+// @Summary Create a recurring booking series
+// @Description Creates a weekly, bi-weekly or monthly series from a booking template. The first occurrence uses the given order, later occurrences get their own orders and are booked up to four weeks ahead. Access instructions in the template are kept encrypted and given to every booking of the series
+// @Tags Booking
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param input body types.CreateBookingSeriesRequest true "Series info"
+// @Success 200 {object} types.BookingSeries
+// @Failure 400 {object} types.ErrorResponse
+// @Failure 500 {object} types.ErrorResponse
+// @Failure 503 {object} types.ErrorResponse
+// @Router /booking/series [post]
+func (h *BookingHandler) CreateBookingSeries(c *gin.Context) {
+	var req types.CreateBookingSeriesRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, types.NewErrorResponse(err))
+		return
+	}
+
+	// Creating a series books every occurrence inside the horizon, so allow more time than a single booking.
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	res, err := h.Service.CreateBookingSeries(ctx, req)
+	if err != nil {
+		if errors.Is(err, tasks.ErrInvalidRecurrence) || errors.Is(err, tasks.ErrInvalidSchedule) ||
+			errors.Is(err, tasks.ErrDirtyScaleMismatch) || isServiceAreaError(err) || isMediaReferenceError(err) ||
+			errors.Is(err, tasks.ErrInvalidAccessInstructions) {
+			c.JSON(http.StatusBadRequest, types.NewErrorResponse(err))
+			return
+		}
+		if errors.Is(err, tasks.ErrAccessInstructionsDisabled) {
+			c.JSON(http.StatusServiceUnavailable, types.NewErrorResponse(err))
+			return
+		}
+		c.JSON(http.StatusInternalServerError, types.NewErrorResponse(err))
+		return
+	}
+
+	c.JSON(http.StatusOK, res)
+}
+
+// GetBookingSeries godoc
+// @Summary Get a recurring booking series
+// @Description Returns a series with every generated occurrence and its status
+// @Tags Booking
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param id path string true "Series ID"
+// @Success 200 {object} types.BookingSeries
+// @Failure 400 {object} types.ErrorResponse
+// @Failure 500 {object} types.ErrorResponse
+// @Router /booking/series/{id} [get]
+func (h *BookingHandler) GetBookingSeries(c *gin.Context) {
+	seriesID := c.Param("id")
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 10*time.Second)
+	defer cancel()
+
+	res, err := h.Service.GetBookingSeries(ctx, seriesID)
+	if err != nil {
+		if errors.Is(err, tasks.ErrSeriesNotFound) {
+			c.JSON(http.StatusBadRequest, types.NewErrorResponse(err))
+			return
+		}
+		c.JSON(http.StatusInternalServerError, types.NewErrorResponse(err))
+		return
+	}
+
+	c.JSON(http.StatusOK, res)
+}
+
+// SkipSeriesOccurrence godoc
+// @Summary Skip one occurrence of a series
+// @Description Skips the occurrence on the given date. A booking already created for it is cancelled under the cancellation policy
+// @Tags Booking
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param id path string true "Series ID"
+// @Param input body types.SkipSeriesOccurrenceRequest true "Occurrence date"
+// @Success 200 {object} types.BookingSeries
+// @Failure 400 {object} types.ErrorResponse
+// @Failure 403 {object} types.ErrorResponse
+// @Failure 409 {object} types.ErrorResponse
+// @Failure 500 {object} types.ErrorResponse
+// @Router /booking/series/{id}/skip [post]
+func (h *BookingHandler) SkipSeriesOccurrence(c *gin.Context) {
+	seriesID := c.Param("id")
+	var req types.SkipSeriesOccurrenceRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, types.NewErrorResponse(err))
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 10*time.Second)
+	defer cancel()
+
+	res, err := h.Service.SkipSeriesOccurrence(ctx, seriesID, req, requestActor(c))
+	if err != nil {
+		switch {
+		case errors.Is(err, tasks.ErrSeriesNotFound), errors.Is(err, tasks.ErrOccurrenceNotInSeries):
+			c.JSON(http.StatusBadRequest, types.NewErrorResponse(err))
+		case errors.Is(err, tasks.ErrSeriesDenied):
+			c.JSON(http.StatusForbidden, types.NewErrorResponse(err))
+		case errors.Is(err, tasks.ErrSeriesNotActive),
+			errors.Is(err, tasks.ErrOccurrenceNotSkippable),
+			errors.Is(err, tasks.ErrBookingNotCancellable):
+			c.JSON(http.StatusConflict, types.NewErrorResponse(err))
+		default:
+			c.JSON(http.StatusInternalServerError, types.NewErrorResponse(err))
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, res)
+}
+
+// EndBookingSeries godoc
+// @Summary End a recurring booking series
+// @Description Stops generating occurrences and cancels every future occurrence of the series
+// @Tags Booking
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param id path string true "Series ID"
+// @Success 200 {object} types.BookingSeries
+// @Failure 400 {object} types.ErrorResponse
+// @Failure 403 {object} types.ErrorResponse
+// @Failure 409 {object} types.ErrorResponse
+// @Failure 500 {object} types.ErrorResponse
+// @Router /booking/series/{id}/end [post]
+func (h *BookingHandler) EndBookingSeries(c *gin.Context) {
+	seriesID := c.Param("id")
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	res, err := h.Service.EndBookingSeries(ctx, seriesID, requestActor(c))
+	if err != nil {
+		switch {
+		case errors.Is(err, tasks.ErrSeriesNotFound):
+			c.JSON(http.StatusBadRequest, types.NewErrorResponse(err))
+		case errors.Is(err, tasks.ErrSeriesDenied):
+			c.JSON(http.StatusForbidden, types.NewErrorResponse(err))
+		case errors.Is(err, tasks.ErrSeriesNotActive):
+			c.JSON(http.StatusConflict, types.NewErrorResponse(err))
+		default:
+			c.JSON(http.StatusInternalServerError, types.NewErrorResponse(err))
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, res)
+}
@@ -0,0 +1,165 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"handworks-api/tasks"
+	"handworks-api/types"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// CreateCalendarFeed godoc
+// @Summary Create a calendar feed
+// @Description Issues a private ICS feed URL for an employee's assignments or a customer's bookings. Employees and customers can create feeds for themselves; admins for anyone. The URL is only shown once
+// @Tags Booking
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param input body types.CreateCalendarFeedRequest true "Feed owner"
+// @Success 200 {object} types.CreateCalendarFeedResponse
+// @Failure 400 {object} types.ErrorResponse
+// @Failure 403 {object} types.ErrorResponse
+// @Failure 500 {object} types.ErrorResponse
+// @Router /booking/calendar/feeds [post]
+func (h *BookingHandler) CreateCalendarFeed(c *gin.Context) {
+	var req types.CreateCalendarFeedRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, types.NewErrorResponse(err))
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	feed, token, err := h.Service.CreateCalendarFeed(ctx, req, requestActor(c))
+	if err != nil {
+		c.JSON(calendarFeedErrorStatus(err), types.NewErrorResponse(err))
+		return
+	}
+
+	c.JSON(http.StatusOK, types.CreateCalendarFeedResponse{
+		Feed: *feed,
+		URL:  requestBaseURL(c) + tasks.CalendarFeedPath + token + ".ics",
+	})
+}
+
+// GetCalendarFeeds godoc
+// @Summary List calendar feeds
+// @Description Lists an employee's or customer's calendar feeds, including revoked ones
+// @Tags Booking
+// @Security BearerAuth
+// @Produce json
+// @Param ownerType query string true "EMPLOYEE or CUSTOMER"
+// @Param ownerId query string true "Employee or customer ID"
+// @Success 200 {array} types.CalendarFeed
+// @Failure 400 {object} types.ErrorResponse
+// @Failure 403 {object} types.ErrorResponse
+// @Failure 500 {object} types.ErrorResponse
+// @Router /booking/calendar/feeds [get]
+func (h *BookingHandler) GetCalendarFeeds(c *gin.Context) {
+	ownerType := types.CalendarFeedOwner(c.Query("ownerType"))
+	ownerID := c.Query("ownerId")
+	if (ownerType != types.CalendarFeedEmployee && ownerType != types.CalendarFeedCustomer) || ownerID == "" {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"status":  "error",
+			"message": "ownerType (EMPLOYEE or CUSTOMER) and ownerId query parameters are required",
+		})
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	feeds, err := h.Service.GetCalendarFeeds(ctx, ownerType, ownerID, requestActor(c))
+	if err != nil {
+		c.JSON(calendarFeedErrorStatus(err), types.NewErrorResponse(err))
+		return
+	}
+
+	c.JSON(http.StatusOK, feeds)
+}
+
+// RevokeCalendarFeed godoc
+// @Summary Revoke a calendar feed
+// @Description Stops the feed's URL from working. Calendar apps subscribed to it stop receiving updates
+// @Tags Booking
+// @Security BearerAuth
+// @Produce json
+// @Param id path string true "Feed ID"
+// @Success 200 {object} map[string]string
+// @Failure 400 {object} types.ErrorResponse
+// @Failure 403 {object} types.ErrorResponse
+// @Failure 500 {object} types.ErrorResponse
+// @Router /booking/calendar/feeds/{id} [delete]
+func (h *BookingHandler) RevokeCalendarFeed(c *gin.Context) {
+	feedID := c.Param("id")
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	if err := h.Service.RevokeCalendarFeed(ctx, feedID, requestActor(c)); err != nil {
+		c.JSON(calendarFeedErrorStatus(err), types.NewErrorResponse(err))
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"id": feedID, "status": "revoked"})
+}
+
+// ServeCalendarFeed godoc
+// @Summary Calendar feed
+// @Description Public ICS feed of the owner's bookings. The token in the URL is the only credential
+// @Tags Booking
+// @Produce text/calendar
+// @Param token path string true "Feed token, optionally ending in .ics"
+// @Success 200 {string} string "ICS calendar"
+// @Failure 404 {object} types.ErrorResponse
+// @Failure 500 {object} types.ErrorResponse
+// @Router /booking/calendar/ics/{token} [get]
+func (h *BookingHandler) ServeCalendarFeed(c *gin.Context) {
+	token := strings.TrimSuffix(c.Param("token"), ".ics")
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	ics, err := h.Service.RenderCalendarFeed(ctx, token)
+	if err != nil {
+		if errors.Is(err, tasks.ErrCalendarFeedNotFound) {
+			c.JSON(http.StatusNotFound, types.NewErrorResponse(err))
+			return
+		}
+		c.JSON(http.StatusInternalServerError, types.NewErrorResponse(err))
+		return
+	}
+
+	c.Header("Cache-Control", "private, no-cache")
+	c.Data(http.StatusOK, "text/calendar; charset=utf-8", ics)
+}
+
+// requestBaseURL is the scheme and host the client used to reach the API, honouring a
+// proxy's X-Forwarded-Proto.
+func requestBaseURL(c *gin.Context) string {
+	scheme := "http"
+	if c.Request.TLS != nil {
+		scheme = "https"
+	}
+	if proto := c.GetHeader("X-Forwarded-Proto"); proto != "" {
+		scheme = proto
+	}
+	return scheme + "://" + c.Request.Host
+}
+
+func calendarFeedErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, tasks.ErrCalendarFeedNotFound),
+		errors.Is(err, tasks.ErrCalendarFeedOwnerNotFound):
+		return http.StatusBadRequest
+	case errors.Is(err, tasks.ErrCalendarFeedDenied):
+		return http.StatusForbidden
+	default:
+		return http.StatusInternalServerError
+	}
+}
@@ -0,0 +1,205 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"handworks-api/tasks"
+	"handworks-api/types"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// GetBookingChecklist godoc
+// @Summary Get a booking's session checklist
+// @Description Returns the checklist items created for the booking when its session started, with the number of required items still outstanding
+// @Tags Booking
+// @Security BearerAuth
+// @Produce json
+// @Param id path string true "Booking ID"
+// @Success 200 {object} types.BookingChecklist
+// @Failure 400 {object} types.ErrorResponse
+// @Failure 500 {object} types.ErrorResponse
+// @Router /booking/session/{id}/checklist [get]
+func (h *BookingHandler) GetBookingChecklist(c *gin.Context) {
+	bookingID := c.Param("id")
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	res, err := h.Service.GetBookingChecklist(ctx, bookingID)
+	if err != nil {
+		c.JSON(checklistErrorStatus(err), types.NewErrorResponse(err))
+		return
+	}
+
+	c.JSON(http.StatusOK, res)
+}
+
+// UpdateChecklistItem godoc
+// @Summary Tick, skip or reopen a checklist item
+// @Description Updates an item of an ongoing session. Only the booking's cleaners and admins can update items. Skipping requires a reason
+// @Tags Booking
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param id path string true "Booking ID"
+// @Param itemId path string true "Checklist item ID"
+// @Param input body types.UpdateChecklistItemRequest true "New item status"
+// @Success 200 {object} types.BookingChecklistItem
+// @Failure 400 {object} types.ErrorResponse
+// @Failure 403 {object} types.ErrorResponse
+// @Failure 409 {object} types.ErrorResponse
+// @Failure 500 {object} types.ErrorResponse
+// @Router /booking/session/{id}/checklist/{itemId} [put]
+func (h *BookingHandler) UpdateChecklistItem(c *gin.Context) {
+	bookingID := c.Param("id")
+	itemID := c.Param("itemId")
+	var req types.UpdateChecklistItemRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, types.NewErrorResponse(err))
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	res, err := h.Service.UpdateChecklistItem(ctx, bookingID, itemID, req, requestActor(c))
+	if err != nil {
+		c.JSON(checklistErrorStatus(err), types.NewErrorResponse(err))
+		return
+	}
+
+	c.JSON(http.StatusOK, res)
+}
+
+func checklistErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, tasks.ErrBookingNotFound),
+		errors.Is(err, tasks.ErrChecklistItemNotFound),
+		errors.Is(err, tasks.ErrChecklistTemplateNotFound),
+		errors.Is(err, tasks.ErrInvalidChecklist),
+		errors.Is(err, tasks.ErrSkipReasonRequired):
+		return http.StatusBadRequest
+	case errors.Is(err, tasks.ErrChecklistDenied):
+		return http.StatusForbidden
+	case errors.Is(err, tasks.ErrSessionNotStarted),
+		errors.Is(err, tasks.ErrCleanerNotAssigned):
+		return http.StatusConflict
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+// GetChecklistTemplates godoc
+// @Summary List checklist templates
+// @Description Returns the active checklist templates, optionally for one service type
+// @Tags Admin
+// @Security BearerAuth
+// @Produce json
+// @Param serviceType query string false "Service type"
+// @Success 200 {array} types.ChecklistTemplate
+// @Failure 500 {object} types.ErrorResponse
+// @Router /admin/checklists [get]
+func (h *AdminHandler) GetChecklistTemplates(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	res, err := h.Service.GetChecklistTemplates(ctx, c.Query("serviceType"))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, types.NewErrorResponse(err))
+		return
+	}
+
+	c.JSON(http.StatusOK, res)
+}
+
+// CreateChecklistTemplate godoc
+// @Summary Create a checklist template
+// @Description Adds an item to the checklist of a service type, either as the main service (MAIN) or as an add-on (ADDON). It is copied onto bookings whose session starts afterwards
+// @Tags Admin
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param input body types.ChecklistTemplateRequest true "Checklist template"
+// @Success 201 {object} types.ChecklistTemplate
+// @Failure 400 {object} types.ErrorResponse
+// @Failure 500 {object} types.ErrorResponse
+// @Router /admin/checklists [post]
+func (h *AdminHandler) CreateChecklistTemplate(c *gin.Context) {
+	var req types.ChecklistTemplateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, types.NewErrorResponse(err))
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	res, err := h.Service.CreateChecklistTemplate(ctx, &req)
+	if err != nil {
+		c.JSON(checklistErrorStatus(err), types.NewErrorResponse(err))
+		return
+	}
+
+	c.JSON(http.StatusCreated, res)
+}
+
+// UpdateChecklistTemplate godoc
+// @Summary Update a checklist template
+// @Description Changes apply to sessions started afterwards; existing booking checklists are not touched
+// @Tags Admin
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param id path string true "Template ID"
+// @Param input body types.ChecklistTemplateRequest true "Checklist template"
+// @Success 200 {object} types.ChecklistTemplate
+// @Failure 400 {object} types.ErrorResponse
+// @Failure 500 {object} types.ErrorResponse
+// @Router /admin/checklists/{id} [put]
+func (h *AdminHandler) UpdateChecklistTemplate(c *gin.Context) {
+	templateID := c.Param("id")
+	var req types.ChecklistTemplateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, types.NewErrorResponse(err))
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	res, err := h.Service.UpdateChecklistTemplate(ctx, templateID, &req)
+	if err != nil {
+		c.JSON(checklistErrorStatus(err), types.NewErrorResponse(err))
+		return
+	}
+
+	c.JSON(http.StatusOK, res)
+}
+
+// DeleteChecklistTemplate godoc
+// @Summary Delete a checklist template
+// @Description Stops the template from being added to new sessions
+// @Tags Admin
+// @Security BearerAuth
+// @Produce json
+// @Param id path string true "Template ID"
+// @Success 200 {object} map[string]string
+// @Failure 400 {object} types.ErrorResponse
+// @Failure 500 {object} types.ErrorResponse
+// @Router /admin/checklists/{id} [delete]
+func (h *AdminHandler) DeleteChecklistTemplate(c *gin.Context) {
+	templateID := c.Param("id")
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	if err := h.Service.DeleteChecklistTemplate(ctx, templateID); err != nil {
+		c.JSON(checklistErrorStatus(err), types.NewErrorResponse(err))
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"id": templateID, "status": "deleted"})
+}
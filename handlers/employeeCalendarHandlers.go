@@ -0,0 +1,147 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"handworks-api/tasks"
+	"handworks-api/types"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// SubmitWeeklyAvailability godoc
+// @Summary Submit a weekly availability schedule
+// @Description Sends the recurring weekly windows an employee can work for admin approval. Once approved, cleaner allocation only uses the employee for jobs that fit inside one window. Weekday 0 is Sunday; times are HH:MM local time
+// @Security BearerAuth
+// @Tags Account
+// @Accept json
+// @Produce json
+// @Param id path string true "Employee ID"
+// @Param input body types.SubmitWeeklyAvailabilityRequest true "Weekly windows"
+// @Success 200 {object} types.WeeklyAvailability
+// @Failure 400 {object} types.ErrorResponse
+// @Failure 500 {object} types.ErrorResponse
+// @Router /account/employee/{id}/availability [put]
+func (h *AccountHandler) SubmitWeeklyAvailability(c *gin.Context) {
+	employeeID := c.Param("id")
+	var req types.SubmitWeeklyAvailabilityRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, types.NewErrorResponse(err))
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	res, err := h.Service.SubmitWeeklyAvailability(ctx, employeeID, req)
+	if err != nil {
+		c.JSON(calendarErrorStatus(err), types.NewErrorResponse(err))
+		return
+	}
+
+	c.JSON(http.StatusOK, res)
+}
+
+// RequestTimeOff godoc
+// @Summary Request time off
+// @Description Asks for leave or another one-off stretch of unavailability. Approved time off keeps the employee out of cleaner allocation for that window
+// @Security BearerAuth
+// @Tags Account
+// @Accept json
+// @Produce json
+// @Param id path string true "Employee ID"
+// @Param input body types.RequestTimeOffRequest true "Time off window"
+// @Success 201 {object} types.TimeOff
+// @Failure 400 {object} types.ErrorResponse
+// @Failure 500 {object} types.ErrorResponse
+// @Router /account/employee/{id}/time-off [post]
+func (h *AccountHandler) RequestTimeOff(c *gin.Context) {
+	employeeID := c.Param("id")
+	var req types.RequestTimeOffRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, types.NewErrorResponse(err))
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	res, err := h.Service.RequestTimeOff(ctx, employeeID, req)
+	if err != nil {
+		c.JSON(calendarErrorStatus(err), types.NewErrorResponse(err))
+		return
+	}
+
+	c.JSON(http.StatusCreated, res)
+}
+
+// CancelTimeOff godoc
+// @Summary Cancel time off
+// @Description Withdraws a pending time-off request, or approved time off that has not ended yet
+// @Security BearerAuth
+// @Tags Account
+// @Produce json
+// @Param id path string true "Employee ID"
+// @Param timeOffId path string true "Time off ID"
+// @Success 200 {object} types.TimeOff
+// @Failure 400 {object} types.ErrorResponse
+// @Failure 409 {object} types.ErrorResponse
+// @Failure 500 {object} types.ErrorResponse
+// @Router /account/employee/{id}/time-off/{timeOffId} [delete]
+func (h *AccountHandler) CancelTimeOff(c *gin.Context) {
+	employeeID := c.Param("id")
+	timeOffID := c.Param("timeOffId")
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	res, err := h.Service.CancelTimeOff(ctx, employeeID, timeOffID)
+	if err != nil {
+		c.JSON(calendarErrorStatus(err), types.NewErrorResponse(err))
+		return
+	}
+
+	c.JSON(http.StatusOK, res)
+}
+
+// GetEmployeeCalendar godoc
+// @Summary Get an employee's availability calendar
+// @Description Returns the approved and pending weekly schedules and upcoming time off
+// @Security BearerAuth
+// @Tags Account
+// @Produce json
+// @Param id path string true "Employee ID"
+// @Success 200 {object} types.EmployeeCalendar
+// @Failure 400 {object} types.ErrorResponse
+// @Failure 500 {object} types.ErrorResponse
+// @Router /account/employee/{id}/calendar [get]
+func (h *AccountHandler) GetEmployeeCalendar(c *gin.Context) {
+	employeeID := c.Param("id")
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	res, err := h.Service.GetEmployeeCalendar(ctx, employeeID)
+	if err != nil {
+		c.JSON(calendarErrorStatus(err), types.NewErrorResponse(err))
+		return
+	}
+
+	c.JSON(http.StatusOK, res)
+}
+
+func calendarErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, tasks.ErrInvalidAvailability),
+		errors.Is(err, tasks.ErrInvalidTimeOff),
+		errors.Is(err, tasks.ErrEmployeeNotFoundOrInactive),
+		errors.Is(err, tasks.ErrCalendarRequestNotFound):
+		return http.StatusBadRequest
+	case errors.Is(err, tasks.ErrCalendarRequestNotPending):
+		return http.StatusConflict
+	default:
+		return http.StatusInternalServerError
+	}
+}
@@ -1,8 +1,13 @@
 package handlers
 
 import (
+	"handworks-api/middleware"
 	"handworks-api/services"
+	"handworks-api/tasks"
 	"handworks-api/utils"
+
+	"github.com/clerk/clerk-sdk-go/v2"
+	"github.com/gin-gonic/gin"
 )
 
 // --- Account Handler ---
@@ -80,3 +85,26 @@ func NewNotificationHandler(service *services.NotificationService, logger *utils
 		Logger:  logger,
 	}
 }
+
+type MediaHandler struct {
+	Service *services.MediaService
+	Logger  *utils.Logger
+}
+
+func NewMediaHandler(service *services.MediaService, logger *utils.Logger) *MediaHandler {
+	return &MediaHandler{
+		Service: service,
+		Logger:  logger,
+	}
+}
+
+// requestActor returns the Clerk user ID of the caller, used to attribute booking
+// state changes. Requests on public paths carry no claims and are recorded as the system.
+func requestActor(c *gin.Context) string {
+	if v, ok := c.Get(string(middleware.ClerkClaimsKey)); ok {
+		if claims, ok := v.(*clerk.SessionClaims); ok && claims.Subject != "" {
+			return claims.Subject
+		}
+	}
+	return tasks.SystemActor
+}
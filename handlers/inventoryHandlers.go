@@ -3,6 +3,7 @@ package handlers
 import (
 	"context"
 	"errors"
+	"handworks-api/tasks"
 	"handworks-api/types"
 	"net/http"
 	"strconv"
@@ -179,3 +180,129 @@ func (h *InventoryHandler) DeleteItem(c *gin.Context) {
 
 	c.JSON(http.StatusOK, resp)
 }
+
+// CreateAllocationRule godoc
+// @Summary Create an inventory allocation rule
+// @Description Defines how much of an inventory item a service type needs. Bookings are allocated equipment and resources from these rules
+// @Security BearerAuth
+// @Tags Inventory
+// @Accept json
+// @Produce json
+// @Param input body types.CreateAllocationRuleRequest true "Rule info"
+// @Success 200 {object} types.AllocationRule
+// @Failure 400 {object} types.ErrorResponse
+// @Failure 500 {object} types.ErrorResponse
+// @Router /inventory/rules [post]
+func (h *InventoryHandler) CreateAllocationRule(c *gin.Context) {
+	var req types.CreateAllocationRuleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, types.NewErrorResponse(err))
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	resp, err := h.Service.CreateAllocationRule(ctx, req)
+	if err != nil {
+		if errors.Is(err, tasks.ErrInvalidAllocationRule) || errors.Is(err, tasks.ErrInventoryItemNotFound) {
+			c.JSON(http.StatusBadRequest, types.NewErrorResponse(err))
+			return
+		}
+		c.JSON(http.StatusInternalServerError, types.NewErrorResponse(err))
+		return
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+// GetAllocationRules godoc
+// @Summary Get inventory allocation rules
+// @Description Retrieve allocation rules, optionally for a single service type
+// @Security BearerAuth
+// @Tags Inventory
+// @Produce json
+// @Param serviceType query string false "Service type (GENERAL_CLEANING, COUCH, MATTRESS, CAR, POST)"
+// @Success 200 {object} types.AllocationRulesResponse
+// @Failure 500 {object} types.ErrorResponse
+// @Router /inventory/rules [get]
+func (h *InventoryHandler) GetAllocationRules(c *gin.Context) {
+	serviceType := c.Query("serviceType")
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	resp, err := h.Service.ListAllocationRules(ctx, serviceType)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, types.NewErrorResponse(err))
+		return
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+// UpdateAllocationRule godoc
+// @Summary Update an inventory allocation rule
+// @Description Change the quantities of an allocation rule. Omitted fields are left unchanged
+// @Security BearerAuth
+// @Tags Inventory
+// @Accept json
+// @Produce json
+// @Param id path string true "Rule ID"
+// @Param input body types.UpdateAllocationRuleRequest true "Updated quantities"
+// @Success 200 {object} types.AllocationRule
+// @Failure 400 {object} types.ErrorResponse
+// @Failure 404 {object} types.ErrorResponse
+// @Failure 500 {object} types.ErrorResponse
+// @Router /inventory/rules/{id} [put]
+func (h *InventoryHandler) UpdateAllocationRule(c *gin.Context) {
+	id := c.Param("id")
+	var req types.UpdateAllocationRuleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, types.NewErrorResponse(err))
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	resp, err := h.Service.UpdateAllocationRule(ctx, id, req)
+	if err != nil {
+		switch {
+		case errors.Is(err, tasks.ErrInvalidAllocationRule):
+			c.JSON(http.StatusBadRequest, types.NewErrorResponse(err))
+		case errors.Is(err, tasks.ErrAllocationRuleNotFound):
+			c.JSON(http.StatusNotFound, types.NewErrorResponse(err))
+		default:
+			c.JSON(http.StatusInternalServerError, types.NewErrorResponse(err))
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+// DeleteAllocationRule godoc
+// @Summary Delete an inventory allocation rule
+// @Description Remove an allocation rule by ID
+// @Security BearerAuth
+// @Tags Inventory
+// @Produce json
+// @Param id path string true "Rule ID"
+// @Success 200 {object} map[string]string
+// @Failure 404 {object} types.ErrorResponse
+// @Failure 500 {object} types.ErrorResponse
+// @Router /inventory/rules/{id} [delete]
+func (h *InventoryHandler) DeleteAllocationRule(c *gin.Context) {
+	id := c.Param("id")
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	if err := h.Service.DeleteAllocationRule(ctx, id); err != nil {
+		if errors.Is(err, tasks.ErrAllocationRuleNotFound) {
+			c.JSON(http.StatusNotFound, types.NewErrorResponse(err))
+			return
+		}
+		c.JSON(http.StatusInternalServerError, types.NewErrorResponse(err))
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "success"})
+}
@@ -0,0 +1,61 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"handworks-api/tasks"
+	"handworks-api/types"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+)
+
+// LocationPingWS handles location.ping messages on the employee websocket.
+func (h *BookingHandler) LocationPingWS(ctx context.Context, employeeID string, data json.RawMessage) error {
+	var msg types.LocationPingMessage
+	if err := json.Unmarshal(data, &msg); err != nil {
+		return err
+	}
+	if err := binding.Validator.ValidateStruct(&msg); err != nil {
+		return err
+	}
+
+	return h.Service.RecordLocationPing(ctx, employeeID, msg)
+}
+
+// GetBookingLocations godoc
+// @Summary Get cleaner locations for a booking
+// @Description Returns the latest position, distance and ETA of each cleaner on the booking who is en route or on duty. Live updates are sent as cleaner.location events. Only admins and the booking's customer can see them
+// @Tags Booking
+// @Security BearerAuth
+// @Produce json
+// @Param id path string true "Booking ID"
+// @Success 200 {array} types.CleanerLocation
+// @Failure 400 {object} types.ErrorResponse
+// @Failure 403 {object} types.ErrorResponse
+// @Failure 500 {object} types.ErrorResponse
+// @Router /booking/{id}/locations [get]
+func (h *BookingHandler) GetBookingLocations(c *gin.Context) {
+	bookingID := c.Param("id")
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	res, err := h.Service.GetBookingLocations(ctx, bookingID, requestActor(c))
+	if err != nil {
+		switch {
+		case errors.Is(err, tasks.ErrBookingNotFound):
+			c.JSON(http.StatusBadRequest, types.NewErrorResponse(err))
+		case errors.Is(err, tasks.ErrLocationDenied):
+			c.JSON(http.StatusForbidden, types.NewErrorResponse(err))
+		default:
+			c.JSON(http.StatusInternalServerError, types.NewErrorResponse(err))
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, res)
+}
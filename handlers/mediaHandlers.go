@@ -0,0 +1,195 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"handworks-api/tasks"
+	"handworks-api/types"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// multipartOverhead allows for the form fields and boundaries around the uploaded file.
+const multipartOverhead = 1 << 20
+
+// UploadMedia godoc
+// @Summary Upload a photo
+// @Description Uploads a JPEG or PNG for use as a booking photo, a session photo or a profile picture. A profile picture belongs to the employee or customer uploading it. The file type is checked from its contents, EXIF/XMP metadata such as GPS location is removed and a thumbnail is generated. Use the returned ID in bookings and sessions; the URLs are signed and expire
+// @Tags Media
+// @Security BearerAuth
+// @Accept multipart/form-data
+// @Produce json
+// @Param file formData file true "Image file"
+// @Param purpose formData string true "BOOKING, SESSION or PROFILE"
+// @Success 201 {object} types.Media
+// @Failure 400 {object} types.ErrorResponse
+// @Failure 413 {object} types.ErrorResponse
+// @Failure 415 {object} types.ErrorResponse
+// @Failure 500 {object} types.ErrorResponse
+// @Router /media [post]
+func (h *MediaHandler) UploadMedia(c *gin.Context) {
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, h.Service.Policy.MaxUploadBytes+multipartOverhead)
+
+	header, err := c.FormFile("file")
+	if err != nil {
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			c.JSON(http.StatusRequestEntityTooLarge, types.NewErrorResponse(tasks.ErrMediaTooLarge))
+			return
+		}
+		c.JSON(http.StatusBadRequest, types.NewErrorResponse(fmt.Errorf("file is required: %w", err)))
+		return
+	}
+	if header.Size > h.Service.Policy.MaxUploadBytes {
+		c.JSON(http.StatusRequestEntityTooLarge, types.NewErrorResponse(tasks.ErrMediaTooLarge))
+		return
+	}
+
+	file, err := header.Open()
+	if err != nil {
+		c.JSON(http.StatusBadRequest, types.NewErrorResponse(err))
+		return
+	}
+	defer file.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	purpose := types.MediaPurpose(c.PostForm("purpose"))
+	res, err := h.Service.Upload(ctx, file, purpose, requestActor(c))
+	if err != nil {
+		c.JSON(mediaErrorStatus(err), types.NewErrorResponse(err))
+		return
+	}
+
+	c.JSON(http.StatusCreated, res)
+}
+
+// GetMedia godoc
+// @Summary Get a photo
+// @Description Returns the media with freshly signed URLs. Admins can get any media; others only what they uploaded, their profile picture and the photos of bookings they are the customer or a cleaner of
+// @Tags Media
+// @Security BearerAuth
+// @Produce json
+// @Param id path string true "Media ID"
+// @Success 200 {object} types.Media
+// @Failure 400 {object} types.ErrorResponse
+// @Failure 403 {object} types.ErrorResponse
+// @Failure 500 {object} types.ErrorResponse
+// @Router /media/{id} [get]
+func (h *MediaHandler) GetMedia(c *gin.Context) {
+	mediaID := c.Param("id")
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	res, err := h.Service.GetMedia(ctx, mediaID, requestActor(c))
+	if err != nil {
+		c.JSON(mediaErrorStatus(err), types.NewErrorResponse(err))
+		return
+	}
+
+	c.JSON(http.StatusOK, res)
+}
+
+// ResolveMedia godoc
+// @Summary Get signed URLs for several photos
+// @Description Resolves the media IDs stored on bookings and sessions, e.g. a booking's photos, to signed URLs in one call. Fails unless the caller may get every one of them, as for GET /media/{id}
+// @Tags Media
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param input body types.ResolveMediaRequest true "Media IDs"
+// @Success 200 {object} types.ResolveMediaResponse
+// @Failure 400 {object} types.ErrorResponse
+// @Failure 403 {object} types.ErrorResponse
+// @Failure 500 {object} types.ErrorResponse
+// @Router /media/resolve [post]
+func (h *MediaHandler) ResolveMedia(c *gin.Context) {
+	var req types.ResolveMediaRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, types.NewErrorResponse(err))
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	res, err := h.Service.ResolveMedia(ctx, req.IDs, requestActor(c))
+	if err != nil {
+		c.JSON(mediaErrorStatus(err), types.NewErrorResponse(err))
+		return
+	}
+
+	c.JSON(http.StatusOK, res)
+}
+
+// ServeMedia godoc
+// @Summary Download a photo
+// @Description Serves the file behind a signed media URL. Needs no bearer token; the signature and expiry in the URL grant access
+// @Tags Media
+// @Produce image/jpeg,image/png
+// @Param id path string true "Media ID"
+// @Param variant query string true "original or thumbnail"
+// @Param expires query int true "Expiry as a Unix timestamp"
+// @Param signature query string true "URL signature"
+// @Success 200 {file} file
+// @Failure 400 {object} types.ErrorResponse
+// @Failure 403 {object} types.ErrorResponse
+// @Failure 500 {object} types.ErrorResponse
+// @Router /media/files/{id} [get]
+func (h *MediaHandler) ServeMedia(c *gin.Context) {
+	mediaID := c.Param("id")
+	expires := c.Query("expires")
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	file, media, err := h.Service.OpenMedia(
+		ctx,
+		mediaID,
+		types.MediaVariant(c.Query("variant")),
+		expires,
+		c.Query("signature"),
+	)
+	if err != nil {
+		c.JSON(mediaErrorStatus(err), types.NewErrorResponse(err))
+		return
+	}
+	defer file.Close()
+
+	// Browsers may cache the file for as long as the link it came from is valid.
+	unix, _ := strconv.ParseInt(expires, 10, 64)
+	maxAge := max(unix-time.Now().Unix(), 0)
+	c.DataFromReader(http.StatusOK, -1, media.ContentType, file, map[string]string{
+		"Cache-Control":          fmt.Sprintf("private, max-age=%d", maxAge),
+		"X-Content-Type-Options": "nosniff",
+	})
+}
+
+// isMediaReferenceError reports whether a booking or session referenced media that does
+// not exist or was uploaded for something else.
+func isMediaReferenceError(err error) bool {
+	return errors.Is(err, tasks.ErrMediaNotFound) || errors.Is(err, tasks.ErrMediaPurposeMismatched)
+}
+
+func mediaErrorStatus(err error) int {
+	switch {
+	case isMediaReferenceError(err),
+		errors.Is(err, tasks.ErrInvalidMediaPurpose):
+		return http.StatusBadRequest
+	case errors.Is(err, tasks.ErrInvalidMediaSignature),
+		errors.Is(err, tasks.ErrMediaDenied):
+		return http.StatusForbidden
+	case errors.Is(err, tasks.ErrMediaTooLarge):
+		return http.StatusRequestEntityTooLarge
+	case errors.Is(err, tasks.ErrUnsupportedMediaType):
+		return http.StatusUnsupportedMediaType
+	default:
+		return http.StatusInternalServerError
+	}
+}
@@ -0,0 +1,57 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"handworks-api/tasks"
+	"handworks-api/types"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ReportCustomerNoShow godoc
+// @Summary Report a customer no-show
+// @Description Closes a booking as NO_SHOW because the customer was not there. Cleaners on the booking can report it once the wait time past the start has passed and must send a location inside the booking's geofence; admins can report it at any time. It is settled like a cancellation at the start time and any remaining days of a multi-day job are cancelled
+// @Tags Booking
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param input body types.ReportCustomerNoShowRequest true "Customer no-show payload"
+// @Success 200 {object} types.CancelBookingResponse
+// @Failure 400 {object} types.ErrorResponse
+// @Failure 403 {object} types.ErrorResponse
+// @Failure 409 {object} types.ErrorResponse
+// @Failure 500 {object} types.ErrorResponse
+// @Router /booking/session/no-show [post]
+func (h *BookingHandler) ReportCustomerNoShow(c *gin.Context) {
+	var req types.ReportCustomerNoShowRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, types.NewErrorResponse(err))
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	res, err := h.Service.ReportCustomerNoShow(ctx, req, requestActor(c))
+	if err != nil {
+		switch {
+		case errors.Is(err, tasks.ErrBookingNotFound), errors.Is(err, tasks.ErrNoShowLocation):
+			c.JSON(http.StatusBadRequest, types.NewErrorResponse(err))
+		case errors.Is(err, tasks.ErrNoShowReportDenied),
+			errors.Is(err, tasks.ErrNoShowTooEarly),
+			errors.Is(err, tasks.ErrOutsideGeofence),
+			errors.Is(err, tasks.ErrGeofenceUnverifiable):
+			c.JSON(http.StatusForbidden, types.NewErrorResponse(err))
+		case errors.Is(err, tasks.ErrInvalidTransition):
+			c.JSON(http.StatusConflict, types.NewErrorResponse(err))
+		default:
+			c.JSON(http.StatusInternalServerError, types.NewErrorResponse(err))
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, res)
+}
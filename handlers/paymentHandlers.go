@@ -3,6 +3,7 @@ package handlers
 import (
 	"context"
 	"errors"
+	"handworks-api/tasks"
 	"handworks-api/types"
 	"net/http"
 	"strconv"
@@ -14,7 +15,7 @@ import (
 
 // MakeQuotation godoc
 // @Summary Create a quotation
-// @Description Generate a new quotation for a customer
+// @Description Generate a new quotation for a customer. Jobs longer than the daily limit get a dayPlan splitting hours and price across consecutive days. A dirtyScale above the base levels adds a dirtyScaleSurcharge and dirtyScaleHours to the totals. The address must be inside an active service zone, whose travelFee is included in the total
 // @Security BearerAuth
 // @Tags Payment
 // @Accept json
@@ -40,7 +41,9 @@ func (h *PaymentHandler) MakeQuotation(c *gin.Context) {
 		if strings.Contains(err.Error(), "exceed maximum allowed limit") ||
 			strings.Contains(err.Error(), "validation failed") ||
 			strings.Contains(err.Error(), "areas above 100 SQM") ||
-			strings.Contains(err.Error(), "daily limit") {
+			strings.Contains(err.Error(), "daily limit") ||
+			errors.Is(err, tasks.ErrInvalidDirtyScale) ||
+			isServiceAreaError(err) {
 			// Return 400 Bad Request for validation errors
 			c.JSON(http.StatusBadRequest, types.NewErrorResponse(err))
 			return
@@ -55,7 +58,7 @@ func (h *PaymentHandler) MakeQuotation(c *gin.Context) {
 
 // MakePublicQuotation godoc
 // @Summary Create a quotation
-// @Description Generate a new quotation
+// @Description Generate a new quotation. Jobs longer than the daily limit get a dayPlan splitting hours and price across consecutive days. A dirtyScale above the base levels adds a dirtyScaleSurcharge and dirtyScaleHours to the totals. The address must be inside an active service zone, whose travelFee is included in the total
 // @Tags Payment
 // @Accept json
 // @Produce json
@@ -80,7 +83,9 @@ func (h *PaymentHandler) MakePublicQuotation(c *gin.Context) {
 		if strings.Contains(err.Error(), "exceed maximum allowed limit") ||
 			strings.Contains(err.Error(), "validation failed") ||
 			strings.Contains(err.Error(), "areas above 100 SQM") ||
-			strings.Contains(err.Error(), "daily limit") {
+			strings.Contains(err.Error(), "daily limit") ||
+			errors.Is(err, tasks.ErrInvalidDirtyScale) ||
+			isServiceAreaError(err) {
 			// Return 400 Bad Request for validation errors
 			c.JSON(http.StatusBadRequest, types.NewErrorResponse(err))
 			return
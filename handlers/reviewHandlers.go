@@ -0,0 +1,165 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"handworks-api/tasks"
+	"handworks-api/types"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// SubmitReview godoc
+// @Summary Review a completed booking
+// @Description Rates a completed booking overall and, optionally, per cleaner. Assigned cleaners without their own rating get the overall one. Each rating is added to the cleaner's performance score. Only the booking's customer can review it
+// @Tags Booking
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param input body types.SubmitReviewRequest true "Review"
+// @Success 201 {object} types.BookingReview
+// @Failure 400 {object} types.ErrorResponse
+// @Failure 403 {object} types.ErrorResponse
+// @Failure 409 {object} types.ErrorResponse
+// @Failure 500 {object} types.ErrorResponse
+// @Router /booking/review [post]
+func (h *BookingHandler) SubmitReview(c *gin.Context) {
+	var req types.SubmitReviewRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, types.NewErrorResponse(err))
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	res, err := h.Service.SubmitReview(ctx, req, requestActor(c))
+	if err != nil {
+		c.JSON(reviewErrorStatus(err), types.NewErrorResponse(err))
+		return
+	}
+
+	c.JSON(http.StatusCreated, res)
+}
+
+// GetBookingReview godoc
+// @Summary Get a booking's review
+// @Tags Booking
+// @Security BearerAuth
+// @Produce json
+// @Param bookingId query string true "Booking ID"
+// @Success 200 {object} types.BookingReview
+// @Failure 400 {object} types.ErrorResponse
+// @Failure 500 {object} types.ErrorResponse
+// @Router /booking/review [get]
+func (h *BookingHandler) GetBookingReview(c *gin.Context) {
+	bookingID := c.Query("bookingId")
+	if bookingID == "" {
+		c.JSON(http.StatusBadRequest, types.NewErrorResponse(errors.New("bookingId query parameter is required")))
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	res, err := h.Service.GetBookingReview(ctx, bookingID)
+	if err != nil {
+		c.JSON(reviewErrorStatus(err), types.NewErrorResponse(err))
+		return
+	}
+
+	c.JSON(http.StatusOK, res)
+}
+
+// GetReviews godoc
+// @Summary List booking reviews
+// @Description Returns reviews newest first, optionally only those with the given moderation status
+// @Tags Admin
+// @Security BearerAuth
+// @Produce json
+// @Param status query string false "PUBLISHED, HIDDEN or REJECTED"
+// @Param page query int false "Page number" default(0)
+// @Param limit query int false "Items per page" default(10)
+// @Success 200 {object} types.FetchReviewsResponse
+// @Failure 400 {object} types.ErrorResponse
+// @Failure 500 {object} types.ErrorResponse
+// @Router /admin/reviews [get]
+func (h *AdminHandler) GetReviews(c *gin.Context) {
+	pageStr := c.DefaultQuery("page", "0")
+	limitStr := c.DefaultQuery("limit", "10")
+	page, err := strconv.Atoi(pageStr)
+	if err != nil || page < 0 {
+		c.JSON(http.StatusBadRequest, types.NewErrorResponse(errors.New("invalid page")))
+		return
+	}
+
+	limit, err := strconv.Atoi(limitStr)
+	if err != nil || limit <= 0 {
+		c.JSON(http.StatusBadRequest, types.NewErrorResponse(errors.New("invalid limit")))
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	res, err := h.Service.GetReviews(ctx, c.Query("status"), page, limit)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, types.NewErrorResponse(err))
+		return
+	}
+
+	c.JSON(http.StatusOK, res)
+}
+
+// ModerateReview godoc
+// @Summary Moderate a booking review
+// @Description HIDDEN keeps the review out of public listings but its ratings still count. REJECTED also removes its ratings from the cleaners' performance scores; moving it back restores them
+// @Tags Admin
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param id path string true "Review ID"
+// @Param input body types.ModerateReviewRequest true "Moderation decision"
+// @Success 200 {object} types.BookingReview
+// @Failure 400 {object} types.ErrorResponse
+// @Failure 500 {object} types.ErrorResponse
+// @Router /admin/reviews/{id}/moderate [post]
+func (h *AdminHandler) ModerateReview(c *gin.Context) {
+	reviewID := c.Param("id")
+	var req types.ModerateReviewRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, types.NewErrorResponse(err))
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	res, err := h.Service.ModerateReview(ctx, reviewID, &req, requestActor(c))
+	if err != nil {
+		c.JSON(reviewErrorStatus(err), types.NewErrorResponse(err))
+		return
+	}
+
+	c.JSON(http.StatusOK, res)
+}
+
+func reviewErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, tasks.ErrBookingNotFound),
+		errors.Is(err, tasks.ErrReviewNotFound),
+		errors.Is(err, tasks.ErrInvalidReview),
+		errors.Is(err, tasks.ErrCleanerNotAssigned):
+		return http.StatusBadRequest
+	case errors.Is(err, tasks.ErrReviewNotAllowed):
+		return http.StatusForbidden
+	case errors.Is(err, tasks.ErrBookingNotReviewable),
+		errors.Is(err, tasks.ErrBookingAlreadyReviewed):
+		return http.StatusConflict
+	default:
+		return http.StatusInternalServerError
+	}
+}
@@ -0,0 +1,140 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"handworks-api/tasks"
+	"handworks-api/types"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+func serviceZoneErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, tasks.ErrInvalidServiceZone),
+		errors.Is(err, tasks.ErrServiceZoneNotFound):
+		return http.StatusBadRequest
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+// isServiceAreaError reports errors about where an address is or what its zone requires.
+func isServiceAreaError(err error) bool {
+	return errors.Is(err, tasks.ErrOutsideServiceArea) ||
+		errors.Is(err, tasks.ErrBelowZoneMinimum) ||
+		errors.Is(err, tasks.ErrServiceZoneMismatch)
+}
+
+// GetServiceZones godoc
+// @Summary List service zones
+// @Description Returns every service zone, active ones first
+// @Tags Admin
+// @Security BearerAuth
+// @Produce json
+// @Success 200 {array} types.ServiceZone
+// @Failure 500 {object} types.ErrorResponse
+// @Router /admin/zones [get]
+func (h *AdminHandler) GetServiceZones(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	res, err := h.Service.GetServiceZones(ctx)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, types.NewErrorResponse(err))
+		return
+	}
+
+	c.JSON(http.StatusOK, res)
+}
+
+// CreateServiceZone godoc
+// @Summary Create a service zone
+// @Description Adds an area bookings are taken in. The boundary is a GeoJSON Polygon or MultiPolygon geometry with [longitude, latitude] positions. Quotes for addresses in the zone include its travel fee and must reach its minimum order value. Zones are active unless active is false
+// @Tags Admin
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param input body types.ServiceZoneRequest true "Service zone"
+// @Success 201 {object} types.ServiceZone
+// @Failure 400 {object} types.ErrorResponse
+// @Failure 500 {object} types.ErrorResponse
+// @Router /admin/zones [post]
+func (h *AdminHandler) CreateServiceZone(c *gin.Context) {
+	var req types.ServiceZoneRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, types.NewErrorResponse(err))
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	res, err := h.Service.CreateServiceZone(ctx, &req)
+	if err != nil {
+		c.JSON(serviceZoneErrorStatus(err), types.NewErrorResponse(err))
+		return
+	}
+
+	c.JSON(http.StatusCreated, res)
+}
+
+// UpdateServiceZone godoc
+// @Summary Update a service zone
+// @Description Changes apply to new quotes and bookings. Set active to reactivate or deactivate the zone; it is left as is when omitted
+// @Tags Admin
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param id path string true "Zone ID"
+// @Param input body types.ServiceZoneRequest true "Service zone"
+// @Success 200 {object} types.ServiceZone
+// @Failure 400 {object} types.ErrorResponse
+// @Failure 500 {object} types.ErrorResponse
+// @Router /admin/zones/{id} [put]
+func (h *AdminHandler) UpdateServiceZone(c *gin.Context) {
+	zoneID := c.Param("id")
+	var req types.ServiceZoneRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, types.NewErrorResponse(err))
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	res, err := h.Service.UpdateServiceZone(ctx, zoneID, &req)
+	if err != nil {
+		c.JSON(serviceZoneErrorStatus(err), types.NewErrorResponse(err))
+		return
+	}
+
+	c.JSON(http.StatusOK, res)
+}
+
+// DeleteServiceZone godoc
+// @Summary Delete a service zone
+// @Description Deactivates the zone so new addresses, quotes and bookings in it are refused. Existing bookings are kept
+// @Tags Admin
+// @Security BearerAuth
+// @Produce json
+// @Param id path string true "Zone ID"
+// @Success 200 {object} map[string]string
+// @Failure 400 {object} types.ErrorResponse
+// @Failure 500 {object} types.ErrorResponse
+// @Router /admin/zones/{id} [delete]
+func (h *AdminHandler) DeleteServiceZone(c *gin.Context) {
+	zoneID := c.Param("id")
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	if err := h.Service.DeleteServiceZone(ctx, zoneID); err != nil {
+		c.JSON(serviceZoneErrorStatus(err), types.NewErrorResponse(err))
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"id": zoneID, "status": "deleted"})
+}
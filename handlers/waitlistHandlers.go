@@ -0,0 +1,121 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"handworks-api/tasks"
+	"handworks-api/types"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// JoinWaitlist godoc
+// @Summary Join the waitlist for a fully booked slot
+// @Description Queues a booking request whose slot has no free cleaners. The entry is booked automatically when a conflicting booking is cancelled or a cleaner becomes active, and expires after the configured waitlist time or at the slot's start. Access instructions are kept encrypted and given to the booking the entry becomes
+// @Tags Booking
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param input body types.CreateBookingRequest true "Booking info"
+// @Success 200 {object} types.WaitlistEntry
+// @Failure 400 {object} types.ErrorResponse
+// @Failure 409 {object} types.ErrorResponse
+// @Failure 500 {object} types.ErrorResponse
+// @Failure 503 {object} types.ErrorResponse
+// @Router /booking/waitlist [post]
+func (h *BookingHandler) JoinWaitlist(c *gin.Context) {
+	var req types.CreateBookingRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, types.NewErrorResponse(err))
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	res, err := h.Service.JoinWaitlist(ctx, req)
+	if err != nil {
+		switch {
+		case errors.Is(err, tasks.ErrInvalidSchedule), errors.Is(err, tasks.ErrDirtyScaleMismatch),
+			isServiceAreaError(err), isMediaReferenceError(err), errors.Is(err, tasks.ErrInvalidAccessInstructions):
+			c.JSON(http.StatusBadRequest, types.NewErrorResponse(err))
+		case errors.Is(err, tasks.ErrOrderAlreadyBooked), errors.Is(err, tasks.ErrOrderAlreadyWaitlisted):
+			c.JSON(http.StatusConflict, types.NewErrorResponse(err))
+		case errors.Is(err, tasks.ErrAccessInstructionsDisabled):
+			c.JSON(http.StatusServiceUnavailable, types.NewErrorResponse(err))
+		default:
+			c.JSON(http.StatusInternalServerError, types.NewErrorResponse(err))
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, res)
+}
+
+// GetWaitlistEntry godoc
+// @Summary Get a waitlist entry
+// @Description Returns a waitlist entry with its status and, once promoted, the booking it became
+// @Tags Booking
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param id path string true "Waitlist entry ID"
+// @Success 200 {object} types.WaitlistEntry
+// @Failure 400 {object} types.ErrorResponse
+// @Failure 500 {object} types.ErrorResponse
+// @Router /booking/waitlist/{id} [get]
+func (h *BookingHandler) GetWaitlistEntry(c *gin.Context) {
+	entryID := c.Param("id")
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 10*time.Second)
+	defer cancel()
+
+	res, err := h.Service.GetWaitlistEntry(ctx, entryID)
+	if err != nil {
+		if errors.Is(err, tasks.ErrWaitlistEntryNotFound) {
+			c.JSON(http.StatusBadRequest, types.NewErrorResponse(err))
+			return
+		}
+		c.JSON(http.StatusInternalServerError, types.NewErrorResponse(err))
+		return
+	}
+
+	c.JSON(http.StatusOK, res)
+}
+
+// LeaveWaitlist godoc
+// @Summary Leave the waitlist
+// @Description Cancels a waitlist entry that has not been promoted yet
+// @Tags Booking
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param id path string true "Waitlist entry ID"
+// @Success 200 {object} types.WaitlistEntry
+// @Failure 400 {object} types.ErrorResponse
+// @Failure 409 {object} types.ErrorResponse
+// @Failure 500 {object} types.ErrorResponse
+// @Router /booking/waitlist/{id} [delete]
+func (h *BookingHandler) LeaveWaitlist(c *gin.Context) {
+	entryID := c.Param("id")
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 10*time.Second)
+	defer cancel()
+
+	res, err := h.Service.LeaveWaitlist(ctx, entryID)
+	if err != nil {
+		switch {
+		case errors.Is(err, tasks.ErrWaitlistEntryNotFound):
+			c.JSON(http.StatusBadRequest, types.NewErrorResponse(err))
+		case errors.Is(err, tasks.ErrWaitlistEntryClosed):
+			c.JSON(http.StatusConflict, types.NewErrorResponse(err))
+		default:
+			c.JSON(http.StatusInternalServerError, types.NewErrorResponse(err))
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, res)
+}
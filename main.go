@@ -12,6 +12,7 @@ import (
 	"handworks-api/utils"
 	"os"
 	"strconv"
+	"time"
 
 	_ "handworks-api/docs"
 
@@ -79,22 +80,57 @@ func main() {
 	publicPaths := []string{
 		"/api/account/address",
 		"/api/account/phones",
-		"/api/ws/employee",
 		"/api/account/customer/signup",
 		"/api/account/employee/signup",
 		"/api/account/admin/signup",
 		"/api/payment/quote/preview",
 		"/api/payment/webhooks/paymongo",
+		"/api/media/files",
+		"/api/booking/calendar/ics",
 	}
 
 	// websocket
 	hubs := realtime.NewRealtimeHubs(logger)
 
+	accessPolicy, err := config.NewAccessInstructionsPolicy()
+	if err != nil {
+		logger.Fatal("Invalid access instructions config: %v", err)
+	}
+	if len(accessPolicy.Key) == 0 {
+		logger.Warn("ACCESS_INSTRUCTIONS_KEY not set, booking access instructions disabled")
+	}
+
 	accountService := services.NewAccountService(conn, logger)
 	inventoryService := services.NewInventoryService(conn, logger)
-	paymentService := services.NewPaymentService(conn, logger, paymongoClient)
-	bookingService := services.NewBookingService(conn, logger, paymentService)
-	adminServie := services.NewAdminService(conn, logger, accountService)
+	businessHours, err := config.NewBusinessHours()
+	if err != nil {
+		logger.Fatal("Invalid business hours config: %v", err)
+	}
+
+	dirtyScalePolicy := config.NewDirtyScalePolicy()
+	largeAreaRate := config.NewLargeAreaRate()
+	paymentService := services.NewPaymentService(conn, logger, paymongoClient, dirtyScalePolicy, largeAreaRate)
+	bookingService, err := services.NewBookingService(conn, logger, paymentService, services.BookingPolicies{
+		Cancellation:       config.NewCancellationPolicy(),
+		Travel:             config.NewTravelPolicy(),
+		WaitlistExpiry:     config.NewWaitlistExpiry(),
+		BusinessHours:      businessHours,
+		Overtime:           config.NewOvertimePolicy(),
+		AccessInstructions: accessPolicy,
+		Geofence:           config.NewGeofencePolicy(),
+		Location:           config.NewLocationPolicy(),
+		NoShow:             config.NewNoShowPolicy(),
+		DirtyScale:         dirtyScalePolicy,
+		LargeAreaRate:      largeAreaRate,
+	})
+	if err != nil {
+		logger.Fatal("Failed to initialize booking service: %v", err)
+	}
+	adminServie := services.NewAdminService(conn, logger, accountService, businessHours.Location)
+	if os.Getenv("MEDIA_URL_SECRET") == "" {
+		logger.Warn("MEDIA_URL_SECRET not set, signed media URLs will stop working on restart")
+	}
+	mediaService := services.NewMediaService(conn, logger, config.NewMediaPolicy())
 
 	fcmCredentialsFile := os.Getenv("FIREBASE_CREDENTIALS_FILE")
 
@@ -131,6 +167,7 @@ func main() {
 	paymentHandler := handlers.NewPaymentHandler(paymentService, logger)
 	adminHandler := handlers.NewAdminHandler(adminServie, logger)
 	notificationHandler := handlers.NewNotificationHandler(notificationService, logger)
+	mediaHandler := handlers.NewMediaHandler(mediaService, logger)
 
 	api := router.Group("/api")
 	api.Use(middleware.ClerkAuthMiddleware(publicPaths, logger))
@@ -141,9 +178,12 @@ func main() {
 		endpoints.PaymentEndpoint(api.Group("/payment"), paymentHandler)
 		endpoints.AdminEndpoint(api.Group("/admin"), adminHandler)
 		endpoints.NotificationEndpoint(api.Group("/notifications"), notificationHandler)
-		endpoints.RealtimeEndpoint(api, hubs)
+		endpoints.MediaEndpoint(api.Group("/media"), mediaHandler)
+		endpoints.RealtimeEndpoint(api, hubs, bookingService.ResolveEmployeeID)
 	}
 
+	hubs.EmployeeHub.Handle("location.ping", bookingHandler.LocationPingWS)
+
 	// running websocket hubs
 	go hubs.EmployeeHub.Run()
 	go hubs.AdminHub.Run()
@@ -167,6 +207,18 @@ func main() {
 		}
 	}()
 
+	// recurring booking series generation
+	go bookingService.RunSeriesGenerator(c, time.Hour)
+
+	// waitlist expiry and promotion retries
+	go bookingService.RunWaitlistMonitor(c, 5*time.Minute)
+
+	// live location retention
+	go bookingService.RunLocationRetention(c, 15*time.Minute)
+
+	// late start and no-show detection
+	go bookingService.RunNoShowMonitor(c, time.Minute)
+
 	port := "8080"
 	logger.Info("Starting server on port %s", port)
 	logger.Info("Swagger on localhost:8080/swagger/index.html")
@@ -0,0 +1,17 @@
+-- Cancellation records for bookings cancelled by admins or customers, with the
+-- refund tier applied at the time of cancelling.
+
+CREATE TABLE IF NOT EXISTS booking.cancellations (
+    id                 uuid PRIMARY KEY DEFAULT gen_random_uuid(),
+    booking_id         uuid NOT NULL REFERENCES booking.bookings (id) ON DELETE CASCADE,
+    cleaner_ids        uuid[] NOT NULL DEFAULT '{}',
+    reason             text,
+    outcome            text NOT NULL,
+    hours_before_start double precision NOT NULL,
+    amount_paid        real NOT NULL DEFAULT 0,
+    refund_amount      real NOT NULL DEFAULT 0,
+    cancellation_fee   real NOT NULL DEFAULT 0,
+    created_at         timestamptz NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS cancellations_booking_id_idx ON booking.cancellations (booking_id);
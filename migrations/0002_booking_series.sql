@@ -0,0 +1,41 @@
+-- Recurring booking series and the occurrences generated from them.
+
+CREATE TABLE IF NOT EXISTS booking.booking_series (
+    id              uuid PRIMARY KEY DEFAULT gen_random_uuid(),
+    customer_id     uuid NOT NULL REFERENCES account.customers (id),
+    quote_id        uuid NOT NULL REFERENCES payment.quotes (id),
+    payment_method  text,
+    frequency       text NOT NULL,
+    status          text NOT NULL DEFAULT 'ACTIVE',
+    anchor_start    timestamptz NOT NULL,
+    anchor_end      timestamptz NOT NULL,
+    end_date        timestamptz,
+    max_occurrences integer,
+    subtotal        real NOT NULL DEFAULT 0,
+    addon_total     real NOT NULL DEFAULT 0,
+    total_amount    real NOT NULL DEFAULT 0,
+    template        jsonb NOT NULL,
+    created_at      timestamptz NOT NULL DEFAULT NOW(),
+    updated_at      timestamptz NOT NULL DEFAULT NOW(),
+    ended_at        timestamptz
+);
+
+CREATE INDEX IF NOT EXISTS booking_series_status_idx ON booking.booking_series (status);
+
+CREATE TABLE IF NOT EXISTS booking.series_occurrences (
+    id               uuid PRIMARY KEY DEFAULT gen_random_uuid(),
+    series_id        uuid NOT NULL REFERENCES booking.booking_series (id) ON DELETE CASCADE,
+    occurrence_index integer NOT NULL,
+    start_sched      timestamptz NOT NULL,
+    end_sched        timestamptz NOT NULL,
+    status           text NOT NULL DEFAULT 'PENDING',
+    booking_id       uuid REFERENCES booking.bookings (id) ON DELETE SET NULL,
+    order_id         uuid REFERENCES payment.orders (id) ON DELETE SET NULL,
+    conflict_reason  text,
+    created_at       timestamptz NOT NULL DEFAULT NOW(),
+    updated_at       timestamptz NOT NULL DEFAULT NOW(),
+    UNIQUE (series_id, occurrence_index)
+);
+
+CREATE INDEX IF NOT EXISTS series_occurrences_status_start_idx
+    ON booking.series_occurrences (status, start_sched);
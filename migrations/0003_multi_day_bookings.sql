@@ -0,0 +1,15 @@
+-- Quotes longer than a single working day are split into a day plan, and the
+-- bookings created for each day are linked under the first day's booking id.
+
+ALTER TABLE payment.quotes
+    ADD COLUMN IF NOT EXISTS service_days integer NOT NULL DEFAULT 1,
+    ADD COLUMN IF NOT EXISTS day_plan     jsonb;
+
+ALTER TABLE booking.bookings
+    ADD COLUMN IF NOT EXISTS day_group_id uuid,
+    ADD COLUMN IF NOT EXISTS day_index    integer,
+    ADD COLUMN IF NOT EXISTS day_count    integer;
+
+CREATE INDEX IF NOT EXISTS bookings_day_group_id_idx
+    ON booking.bookings (day_group_id)
+    WHERE day_group_id IS NOT NULL;
@@ -0,0 +1,17 @@
+-- Rules that size the inventory allocated to a booking from its service type,
+-- area, units and dirty scale.
+
+CREATE TABLE IF NOT EXISTS inventory.allocation_rules (
+    id                 uuid PRIMARY KEY DEFAULT gen_random_uuid(),
+    service_type       text NOT NULL,
+    item_id            uuid NOT NULL REFERENCES inventory.items (id) ON DELETE CASCADE,
+    base_quantity      double precision NOT NULL DEFAULT 0,
+    per_sqm            double precision NOT NULL DEFAULT 0,
+    per_unit           double precision NOT NULL DEFAULT 0,
+    dirty_scale_factor double precision NOT NULL DEFAULT 0,
+    created_at         timestamptz NOT NULL DEFAULT NOW(),
+    updated_at         timestamptz NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS allocation_rules_service_type_idx
+    ON inventory.allocation_rules (service_type);
@@ -0,0 +1,18 @@
+-- Audit trail of every booking lifecycle transition. actor is the Clerk user id
+-- of whoever made the change, or "system" for background jobs.
+
+CREATE TABLE IF NOT EXISTS booking.booking_status_history (
+    id                 uuid PRIMARY KEY DEFAULT gen_random_uuid(),
+    booking_id         uuid NOT NULL REFERENCES booking.bookings (id) ON DELETE CASCADE,
+    event              text NOT NULL,
+    from_status        text,
+    to_status          text NOT NULL,
+    from_review_status text,
+    to_review_status   text NOT NULL,
+    actor              text NOT NULL,
+    note               text,
+    created_at         timestamptz NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS booking_status_history_booking_id_idx
+    ON booking.booking_status_history (booking_id, created_at);
@@ -0,0 +1,24 @@
+-- Booking requests that could not get cleaners, kept until cleaners free up or
+-- the entry expires.
+
+CREATE TABLE IF NOT EXISTS booking.waitlist_entries (
+    id          uuid PRIMARY KEY DEFAULT gen_random_uuid(),
+    customer_id uuid NOT NULL REFERENCES account.customers (id),
+    order_id    uuid NOT NULL REFERENCES payment.orders (id),
+    start_sched timestamptz NOT NULL,
+    end_sched   timestamptz NOT NULL,
+    status      text NOT NULL DEFAULT 'WAITING',
+    booking_id  uuid REFERENCES booking.bookings (id) ON DELETE SET NULL,
+    request     jsonb NOT NULL,
+    expires_at  timestamptz NOT NULL,
+    created_at  timestamptz NOT NULL DEFAULT NOW(),
+    updated_at  timestamptz NOT NULL DEFAULT NOW()
+);
+
+-- An order can only wait in line once at a time.
+CREATE UNIQUE INDEX IF NOT EXISTS waitlist_entries_waiting_order_idx
+    ON booking.waitlist_entries (order_id)
+    WHERE status = 'WAITING';
+
+CREATE INDEX IF NOT EXISTS waitlist_entries_status_created_idx
+    ON booking.waitlist_entries (status, created_at);
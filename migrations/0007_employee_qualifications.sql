@@ -0,0 +1,9 @@
+-- Service types a cleaner is qualified for beyond general cleaning, which every
+-- cleaner can take.
+
+CREATE TABLE IF NOT EXISTS account.employee_qualifications (
+    employee_id  uuid NOT NULL REFERENCES account.employees (id) ON DELETE CASCADE,
+    service_type text NOT NULL,
+    created_at   timestamptz NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (employee_id, service_type)
+);
@@ -0,0 +1,42 @@
+-- Weekly availability and time off submitted by employees and reviewed by admins.
+-- Only APPROVED schedules and time off limit allocation.
+
+CREATE TABLE IF NOT EXISTS account.employee_availability_schedules (
+    id           uuid PRIMARY KEY DEFAULT gen_random_uuid(),
+    employee_id  uuid NOT NULL REFERENCES account.employees (id) ON DELETE CASCADE,
+    status       text NOT NULL DEFAULT 'PENDING',
+    submitted_at timestamptz NOT NULL DEFAULT NOW(),
+    reviewed_by  text,
+    reviewed_at  timestamptz
+);
+
+CREATE INDEX IF NOT EXISTS employee_availability_schedules_employee_status_idx
+    ON account.employee_availability_schedules (employee_id, status);
+
+CREATE TABLE IF NOT EXISTS account.employee_availability_windows (
+    id          uuid PRIMARY KEY DEFAULT gen_random_uuid(),
+    schedule_id uuid NOT NULL REFERENCES account.employee_availability_schedules (id) ON DELETE CASCADE,
+    weekday     integer NOT NULL CHECK (weekday BETWEEN 0 AND 6),
+    start_time  time NOT NULL,
+    end_time    time NOT NULL,
+    CHECK (end_time > start_time)
+);
+
+CREATE INDEX IF NOT EXISTS employee_availability_windows_schedule_id_idx
+    ON account.employee_availability_windows (schedule_id);
+
+CREATE TABLE IF NOT EXISTS account.employee_time_off (
+    id           uuid PRIMARY KEY DEFAULT gen_random_uuid(),
+    employee_id  uuid NOT NULL REFERENCES account.employees (id) ON DELETE CASCADE,
+    start_at     timestamptz NOT NULL,
+    end_at       timestamptz NOT NULL,
+    reason       text,
+    status       text NOT NULL DEFAULT 'PENDING',
+    requested_at timestamptz NOT NULL DEFAULT NOW(),
+    reviewed_by  text,
+    reviewed_at  timestamptz,
+    CHECK (end_at > start_at)
+);
+
+CREATE INDEX IF NOT EXISTS employee_time_off_employee_status_idx
+    ON account.employee_time_off (employee_id, status);
@@ -0,0 +1,37 @@
+-- Per-service checklist templates managed by admins, and the items copied from
+-- them onto each booking when its session starts.
+
+CREATE TABLE IF NOT EXISTS booking.checklist_templates (
+    id           uuid PRIMARY KEY DEFAULT gen_random_uuid(),
+    service_type text NOT NULL,
+    scope        text NOT NULL,
+    title        text NOT NULL,
+    description  text,
+    required     boolean NOT NULL DEFAULT TRUE,
+    position     integer NOT NULL DEFAULT 0,
+    active       boolean NOT NULL DEFAULT TRUE,
+    created_at   timestamptz NOT NULL DEFAULT NOW(),
+    updated_at   timestamptz NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS checklist_templates_service_type_idx
+    ON booking.checklist_templates (service_type)
+    WHERE active;
+
+CREATE TABLE IF NOT EXISTS booking.booking_checklist_items (
+    id           uuid PRIMARY KEY DEFAULT gen_random_uuid(),
+    booking_id   uuid NOT NULL REFERENCES booking.bookings (id) ON DELETE CASCADE,
+    template_id  uuid REFERENCES booking.checklist_templates (id) ON DELETE SET NULL,
+    service_type text NOT NULL,
+    scope        text NOT NULL,
+    title        text NOT NULL,
+    description  text,
+    required     boolean NOT NULL DEFAULT TRUE,
+    position     integer NOT NULL DEFAULT 0,
+    status       text NOT NULL DEFAULT 'PENDING',
+    skip_reason  text,
+    updated_by   text,
+    created_at   timestamptz NOT NULL DEFAULT NOW(),
+    updated_at   timestamptz,
+    UNIQUE (booking_id, template_id)
+);
@@ -0,0 +1,20 @@
+-- Session start, pause, resume and end events, and the measured duration and
+-- overtime stored on the session when it ends.
+
+CREATE TABLE IF NOT EXISTS booking.session_events (
+    id         bigserial PRIMARY KEY,
+    booking_id uuid NOT NULL REFERENCES booking.bookings (id) ON DELETE CASCADE,
+    event_type text NOT NULL,
+    actor      text,
+    reason     text,
+    created_at timestamptz NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS session_events_booking_id_idx
+    ON booking.session_events (booking_id, created_at);
+
+ALTER TABLE booking.sessions
+    ADD COLUMN IF NOT EXISTS worked_minutes  double precision,
+    ADD COLUMN IF NOT EXISTS paused_minutes  double precision,
+    ADD COLUMN IF NOT EXISTS overtime_hours  real,
+    ADD COLUMN IF NOT EXISTS overtime_charge real;
@@ -0,0 +1,31 @@
+-- Customer reviews of completed bookings and the per-cleaner ratings that feed
+-- account.employees.performance_score. counted tracks whether a rating is
+-- currently included in the cleaner's running average.
+
+CREATE TABLE IF NOT EXISTS booking.reviews (
+    id              uuid PRIMARY KEY DEFAULT gen_random_uuid(),
+    booking_id      uuid NOT NULL UNIQUE REFERENCES booking.bookings (id) ON DELETE CASCADE,
+    customer_id     uuid NOT NULL REFERENCES account.customers (id),
+    overall_rating  integer NOT NULL CHECK (overall_rating BETWEEN 1 AND 5),
+    comment         text,
+    status          text NOT NULL DEFAULT 'PUBLISHED',
+    moderation_note text,
+    moderated_by    text,
+    moderated_at    timestamptz,
+    created_at      timestamptz NOT NULL DEFAULT NOW(),
+    updated_at      timestamptz NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS reviews_status_created_idx
+    ON booking.reviews (status, created_at);
+
+CREATE TABLE IF NOT EXISTS booking.cleaner_ratings (
+    id          uuid PRIMARY KEY DEFAULT gen_random_uuid(),
+    review_id   uuid NOT NULL REFERENCES booking.reviews (id) ON DELETE CASCADE,
+    booking_id  uuid NOT NULL REFERENCES booking.bookings (id) ON DELETE CASCADE,
+    employee_id uuid NOT NULL REFERENCES account.employees (id) ON DELETE CASCADE,
+    rating      integer NOT NULL CHECK (rating BETWEEN 1 AND 5),
+    counted     boolean NOT NULL DEFAULT FALSE,
+    created_at  timestamptz NOT NULL DEFAULT NOW(),
+    UNIQUE (review_id, employee_id)
+);
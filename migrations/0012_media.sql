@@ -0,0 +1,17 @@
+-- Metadata of uploaded photos. The processed files live in the media storage
+-- directory under their id. owner_id is the customer or employee a profile
+-- picture belongs to.
+
+CREATE TABLE IF NOT EXISTS booking.media (
+    id           uuid PRIMARY KEY DEFAULT gen_random_uuid(),
+    purpose      text NOT NULL,
+    owner_id     text,
+    content_type text NOT NULL,
+    size_bytes   bigint NOT NULL,
+    width        integer NOT NULL,
+    height       integer NOT NULL,
+    uploaded_by  text NOT NULL,
+    created_at   timestamptz NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS media_uploaded_by_idx ON booking.media (uploaded_by);
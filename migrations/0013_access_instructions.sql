@@ -0,0 +1,33 @@
+-- Encrypted access instructions (gate codes, key locations, alarm notes) for
+-- bookings. ciphertext is sealed with the key named by key_id; rows sealed with
+-- an older key can no longer be read once the key changes.
+
+CREATE TABLE IF NOT EXISTS booking.access_instructions (
+    booking_id uuid PRIMARY KEY REFERENCES booking.bookings (id) ON DELETE CASCADE,
+    ciphertext bytea NOT NULL,
+    key_id     text NOT NULL,
+    updated_by text NOT NULL,
+    updated_at timestamptz NOT NULL DEFAULT NOW()
+);
+
+-- Instructions given before a booking exists, held under "waitlist:<entry id>"
+-- or "series:<series id>" until the booking is made.
+CREATE TABLE IF NOT EXISTS booking.held_access_instructions (
+    owner      text PRIMARY KEY,
+    ciphertext bytea NOT NULL,
+    key_id     text NOT NULL,
+    updated_at timestamptz NOT NULL DEFAULT NOW()
+);
+
+CREATE TABLE IF NOT EXISTS booking.access_instruction_logs (
+    id          uuid PRIMARY KEY DEFAULT gen_random_uuid(),
+    booking_id  uuid NOT NULL REFERENCES booking.bookings (id) ON DELETE CASCADE,
+    actor       text NOT NULL,
+    viewer_role text,
+    action      text NOT NULL,
+    reason      text,
+    created_at  timestamptz NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS access_instruction_logs_booking_id_idx
+    ON booking.access_instruction_logs (booking_id, created_at);
@@ -0,0 +1,16 @@
+-- Secret iCalendar feed links for employees and customers. Only the SHA-256 hash
+-- of a feed's token is stored.
+
+CREATE TABLE IF NOT EXISTS booking.calendar_feeds (
+    id               uuid PRIMARY KEY DEFAULT gen_random_uuid(),
+    owner_type       text NOT NULL,
+    owner_id         text NOT NULL,
+    token_hash       text NOT NULL UNIQUE,
+    created_by       text NOT NULL,
+    created_at       timestamptz NOT NULL DEFAULT NOW(),
+    last_accessed_at timestamptz,
+    revoked_at       timestamptz
+);
+
+CREATE INDEX IF NOT EXISTS calendar_feeds_owner_idx
+    ON booking.calendar_feeds (owner_type, owner_id);
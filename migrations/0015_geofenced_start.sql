@@ -0,0 +1,15 @@
+-- Where a session was started from and where an employee timed in, kept for
+-- disputes. start_distance_m is NULL when the booking address has no
+-- coordinates.
+
+ALTER TABLE booking.sessions
+    ADD COLUMN IF NOT EXISTS start_latitude       double precision,
+    ADD COLUMN IF NOT EXISTS start_longitude      double precision,
+    ADD COLUMN IF NOT EXISTS start_accuracy_m     double precision,
+    ADD COLUMN IF NOT EXISTS start_distance_m     double precision,
+    ADD COLUMN IF NOT EXISTS geofence_override_by text;
+
+ALTER TABLE account.employee_timesheet
+    ADD COLUMN IF NOT EXISTS time_in_latitude   double precision,
+    ADD COLUMN IF NOT EXISTS time_in_longitude  double precision,
+    ADD COLUMN IF NOT EXISTS time_in_accuracy_m double precision;
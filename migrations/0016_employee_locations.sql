@@ -0,0 +1,20 @@
+-- Latest position of each cleaner on the booking they are travelling to or
+-- working on. One row per employee; rows past the retention limit are purged.
+
+CREATE TABLE IF NOT EXISTS account.employee_locations (
+    employee_id uuid PRIMARY KEY REFERENCES account.employees (id) ON DELETE CASCADE,
+    booking_id  uuid NOT NULL REFERENCES booking.bookings (id) ON DELETE CASCADE,
+    latitude    double precision NOT NULL,
+    longitude   double precision NOT NULL,
+    accuracy_m  double precision NOT NULL,
+    distance_m  double precision,
+    eta_seconds bigint,
+    arrived     boolean NOT NULL DEFAULT FALSE,
+    recorded_at timestamptz NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS employee_locations_booking_id_idx
+    ON account.employee_locations (booking_id);
+
+CREATE INDEX IF NOT EXISTS employee_locations_recorded_at_idx
+    ON account.employee_locations (recorded_at);
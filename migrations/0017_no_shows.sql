@@ -0,0 +1,18 @@
+-- Customer and cleaner no-shows, whether reported by a cleaner or admin or
+-- detected by the monitor. reported_by is "system" for detected ones, and the
+-- location is where the reporting cleaner was.
+
+CREATE TABLE IF NOT EXISTS booking.no_shows (
+    id          uuid PRIMARY KEY DEFAULT gen_random_uuid(),
+    booking_id  uuid NOT NULL REFERENCES booking.bookings (id) ON DELETE CASCADE,
+    kind        text NOT NULL,
+    cleaner_ids uuid[] NOT NULL DEFAULT '{}',
+    reported_by text NOT NULL,
+    latitude    double precision,
+    longitude   double precision,
+    accuracy_m  double precision,
+    notes       text,
+    created_at  timestamptz NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS no_shows_booking_id_idx ON booking.no_shows (booking_id);
@@ -0,0 +1,8 @@
+-- The dirty scale a quote was priced for and the surcharge and hours it added.
+-- Quotes saved before this keep dirty_scale 0 and accept any dirty scale when
+-- booked.
+
+ALTER TABLE payment.quotes
+    ADD COLUMN IF NOT EXISTS dirty_scale           integer NOT NULL DEFAULT 0,
+    ADD COLUMN IF NOT EXISTS dirty_scale_surcharge real NOT NULL DEFAULT 0,
+    ADD COLUMN IF NOT EXISTS dirty_scale_hours     integer NOT NULL DEFAULT 0;
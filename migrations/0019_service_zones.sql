@@ -0,0 +1,18 @@
+-- Areas the business serves, drawn as GeoJSON Polygon or MultiPolygon
+-- boundaries, each with its own travel fee and minimum order. Quotes keep the
+-- zone and travel fee they were priced with.
+
+CREATE TABLE IF NOT EXISTS booking.service_zones (
+    id              uuid PRIMARY KEY DEFAULT gen_random_uuid(),
+    name            text NOT NULL,
+    boundary        jsonb NOT NULL,
+    travel_fee      real NOT NULL DEFAULT 0,
+    min_order_value real NOT NULL DEFAULT 0,
+    active          boolean NOT NULL DEFAULT TRUE,
+    created_at      timestamptz NOT NULL DEFAULT NOW(),
+    updated_at      timestamptz NOT NULL DEFAULT NOW()
+);
+
+ALTER TABLE payment.quotes
+    ADD COLUMN IF NOT EXISTS service_zone_id uuid REFERENCES booking.service_zones (id),
+    ADD COLUMN IF NOT EXISTS travel_fee      real NOT NULL DEFAULT 0;
@@ -0,0 +1,11 @@
+-- CSV booking imports. rows holds every parsed row with its validation result
+-- and, once confirmed, the booking created from it or why it failed.
+
+CREATE TABLE IF NOT EXISTS booking.booking_imports (
+    id         uuid PRIMARY KEY DEFAULT gen_random_uuid(),
+    created_by text NOT NULL,
+    status     text NOT NULL,
+    rows       jsonb NOT NULL,
+    created_at timestamptz NOT NULL DEFAULT NOW(),
+    updated_at timestamptz NOT NULL DEFAULT NOW()
+);
@@ -1,9 +1,13 @@
 package realtime
 
 import (
+	"context"
 	"encoding/json"
+	"handworks-api/middleware"
 	"net/http"
+	"time"
 
+	"github.com/clerk/clerk-sdk-go/v2"
 	"github.com/gin-gonic/gin"
 	"github.com/gorilla/websocket"
 )
@@ -13,12 +17,15 @@ func (h *EmployeeHub) Run() {
 	for {
 		select {
 		case ec := <-h.register:
+			h.mu.Lock()
 			if h.clients[ec.employeeID] == nil {
 				h.clients[ec.employeeID] = make(map[*websocket.Conn]bool)
 			}
 			h.clients[ec.employeeID][ec.conn] = true
+			h.mu.Unlock()
 
 		case ec := <-h.unregister:
+			h.mu.Lock()
 			if conns, ok := h.clients[ec.employeeID]; ok {
 				delete(conns, ec.conn)
 				ec.conn.Close()
@@ -27,16 +34,26 @@ func (h *EmployeeHub) Run() {
 				}
 				h.log.Info("Employee disconnected")
 			}
+			h.mu.Unlock()
 		}
 	}
 }
 
+// Handle registers fn for messages with the given event. Register handlers before Run.
+func (h *EmployeeHub) Handle(event string, fn EmployeeMessageHandler) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.handlers[event] = fn
+}
+
 func (h *EmployeeHub) SendToEmployee(employeeID string, event string, payload any) {
 	msg, _ := json.Marshal(map[string]any{
 		"event": event,
 		"data":  payload,
 	})
 
+	h.mu.Lock()
+	defer h.mu.Unlock()
 	for conn := range h.clients[employeeID] {
 		if err := conn.WriteMessage(websocket.TextMessage, msg); err != nil {
 			delete(h.clients[employeeID], conn)
@@ -44,11 +61,52 @@ func (h *EmployeeHub) SendToEmployee(employeeID string, event string, payload an
 		}
 	}
 }
-func EmployeeWS(hub *EmployeeHub) gin.HandlerFunc {
+
+// dispatch runs the handler registered for an incoming message, if any.
+func (h *EmployeeHub) dispatch(employeeID string, raw []byte) {
+	var msg struct {
+		Event string          `json:"event"`
+		Data  json.RawMessage `json:"data"`
+	}
+	if err := json.Unmarshal(raw, &msg); err != nil {
+		h.SendToEmployee(employeeID, "error", map[string]string{"error": "invalid message"})
+		return
+	}
+
+	h.mu.Lock()
+	fn, ok := h.handlers[msg.Event]
+	h.mu.Unlock()
+	if !ok {
+		h.SendToEmployee(employeeID, "error", map[string]string{"event": msg.Event, "error": "unknown event"})
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	if err := fn(ctx, employeeID, msg.Data); err != nil {
+		h.SendToEmployee(employeeID, "error", map[string]string{"event": msg.Event, "error": err.Error()})
+	}
+}
+
+// EmployeeWS connects a signed-in employee. The employee is taken from the Clerk session,
+// so messages they send can only act as themselves.
+func EmployeeWS(hub *EmployeeHub, resolve EmployeeResolver) gin.HandlerFunc {
 	return func(c *gin.Context) {
-		employeeID := c.Query("employeeID")
+		v, _ := c.Get(string(middleware.ClerkClaimsKey))
+		claims, ok := v.(*clerk.SessionClaims)
+		if !ok || claims.Subject == "" {
+			c.AbortWithStatus(http.StatusUnauthorized)
+			return
+		}
+		ctx, cancel := context.WithTimeout(c.Request.Context(), 10*time.Second)
+		employeeID, err := resolve(ctx, claims.Subject)
+		cancel()
+		if err != nil {
+			c.AbortWithStatus(http.StatusInternalServerError)
+			return
+		}
 		if employeeID == "" {
-			c.AbortWithStatus(http.StatusBadRequest)
+			c.AbortWithStatus(http.StatusForbidden)
 			return
 		}
 		conn, err := upgrader.Upgrade(c.Writer, c.Request, nil)
@@ -64,9 +122,11 @@ func EmployeeWS(hub *EmployeeHub) gin.HandlerFunc {
 				hub.unregister <- ec
 			}()
 			for {
-				if _, _, err := conn.ReadMessage(); err != nil {
+				_, msg, err := conn.ReadMessage()
+				if err != nil {
 					break
 				}
+				hub.dispatch(employeeID, msg)
 			}
 		}()
 	}
@@ -1,7 +1,10 @@
 package realtime
 
 import (
+	"context"
+	"encoding/json"
 	"handworks-api/utils"
+	"sync"
 
 	"github.com/gorilla/websocket"
 )
@@ -40,11 +43,21 @@ func NewAdminHub(log *utils.Logger) *AdminHub {
 
 type EmployeeHub struct {
 	log        *utils.Logger
+	mu         sync.Mutex
 	clients    map[string]map[*websocket.Conn]bool
 	register   chan employeeConn
 	unregister chan employeeConn
+	handlers   map[string]EmployeeMessageHandler
 }
 
+// EmployeeMessageHandler handles one kind of message an employee sends over the websocket.
+// A returned error is sent back to that employee as an "error" event.
+type EmployeeMessageHandler func(ctx context.Context, employeeID string, data json.RawMessage) error
+
+// EmployeeResolver returns the employee ID of a signed-in Clerk user, or "" when the user
+// is not an employee.
+type EmployeeResolver func(ctx context.Context, clerkID string) (string, error)
+
 type employeeConn struct {
 	employeeID string
 	conn       *websocket.Conn
@@ -56,6 +69,7 @@ func NewEmployeeHub(log *utils.Logger) *EmployeeHub {
 		clients:    make(map[string]map[*websocket.Conn]bool),
 		register:   make(chan employeeConn),
 		unregister: make(chan employeeConn),
+		handlers:   make(map[string]EmployeeMessageHandler),
 	}
 }
 
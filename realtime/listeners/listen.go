@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"handworks-api/realtime"
 	"handworks-api/services"
+	"handworks-api/types"
 	"handworks-api/utils"
 	"time"
 
@@ -21,8 +22,25 @@ type Listener struct {
 	listener         *pq.Listener
 	bookingService   *services.BookingService
 	inventoryService *services.InventoryService
+	// trips holds the last pushed state of each cleaner's trip to a booking, keyed by
+	// booking and employee. Only the dispatch goroutine touches it.
+	trips map[string]tripState
 }
 
+// tripState is what a customer was last pushed about a cleaner on the way.
+type tripState struct {
+	arrived   bool
+	etaBucket int64
+	seenAt    time.Time
+}
+
+const (
+	// etaBucketSeconds is how far an ETA has to move before the customer is pushed again.
+	etaBucketSeconds = 5 * 60
+	// tripStateTTL drops trips that stopped pinging, such as finished bookings.
+	tripStateTTL = 6 * time.Hour
+)
+
 func NewListener(
 	ctx context.Context,
 	log *utils.Logger,
@@ -50,6 +68,7 @@ func NewListener(
 		),
 		bookingService:   bookingService,
 		inventoryService: inventoryService,
+		trips:            make(map[string]tripState),
 	}
 }
 func (l *Listener) Start() error {
@@ -65,6 +84,36 @@ func (l *Listener) Start() error {
 	if err := l.listener.Listen("inventory_low"); err != nil {
 		return err
 	}
+	if err := l.listener.Listen("booking_rescheduled"); err != nil {
+		return err
+	}
+	if err := l.listener.Listen("booking_cancelled"); err != nil {
+		return err
+	}
+	if err := l.listener.Listen("employee_activated"); err != nil {
+		return err
+	}
+	if err := l.listener.Listen("waitlist_promoted"); err != nil {
+		return err
+	}
+	if err := l.listener.Listen("waitlist_expired"); err != nil {
+		return err
+	}
+	if err := l.listener.Listen("checklist_updated"); err != nil {
+		return err
+	}
+	if err := l.listener.Listen("review_submitted"); err != nil {
+		return err
+	}
+	if err := l.listener.Listen("cleaner_location"); err != nil {
+		return err
+	}
+	if err := l.listener.Listen("booking_late"); err != nil {
+		return err
+	}
+	if err := l.listener.Listen("booking_no_show"); err != nil {
+		return err
+	}
 
 	l.log.Info("Started listening to events")
 
@@ -104,6 +153,26 @@ func (l *Listener) dispatch(channel string, payload string) {
 		l.handleBookingOngoing(payload)
 	case "inventory_low":
 		l.handleInventoryLow(payload)
+	case "booking_rescheduled":
+		l.handleBookingRescheduled(payload)
+	case "booking_cancelled":
+		l.handleBookingCancelled(payload)
+	case "employee_activated":
+		l.handleEmployeeActivated(payload)
+	case "waitlist_promoted":
+		l.handleWaitlistPromoted(payload)
+	case "waitlist_expired":
+		l.handleWaitlistExpired(payload)
+	case "checklist_updated":
+		l.handleChecklistUpdated(payload)
+	case "review_submitted":
+		l.handleReviewSubmitted(payload)
+	case "cleaner_location":
+		l.handleCleanerLocation(payload)
+	case "booking_late":
+		l.handleBookingLate(payload)
+	case "booking_no_show":
+		l.handleBookingNoShow(payload)
 	default:
 		l.log.Warn("Unhandled channel: %s", channel)
 	}
@@ -182,6 +251,313 @@ func (l *Listener) handleBookingCreated(payload string) {
 	l.sendToAdmin("booking.created", booking)
 }
 
+func (l *Listener) handleBookingRescheduled(payload string) {
+	l.log.Debug("booking_rescheduled payload: %s", payload)
+
+	var evt = struct {
+		Event              string   `json:"event"`
+		BookingID          string   `json:"bookingId"`
+		CleanerIDs         []string `json:"cleanerIds"`
+		PreviousCleanerIDs []string `json:"previousCleanerIds"`
+	}{}
+
+	if err := json.Unmarshal([]byte(payload), &evt); err != nil {
+		l.log.Error("Invalid booking_rescheduled payload: %v", err)
+		return
+	}
+
+	booking, err := l.bookingService.GetBookingByID(l.ctx, evt.BookingID)
+	if err != nil {
+		l.log.Error("Failed to fetch booking: %v", err)
+		return
+	}
+
+	const event = "booking.rescheduled"
+
+	current := make(map[string]struct{}, len(evt.CleanerIDs))
+	for _, cleanerID := range evt.CleanerIDs {
+		current[cleanerID] = struct{}{}
+		l.sendToEmployee(cleanerID, event, booking)
+	}
+	for _, cleanerID := range evt.PreviousCleanerIDs {
+		if _, ok := current[cleanerID]; ok {
+			continue
+		}
+		l.sendToEmployee(cleanerID, "booking.unassigned", booking)
+	}
+	l.sendToAdmin(event, booking)
+}
+
+func (l *Listener) handleBookingCancelled(payload string) {
+	l.log.Debug("booking_cancelled payload: %s", payload)
+
+	var evt = struct {
+		Event      string   `json:"event"`
+		BookingID  string   `json:"bookingId"`
+		CustomerID string   `json:"customerId"`
+		CleanerIDs []string `json:"cleanerIds"`
+	}{}
+
+	if err := json.Unmarshal([]byte(payload), &evt); err != nil {
+		l.log.Error("Invalid booking_cancelled payload: %v", err)
+		return
+	}
+
+	booking, err := l.bookingService.GetBookingByID(l.ctx, evt.BookingID)
+	if err != nil {
+		l.log.Error("Failed to fetch booking: %v", err)
+		return
+	}
+
+	const event = "booking.cancelled"
+
+	for _, cleanerID := range evt.CleanerIDs {
+		l.sendToEmployee(cleanerID, event, booking)
+	}
+	l.sendToCustomer(evt.CustomerID, event, booking)
+	l.sendToAdmin(event, booking)
+
+	// The cancelled booking's cleaners may be enough for a waitlisted slot.
+	l.promoteWaitlist()
+}
+
+func (l *Listener) handleBookingLate(payload string) {
+	l.log.Debug("booking_late payload: %s", payload)
+
+	var evt = struct {
+		Event       string   `json:"event"`
+		BookingID   string   `json:"bookingId"`
+		CustomerID  string   `json:"customerId"`
+		CleanerIDs  []string `json:"cleanerIds"`
+		MinutesLate int      `json:"minutesLate"`
+	}{}
+
+	if err := json.Unmarshal([]byte(payload), &evt); err != nil {
+		l.log.Error("Invalid booking_late payload: %v", err)
+		return
+	}
+
+	booking, err := l.bookingService.GetBookingByID(l.ctx, evt.BookingID)
+	if err != nil {
+		l.log.Error("Failed to fetch booking: %v", err)
+		return
+	}
+
+	const event = "booking.late"
+	data := map[string]any{"minutesLate": evt.MinutesLate, "booking": booking}
+
+	for _, cleanerID := range evt.CleanerIDs {
+		l.sendToEmployee(cleanerID, event, data)
+	}
+	l.sendToCustomer(evt.CustomerID, event, data)
+	l.sendToAdmin(event, data)
+}
+
+// handleBookingNoShow tells everyone on the booking who did not show up. A cleaner
+// no-show leaves the booking pending for admins to reschedule with a new crew.
+func (l *Listener) handleBookingNoShow(payload string) {
+	l.log.Debug("booking_no_show payload: %s", payload)
+
+	var evt = struct {
+		Event      string   `json:"event"`
+		Kind       string   `json:"kind"`
+		BookingID  string   `json:"bookingId"`
+		CustomerID string   `json:"customerId"`
+		CleanerIDs []string `json:"cleanerIds"`
+	}{}
+
+	if err := json.Unmarshal([]byte(payload), &evt); err != nil {
+		l.log.Error("Invalid booking_no_show payload: %v", err)
+		return
+	}
+
+	booking, err := l.bookingService.GetBookingByID(l.ctx, evt.BookingID)
+	if err != nil {
+		l.log.Error("Failed to fetch booking: %v", err)
+		return
+	}
+
+	const event = "booking.no_show"
+	data := map[string]any{"kind": evt.Kind, "booking": booking}
+
+	for _, cleanerID := range evt.CleanerIDs {
+		l.sendToEmployee(cleanerID, event, data)
+	}
+	l.sendToCustomer(evt.CustomerID, event, data)
+	l.sendToAdmin(event, data)
+}
+
+func (l *Listener) handleEmployeeActivated(payload string) {
+	l.log.Debug("employee_activated payload: %s", payload)
+	l.promoteWaitlist()
+}
+
+// promoteWaitlist hands promotion to the waitlist monitor so bookings are not made on
+// the listener goroutine, where they would hold up every other notification.
+func (l *Listener) promoteWaitlist() {
+	l.bookingService.RequestWaitlistPromotion()
+}
+
+func (l *Listener) handleWaitlistPromoted(payload string) {
+	l.log.Debug("waitlist_promoted payload: %s", payload)
+
+	var evt = struct {
+		Event      string `json:"event"`
+		EntryID    string `json:"entryId"`
+		BookingID  string `json:"bookingId"`
+		CustomerID string `json:"customerId"`
+	}{}
+
+	if err := json.Unmarshal([]byte(payload), &evt); err != nil {
+		l.log.Error("Invalid waitlist_promoted payload: %v", err)
+		return
+	}
+
+	booking, err := l.bookingService.GetBookingByID(l.ctx, evt.BookingID)
+	if err != nil {
+		l.log.Error("Failed to fetch booking: %v", err)
+		return
+	}
+
+	l.sendToCustomer(evt.CustomerID, "waitlist.promoted", booking)
+	l.sendToAdmin("booking.created", booking)
+}
+
+func (l *Listener) handleWaitlistExpired(payload string) {
+	l.log.Debug("waitlist_expired payload: %s", payload)
+
+	var evt = struct {
+		Event      string    `json:"event"`
+		EntryID    string    `json:"entryId"`
+		CustomerID string    `json:"customerId"`
+		StartSched time.Time `json:"startSched"`
+		EndSched   time.Time `json:"endSched"`
+	}{}
+
+	if err := json.Unmarshal([]byte(payload), &evt); err != nil {
+		l.log.Error("Invalid waitlist_expired payload: %v", err)
+		return
+	}
+
+	l.sendToCustomer(evt.CustomerID, "waitlist.expired", evt)
+}
+
+// handleChecklistUpdated sends the whole checklist so every device of every cleaner on the
+// booking shows the same progress.
+func (l *Listener) handleChecklistUpdated(payload string) {
+	l.log.Debug("checklist_updated payload: %s", payload)
+
+	var evt = struct {
+		Event      string   `json:"event"`
+		BookingID  string   `json:"bookingId"`
+		ItemID     string   `json:"itemId"`
+		CleanerIDs []string `json:"cleanerIds"`
+	}{}
+
+	if err := json.Unmarshal([]byte(payload), &evt); err != nil {
+		l.log.Error("Invalid checklist_updated payload: %v", err)
+		return
+	}
+
+	checklist, err := l.bookingService.GetBookingChecklist(l.ctx, evt.BookingID)
+	if err != nil {
+		l.log.Error("Failed to fetch booking checklist: %v", err)
+		return
+	}
+
+	const event = "checklist.updated"
+
+	for _, cleanerID := range evt.CleanerIDs {
+		l.sendToEmployee(cleanerID, event, checklist)
+	}
+	l.sendToAdmin(event, checklist)
+}
+
+func (l *Listener) handleReviewSubmitted(payload string) {
+	l.log.Debug("review_submitted payload: %s", payload)
+
+	var evt = struct {
+		Event     string `json:"event"`
+		ReviewID  string `json:"reviewId"`
+		BookingID string `json:"bookingId"`
+	}{}
+
+	if err := json.Unmarshal([]byte(payload), &evt); err != nil {
+		l.log.Error("Invalid review_submitted payload: %v", err)
+		return
+	}
+
+	review, err := l.bookingService.GetBookingReview(l.ctx, evt.BookingID)
+	if err != nil {
+		l.log.Error("Failed to fetch booking review: %v", err)
+		return
+	}
+
+	l.sendToAdmin("review.submitted", review)
+}
+
+// handleCleanerLocation relays a cleaner's position and ETA as it is, since pings are too
+// frequent to re-read the booking for each one. Admin dashboards get every ping over the
+// websocket; push notifications only go out when the trip changes, that is when the cleaner
+// arrives or the ETA moves to another five-minute step, so devices are not buzzed every
+// few seconds.
+func (l *Listener) handleCleanerLocation(payload string) {
+	var evt = struct {
+		Event    string                `json:"event"`
+		Location types.CleanerLocation `json:"location"`
+	}{}
+
+	if err := json.Unmarshal([]byte(payload), &evt); err != nil {
+		l.log.Error("Invalid cleaner_location payload: %v", err)
+		return
+	}
+
+	const event = "cleaner.location"
+	loc := evt.Location
+
+	if l.hub != nil && l.hub.AdminHub != nil {
+		l.hub.AdminHub.SendToAdmin(event, loc)
+	}
+
+	arrived, changed := l.updateTrip(loc)
+	if !changed {
+		return
+	}
+	l.sendToCustomer(loc.CustomerID, event, loc)
+	if arrived && l.notifier != nil {
+		if err := l.notifier.SendToAdmins(l.ctx, event, loc); err != nil {
+			l.log.Error("Failed to send FCM admin event (%s): %v", event, err)
+		}
+	}
+}
+
+// updateTrip records the trip state of a location ping and reports whether the cleaner
+// has just arrived and whether anything worth a push changed since the last one.
+func (l *Listener) updateTrip(loc types.CleanerLocation) (arrivedNow bool, changed bool) {
+	now := time.Now()
+	key := loc.BookingID + ":" + loc.EmployeeID
+
+	next := tripState{arrived: loc.Arrived, etaBucket: -1, seenAt: now}
+	if loc.ETASeconds != nil {
+		next.etaBucket = (*loc.ETASeconds + etaBucketSeconds - 1) / etaBucketSeconds
+	}
+
+	prev, ok := l.trips[key]
+	if !ok {
+		for k, trip := range l.trips {
+			if now.Sub(trip.seenAt) > tripStateTTL {
+				delete(l.trips, k)
+			}
+		}
+	}
+	l.trips[key] = next
+
+	if !ok {
+		return next.arrived, true
+	}
+	return next.arrived && !prev.arrived, next.arrived != prev.arrived || next.etaBucket != prev.etaBucket
+}
+
 func (l *Listener) handleInventoryLow(payload string) {
 	l.log.Debug("inventory_low payload: %s", payload)
 	var evt = struct {
@@ -225,3 +601,12 @@ func (l *Listener) sendToEmployee(employeeID string, event string, payload any)
 	}
 
 }
+
+func (l *Listener) sendToCustomer(customerID string, event string, payload any) {
+	if l.notifier == nil || customerID == "" {
+		return
+	}
+	if err := l.notifier.SendToCustomer(l.ctx, customerID, event, payload); err != nil {
+		l.log.Error("Failed to send FCM customer event (%s, %s): %v", customerID, event, err)
+	}
+}
@@ -0,0 +1,101 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"handworks-api/tasks"
+	"handworks-api/types"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// SetAccessInstructions replaces the booking's access instructions, on every day of a
+// multi-day job.
+func (s *BookingService) SetAccessInstructions(ctx context.Context, bookingID string, instr types.AccessInstructions, actor string) error {
+	if err := s.withTx(ctx, func(tx pgx.Tx) error {
+		snap, err := s.Tasks.FetchBookingSnapshot(ctx, tx, bookingID)
+		if err != nil {
+			return err
+		}
+		viewer, err := s.Tasks.ResolveAccessViewer(ctx, tx, actor)
+		if err != nil {
+			return err
+		}
+		if err := tasks.CanWriteAccessInstructions(viewer, snap); err != nil {
+			return err
+		}
+
+		linked, err := s.Tasks.FetchLinkedBookingIDs(ctx, tx, bookingID)
+		if err != nil {
+			return err
+		}
+		for _, id := range append([]string{bookingID}, linked...) {
+			if err := s.Tasks.SaveAccessInstructions(ctx, tx, s.AccessVault, id, &instr, actor); err != nil {
+				return err
+			}
+		}
+		return nil
+	}); err != nil {
+		if !errors.Is(err, tasks.ErrAccessInstructionsDenied) && !errors.Is(err, tasks.ErrInvalidAccessInstructions) {
+			s.Logger.Error("failed to save access instructions for booking %s: %v", bookingID, err)
+		}
+		return err
+	}
+	return nil
+}
+
+// GetAccessInstructions decrypts the booking's access instructions for an admin or one of
+// its cleaners. Every attempt, allowed or not, is written to the access log.
+func (s *BookingService) GetAccessInstructions(ctx context.Context, bookingID, actor string) (*types.AccessInstructionsResponse, error) {
+	var (
+		res    *types.AccessInstructionsResponse
+		denied error
+	)
+	if err := s.withTx(ctx, func(tx pgx.Tx) error {
+		snap, err := s.Tasks.FetchBookingSnapshot(ctx, tx, bookingID)
+		if err != nil {
+			return err
+		}
+		viewer, err := s.Tasks.ResolveAccessViewer(ctx, tx, actor)
+		if err != nil {
+			return err
+		}
+
+		// A refusal is still committed so the log shows who tried.
+		if denied = s.AccessVault.CanReadAccessInstructions(viewer, snap, time.Now()); denied != nil {
+			return s.Tasks.RecordAccessLog(ctx, tx, bookingID, actor, viewer.Role, types.AccessLogDenied, denied.Error())
+		}
+
+		res, err = s.Tasks.FetchAccessInstructions(ctx, tx, s.AccessVault, bookingID)
+		if err != nil {
+			return err
+		}
+		return s.Tasks.RecordAccessLog(ctx, tx, bookingID, actor, viewer.Role, types.AccessLogRead, "")
+	}); err != nil {
+		if !errors.Is(err, tasks.ErrAccessInstructionsNotFound) && !errors.Is(err, tasks.ErrBookingNotFound) {
+			s.Logger.Error("failed to read access instructions for booking %s: %v", bookingID, err)
+		}
+		return nil, err
+	}
+	if denied != nil {
+		s.Logger.Warn("Denied access instructions of booking %s to %s: %v", bookingID, actor, denied)
+		return nil, denied
+	}
+
+	return res, nil
+}
+
+func (s *AdminService) GetAccessLog(ctx context.Context, bookingID string) ([]types.AccessLogEntry, error) {
+	var entries []types.AccessLogEntry
+	if err := s.withTx(ctx, func(tx pgx.Tx) error {
+		var err error
+		entries, err = s.Tasks.FetchAccessLog(ctx, tx, bookingID)
+		return err
+	}); err != nil {
+		s.Logger.Error("Failed to fetch access log for booking %s: %v", bookingID, err)
+		return nil, err
+	}
+
+	return entries, nil
+}
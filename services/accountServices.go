@@ -3,6 +3,7 @@ package services
 import (
 	"context"
 	"fmt"
+	"handworks-api/tasks"
 	"handworks-api/types"
 	"handworks-api/utils"
 	"strings"
@@ -371,6 +372,9 @@ func (s *AccountService) TimesheetToday(ctx context.Context, empId string) (*typ
 func (s *AccountService) CreateAddress(ctx context.Context, req types.CreateAddressRequest) (*types.CreateAddressResponse, error) {
 	var saved types.SavedAddress
 	if err := s.withTx(ctx, func(tx pgx.Tx) error {
+		if _, err := tasks.ResolveServiceZone(ctx, tx, req.Address); err != nil {
+			return err
+		}
 		address, err := s.Tasks.CreateAddress(ctx, tx, req.AccountID, req.Address)
 		if err != nil {
 			return err
@@ -419,6 +423,9 @@ func (s *AccountService) GetAddresses(ctx context.Context, accountID string) (*t
 func (s *AccountService) UpdateAddress(ctx context.Context, req types.UpdateAddressRequest) (*types.UpdateAddressResponse, error) {
 	var saved types.SavedAddress
 	if err := s.withTx(ctx, func(tx pgx.Tx) error {
+		if _, err := tasks.ResolveServiceZone(ctx, tx, req.Address); err != nil {
+			return err
+		}
 		address, err := s.Tasks.UpdateAddress(ctx, tx, req.ID, req.AccountID, req.Address)
 		if err != nil {
 			return err
@@ -77,10 +77,13 @@ func (s *AdminService) OnboardEmployee(ctx context.Context, req *types.OnboardEm
 	return emp, nil
 }
 
-func (s *AdminService) AcceptBooking(ctx context.Context, bookingId string) (*types.AcceptBookingResponse, error) {
+func (s *AdminService) AcceptBooking(ctx context.Context, bookingId string, actor string) (*types.AcceptBookingResponse, error) {
 	s.Logger.Info("Accepting booking with ID: %s", bookingId)
+	var state *tasks.BookingState
 	if err := s.withTx(ctx, func(tx pgx.Tx) error {
-		return s.Tasks.AcceptBooking(ctx, tx, bookingId)
+		var err error
+		state, err = s.Tasks.TransitionBooking(ctx, tx, bookingId, tasks.BookingEventApprove, actor, "")
+		return err
 	}); err != nil {
 		s.Logger.Error("Failed to accept booking: %v", err)
 		return nil, err
@@ -88,7 +91,7 @@ func (s *AdminService) AcceptBooking(ctx context.Context, bookingId string) (*ty
 
 	return &types.AcceptBookingResponse{
 		BookingID: bookingId,
-		Status:    "SCHEDULED",
+		Status:    state.ReviewStatus,
 	}, nil
 }
 
@@ -149,6 +152,21 @@ func (s *AdminService) GetBookingTrends(ctx context.Context) (*types.BookingTren
 	return res, nil
 }
 
+func (s *AdminService) GetSeriesConflicts(ctx context.Context) (*types.SeriesConflictsResponse, error) {
+	var res *types.SeriesConflictsResponse
+
+	if err := s.withTx(ctx, func(tx pgx.Tx) error {
+		var err error
+		res, err = s.Tasks.FetchSeriesConflicts(ctx, tx)
+		return err
+	}); err != nil {
+		s.Logger.Error("Failed to fetch series conflicts: %v", err)
+		return nil, err
+	}
+
+	return res, nil
+}
+
 func (s *AdminService) GetAvailableCleaners(ctx context.Context, bookingId string) (*types.AvailableCleanersResponse, error) {
 	var (
 		window   *tasks.BookingScheduleWindow
@@ -162,7 +180,12 @@ func (s *AdminService) GetAvailableCleaners(ctx context.Context, bookingId strin
 			return err
 		}
 
-		cleaners, err = s.Tasks.FetchAvailableCleanersByBooking(ctx, tx, bookingId, window.StartSched, window.EndSched)
+		serviceTypes, err := s.Tasks.FetchBookingServiceTypes(ctx, tx, bookingId)
+		if err != nil {
+			return err
+		}
+
+		cleaners, err = s.Tasks.FetchAvailableCleanersByBooking(ctx, tx, bookingId, window.StartSched, window.EndSched, serviceTypes)
 		if err != nil {
 			return err
 		}
@@ -182,6 +205,87 @@ func (s *AdminService) GetAvailableCleaners(ctx context.Context, bookingId strin
 	}, nil
 }
 
+func (s *AdminService) GetEmployeeQualifications(ctx context.Context, employeeID string) (*types.EmployeeQualificationsResponse, error) {
+	var qualifications []types.MainServiceType
+	if err := s.withTx(ctx, func(tx pgx.Tx) error {
+		var err error
+		qualifications, err = s.Tasks.FetchEmployeeQualifications(ctx, tx, employeeID)
+		return err
+	}); err != nil {
+		s.Logger.Error("Failed to fetch employee qualifications: %v", err)
+		return nil, err
+	}
+
+	return &types.EmployeeQualificationsResponse{
+		EmployeeID:     employeeID,
+		Qualifications: qualifications,
+	}, nil
+}
+
+func (s *AdminService) UpdateEmployeeQualifications(ctx context.Context, employeeID string, req *types.UpdateEmployeeQualificationsRequest) (*types.EmployeeQualificationsResponse, error) {
+	if err := s.withTx(ctx, func(tx pgx.Tx) error {
+		return s.Tasks.ReplaceEmployeeQualifications(ctx, tx, employeeID, req.Qualifications)
+	}); err != nil {
+		s.Logger.Error("Failed to update employee qualifications: %v", err)
+		return nil, err
+	}
+
+	return s.GetEmployeeQualifications(ctx, employeeID)
+}
+
+func (s *AdminService) GetPendingCalendarRequests(ctx context.Context) (*types.PendingCalendarRequestsResponse, error) {
+	var res *types.PendingCalendarRequestsResponse
+	if err := s.withTx(ctx, func(tx pgx.Tx) error {
+		var err error
+		res, err = s.Tasks.FetchPendingCalendarRequests(ctx, tx)
+		return err
+	}); err != nil {
+		s.Logger.Error("Failed to fetch pending calendar requests: %v", err)
+		return nil, err
+	}
+
+	return res, nil
+}
+
+func (s *AdminService) ReviewWeeklyAvailability(ctx context.Context, scheduleID string, approve bool, actor string) (*types.WeeklyAvailability, error) {
+	var schedule *types.WeeklyAvailability
+	if err := s.withTx(ctx, func(tx pgx.Tx) error {
+		var err error
+		schedule, err = s.Tasks.ReviewWeeklyAvailability(ctx, tx, scheduleID, approve, actor)
+		return err
+	}); err != nil {
+		s.Logger.Error("Failed to review weekly availability %s: %v", scheduleID, err)
+		return nil, err
+	}
+
+	return schedule, nil
+}
+
+// ReviewTimeOff approves or rejects a time-off request. Approving does not unassign the
+// employee from bookings they already have in that window; those are returned so an admin
+// can reassign them.
+func (s *AdminService) ReviewTimeOff(ctx context.Context, timeOffID string, approve bool, actor string) (*types.ReviewTimeOffResponse, error) {
+	res := &types.ReviewTimeOffResponse{AffectedBookingIDs: []string{}}
+	if err := s.withTx(ctx, func(tx pgx.Tx) error {
+		timeOff, err := s.Tasks.ReviewTimeOff(ctx, tx, timeOffID, approve, actor)
+		if err != nil {
+			return err
+		}
+		res.TimeOff = *timeOff
+
+		if !approve {
+			return nil
+		}
+		res.AffectedBookingIDs, err = s.Tasks.FetchBookingsDuringTimeOff(ctx, tx, timeOff.EmployeeID, timeOff.StartAt, timeOff.EndAt)
+		return err
+	}); err != nil {
+		s.Logger.Error("Failed to review time off %s: %v", timeOffID, err)
+		return nil, err
+	}
+
+	return res, nil
+}
+
 func (s *AdminService) AssignEmployeeToBooking(ctx context.Context, req *types.AssignEmployeeToBookingRequest) (*types.AssignEmployeeToBookingResponse, error) {
 	if err := s.withTx(ctx, func(tx pgx.Tx) error {
 		window, err := s.Tasks.GetBookingScheduleWindow(ctx, tx, req.BookingID)
@@ -194,6 +298,17 @@ func (s *AdminService) AssignEmployeeToBooking(ctx context.Context, req *types.A
 		}
 
 		if req.Action == types.AssignEmployeeActionAdd {
+			serviceTypes, err := s.Tasks.FetchBookingServiceTypes(ctx, tx, req.BookingID)
+			if err != nil {
+				return err
+			}
+			if err := s.Tasks.ValidateCleanerQualified(ctx, tx, req.EmployeeID, serviceTypes); err != nil {
+				return err
+			}
+			if err := s.Tasks.ValidateCleanerOnCalendar(ctx, tx, req.EmployeeID, window.StartSched, window.EndSched); err != nil {
+				return err
+			}
+
 			hasConflict, err := s.Tasks.CleanerHasScheduleConflict(ctx, tx, req.EmployeeID, window.StartSched, window.EndSched, req.BookingID)
 			if err != nil {
 				return err
@@ -209,7 +324,9 @@ func (s *AdminService) AssignEmployeeToBooking(ctx context.Context, req *types.A
 			errors.Is(err, tasks.ErrEmployeeNotFoundOrInactive) ||
 			errors.Is(err, tasks.ErrCleanerAlreadyAssigned) ||
 			errors.Is(err, tasks.ErrCleanerNotAssigned) ||
-			errors.Is(err, tasks.ErrCleanerHasConflict) {
+			errors.Is(err, tasks.ErrCleanerHasConflict) ||
+			errors.Is(err, tasks.ErrCleanerNotQualified) ||
+			errors.Is(err, tasks.ErrCleanerUnavailable) {
 			return nil, err
 		}
 
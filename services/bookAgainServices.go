@@ -0,0 +1,89 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"handworks-api/tasks"
+	"handworks-api/types"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// BookAgain re-prices a past booking's services at current rates and creates a quote and
+// an unpaid order for them, so the customer only has to pick a new date. An empty
+// paymentMethod reuses the one of the past booking's order.
+func (s *BookingService) BookAgain(ctx context.Context, bookingID, paymentMethod, actor string) (*types.BookAgainResponse, error) {
+	var template *types.CreateBookingRequest
+	if err := s.withTx(ctx, func(tx pgx.Tx) error {
+		var (
+			pastMethod string
+			err        error
+		)
+		if template, pastMethod, err = s.Tasks.FetchBookAgainTemplate(ctx, tx, bookingID); err != nil {
+			return err
+		}
+		viewer, err := s.Tasks.ResolveAccessViewer(ctx, tx, actor)
+		if err != nil {
+			return err
+		}
+		if paymentMethod == "" {
+			paymentMethod = pastMethod
+		}
+		return tasks.CanBookAgain(viewer, template.Base.CustID)
+	}); err != nil {
+		if !errors.Is(err, tasks.ErrBookingNotFound) && !errors.Is(err, tasks.ErrBookAgainDenied) {
+			s.Logger.Error("failed to load booking %s to book again: %v", bookingID, err)
+		}
+		return nil, err
+	}
+	if paymentMethod == "" {
+		return nil, tasks.ErrBookAgainPaymentMethod
+	}
+
+	// Services that no longer price, such as a retired car type, are reported before any
+	// quote is stored.
+	if _, err := s.Tasks.EstimateServiceHours(&types.AvailabilityRequest{
+		Service:    template.MainService,
+		Addons:     template.Addons,
+		DirtyScale: template.Base.DirtyScale,
+	}, s.DirtyScalePolicy, s.LargeAreaRate); err != nil {
+		return nil, err
+	}
+
+	quote, err := s.PaymentPort.MakeQuotation(ctx, types.QuoteRequest{
+		CustomerID: template.Base.CustID,
+		Service:    template.MainService,
+		Addons:     template.Addons,
+		DirtyScale: template.Base.DirtyScale,
+		Address:    &template.Base.Address,
+	})
+	if err != nil {
+		s.Logger.Error("failed to quote booking %s again: %v", bookingID, err)
+		return nil, err
+	}
+
+	addonTotal := quote.AddonTotal
+	created, err := s.PaymentPort.CreateOrder(ctx, types.CreateOrderRequest{
+		QuoteID:       quote.QuoteId,
+		CustomerID:    template.Base.CustID,
+		PaymentMethod: paymentMethod,
+		Subtotal:      quote.TotalPrice - quote.AddonTotal,
+		AddonTotal:    &addonTotal,
+		TotalAmount:   quote.TotalPrice,
+	})
+	if err != nil {
+		s.Logger.Error("failed to create order to book %s again: %v", bookingID, err)
+		return nil, err
+	}
+
+	template.Base.QuoteId = quote.QuoteId
+	template.Base.OrderId = created.Order.ID
+	template.TotalServiceHours = float32(quote.TotalServiceHours)
+
+	return &types.BookAgainResponse{
+		SourceBookingID: bookingID,
+		Quote:           *quote,
+		Order:           created.Order,
+		Booking:         *template,
+	}, nil
+}
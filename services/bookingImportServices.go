@@ -0,0 +1,310 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"handworks-api/tasks"
+	"handworks-api/types"
+	"io"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// ImportBookings checks every row of a CSV import against current pricing, the service
+// zones and cleaner availability, giving each the first free slot in its preferred
+// window. Nothing is booked: the rows and their slots are stored as an import job that
+// ConfirmBookingImport books later. Rows are checked one by one against what is already
+// booked, so rows that compete for the same cleaners may still fail when they are created.
+func (s *BookingService) ImportBookings(
+	ctx context.Context,
+	file io.Reader,
+	defaultPaymentMethod string,
+	actor string,
+) (*types.BookingImportResponse, error) {
+	if err := s.authorizeBookingImport(ctx, actor); err != nil {
+		return nil, err
+	}
+
+	rows, err := tasks.ParseBookingImportCSV(file)
+	if err != nil {
+		return nil, err
+	}
+
+	var claims tasks.BookingImportClaims
+	jobRows := make([]tasks.BookingImportJobRow, len(rows))
+	for i := range rows {
+		row, jobRow := &rows[i], &jobRows[i]
+		jobRow.Result.Line = row.Line
+		jobRow.Result.CustomerID = row.Request.Base.CustID
+		if row.PaymentMethod == "" {
+			row.PaymentMethod = defaultPaymentMethod
+		}
+
+		if err := s.validateImportRow(ctx, row, &jobRow.Result, &claims); err != nil {
+			jobRow.Result.Status, jobRow.Result.Error = types.BookingImportInvalid, err.Error()
+			continue
+		}
+		jobRow.Result.Status = types.BookingImportValid
+		jobRow.Request = &row.Request
+		jobRow.PaymentMethod = row.PaymentMethod
+	}
+
+	var job *tasks.BookingImportJob
+	if err := s.withTx(ctx, func(tx pgx.Tx) error {
+		var err error
+		job, err = s.Tasks.InsertBookingImportJob(ctx, tx, actor, jobRows)
+		return err
+	}); err != nil {
+		s.Logger.Error("failed to store booking import: %v", err)
+		return nil, err
+	}
+
+	return job.Report(), nil
+}
+
+// GetBookingImport returns the stored report of an import.
+func (s *BookingService) GetBookingImport(ctx context.Context, jobID, actor string) (*types.BookingImportResponse, error) {
+	if err := s.authorizeBookingImport(ctx, actor); err != nil {
+		return nil, err
+	}
+
+	var job *tasks.BookingImportJob
+	if err := s.withTx(ctx, func(tx pgx.Tx) error {
+		var err error
+		job, err = s.Tasks.FetchBookingImportJob(ctx, tx, jobID)
+		return err
+	}); err != nil {
+		return nil, err
+	}
+	return job.Report(), nil
+}
+
+// ConfirmBookingImport starts creating the bookings of a validated import in the
+// background and returns its report as it stands. An import whose worker stopped without
+// finishing can be confirmed again once it is stale; it picks up the rows not yet tried.
+func (s *BookingService) ConfirmBookingImport(ctx context.Context, jobID, actor string) (*types.BookingImportResponse, error) {
+	if err := s.authorizeBookingImport(ctx, actor); err != nil {
+		return nil, err
+	}
+
+	var job *tasks.BookingImportJob
+	if err := s.withTx(ctx, func(tx pgx.Tx) error {
+		var err error
+		job, err = s.Tasks.ClaimBookingImportJob(ctx, tx, jobID)
+		return err
+	}); err != nil {
+		return nil, err
+	}
+
+	res := job.Report()
+	go s.runBookingImport(job, actor)
+	return res, nil
+}
+
+func (s *BookingService) authorizeBookingImport(ctx context.Context, actor string) error {
+	return s.withTx(ctx, func(tx pgx.Tx) error {
+		viewer, err := s.Tasks.ResolveAccessViewer(ctx, tx, actor)
+		if err != nil {
+			return err
+		}
+		if viewer.Role != tasks.ViewerRoleAdmin {
+			return tasks.ErrBookingImportDenied
+		}
+		return nil
+	})
+}
+
+// runBookingImport books the valid rows of a claimed import in the slots the dry run gave
+// them, BookingImportBatchSize rows at a time. A batch's rows are saved as CREATING before
+// any of them is booked and the report is saved after every batch, so a worker that dies
+// leaves behind exactly which rows may or may not have been booked.
+func (s *BookingService) runBookingImport(job *tasks.BookingImportJob, actor string) {
+	ctx := context.Background()
+
+	var pending []int
+	for i := range job.Rows {
+		result := &job.Rows[i].Result
+		switch result.Status {
+		case types.BookingImportCreating:
+			result.Status = types.BookingImportFailed
+			result.Error = "import was interrupted while this row was being booked; check the customer's bookings before importing it again"
+		case types.BookingImportValid:
+			pending = append(pending, i)
+		}
+	}
+
+	for start := 0; start < len(pending); start += tasks.BookingImportBatchSize {
+		batch := pending[start:min(start+tasks.BookingImportBatchSize, len(pending))]
+		for _, i := range batch {
+			job.Rows[i].Result.Status = types.BookingImportCreating
+		}
+		if err := s.saveBookingImport(ctx, job); err != nil {
+			return
+		}
+
+		s.Logger.Info("Importing bookings %d-%d of %d for import %s", start+1, start+len(batch), len(pending), job.ID)
+		batchCtx, cancel := context.WithTimeout(ctx, tasks.BookingImportBatchTimeout)
+		for _, i := range batch {
+			row := &job.Rows[i]
+			if err := s.createImportedBooking(batchCtx, row, actor); err != nil {
+				s.Logger.Error("failed to import booking on line %d: %v", row.Result.Line, err)
+				row.Result.Status, row.Result.Error = types.BookingImportFailed, err.Error()
+				continue
+			}
+			row.Result.Status = types.BookingImportCreated
+		}
+		cancel()
+
+		if err := s.saveBookingImport(ctx, job); err != nil {
+			return
+		}
+	}
+
+	job.Status = types.BookingImportJobCompleted
+	if err := s.saveBookingImport(ctx, job); err != nil {
+		return
+	}
+	s.Logger.Info("Booking import %s completed", job.ID)
+}
+
+func (s *BookingService) saveBookingImport(ctx context.Context, job *tasks.BookingImportJob) error {
+	err := s.withTx(ctx, func(tx pgx.Tx) error {
+		return s.Tasks.SaveBookingImportJob(ctx, tx, job)
+	})
+	switch {
+	case errors.Is(err, tasks.ErrBookingImportStarted):
+		s.Logger.Info("Booking import %s was taken over by another worker", job.ID)
+	case err != nil:
+		s.Logger.Error("failed to save booking import %s: %v", job.ID, err)
+	}
+	return err
+}
+
+// validateImportRow prices the row without storing a quote and picks its slot. On success
+// the row's request is ready to book once it has an order.
+func (s *BookingService) validateImportRow(
+	ctx context.Context,
+	row *tasks.BookingImportRow,
+	result *types.BookingImportRowResult,
+	claims *tasks.BookingImportClaims,
+) error {
+	if row.Err != nil {
+		return row.Err
+	}
+	if row.PaymentMethod == "" {
+		return fmt.Errorf("%w: paymentMethod is required", tasks.ErrInvalidImportRow)
+	}
+
+	req := &row.Request
+	if err := s.withTx(ctx, func(tx pgx.Tx) error {
+		return s.Tasks.FetchImportCustomer(ctx, tx, &req.Base)
+	}); err != nil {
+		return err
+	}
+
+	quote, err := s.PaymentPort.MakePublicQuotation(ctx, types.QuoteRequest{
+		CustomerID: req.Base.CustID,
+		Service:    req.MainService,
+		Addons:     req.Addons,
+		DirtyScale: req.Base.DirtyScale,
+		Address:    &req.Base.Address,
+	})
+	if err != nil {
+		return err
+	}
+	result.Quote = quote
+
+	availability := types.AvailabilityRequest{
+		Service:    req.MainService,
+		Addons:     req.Addons,
+		DirtyScale: req.Base.DirtyScale,
+		Address:    &req.Base.Address,
+	}
+	serviceHours, err := s.Tasks.EstimateServiceHours(&availability, s.DirtyScalePolicy, s.LargeAreaRate)
+	if err != nil {
+		return err
+	}
+
+	loc := s.BusinessHours.Location
+	from, to := row.WindowStart.In(loc), row.WindowEnd.In(loc)
+	from = time.Date(from.Year(), from.Month(), from.Day(), 0, 0, 0, 0, loc)
+	to = time.Date(to.Year(), to.Month(), to.Day(), 0, 0, 0, 0, loc)
+	prices := &types.CleaningPrices{DayPlan: quote.DayPlan}
+
+	return s.withTx(ctx, func(tx pgx.Tx) error {
+		slots, err := s.Tasks.FindAvailableSlots(ctx, tx, &availability, serviceHours, s.BusinessHours, from, to)
+		if err != nil {
+			return err
+		}
+		for _, slot := range slots.Slots {
+			if !tasks.SlotInWindow(slot, row.WindowStart, row.WindowEnd) {
+				continue
+			}
+			req.Base.StartSched, req.Base.EndSched = slot.StartSched, slot.EndSched
+			windows := bookingWindows(*req, prices)
+			if claims.Overlaps(req.Base.CustID, req.Base.Address, windows) {
+				continue
+			}
+			overlaps, err := s.Tasks.FindOverlappingBookings(ctx, tx, req.Base.CustID, req.Base.Address, windows)
+			if err != nil {
+				return err
+			}
+			if len(overlaps) > 0 {
+				continue
+			}
+
+			claims.Add(req.Base.CustID, req.Base.Address, windows)
+			result.StartSched, result.EndSched = &slot.StartSched, &slot.EndSched
+			return nil
+		}
+		return tasks.ErrNoSlotInWindow
+	})
+}
+
+// createImportedBooking stores the quote and order for a validated row and books it in
+// the slot the dry run gave it. The booking itself still goes through CreateBooking, so a
+// slot taken since the dry run fails the row instead of double-booking.
+func (s *BookingService) createImportedBooking(ctx context.Context, row *tasks.BookingImportJobRow, actor string) error {
+	req := *row.Request
+	if !req.Base.StartSched.After(time.Now()) {
+		return fmt.Errorf("%w: the slot chosen for this row has already passed", tasks.ErrInvalidImportRow)
+	}
+
+	quote, err := s.PaymentPort.MakeQuotation(ctx, types.QuoteRequest{
+		CustomerID: req.Base.CustID,
+		Service:    req.MainService,
+		Addons:     req.Addons,
+		DirtyScale: req.Base.DirtyScale,
+		Address:    &req.Base.Address,
+	})
+	if err != nil {
+		return err
+	}
+	row.Result.Quote = quote
+
+	addonTotal := quote.AddonTotal
+	created, err := s.PaymentPort.CreateOrder(ctx, types.CreateOrderRequest{
+		QuoteID:       quote.QuoteId,
+		CustomerID:    req.Base.CustID,
+		PaymentMethod: row.PaymentMethod,
+		Subtotal:      quote.TotalPrice - quote.AddonTotal,
+		AddonTotal:    &addonTotal,
+		TotalAmount:   quote.TotalPrice,
+	})
+	if err != nil {
+		return err
+	}
+	row.Result.OrderID = created.Order.ID
+
+	req.Base.QuoteId = quote.QuoteId
+	req.Base.OrderId = created.Order.ID
+	req.TotalServiceHours = float32(quote.TotalServiceHours)
+	booking, err := s.CreateBooking(ctx, req, actor)
+	if err != nil {
+		return err
+	}
+	row.Result.BookingID = booking.ID
+	return nil
+}
@@ -0,0 +1,358 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"handworks-api/tasks"
+	"handworks-api/types"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+)
+
+func (s *BookingService) CreateBookingSeries(ctx context.Context, req types.CreateBookingSeriesRequest) (*types.BookingSeries, error) {
+	if err := s.Tasks.ValidateRecurrence(&req); err != nil {
+		return nil, err
+	}
+	if !req.Booking.Base.EndSched.After(req.Booking.Base.StartSched) {
+		return nil, fmt.Errorf("%w: endSched must be after startSched", tasks.ErrInvalidSchedule)
+	}
+	if !req.Booking.Base.StartSched.After(time.Now()) {
+		return nil, fmt.Errorf("%w: startSched must be in the future", tasks.ErrInvalidSchedule)
+	}
+
+	order, prices, err := s.Tasks.FetchOrderAndPrices(ctx, s.PaymentPort, req.Booking.Base.OrderId)
+	if err != nil {
+		s.Logger.Error("Failed to fetch series order: %v", err)
+		return nil, err
+	}
+	if err := tasks.CheckQuotedDirtyScale(req.Booking.Base.DirtyScale, prices.DirtyScale); err != nil {
+		return nil, err
+	}
+
+	var series *types.BookingSeries
+	if err := s.withTx(ctx, func(tx pgx.Tx) error {
+		if err := s.Tasks.ValidateMediaIDs(ctx, tx, req.Booking.Base.Photos, types.MediaPurposeBooking); err != nil {
+			return err
+		}
+		if err := tasks.CheckBookingServiceZone(ctx, tx, req.Booking.Base.Address, prices.ServiceZoneID); err != nil {
+			return err
+		}
+		var err error
+		series, err = s.Tasks.InsertBookingSeries(ctx, tx, &req, order)
+		if err != nil {
+			return err
+		}
+		if instr := req.Booking.Base.AccessInstructions; instr != nil {
+			if err := s.Tasks.SaveHeldAccessInstructions(ctx, tx, s.AccessVault, tasks.SeriesInstructionsOwner(series.ID), instr); err != nil {
+				return err
+			}
+		}
+
+		// The first occurrence is paid for by the order the customer already placed.
+		start, end := s.Tasks.SeriesOccurrenceWindow(series, 0)
+		if err := s.Tasks.InsertSeriesOccurrence(ctx, tx, series.ID, 0, start, end, types.OccurrencePending, order.ID); err != nil {
+			return err
+		}
+
+		return s.Tasks.EnsureSeriesOccurrences(ctx, tx, series, seriesHorizon())
+	}); err != nil {
+		s.Logger.Error("Failed to create booking series: %v", err)
+		return nil, err
+	}
+
+	s.materializeSeries(ctx, series)
+
+	return s.GetBookingSeries(ctx, series.ID)
+}
+
+func (s *BookingService) GetBookingSeries(ctx context.Context, seriesID string) (*types.BookingSeries, error) {
+	var series *types.BookingSeries
+
+	if err := s.withTx(ctx, func(tx pgx.Tx) error {
+		var err error
+		series, err = s.Tasks.FetchBookingSeries(ctx, tx, seriesID)
+		if err != nil {
+			return err
+		}
+		series.Occurrences, err = s.Tasks.FetchSeriesOccurrences(ctx, tx, seriesID)
+		return err
+	}); err != nil {
+		s.Logger.Error("failed to fetch booking series %s: %v", seriesID, err)
+		return nil, err
+	}
+
+	return series, nil
+}
+
+func (s *BookingService) SkipSeriesOccurrence(ctx context.Context, seriesID string, req types.SkipSeriesOccurrenceRequest, actor string) (*types.BookingSeries, error) {
+	if err := s.withTx(ctx, func(tx pgx.Tx) error {
+		series, err := s.Tasks.FetchBookingSeries(ctx, tx, seriesID)
+		if err != nil {
+			return err
+		}
+		if err := s.authorizeSeriesChange(ctx, tx, series, actor); err != nil {
+			return err
+		}
+		if series.Status != "ACTIVE" {
+			return tasks.ErrSeriesNotActive
+		}
+
+		index, err := s.Tasks.ResolveSeriesOccurrenceIndex(series, req.OccurrenceDate)
+		if err != nil {
+			return err
+		}
+		start, end := s.Tasks.SeriesOccurrenceWindow(series, index)
+		if !start.After(time.Now()) {
+			return tasks.ErrOccurrenceNotSkippable
+		}
+
+		occurrence, err := s.Tasks.FetchSeriesOccurrenceByIndex(ctx, tx, seriesID, index)
+		if err != nil {
+			return err
+		}
+		if occurrence == nil {
+			// Skipping ahead of the generation horizon reserves the index so it is never generated.
+			return s.Tasks.InsertSeriesOccurrence(ctx, tx, seriesID, index, start, end, types.OccurrenceSkipped, "")
+		}
+		if occurrence.Status == types.OccurrenceSkipped || occurrence.Status == types.OccurrenceCancelled {
+			return tasks.ErrOccurrenceNotSkippable
+		}
+
+		if err := s.releaseSeriesOccurrence(ctx, tx, occurrence, "series occurrence skipped", actor); err != nil {
+			return err
+		}
+		return s.Tasks.UpdateSeriesOccurrence(ctx, tx, occurrence.ID, types.OccurrenceSkipped, "", "", "")
+	}); err != nil {
+		s.Logger.Error("failed to skip occurrence of booking series %s: %v", seriesID, err)
+		return nil, err
+	}
+
+	return s.GetBookingSeries(ctx, seriesID)
+}
+
+func (s *BookingService) EndBookingSeries(ctx context.Context, seriesID string, actor string) (*types.BookingSeries, error) {
+	if err := s.withTx(ctx, func(tx pgx.Tx) error {
+		series, err := s.Tasks.FetchBookingSeries(ctx, tx, seriesID)
+		if err != nil {
+			return err
+		}
+		if err := s.authorizeSeriesChange(ctx, tx, series, actor); err != nil {
+			return err
+		}
+		if err := s.Tasks.EndBookingSeries(ctx, tx, seriesID); err != nil {
+			return err
+		}
+		if err := s.Tasks.DeleteHeldAccessInstructions(ctx, tx, tasks.SeriesInstructionsOwner(seriesID)); err != nil {
+			return err
+		}
+		occurrences, err := s.Tasks.FetchSeriesOccurrences(ctx, tx, seriesID)
+		if err != nil {
+			return err
+		}
+
+		now := time.Now()
+		for _, occ := range occurrences {
+			if !occ.StartSched.After(now) {
+				continue
+			}
+			switch occ.Status {
+			case types.OccurrencePending, types.OccurrenceConflict, types.OccurrenceFailed, types.OccurrenceScheduled:
+			default:
+				continue
+			}
+
+			if err := s.releaseSeriesOccurrence(ctx, tx, &occ, "booking series ended", actor); err != nil {
+				if errors.Is(err, tasks.ErrInvalidTransition) {
+					// Its booking has moved on by itself, so it is left as it is.
+					continue
+				}
+				return err
+			}
+			if err := s.Tasks.UpdateSeriesOccurrence(ctx, tx, occ.ID, types.OccurrenceCancelled, "", "", ""); err != nil {
+				return err
+			}
+		}
+		return nil
+	}); err != nil {
+		s.Logger.Error("failed to end booking series %s: %v", seriesID, err)
+		return nil, err
+	}
+
+	return s.GetBookingSeries(ctx, seriesID)
+}
+
+// releaseSeriesOccurrence cancels the booking made for an occurrence, or the order paid
+// for it when it was never booked, in the caller's transaction.
+func (s *BookingService) releaseSeriesOccurrence(ctx context.Context, tx pgx.Tx, occ *types.SeriesOccurrence, reason, actor string) error {
+	if occ.BookingID != nil {
+		snap, err := s.Tasks.FetchBookingSnapshot(ctx, tx, *occ.BookingID)
+		if err != nil {
+			return err
+		}
+		_, err = s.cancelRequestedBooking(ctx, tx, snap, reason, actor)
+		return err
+	}
+	if occ.OrderID != nil {
+		return s.Tasks.CancelUnusedOrder(ctx, tx, *occ.OrderID)
+	}
+	return nil
+}
+
+// authorizeSeriesChange lets admins and the series' customer skip or end it.
+func (s *BookingService) authorizeSeriesChange(ctx context.Context, tx pgx.Tx, series *types.BookingSeries, actor string) error {
+	viewer, err := s.Tasks.ResolveAccessViewer(ctx, tx, actor)
+	if err != nil {
+		return err
+	}
+	if viewer.Role == tasks.ViewerRoleAdmin ||
+		(viewer.Role == tasks.ViewerRoleCustomer && viewer.CustomerID == series.CustomerID) {
+		return nil
+	}
+	return tasks.ErrSeriesDenied
+}
+
+// GenerateSeriesOccurrences extends every active series up to the generation horizon
+// and books any occurrence that is still waiting for cleaners.
+func (s *BookingService) GenerateSeriesOccurrences(ctx context.Context) error {
+	var seriesIDs []string
+	if err := s.withTx(ctx, func(tx pgx.Tx) error {
+		var err error
+		seriesIDs, err = s.Tasks.FetchActiveSeriesIDs(ctx, tx)
+		return err
+	}); err != nil {
+		s.Logger.Error("failed to list active booking series: %v", err)
+		return err
+	}
+
+	for _, seriesID := range seriesIDs {
+		var series *types.BookingSeries
+		if err := s.withTx(ctx, func(tx pgx.Tx) error {
+			var err error
+			series, err = s.Tasks.FetchBookingSeries(ctx, tx, seriesID)
+			if err != nil {
+				return err
+			}
+			return s.Tasks.EnsureSeriesOccurrences(ctx, tx, series, seriesHorizon())
+		}); err != nil {
+			s.Logger.Error("failed to generate occurrences for series %s: %v", seriesID, err)
+			continue
+		}
+		s.materializeSeries(ctx, series)
+	}
+
+	return nil
+}
+
+// RunSeriesGenerator periodically calls GenerateSeriesOccurrences until ctx is done.
+func (s *BookingService) RunSeriesGenerator(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		s.runExclusive(ctx, "series-generator", func() {
+			if err := s.GenerateSeriesOccurrences(ctx); err != nil {
+				s.Logger.Error("series generator run failed: %v", err)
+			}
+		})
+
+		select {
+		case <-ctx.Done():
+			s.Logger.Info("Stopping booking series generator")
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (s *BookingService) materializeSeries(ctx context.Context, series *types.BookingSeries) {
+	var pending []types.SeriesOccurrence
+	if err := s.withTx(ctx, func(tx pgx.Tx) error {
+		var err error
+		pending, err = s.Tasks.FetchOccurrencesToMaterialize(ctx, tx, series.ID)
+		return err
+	}); err != nil {
+		s.Logger.Error("failed to load pending occurrences for series %s: %v", series.ID, err)
+		return
+	}
+
+	for _, occ := range pending {
+		s.materializeOccurrence(ctx, series, occ)
+	}
+}
+
+func (s *BookingService) materializeOccurrence(ctx context.Context, series *types.BookingSeries, occ types.SeriesOccurrence) {
+	req := series.Template
+	if err := s.withTx(ctx, func(tx pgx.Tx) error {
+		var err error
+		req.Base.AccessInstructions, err = s.Tasks.FetchHeldAccessInstructions(ctx, tx, s.AccessVault, tasks.SeriesInstructionsOwner(series.ID))
+		return err
+	}); err != nil {
+		s.recordOccurrenceResult(ctx, occ, types.OccurrenceFailed, "", "", err.Error())
+		return
+	}
+
+	orderID := ""
+	if occ.OrderID != nil {
+		orderID = *occ.OrderID
+	} else {
+		addonTotal := series.AddonTotal
+		created, err := s.PaymentPort.CreateOrder(ctx, types.CreateOrderRequest{
+			QuoteID:       series.QuoteID,
+			CustomerID:    series.CustomerID,
+			PaymentMethod: series.PaymentMethod,
+			Subtotal:      series.Subtotal,
+			AddonTotal:    &addonTotal,
+			TotalAmount:   series.TotalAmount,
+		})
+		if err != nil {
+			s.recordOccurrenceResult(ctx, occ, types.OccurrenceFailed, "", "", err.Error())
+			return
+		}
+		orderID = created.Order.ID
+	}
+
+	req.Base.OrderId = orderID
+	req.Base.StartSched = occ.StartSched
+	req.Base.EndSched = occ.EndSched
+
+	booking, err := s.CreateBooking(ctx, req, tasks.SystemActor)
+	if err != nil {
+		if errors.Is(err, tasks.ErrNoAvailableCleaners) || errors.Is(err, tasks.ErrInsufficientCleaners) {
+			s.recordOccurrenceResult(ctx, occ, types.OccurrenceConflict, "", orderID, err.Error())
+			return
+		}
+
+		// A failed occurrence is not retried, so its order would never be used.
+		reason := err.Error()
+		if err := s.withTx(ctx, func(tx pgx.Tx) error {
+			if err := s.Tasks.CancelUnusedOrder(ctx, tx, orderID); err != nil {
+				return err
+			}
+			return s.Tasks.UpdateSeriesOccurrence(ctx, tx, occ.ID, types.OccurrenceFailed, "", orderID, reason)
+		}); err != nil {
+			s.Logger.Error("failed to record result for series occurrence %s: %v", occ.ID, err)
+		}
+		return
+	}
+
+	s.recordOccurrenceResult(ctx, occ, types.OccurrenceScheduled, booking.ID, orderID, "")
+}
+
+func (s *BookingService) recordOccurrenceResult(
+	ctx context.Context,
+	occ types.SeriesOccurrence,
+	status types.OccurrenceStatus,
+	bookingID, orderID, reason string,
+) {
+	if err := s.withTx(ctx, func(tx pgx.Tx) error {
+		return s.Tasks.UpdateSeriesOccurrence(ctx, tx, occ.ID, status, bookingID, orderID, reason)
+	}); err != nil {
+		s.Logger.Error("failed to record result for series occurrence %s: %v", occ.ID, err)
+	}
+}
+
+func seriesHorizon() time.Time {
+	return time.Now().AddDate(0, 0, tasks.SeriesGenerationHorizonDays)
+}
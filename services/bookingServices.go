@@ -2,7 +2,9 @@ package services
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"handworks-api/tasks"
 	"handworks-api/types"
 	"time"
 
@@ -29,16 +31,41 @@ func (s *BookingService) withTx(
 	return fn(tx)
 }
 
-func (s *BookingService) CreateBooking(ctx context.Context, req types.CreateBookingRequest) (*types.Booking, error) {
-	s.Logger.Info("Creating booking for customer: %s...", req.Base.CustomerFirstName)
+// runExclusive runs one pass of a background job unless another API instance is already
+// running it. The session advisory lock is held on a dedicated connection for the pass.
+func (s *BookingService) runExclusive(ctx context.Context, job string, pass func()) {
+	conn, err := s.DB.Acquire(ctx)
+	if err != nil {
+		s.Logger.Error("failed to acquire connection for %s: %v", job, err)
+		return
+	}
+	defer conn.Release()
 
-	if req.ExtraHours > 0 {
-		if req.MainService.ServiceType != types.GeneralCleaning {
-			return nil, fmt.Errorf("extra hours can only be added for General Cleaning services")
-		}
-		if req.ExtraHours > 4 {
-			return nil, fmt.Errorf("extra hours cannot exceed 4 hours")
+	key := "job:" + job
+	var locked bool
+	if err := conn.QueryRow(ctx, `SELECT pg_try_advisory_lock(hashtext($1))`, key).Scan(&locked); err != nil {
+		s.Logger.Error("failed to lock %s: %v", job, err)
+		return
+	}
+	if !locked {
+		return
+	}
+	defer func() {
+		if _, err := conn.Exec(context.Background(), `SELECT pg_advisory_unlock(hashtext($1))`, key); err != nil {
+			s.Logger.Error("failed to unlock %s: %v", job, err)
+			// Never hand a connection that may still hold the lock back to the pool.
+			conn.Conn().Close(context.Background())
 		}
+	}()
+
+	pass()
+}
+
+func (s *BookingService) CreateBooking(ctx context.Context, req types.CreateBookingRequest, actor string) (*types.Booking, error) {
+	s.Logger.Info("Creating booking for customer: %s...", req.Base.CustomerFirstName)
+
+	if err := validateExtraHours(req); err != nil {
+		return nil, err
 	}
 
 	order, prices, err := s.Tasks.FetchOrderAndPrices(ctx, s.PaymentPort, req.Base.OrderId)
@@ -46,106 +73,302 @@ func (s *BookingService) CreateBooking(ctx context.Context, req types.CreateBook
 		s.Logger.Error("Failed to fetch order and prices: %v", err)
 		return nil, err
 	}
+	if err := tasks.CheckQuotedDirtyScale(req.Base.DirtyScale, prices.DirtyScale); err != nil {
+		return nil, err
+	}
 	var createdBooking *types.Booking
 
 	err = s.withTx(ctx, func(tx pgx.Tx) error {
+		var err error
+		createdBooking, err = s.bookOrderChecked(ctx, tx, req, order.ID, prices, actor)
+		return err
+	})
 
-		cleaners, err := s.Tasks.AllocateCleaners(ctx, tx, &req)
-		if err != nil {
-			return err
+	if err != nil {
+		return nil, err
+	}
+
+	return createdBooking, nil
+}
+
+// bookOrderChecked runs every check a new booking for an order has to pass and then books
+// it. A booking that already exists for the same order and schedule is returned as is, so
+// double-submitted requests do not fail. Every path that books an order goes through here.
+func (s *BookingService) bookOrderChecked(
+	ctx context.Context,
+	tx pgx.Tx,
+	req types.CreateBookingRequest,
+	orderID string,
+	prices *types.CleaningPrices,
+	actor string,
+) (*types.Booking, error) {
+	if err := s.Tasks.LockBookingSlot(ctx, tx, req.Base.CustID, req.Base.Address); err != nil {
+		return nil, err
+	}
+	overlaps, err := s.Tasks.FindOverlappingBookings(ctx, tx, req.Base.CustID, req.Base.Address, bookingWindows(req, prices))
+	if err != nil {
+		return nil, err
+	}
+	for _, o := range overlaps {
+		if o.OrderID == orderID {
+			s.Logger.Info("Order %s is already booked as %s, returning the existing booking", orderID, o.BookingID)
+			return s.Tasks.FetchBookingByID(ctx, tx, o.BookingID, s.Logger)
+		}
+	}
+	if len(overlaps) > 0 {
+		return nil, fmt.Errorf("%w: booking %s", tasks.ErrOverlappingBooking, overlaps[0].BookingID)
+	}
+	booked, err := s.Tasks.OrderHasActiveBooking(ctx, tx, orderID)
+	if err != nil {
+		return nil, err
+	}
+	if booked {
+		return nil, tasks.ErrOrderAlreadyBooked
+	}
+
+	if err := s.Tasks.ValidateMediaIDs(ctx, tx, req.Base.Photos, types.MediaPurposeBooking); err != nil {
+		return nil, err
+	}
+	if err := tasks.CheckBookingServiceZone(ctx, tx, req.Base.Address, prices.ServiceZoneID); err != nil {
+		return nil, err
+	}
+	return s.bookOrder(ctx, tx, req, orderID, prices, actor)
+}
+
+func validateExtraHours(req types.CreateBookingRequest) error {
+	if req.ExtraHours > 0 {
+		if req.MainService.ServiceType != types.GeneralCleaning {
+			return fmt.Errorf("extra hours can only be added for General Cleaning services")
+		}
+		if req.ExtraHours > 4 {
+			return fmt.Errorf("extra hours cannot exceed 4 hours")
 		}
+	}
+	return nil
+}
 
-		mainService, err := s.Tasks.CreateMainServiceBooking(ctx, tx, s.Logger, req.MainService.Details)
-		if err != nil {
-			return err
+// bookingWindows returns the schedule each day of the booking will occupy, laid out the
+// same way bookOrder creates them.
+func bookingWindows(req types.CreateBookingRequest, prices *types.CleaningPrices) []tasks.BookingScheduleWindow {
+	extra := time.Duration(req.ExtraHours * float32(time.Hour))
+	if len(prices.DayPlan) <= 1 {
+		return []tasks.BookingScheduleWindow{{StartSched: req.Base.StartSched, EndSched: req.Base.EndSched.Add(extra)}}
+	}
+
+	windows := make([]tasks.BookingScheduleWindow, 0, len(prices.DayPlan))
+	for i, day := range prices.DayPlan {
+		start := req.Base.StartSched.AddDate(0, 0, int(day.DayIndex))
+		end := start.Add(time.Duration(day.Hours) * time.Hour)
+		if i == len(prices.DayPlan)-1 {
+			end = end.Add(extra)
 		}
+		windows = append(windows, tasks.BookingScheduleWindow{StartSched: start, EndSched: end})
+	}
+	return windows
+}
 
-		originalEndSched := req.Base.EndSched
-		var extraHourCost float32
-
-		if req.ExtraHours > 0 && len(cleaners) > 0 {
-			extraHourCost = req.ExtraHours * 250.00 * float32(len(cleaners))
-			req.Base.EndSched = req.Base.EndSched.Add(time.Duration(req.ExtraHours * float32(time.Hour)))
-		}
-
-		baseBook, err := s.Tasks.MakeBaseBooking(
-			ctx,
-			tx,
-			req.Base.CustID,
-			req.Base.CustomerFirstName,
-			req.Base.CustomerLastName,
-			req.Base.CustomerPhoneNo,
-			req.Base.Address,
-			req.Base.StartSched,
-			req.Base.EndSched, // ✅ set correctly by AllocateAll
-			req.Base.DirtyScale,
-			req.Base.Photos,
-			order.ID,
-			req.ExtraHours,
-			extraHourCost,
-			&originalEndSched,
-		)
+// bookOrder creates the booking, or the linked days of a multi-day job, for a paid order.
+func (s *BookingService) bookOrder(
+	ctx context.Context,
+	tx pgx.Tx,
+	req types.CreateBookingRequest,
+	orderID string,
+	prices *types.CleaningPrices,
+	actor string,
+) (*types.Booking, error) {
+	var (
+		booking *types.Booking
+		err     error
+	)
+	if len(prices.DayPlan) > 1 {
+		booking, err = s.createMultiDayBooking(ctx, tx, req, orderID, prices, actor)
+	} else {
+		booking, err = s.createBookingDay(ctx, tx, req, orderID, prices, prices.MainServicePrice, actor)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	// The order is booked now, so nothing left on the waitlist for it may be promoted.
+	if err := s.Tasks.CancelOrderWaitlistEntries(ctx, tx, orderID); err != nil {
+		return nil, err
+	}
+	return booking, nil
+}
+
+// createMultiDayBooking books one linked booking per day of the quote's day plan.
+// Every day starts at the requested time of day and runs for that day's planned hours;
+// addons are done on the first day and extra hours are added to the last.
+func (s *BookingService) createMultiDayBooking(
+	ctx context.Context,
+	tx pgx.Tx,
+	req types.CreateBookingRequest,
+	orderID string,
+	prices *types.CleaningPrices,
+	actor string,
+) (*types.Booking, error) {
+	var (
+		first  *types.Booking
+		linked = make([]types.LinkedBookingDay, 0, len(prices.DayPlan))
+		ids    = make([]string, 0, len(prices.DayPlan))
+	)
+
+	for i, day := range prices.DayPlan {
+		dayReq := req
+		dayReq.Base.StartSched = req.Base.StartSched.AddDate(0, 0, int(day.DayIndex))
+		dayReq.Base.EndSched = dayReq.Base.StartSched.Add(time.Duration(day.Hours) * time.Hour)
+		dayReq.TotalServiceHours = float32(day.Hours)
+		if i > 0 {
+			dayReq.Addons = nil
+		}
+		if i < len(prices.DayPlan)-1 {
+			dayReq.ExtraHours = 0
+		}
+
+		booking, err := s.createBookingDay(ctx, tx, dayReq, orderID, prices, day.Price, actor)
 		if err != nil {
-			return err
+			return nil, fmt.Errorf("day %d of %d: %w", day.DayIndex+1, len(prices.DayPlan), err)
+		}
+		if first == nil {
+			first = booking
 		}
 
-		var addonModels []types.AddOns
-		var addonIDs []string
-		for _, addonReq := range req.Addons {
-			var addonPrice float32
-			for _, ap := range prices.AddonPrices {
-				if ap.AddonName == string(addonReq.ServiceDetail.ServiceType) {
-					addonPrice = ap.AddonPrice
-					break
-				}
-			}
-			createdAddon, err := s.Tasks.CreateAddOn(ctx, tx, s.Logger, addonReq, addonPrice)
-			if err != nil {
-				return err
+		ids = append(ids, booking.ID)
+		linked = append(linked, types.LinkedBookingDay{
+			BookingID:  booking.ID,
+			DayIndex:   day.DayIndex,
+			StartSched: booking.Base.StartSched,
+			EndSched:   booking.Base.EndSched,
+			Hours:      day.Hours,
+			Price:      booking.TotalPrice,
+			Cleaners:   booking.Cleaners,
+		})
+	}
+
+	if err := s.Tasks.LinkBookingDays(ctx, tx, ids); err != nil {
+		return nil, err
+	}
+
+	first.LinkedBookings = linked
+	return first, nil
+}
+
+func (s *BookingService) createBookingDay(
+	ctx context.Context,
+	tx pgx.Tx,
+	req types.CreateBookingRequest,
+	orderID string,
+	prices *types.CleaningPrices,
+	price float32,
+	actor string,
+) (*types.Booking, error) {
+	cleaners, err := s.Tasks.AllocateCleaners(ctx, tx, &req, s.TravelEstimator)
+	if err != nil {
+		return nil, err
+	}
+
+	mainService, err := s.Tasks.CreateMainServiceBooking(ctx, tx, s.Logger, req.MainService.Details)
+	if err != nil {
+		return nil, err
+	}
+
+	originalEndSched := req.Base.EndSched
+	var extraHourCost float32
+
+	if req.ExtraHours > 0 && len(cleaners) > 0 {
+		extraHourCost = req.ExtraHours * tasks.ExtraHourRatePerCleaner * float32(len(cleaners))
+		req.Base.EndSched = req.Base.EndSched.Add(time.Duration(req.ExtraHours * float32(time.Hour)))
+	}
+
+	baseBook, err := s.Tasks.MakeBaseBooking(
+		ctx,
+		tx,
+		req.Base.CustID,
+		req.Base.CustomerFirstName,
+		req.Base.CustomerLastName,
+		req.Base.CustomerPhoneNo,
+		req.Base.Address,
+		req.Base.StartSched,
+		req.Base.EndSched, // ✅ set correctly by AllocateAll
+		req.Base.DirtyScale,
+		req.Base.Photos,
+		orderID,
+		req.ExtraHours,
+		extraHourCost,
+		&originalEndSched,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	var addonModels []types.AddOns
+	var addonIDs []string
+	for _, addonReq := range req.Addons {
+		var addonPrice float32
+		for _, ap := range prices.AddonPrices {
+			if ap.AddonName == string(addonReq.ServiceDetail.ServiceType) {
+				addonPrice = ap.AddonPrice
+				break
 			}
-			addonModels = append(addonModels, *createdAddon)
-			addonIDs = append(addonIDs, createdAddon.ID)
-		}
-		cleanerIDs := make([]string, 0, len(cleaners))
-		for _, c := range cleaners {
-			cleanerIDs = append(cleanerIDs, c.ID)
-		}
-
-		bookingID, err := s.Tasks.SaveBooking(
-			ctx,
-			tx,
-			baseBook.ID,
-			mainService.ID,
-			addonIDs,
-			[]string{},
-			[]string{},
-			cleanerIDs,
-			prices.MainServicePrice,
-			extraHourCost,
-		)
+		}
+		createdAddon, err := s.Tasks.CreateAddOn(ctx, tx, s.Logger, addonReq, addonPrice)
 		if err != nil {
-			return err
+			return nil, err
 		}
+		addonModels = append(addonModels, *createdAddon)
+		addonIDs = append(addonIDs, createdAddon.ID)
+	}
+	cleanerIDs := make([]string, 0, len(cleaners))
+	for _, c := range cleaners {
+		cleanerIDs = append(cleanerIDs, c.ID)
+	}
 
-		createdBooking = &types.Booking{
-			ID:          bookingID,
-			Base:        *baseBook,
-			MainService: *mainService,
-			Addons:      addonModels,
-			Equipments:  []types.CleaningEquipment{},
-			Resources:   []types.CleaningResources{},
-			Cleaners:    cleaners,
-			TotalPrice:  prices.MainServicePrice + extraHourCost,
+	bookingID, err := s.Tasks.SaveBooking(
+		ctx,
+		tx,
+		baseBook.ID,
+		mainService.ID,
+		addonIDs,
+		[]string{},
+		[]string{},
+		cleanerIDs,
+		price,
+		extraHourCost,
+	)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.Tasks.RecordBookingCreated(ctx, tx, bookingID, actor); err != nil {
+		return nil, err
+	}
+	if req.Base.AccessInstructions != nil {
+		if err := s.Tasks.SaveAccessInstructions(ctx, tx, s.AccessVault, bookingID, req.Base.AccessInstructions, actor); err != nil {
+			return nil, err
 		}
+	}
 
-		return nil
-	})
-
+	allocation, err := s.Tasks.AllocateEquipmentAndResources(ctx, tx, &req)
 	if err != nil {
 		return nil, err
 	}
+	if err := s.Tasks.AssignAllocatedInventory(ctx, tx, bookingID, allocation); err != nil {
+		return nil, err
+	}
+	for _, warning := range allocation.Warnings {
+		s.Logger.Warn("booking %s inventory: %s", bookingID, warning)
+	}
 
-	return createdBooking, nil
+	return &types.Booking{
+		ID:                bookingID,
+		Base:              *baseBook,
+		MainService:       *mainService,
+		Addons:            addonModels,
+		Equipments:        allocation.CleaningEquipment,
+		Resources:         allocation.CleaningResources,
+		Cleaners:          cleaners,
+		TotalPrice:        price + extraHourCost,
+		InventoryWarnings: allocation.Warnings,
+	}, nil
 }
 
 func (s *BookingService) GetBookings(
@@ -235,6 +458,43 @@ func (s *BookingService) GetBookedSlots(ctx context.Context, date string) (*type
 	return result, nil
 }
 
+// SearchAvailability lists the start times between two dates (YYYY-MM-DD, inclusive) at
+// which the requested services could be booked right now.
+func (s *BookingService) SearchAvailability(ctx context.Context, req types.AvailabilityRequest) (*types.AvailabilityResponse, error) {
+	loc := s.BusinessHours.Location
+	from, err := time.ParseInLocation("2006-01-02", req.StartDate, loc)
+	if err != nil {
+		return nil, fmt.Errorf("%w: startDate must be YYYY-MM-DD", tasks.ErrInvalidSchedule)
+	}
+	to, err := time.ParseInLocation("2006-01-02", req.EndDate, loc)
+	if err != nil {
+		return nil, fmt.Errorf("%w: endDate must be YYYY-MM-DD", tasks.ErrInvalidSchedule)
+	}
+	if to.Before(from) {
+		return nil, fmt.Errorf("%w: endDate is before startDate", tasks.ErrInvalidSchedule)
+	}
+	if to.Sub(from) >= tasks.MaxAvailabilityRangeDays*24*time.Hour {
+		return nil, fmt.Errorf("%w: date range cannot exceed %d days", tasks.ErrInvalidSchedule, tasks.MaxAvailabilityRangeDays)
+	}
+
+	serviceHours, err := s.Tasks.EstimateServiceHours(&req, s.DirtyScalePolicy, s.LargeAreaRate)
+	if err != nil {
+		return nil, err
+	}
+
+	var result *types.AvailabilityResponse
+	if err := s.withTx(ctx, func(tx pgx.Tx) error {
+		var err error
+		result, err = s.Tasks.FindAvailableSlots(ctx, tx, &req, serviceHours, s.BusinessHours, from, to)
+		return err
+	}); err != nil {
+		s.Logger.Error("failed to search availability: %v", err)
+		return nil, err
+	}
+
+	return result, nil
+}
+
 func (s *BookingService) GetUsedInventoryByBooking(ctx context.Context, bookingID string, itemType string) ([]types.UsedInventoryItem, error) {
 	var result []types.UsedInventoryItem
 
@@ -250,28 +510,71 @@ func (s *BookingService) GetUsedInventoryByBooking(ctx context.Context, bookingI
 	return result, nil
 }
 
-func (s *BookingService) StartSession(ctx context.Context, bookingID string, startPhotos []string) error {
+// StartSession starts the booking's session from the device location in req. Outside the
+// geofence, or when the address has no coordinates, only an admin override lets it start.
+func (s *BookingService) StartSession(ctx context.Context, req types.StartSessionRequest, actor string) error {
+	bookingID, startPhotos := req.BookingID, req.StartPhotos
 	if err := s.withTx(ctx, func(tx pgx.Tx) error {
-		canStart, startSched, err := s.Tasks.ValidateSessionStart(ctx, tx, bookingID)
+		snap, err := s.Tasks.FetchBookingSnapshot(ctx, tx, bookingID)
 		if err != nil {
 			return err
 		}
-		if !canStart {
-			return fmt.Errorf("booking cannot be started in its current state")
-		}
 
 		now := time.Now()
-		startSchedLocal := startSched.In(now.Location())
+		startSchedLocal := snap.StartSched.In(now.Location())
 		nowYear, nowMonth, nowDay := now.Date()
 		startYear, startMonth, startDay := startSchedLocal.Date()
 		if nowYear != startYear || nowMonth != startMonth || nowDay != startDay {
 			return fmt.Errorf("session can only be started within today's timeframe")
 		}
 
+		var (
+			distance     *float64
+			overriddenBy string
+			note         string
+		)
+		meters, fenceErr := tasks.CheckGeofence(s.GeofencePolicy, snap.Address, req.Location)
+		if !errors.Is(fenceErr, tasks.ErrGeofenceUnverifiable) {
+			distance = &meters
+		}
+		if fenceErr != nil {
+			if !req.OverrideGeofence {
+				return fenceErr
+			}
+			viewer, err := s.Tasks.ResolveAccessViewer(ctx, tx, actor)
+			if err != nil {
+				return err
+			}
+			if viewer.Role != tasks.ViewerRoleAdmin {
+				return tasks.ErrGeofenceOverrideDenied
+			}
+			overriddenBy, note = actor, "geofence overridden: "+fenceErr.Error()
+		}
+
+		if err := s.Tasks.ValidateMediaIDs(ctx, tx, startPhotos, types.MediaPurposeSession); err != nil {
+			return err
+		}
+
+		if _, err := s.Tasks.TransitionBooking(ctx, tx, bookingID, tasks.BookingEventStart, actor, ""); err != nil {
+			return err
+		}
+
 		if err := s.Tasks.StartSession(ctx, tx, bookingID, startPhotos); err != nil {
 			return err
 		}
 
+		if err := s.Tasks.RecordSessionStartLocation(ctx, tx, bookingID, req.Location, distance, overriddenBy); err != nil {
+			return err
+		}
+
+		if err := s.Tasks.InstantiateChecklist(ctx, tx, bookingID); err != nil {
+			return err
+		}
+
+		if err := s.Tasks.RecordSessionEvent(ctx, tx, bookingID, types.SessionEventStart, actor, note); err != nil {
+			return err
+		}
+
 		if err := s.Tasks.UpdateCleanerStatusesForBooking(ctx, tx, bookingID, "ONDUTY"); err != nil {
 			return err
 		}
@@ -284,12 +587,50 @@ func (s *BookingService) StartSession(ctx context.Context, bookingID string, sta
 	return nil
 }
 
-func (s *BookingService) EndSession(ctx context.Context, bookingID string, endPhotos []string) error {
+// EndSession completes the booking and bills any overtime beyond the quoted hours to the
+// order, returning how long the job actually took.
+func (s *BookingService) EndSession(ctx context.Context, bookingID string, endPhotos []string, actor string) (*types.SessionSummary, error) {
+	var summary *types.SessionSummary
 	if err := s.withTx(ctx, func(tx pgx.Tx) error {
+		snap, err := s.Tasks.FetchBookingSnapshot(ctx, tx, bookingID)
+		if err != nil {
+			return err
+		}
+
+		if err := s.Tasks.ValidateMediaIDs(ctx, tx, endPhotos, types.MediaPurposeSession); err != nil {
+			return err
+		}
+
+		if _, err := s.Tasks.TransitionBooking(ctx, tx, bookingID, tasks.BookingEventComplete, actor, ""); err != nil {
+			return err
+		}
+
+		if err := s.Tasks.EnsureChecklistComplete(ctx, tx, bookingID); err != nil {
+			return err
+		}
+
 		if err := s.Tasks.EndSession(ctx, tx, bookingID, endPhotos); err != nil {
 			return err
 		}
 
+		if err := s.Tasks.RecordSessionEvent(ctx, tx, bookingID, types.SessionEventEnd, actor, ""); err != nil {
+			return err
+		}
+
+		summary, err = s.sessionSummary(ctx, tx, snap)
+		if err != nil {
+			return err
+		}
+		summary.Status = tasks.BookingStatusCompleted
+		summary.Paused = false
+
+		if err := s.Tasks.SaveSessionTotals(ctx, tx, summary); err != nil {
+			return err
+		}
+		if err := s.Tasks.ChargeOvertime(ctx, tx, bookingID, snap.OrderID, summary.OvertimeCharge); err != nil {
+			return err
+		}
+
 		if err := s.Tasks.UpdateCleanerStatusesForBooking(ctx, tx, bookingID, "ACTIVE"); err != nil {
 			return err
 		}
@@ -297,9 +638,13 @@ func (s *BookingService) EndSession(ctx context.Context, bookingID string, endPh
 		return nil
 	}); err != nil {
 		s.Logger.Error("failed to end session for booking %s: %v", bookingID, err)
-		return err
+		return nil, err
 	}
-	return nil
+
+	if summary.OvertimeCharge > 0 {
+		s.Logger.Info("Booking %s ran %.2fh over; billed %.2f for %.1fh overtime", bookingID, summary.OvertimeHours, summary.OvertimeCharge, summary.BilledOvertimeHours)
+	}
+	return summary, nil
 }
 
 func (s *BookingService) GetBookingsToday(ctx context.Context) (types.FetchBookingsTodayResponse, error) {
@@ -335,10 +680,272 @@ func (s *BookingService) GetActiveBooking(ctx context.Context, customerId string
 	return booking, nil
 }
 
-func (s *BookingService) UpdateBooking(ctx context.Context) error {
-	return nil
+func (s *BookingService) RescheduleBooking(ctx context.Context, bookingID string, req types.RescheduleBookingRequest, actor string) (*types.RescheduleBookingResponse, error) {
+	if !req.EndSched.After(req.StartSched) {
+		return nil, fmt.Errorf("%w: endSched must be after startSched", tasks.ErrInvalidSchedule)
+	}
+	if !req.StartSched.After(time.Now()) {
+		return nil, fmt.Errorf("%w: startSched must be in the future", tasks.ErrInvalidSchedule)
+	}
+
+	var res *types.RescheduleBookingResponse
+
+	if err := s.withTx(ctx, func(tx pgx.Tx) error {
+		snap, err := s.Tasks.FetchBookingSnapshot(ctx, tx, bookingID)
+		if err != nil {
+			return err
+		}
+		if err := s.authorizeBookingChange(ctx, tx, snap, actor); err != nil {
+			return err
+		}
+
+		state, err := s.Tasks.TransitionBooking(ctx, tx, bookingID, tasks.BookingEventReschedule, actor,
+			fmt.Sprintf("%s - %s", req.StartSched.Format(time.RFC3339), req.EndSched.Format(time.RFC3339)))
+		if err != nil {
+			return err
+		}
+
+		available, err := s.Tasks.IsScheduleSlotAvailable(ctx, tx, bookingID, req.StartSched, req.EndSched, s.Logger)
+		if err != nil {
+			return err
+		}
+		if !available {
+			return tasks.ErrScheduleSlotUnavailable
+		}
+
+		conflicts, err := s.Tasks.FindConflictingCleaners(ctx, tx, snap.CleanerIDs, snap.Address, req.StartSched, req.EndSched, bookingID, s.TravelEstimator)
+		if err != nil {
+			return err
+		}
+
+		cleanerIDs := snap.CleanerIDs
+		reassigned := len(conflicts) > 0 || len(cleanerIDs) == 0
+		if reassigned {
+			// Drop the current crew first so the booking's own old window
+			// does not block its cleaners from being picked again.
+			if err := s.Tasks.ReplaceBookingCleaners(ctx, tx, bookingID, nil); err != nil {
+				return err
+			}
+
+			serviceTypes, err := s.Tasks.FetchBookingServiceTypes(ctx, tx, bookingID)
+			if err != nil {
+				return err
+			}
+			mainService, addons := tasks.ServiceRequestsFor(serviceTypes)
+
+			cleaners, err := s.Tasks.AllocateCleaners(ctx, tx, &types.CreateBookingRequest{
+				Base: types.BaseBookingDetailsRequest{
+					Address:    snap.Address,
+					StartSched: req.StartSched,
+					EndSched:   req.EndSched,
+					DirtyScale: snap.DirtyScale,
+				},
+				MainService:       mainService,
+				Addons:            addons,
+				ExtraHours:        snap.ExtraHours,
+				TotalServiceHours: snap.TotalServiceHours,
+			}, s.TravelEstimator)
+			if err != nil {
+				return err
+			}
+
+			cleanerIDs = make([]string, 0, len(cleaners))
+			for _, c := range cleaners {
+				cleanerIDs = append(cleanerIDs, c.ID)
+			}
+			if err := s.Tasks.ReplaceBookingCleaners(ctx, tx, bookingID, cleanerIDs); err != nil {
+				return err
+			}
+		}
+
+		// An approved booking that received a new crew has to be approved again.
+		if reassigned && state.ReviewStatus == tasks.ReviewStatusScheduled {
+			state, err = s.Tasks.TransitionBooking(ctx, tx, bookingID, tasks.BookingEventRequeue, actor, "cleaners reassigned")
+			if err != nil {
+				return err
+			}
+		}
+
+		originalEndSched := req.EndSched.Add(-time.Duration(snap.ExtraHours * float32(time.Hour)))
+		if err := s.Tasks.UpdateBookingSchedule(ctx, tx, snap.BaseBookingID, req.StartSched, req.EndSched, originalEndSched); err != nil {
+			return err
+		}
+
+		if err := s.Tasks.PublishBookingEvent(ctx, tx, "booking_rescheduled", map[string]any{
+			"event":              "booking_rescheduled",
+			"bookingId":          bookingID,
+			"cleanerIds":         cleanerIDs,
+			"previousCleanerIds": snap.CleanerIDs,
+		}); err != nil {
+			return err
+		}
+
+		res = &types.RescheduleBookingResponse{
+			BookingID:          bookingID,
+			StartSched:         req.StartSched,
+			EndSched:           req.EndSched,
+			ReviewStatus:       state.ReviewStatus,
+			CleanerIDs:         cleanerIDs,
+			CleanersReassigned: reassigned,
+		}
+		return nil
+	}); err != nil {
+		s.Logger.Error("failed to reschedule booking %s: %v", bookingID, err)
+		return nil, err
+	}
+
+	return res, nil
 }
 
-func (s *BookingService) DeleteBooking(ctx context.Context) error {
-	return nil
+func (s *BookingService) CancelBooking(ctx context.Context, bookingID string, reason string, actor string) (*types.CancelBookingResponse, error) {
+	var res *types.CancelBookingResponse
+
+	if err := s.withTx(ctx, func(tx pgx.Tx) error {
+		snap, err := s.Tasks.FetchBookingSnapshot(ctx, tx, bookingID)
+		if err != nil {
+			return err
+		}
+		if err := s.authorizeBookingChange(ctx, tx, snap, actor); err != nil {
+			return err
+		}
+		res, err = s.cancelRequestedBooking(ctx, tx, snap, reason, actor)
+		return err
+	}); err != nil {
+		s.Logger.Error("failed to cancel booking %s: %v", bookingID, err)
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// cancelRequestedBooking cancels a booking on request, refunding by how long before its
+// start the request came in, and publishes the cancellation.
+func (s *BookingService) cancelRequestedBooking(
+	ctx context.Context,
+	tx pgx.Tx,
+	snap *tasks.BookingSnapshot,
+	reason, actor string,
+) (*types.CancelBookingResponse, error) {
+	hoursBeforeStart := time.Until(snap.StartSched).Hours()
+	if hoursBeforeStart < 0 {
+		hoursBeforeStart = 0
+	}
+	res, err := s.cancelBooking(ctx, tx, snap, tasks.BookingEventCancel, reason, actor, hoursBeforeStart)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.Tasks.PublishBookingEvent(ctx, tx, "booking_cancelled", map[string]any{
+		"event":      "booking_cancelled",
+		"bookingId":  snap.BookingID,
+		"customerId": snap.CustID,
+		"cleanerIds": snap.CleanerIDs,
+	}); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// authorizeBookingChange lets only admins and the booking's customer change a booking.
+func (s *BookingService) authorizeBookingChange(ctx context.Context, tx pgx.Tx, snap *tasks.BookingSnapshot, actor string) error {
+	viewer, err := s.Tasks.ResolveAccessViewer(ctx, tx, actor)
+	if err != nil {
+		return err
+	}
+	if viewer.Role == tasks.ViewerRoleAdmin ||
+		(viewer.Role == tasks.ViewerRoleCustomer && viewer.CustomerID == snap.CustID) {
+		return nil
+	}
+	return tasks.ErrBookingChangeDenied
+}
+
+// cancelBooking applies event to the booking, frees its cleaners and inventory, cancels
+// the remaining days of a multi-day job and settles the shared order by the cancellation
+// policy. Days that have already started stay on the order, so only the cancelled days'
+// share of the day plan is refunded. Publishing the booking's own event is left to the caller.
+func (s *BookingService) cancelBooking(
+	ctx context.Context,
+	tx pgx.Tx,
+	snap *tasks.BookingSnapshot,
+	event tasks.BookingEvent,
+	reason, actor string,
+	hoursBeforeStart float64,
+) (*types.CancelBookingResponse, error) {
+	bookingID := snap.BookingID
+	state, err := s.Tasks.TransitionBooking(ctx, tx, bookingID, event, actor, reason)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.Tasks.ReplaceBookingCleaners(ctx, tx, bookingID, nil); err != nil {
+		return nil, err
+	}
+	if err := s.Tasks.ReleaseBookingInventory(ctx, tx, bookingID); err != nil {
+		return nil, err
+	}
+
+	// The days of a multi-day job share one order, so the remaining days go with it.
+	linkedIDs, err := s.Tasks.FetchLinkedBookingIDs(ctx, tx, bookingID)
+	if err != nil {
+		return nil, err
+	}
+	cancelledDays := []int32{snap.DayIndex}
+	for _, linkedID := range linkedIDs {
+		linked, err := s.Tasks.FetchBookingSnapshot(ctx, tx, linkedID)
+		if err != nil {
+			return nil, err
+		}
+		if _, err := s.Tasks.TransitionBooking(ctx, tx, linkedID, tasks.BookingEventCancel, actor, reason); err != nil {
+			if errors.Is(err, tasks.ErrInvalidTransition) {
+				continue
+			}
+			return nil, err
+		}
+		cancelledDays = append(cancelledDays, linked.DayIndex)
+		if err := s.Tasks.ReplaceBookingCleaners(ctx, tx, linkedID, nil); err != nil {
+			return nil, err
+		}
+		if err := s.Tasks.ReleaseBookingInventory(ctx, tx, linkedID); err != nil {
+			return nil, err
+		}
+		if err := s.Tasks.PublishBookingEvent(ctx, tx, "booking_cancelled", map[string]any{
+			"event":      "booking_cancelled",
+			"bookingId":  linkedID,
+			"customerId": linked.CustID,
+			"cleanerIds": linked.CleanerIDs,
+		}); err != nil {
+			return nil, err
+		}
+	}
+
+	summary, err := s.Tasks.FetchOrderPaymentSummary(ctx, tx, snap.OrderID)
+	if err != nil {
+		return nil, err
+	}
+	cancelled, keptBalance := summary, float32(0)
+	wholeOrder := len(cancelledDays) == len(linkedIDs)+1
+	if !wholeOrder {
+		plan, err := s.Tasks.FetchOrderDayPlan(ctx, tx, snap.OrderID)
+		if err != nil {
+			return nil, err
+		}
+		cancelled, keptBalance = tasks.ScopeCancelledDays(summary, tasks.DayPlanValue(plan, cancelledDays))
+	}
+	outcome, refundAmount := s.Tasks.ResolveCancellationOutcome(s.CancellationPolicy, hoursBeforeStart, cancelled)
+
+	if err := s.Tasks.RecordCancellation(ctx, tx, bookingID, snap.CleanerIDs, reason, outcome, hoursBeforeStart, cancelled.AmountPaid, refundAmount); err != nil {
+		return nil, err
+	}
+	if err := s.Tasks.SettleCancelledOrder(ctx, tx, summary, outcome, refundAmount, keptBalance, wholeOrder); err != nil {
+		return nil, err
+	}
+
+	return &types.CancelBookingResponse{
+		BookingID:        bookingID,
+		Status:           state.Status,
+		Outcome:          outcome,
+		HoursBeforeStart: hoursBeforeStart,
+		AmountPaid:       cancelled.AmountPaid,
+		RefundAmount:     refundAmount,
+		CancellationFee:  cancelled.AmountPaid - refundAmount,
+	}, nil
 }
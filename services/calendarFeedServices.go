@@ -0,0 +1,124 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"handworks-api/tasks"
+	"handworks-api/types"
+	"handworks-api/utils"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// CreateCalendarFeed issues a new feed token for an employee or customer. The token is
+// returned once and only its hash is kept.
+func (s *BookingService) CreateCalendarFeed(
+	ctx context.Context,
+	req types.CreateCalendarFeedRequest,
+	actor string,
+) (*types.CalendarFeed, string, error) {
+	token, hash, err := tasks.NewCalendarFeedToken()
+	if err != nil {
+		return nil, "", err
+	}
+
+	var feed *types.CalendarFeed
+	if err := s.withTx(ctx, func(tx pgx.Tx) error {
+		viewer, err := s.Tasks.ResolveAccessViewer(ctx, tx, actor)
+		if err != nil {
+			return err
+		}
+		if err := tasks.CanManageCalendarFeed(viewer, req.OwnerType, req.OwnerID); err != nil {
+			return err
+		}
+		feed, err = s.Tasks.InsertCalendarFeed(ctx, tx, req, hash, actor)
+		return err
+	}); err != nil {
+		if !errors.Is(err, tasks.ErrCalendarFeedDenied) && !errors.Is(err, tasks.ErrCalendarFeedOwnerNotFound) {
+			s.Logger.Error("failed to create calendar feed for %s %s: %v", req.OwnerType, req.OwnerID, err)
+		}
+		return nil, "", err
+	}
+
+	return feed, token, nil
+}
+
+func (s *BookingService) GetCalendarFeeds(
+	ctx context.Context,
+	ownerType types.CalendarFeedOwner,
+	ownerID, actor string,
+) ([]types.CalendarFeed, error) {
+	var feeds []types.CalendarFeed
+	if err := s.withTx(ctx, func(tx pgx.Tx) error {
+		viewer, err := s.Tasks.ResolveAccessViewer(ctx, tx, actor)
+		if err != nil {
+			return err
+		}
+		if err := tasks.CanManageCalendarFeed(viewer, ownerType, ownerID); err != nil {
+			return err
+		}
+		feeds, err = s.Tasks.FetchCalendarFeeds(ctx, tx, ownerType, ownerID)
+		return err
+	}); err != nil {
+		if !errors.Is(err, tasks.ErrCalendarFeedDenied) {
+			s.Logger.Error("failed to fetch calendar feeds for %s %s: %v", ownerType, ownerID, err)
+		}
+		return nil, err
+	}
+
+	return feeds, nil
+}
+
+func (s *BookingService) RevokeCalendarFeed(ctx context.Context, feedID, actor string) error {
+	if err := s.withTx(ctx, func(tx pgx.Tx) error {
+		feed, err := s.Tasks.FetchCalendarFeed(ctx, tx, feedID)
+		if err != nil {
+			return err
+		}
+		viewer, err := s.Tasks.ResolveAccessViewer(ctx, tx, actor)
+		if err != nil {
+			return err
+		}
+		if err := tasks.CanManageCalendarFeed(viewer, feed.OwnerType, feed.OwnerID); err != nil {
+			return err
+		}
+		return s.Tasks.RevokeCalendarFeed(ctx, tx, feedID)
+	}); err != nil {
+		if !errors.Is(err, tasks.ErrCalendarFeedDenied) && !errors.Is(err, tasks.ErrCalendarFeedNotFound) {
+			s.Logger.Error("failed to revoke calendar feed %s: %v", feedID, err)
+		}
+		return err
+	}
+
+	return nil
+}
+
+// RenderCalendarFeed builds the ICS document for a feed token.
+func (s *BookingService) RenderCalendarFeed(ctx context.Context, token string) ([]byte, error) {
+	var (
+		feed     *types.CalendarFeed
+		bookings []types.Booking
+	)
+	now := time.Now()
+	if err := s.withTx(ctx, func(tx pgx.Tx) error {
+		var err error
+		feed, err = s.Tasks.UseCalendarFeedToken(ctx, tx, token)
+		if err != nil {
+			return err
+		}
+		bookings, err = s.Tasks.FetchCalendarFeedBookings(ctx, tx, feed, now, s.Logger)
+		return err
+	}); err != nil {
+		if !errors.Is(err, tasks.ErrCalendarFeedNotFound) {
+			s.Logger.Error("failed to render calendar feed: %v", err)
+		}
+		return nil, err
+	}
+
+	name := "Handworks bookings"
+	if feed.OwnerType == types.CalendarFeedEmployee {
+		name = "Handworks assignments"
+	}
+	return utils.RenderICS(name, tasks.BookingCalendarEvents(bookings), now), nil
+}
@@ -0,0 +1,133 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"handworks-api/tasks"
+	"handworks-api/types"
+	"slices"
+
+	"github.com/jackc/pgx/v5"
+)
+
+func (s *BookingService) GetBookingChecklist(ctx context.Context, bookingID string) (*types.BookingChecklist, error) {
+	var checklist *types.BookingChecklist
+	if err := s.withTx(ctx, func(tx pgx.Tx) error {
+		if _, err := s.Tasks.FetchBookingSnapshot(ctx, tx, bookingID); err != nil {
+			return err
+		}
+		var err error
+		checklist, err = s.Tasks.FetchBookingChecklist(ctx, tx, bookingID)
+		return err
+	}); err != nil {
+		s.Logger.Error("failed to fetch checklist for booking %s: %v", bookingID, err)
+		return nil, err
+	}
+
+	return checklist, nil
+}
+
+// UpdateChecklistItem records progress on an item of an ongoing session and tells the
+// booking's cleaners and the admins. Only the booking's cleaners and admins may update it.
+func (s *BookingService) UpdateChecklistItem(
+	ctx context.Context,
+	bookingID, itemID string,
+	req types.UpdateChecklistItemRequest,
+	actor string,
+) (*types.BookingChecklistItem, error) {
+	var item *types.BookingChecklistItem
+	if err := s.withTx(ctx, func(tx pgx.Tx) error {
+		snap, err := s.Tasks.FetchBookingSnapshot(ctx, tx, bookingID)
+		if err != nil {
+			return err
+		}
+		if snap.Status != tasks.BookingStatusOngoing {
+			return tasks.ErrSessionNotStarted
+		}
+		viewer, err := s.Tasks.ResolveAccessViewer(ctx, tx, actor)
+		if err != nil {
+			return err
+		}
+		switch viewer.Role {
+		case tasks.ViewerRoleAdmin:
+		case tasks.ViewerRoleEmployee:
+			if !slices.Contains(snap.CleanerIDs, viewer.EmployeeID) {
+				return tasks.ErrCleanerNotAssigned
+			}
+		default:
+			return tasks.ErrChecklistDenied
+		}
+
+		item, err = s.Tasks.UpdateChecklistItem(ctx, tx, bookingID, itemID, &req, actor)
+		if err != nil {
+			return err
+		}
+
+		return s.Tasks.PublishBookingEvent(ctx, tx, "checklist_updated", map[string]any{
+			"event":      "checklist_updated",
+			"bookingId":  bookingID,
+			"itemId":     item.ID,
+			"cleanerIds": snap.CleanerIDs,
+		})
+	}); err != nil {
+		if !errors.Is(err, tasks.ErrSkipReasonRequired) && !errors.Is(err, tasks.ErrChecklistItemNotFound) {
+			s.Logger.Error("failed to update checklist item %s on booking %s: %v", itemID, bookingID, err)
+		}
+		return nil, err
+	}
+
+	return item, nil
+}
+
+func (s *AdminService) GetChecklistTemplates(ctx context.Context, serviceType string) ([]types.ChecklistTemplate, error) {
+	var templates []types.ChecklistTemplate
+	if err := s.withTx(ctx, func(tx pgx.Tx) error {
+		var err error
+		templates, err = s.Tasks.FetchChecklistTemplates(ctx, tx, serviceType)
+		return err
+	}); err != nil {
+		s.Logger.Error("Failed to fetch checklist templates: %v", err)
+		return nil, err
+	}
+
+	return templates, nil
+}
+
+func (s *AdminService) CreateChecklistTemplate(ctx context.Context, req *types.ChecklistTemplateRequest) (*types.ChecklistTemplate, error) {
+	var tpl *types.ChecklistTemplate
+	if err := s.withTx(ctx, func(tx pgx.Tx) error {
+		var err error
+		tpl, err = s.Tasks.CreateChecklistTemplate(ctx, tx, req)
+		return err
+	}); err != nil {
+		s.Logger.Error("Failed to create checklist template: %v", err)
+		return nil, err
+	}
+
+	return tpl, nil
+}
+
+func (s *AdminService) UpdateChecklistTemplate(ctx context.Context, templateID string, req *types.ChecklistTemplateRequest) (*types.ChecklistTemplate, error) {
+	var tpl *types.ChecklistTemplate
+	if err := s.withTx(ctx, func(tx pgx.Tx) error {
+		var err error
+		tpl, err = s.Tasks.UpdateChecklistTemplate(ctx, tx, templateID, req)
+		return err
+	}); err != nil {
+		s.Logger.Error("Failed to update checklist template %s: %v", templateID, err)
+		return nil, err
+	}
+
+	return tpl, nil
+}
+
+func (s *AdminService) DeleteChecklistTemplate(ctx context.Context, templateID string) error {
+	if err := s.withTx(ctx, func(tx pgx.Tx) error {
+		return s.Tasks.DeactivateChecklistTemplate(ctx, tx, templateID)
+	}); err != nil {
+		s.Logger.Error("Failed to delete checklist template %s: %v", templateID, err)
+		return err
+	}
+
+	return nil
+}
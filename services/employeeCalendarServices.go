@@ -0,0 +1,63 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"handworks-api/types"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// SubmitWeeklyAvailability sends a new weekly schedule for admin approval. Allocation keeps
+// using the employee's current approved schedule until then.
+func (s *AccountService) SubmitWeeklyAvailability(ctx context.Context, employeeID string, req types.SubmitWeeklyAvailabilityRequest) (*types.WeeklyAvailability, error) {
+	var schedule *types.WeeklyAvailability
+	if err := s.withTx(ctx, func(tx pgx.Tx) error {
+		var err error
+		schedule, err = s.Tasks.SubmitWeeklyAvailability(ctx, tx, employeeID, req.Windows)
+		return err
+	}); err != nil {
+		return nil, fmt.Errorf("could not submit weekly availability: %w", err)
+	}
+
+	return schedule, nil
+}
+
+func (s *AccountService) RequestTimeOff(ctx context.Context, employeeID string, req types.RequestTimeOffRequest) (*types.TimeOff, error) {
+	var timeOff *types.TimeOff
+	if err := s.withTx(ctx, func(tx pgx.Tx) error {
+		var err error
+		timeOff, err = s.Tasks.RequestTimeOff(ctx, tx, employeeID, &req)
+		return err
+	}); err != nil {
+		return nil, fmt.Errorf("could not request time off: %w", err)
+	}
+
+	return timeOff, nil
+}
+
+func (s *AccountService) CancelTimeOff(ctx context.Context, employeeID, timeOffID string) (*types.TimeOff, error) {
+	var timeOff *types.TimeOff
+	if err := s.withTx(ctx, func(tx pgx.Tx) error {
+		var err error
+		timeOff, err = s.Tasks.CancelTimeOff(ctx, tx, employeeID, timeOffID)
+		return err
+	}); err != nil {
+		return nil, fmt.Errorf("could not cancel time off: %w", err)
+	}
+
+	return timeOff, nil
+}
+
+func (s *AccountService) GetEmployeeCalendar(ctx context.Context, employeeID string) (*types.EmployeeCalendar, error) {
+	var calendar *types.EmployeeCalendar
+	if err := s.withTx(ctx, func(tx pgx.Tx) error {
+		var err error
+		calendar, err = s.Tasks.FetchEmployeeCalendar(ctx, tx, employeeID)
+		return err
+	}); err != nil {
+		return nil, fmt.Errorf("could not get employee calendar: %w", err)
+	}
+
+	return calendar, nil
+}
@@ -5,8 +5,10 @@ import (
 	"fmt"
 	"handworks-api/config"
 	"handworks-api/tasks"
+	"handworks-api/types"
 	"handworks-api/utils"
 	"strings"
+	"time"
 
 	firebase "firebase.google.com/go/v4"
 	"firebase.google.com/go/v4/messaging"
@@ -39,26 +41,97 @@ func NewInventoryService(db *pgxpool.Pool, logger *utils.Logger) *InventoryServi
 // --- Booking Service ---
 
 type BookingService struct {
-	DB          *pgxpool.Pool
-	Logger      *utils.Logger
-	Tasks       *tasks.BookingTasks
-	PaymentPort tasks.PaymentPort
+	DB                 *pgxpool.Pool
+	Logger             *utils.Logger
+	Tasks              *tasks.BookingTasks
+	PaymentPort        tasks.PaymentPort
+	CancellationPolicy types.CancellationPolicy
+	TravelEstimator    tasks.TravelEstimator
+	WaitlistExpiry     time.Duration
+	BusinessHours      types.BusinessHours
+	OvertimePolicy     types.OvertimePolicy
+	AccessVault        *tasks.AccessInstructionsVault
+	GeofencePolicy     types.GeofencePolicy
+	LocationPolicy     types.LocationPolicy
+	NoShowPolicy       types.NoShowPolicy
+	DirtyScalePolicy   types.DirtyScalePolicy
+	LargeAreaRate      float32
+
+	waitlistWake chan struct{}
 }
 
-func NewBookingService(db *pgxpool.Pool, logger *utils.Logger, paymentPort tasks.PaymentPort) *BookingService {
-	return &BookingService{DB: db, Logger: logger, Tasks: &tasks.BookingTasks{}, PaymentPort: paymentPort}
+// BookingPolicies are the configurable rules the booking service applies.
+type BookingPolicies struct {
+	Cancellation       types.CancellationPolicy
+	Travel             types.TravelPolicy
+	WaitlistExpiry     time.Duration
+	BusinessHours      types.BusinessHours
+	Overtime           types.OvertimePolicy
+	AccessInstructions types.AccessInstructionsPolicy
+	Geofence           types.GeofencePolicy
+	Location           types.LocationPolicy
+	NoShow             types.NoShowPolicy
+	DirtyScale         types.DirtyScalePolicy
+	LargeAreaRate      float32
+}
+
+func NewBookingService(
+	db *pgxpool.Pool,
+	logger *utils.Logger,
+	paymentPort tasks.PaymentPort,
+	policies BookingPolicies,
+) (*BookingService, error) {
+	accessVault, err := tasks.NewAccessInstructionsVault(policies.AccessInstructions)
+	if err != nil {
+		return nil, err
+	}
+
+	return &BookingService{
+		DB:          db,
+		Logger:      logger,
+		Tasks:       &tasks.BookingTasks{Location: policies.BusinessHours.Location},
+		PaymentPort: paymentPort,
+		TravelEstimator: &tasks.HaversineTravelEstimator{
+			AverageSpeedKPH: policies.Travel.AverageSpeedKPH,
+			MinBuffer:       policies.Travel.MinBuffer,
+		},
+		CancellationPolicy: policies.Cancellation,
+		WaitlistExpiry:     policies.WaitlistExpiry,
+		BusinessHours:      policies.BusinessHours,
+		OvertimePolicy:     policies.Overtime,
+		AccessVault:        accessVault,
+		GeofencePolicy:     policies.Geofence,
+		LocationPolicy:     policies.Location,
+		NoShowPolicy:       policies.NoShow,
+		DirtyScalePolicy:   policies.DirtyScale,
+		LargeAreaRate:      policies.LargeAreaRate,
+		waitlistWake:       make(chan struct{}, 1),
+	}, nil
 }
 
 // --- Payment Service ---
 type PaymentService struct {
-	DB             *pgxpool.Pool
-	Logger         *utils.Logger
-	Tasks          *tasks.PaymentTasks
-	PaymongoClient *config.PaymongoClient
+	DB               *pgxpool.Pool
+	Logger           *utils.Logger
+	Tasks            *tasks.PaymentTasks
+	PaymongoClient   *config.PaymongoClient
+	DirtyScalePolicy types.DirtyScalePolicy
 }
 
-func NewPaymentService(db *pgxpool.Pool, logger *utils.Logger, paymongoClient *config.PaymongoClient) *PaymentService {
-	return &PaymentService{DB: db, Logger: logger, Tasks: &tasks.PaymentTasks{}, PaymongoClient: paymongoClient}
+func NewPaymentService(
+	db *pgxpool.Pool,
+	logger *utils.Logger,
+	paymongoClient *config.PaymongoClient,
+	dirtyScalePolicy types.DirtyScalePolicy,
+	largeAreaRate float32,
+) *PaymentService {
+	return &PaymentService{
+		DB:               db,
+		Logger:           logger,
+		Tasks:            &tasks.PaymentTasks{LargeAreaRate: largeAreaRate},
+		PaymongoClient:   paymongoClient,
+		DirtyScalePolicy: dirtyScalePolicy,
+	}
 }
 
 // Admin Service
@@ -69,8 +142,24 @@ type AdminService struct {
 	AccountPort tasks.AccountPort
 }
 
-func NewAdminService(db *pgxpool.Pool, logger *utils.Logger, accountService tasks.AccountPort) *AdminService {
-	return &AdminService{DB: db, Logger: logger, Tasks: &tasks.AdminTasks{}, AccountPort: accountService}
+func NewAdminService(db *pgxpool.Pool, logger *utils.Logger, accountService tasks.AccountPort, location *time.Location) *AdminService {
+	return &AdminService{DB: db, Logger: logger, Tasks: &tasks.AdminTasks{Location: location}, AccountPort: accountService}
+}
+
+// --- Media Service ---
+type MediaService struct {
+	DB      *pgxpool.Pool
+	Logger  *utils.Logger
+	Tasks   *tasks.MediaTasks
+	Storage tasks.MediaStorage
+	Policy  types.MediaPolicy
+}
+
+// NewMediaService stores media on the local filesystem. Other backends, such as an
+// S3-compatible store, only need to implement tasks.MediaStorage.
+func NewMediaService(db *pgxpool.Pool, logger *utils.Logger, policy types.MediaPolicy) *MediaService {
+	storage := &tasks.LocalMediaStorage{Root: policy.StorageDir}
+	return &MediaService{DB: db, Logger: logger, Tasks: &tasks.MediaTasks{}, Storage: storage, Policy: policy}
 }
 
 // FCM Service
@@ -3,6 +3,7 @@ package services
 import (
 	"context"
 	"fmt"
+	"handworks-api/tasks"
 	"handworks-api/types"
 
 	"github.com/jackc/pgx/v5"
@@ -101,8 +102,62 @@ func (s *InventoryService) DeleteItem(ctx context.Context, id string) (*types.In
 	}
 	return &item, nil
 }
-func (s *InventoryService) AssignEquipmentAndResources(ctx context.Context, req *types.CreateBookingRequest) (*types.CleaningAllocation, error) {
-	// TODO: implement assignment logic
 
-	return nil, nil
+func (s *InventoryService) CreateAllocationRule(ctx context.Context, req types.CreateAllocationRuleRequest) (*types.AllocationRule, error) {
+	if err := s.Tasks.ValidateAllocationRule(req.ServiceType, req.BaseQuantity, req.PerSQM, req.PerUnit, req.DirtyScaleFactor); err != nil {
+		return nil, err
+	}
+
+	var rule *types.AllocationRule
+	if err := s.withTx(ctx, func(tx pgx.Tx) error {
+		var err error
+		rule, err = s.Tasks.CreateAllocationRule(ctx, tx, &req)
+		return err
+	}); err != nil {
+		s.Logger.Error("failed to create allocation rule: %v", err)
+		return nil, err
+	}
+	return rule, nil
+}
+
+func (s *InventoryService) ListAllocationRules(ctx context.Context, serviceType string) (*types.AllocationRulesResponse, error) {
+	var rules []types.AllocationRule
+	if err := s.withTx(ctx, func(tx pgx.Tx) error {
+		var err error
+		rules, err = s.Tasks.FetchAllocationRules(ctx, tx, serviceType)
+		return err
+	}); err != nil {
+		s.Logger.Error("failed to list allocation rules: %v", err)
+		return nil, err
+	}
+	return &types.AllocationRulesResponse{Rules: rules}, nil
+}
+
+func (s *InventoryService) UpdateAllocationRule(ctx context.Context, id string, req types.UpdateAllocationRuleRequest) (*types.AllocationRule, error) {
+	for _, q := range []*float64{req.BaseQuantity, req.PerSQM, req.PerUnit, req.DirtyScaleFactor} {
+		if q != nil && *q < 0 {
+			return nil, fmt.Errorf("%w: quantities and factors cannot be negative", tasks.ErrInvalidAllocationRule)
+		}
+	}
+
+	var rule *types.AllocationRule
+	if err := s.withTx(ctx, func(tx pgx.Tx) error {
+		var err error
+		rule, err = s.Tasks.UpdateAllocationRule(ctx, tx, id, &req)
+		return err
+	}); err != nil {
+		s.Logger.Error("failed to update allocation rule %s: %v", id, err)
+		return nil, err
+	}
+	return rule, nil
+}
+
+func (s *InventoryService) DeleteAllocationRule(ctx context.Context, id string) error {
+	if err := s.withTx(ctx, func(tx pgx.Tx) error {
+		return s.Tasks.DeleteAllocationRule(ctx, tx, id)
+	}); err != nil {
+		s.Logger.Error("failed to delete allocation rule %s: %v", id, err)
+		return err
+	}
+	return nil
 }
@@ -0,0 +1,145 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"handworks-api/tasks"
+	"handworks-api/types"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// RecordLocationPing stores a cleaner's position on a booking and relays it, with an ETA,
+// to admins and the booking's customer. Pings arriving faster than the policy allows are
+// dropped without error.
+func (s *BookingService) RecordLocationPing(ctx context.Context, employeeID string, msg types.LocationPingMessage) error {
+	if err := s.withTx(ctx, func(tx pgx.Tx) error {
+		snap, err := s.Tasks.FetchBookingSnapshot(ctx, tx, msg.BookingID)
+		if err != nil {
+			return err
+		}
+		now := time.Now()
+		if err := tasks.CanTrackLocation(s.LocationPolicy, snap, employeeID, now); err != nil {
+			return err
+		}
+
+		loc := &types.CleanerLocation{
+			EmployeeID:     employeeID,
+			BookingID:      msg.BookingID,
+			CustomerID:     snap.CustID,
+			Latitude:       *msg.Latitude,
+			Longitude:      *msg.Longitude,
+			AccuracyMeters: msg.AccuracyMeters,
+			RecordedAt:     now,
+		}
+		tasks.EstimateArrival(s.LocationPolicy, s.GeofencePolicy, snap.Address, loc)
+
+		saved, err := s.Tasks.SaveEmployeeLocation(ctx, tx, loc, s.LocationPolicy.MinPingInterval)
+		if err != nil || !saved {
+			return err
+		}
+		return s.Tasks.PublishBookingEvent(ctx, tx, "cleaner_location", map[string]any{
+			"event":    "cleaner_location",
+			"location": loc,
+		})
+	}); err != nil {
+		if !errors.Is(err, tasks.ErrLocationNotTracked) && !errors.Is(err, tasks.ErrCleanerNotAssigned) {
+			s.Logger.Error("failed to record location of employee %s on booking %s: %v", employeeID, msg.BookingID, err)
+		}
+		return err
+	}
+	return nil
+}
+
+// ResolveEmployeeID returns the employee ID of the signed-in user, or "" when they are
+// not an employee. The employee websocket uses it to identify who is connecting.
+func (s *BookingService) ResolveEmployeeID(ctx context.Context, actor string) (string, error) {
+	var employeeID string
+	if err := s.withTx(ctx, func(tx pgx.Tx) error {
+		viewer, err := s.Tasks.ResolveAccessViewer(ctx, tx, actor)
+		if err != nil {
+			return err
+		}
+		if viewer.Role == tasks.ViewerRoleEmployee {
+			employeeID = viewer.EmployeeID
+		}
+		return nil
+	}); err != nil {
+		s.Logger.Error("failed to resolve employee for %s: %v", actor, err)
+		return "", err
+	}
+	return employeeID, nil
+}
+
+// GetBookingLocations returns the latest positions of the booking's cleaners to an admin
+// or the booking's customer.
+func (s *BookingService) GetBookingLocations(ctx context.Context, bookingID, actor string) ([]types.CleanerLocation, error) {
+	var locations []types.CleanerLocation
+	if err := s.withTx(ctx, func(tx pgx.Tx) error {
+		snap, err := s.Tasks.FetchBookingSnapshot(ctx, tx, bookingID)
+		if err != nil {
+			return err
+		}
+		viewer, err := s.Tasks.ResolveAccessViewer(ctx, tx, actor)
+		if err != nil {
+			return err
+		}
+		if viewer.Role != tasks.ViewerRoleAdmin && (viewer.Role != tasks.ViewerRoleCustomer || viewer.CustomerID != snap.CustID) {
+			return tasks.ErrLocationDenied
+		}
+		// Cleaners stop being tracked for the customer once the job is over.
+		switch snap.Status {
+		case tasks.BookingStatusCompleted, tasks.BookingStatusCancelled, tasks.BookingStatusNoShow:
+			locations = []types.CleanerLocation{}
+			return nil
+		}
+		locations, err = s.Tasks.FetchBookingLocations(ctx, tx, bookingID, time.Now().Add(-s.LocationPolicy.Retention))
+		return err
+	}); err != nil {
+		if !errors.Is(err, tasks.ErrLocationDenied) && !errors.Is(err, tasks.ErrBookingNotFound) {
+			s.Logger.Error("failed to fetch cleaner locations for booking %s: %v", bookingID, err)
+		}
+		return nil, err
+	}
+	return locations, nil
+}
+
+func (s *BookingService) PurgeLocations(ctx context.Context) error {
+	var purged int64
+	if err := s.withTx(ctx, func(tx pgx.Tx) error {
+		var err error
+		purged, err = s.Tasks.PurgeEmployeeLocations(ctx, tx, time.Now().Add(-s.LocationPolicy.Retention))
+		return err
+	}); err != nil {
+		s.Logger.Error("failed to purge employee locations: %v", err)
+		return err
+	}
+
+	if purged > 0 {
+		s.Logger.Info("Purged %d employee locations", purged)
+	}
+	return nil
+}
+
+// RunLocationRetention deletes positions past the retention limit every interval until
+// ctx is done.
+func (s *BookingService) RunLocationRetention(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		s.runExclusive(ctx, "location-retention", func() {
+			if err := s.PurgeLocations(ctx); err != nil {
+				s.Logger.Error("location retention run failed: %v", err)
+			}
+		})
+
+		select {
+		case <-ctx.Done():
+			s.Logger.Info("Stopping location retention")
+			return
+		case <-ticker.C:
+		}
+	}
+}
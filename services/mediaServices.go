@@ -0,0 +1,226 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"handworks-api/tasks"
+	"handworks-api/types"
+	"handworks-api/utils"
+	"io"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+)
+
+func (s *MediaService) withTx(
+	ctx context.Context,
+	fn func(pgx.Tx) error,
+) (err error) {
+	tx, err := s.DB.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin tx: %w", err)
+	}
+	defer func() {
+		if err != nil {
+			if rbErr := tx.Rollback(ctx); rbErr != nil {
+				s.Logger.Error("rollback failed: %v", rbErr)
+			}
+		} else {
+			err = tx.Commit(ctx)
+		}
+	}()
+	return fn(tx)
+}
+
+// Upload checks and cleans an uploaded image, stores it with a thumbnail and returns it
+// with signed URLs. A profile picture belongs to the employee or customer uploading it.
+func (s *MediaService) Upload(
+	ctx context.Context,
+	file io.Reader,
+	purpose types.MediaPurpose,
+	actor string,
+) (*types.Media, error) {
+	if err := tasks.ValidateMediaPurpose(purpose); err != nil {
+		return nil, err
+	}
+	ownerID := ""
+	if purpose == types.MediaPurposeProfile {
+		viewer, err := s.resolveViewer(ctx, actor)
+		if err != nil {
+			return nil, err
+		}
+		switch viewer.Role {
+		case tasks.ViewerRoleEmployee:
+			ownerID = viewer.EmployeeID
+		case tasks.ViewerRoleCustomer:
+			ownerID = viewer.CustomerID
+		default:
+			return nil, fmt.Errorf("%w: only employees and customers have profile pictures", tasks.ErrInvalidMediaPurpose)
+		}
+	}
+
+	data, err := io.ReadAll(io.LimitReader(file, s.Policy.MaxUploadBytes+1))
+	if err != nil {
+		return nil, fmt.Errorf("could not read upload: %w", err)
+	}
+	if int64(len(data)) > s.Policy.MaxUploadBytes {
+		return nil, fmt.Errorf("%w of %d MB", tasks.ErrMediaTooLarge, s.Policy.MaxUploadBytes>>20)
+	}
+
+	img, err := utils.ProcessImage(data, s.Policy.ThumbnailSize)
+	if err != nil {
+		if errors.Is(err, utils.ErrUnsupportedImage) || errors.Is(err, utils.ErrInvalidImage) {
+			return nil, fmt.Errorf("%w: %v", tasks.ErrUnsupportedMediaType, err)
+		}
+		s.Logger.Error("Failed to process upload: %v", err)
+		return nil, err
+	}
+
+	var (
+		media  *types.Media
+		stored []string
+	)
+	err = s.withTx(ctx, func(tx pgx.Tx) error {
+		var err error
+		media, err = s.Tasks.InsertMedia(ctx, tx, &types.Media{
+			Purpose:     purpose,
+			OwnerID:     ownerID,
+			ContentType: img.ContentType,
+			SizeBytes:   int64(len(img.Data)),
+			Width:       img.Width,
+			Height:      img.Height,
+			UploadedBy:  actor,
+		})
+		if err != nil {
+			return err
+		}
+
+		for variant, data := range map[types.MediaVariant][]byte{
+			types.MediaVariantOriginal:  img.Data,
+			types.MediaVariantThumbnail: img.Thumbnail,
+		} {
+			key := tasks.MediaObjectKey(media, variant)
+			if err := s.Storage.Put(ctx, key, img.ContentType, data); err != nil {
+				return err
+			}
+			stored = append(stored, key)
+		}
+		return nil
+	})
+	if err != nil {
+		// The row was rolled back, so files already written are unreachable.
+		for _, key := range stored {
+			if delErr := s.Storage.Delete(context.Background(), key); delErr != nil {
+				s.Logger.Error("Failed to remove orphaned media %s: %v", key, delErr)
+			}
+		}
+		s.Logger.Error("Failed to store upload: %v", err)
+		return nil, err
+	}
+
+	tasks.SignMediaURLs(media, s.Policy, time.Now())
+	return media, nil
+}
+
+// GetMedia returns the media with fresh signed URLs if actor may see it.
+func (s *MediaService) GetMedia(ctx context.Context, mediaID, actor string) (*types.Media, error) {
+	var media *types.Media
+	if err := s.withTx(ctx, func(tx pgx.Tx) error {
+		var err error
+		media, err = s.Tasks.FetchMedia(ctx, tx, mediaID)
+		if err != nil {
+			return err
+		}
+		return s.checkAccess(ctx, tx, actor, []string{mediaID})
+	}); err != nil {
+		if !errors.Is(err, tasks.ErrMediaNotFound) && !errors.Is(err, tasks.ErrMediaDenied) {
+			s.Logger.Error("Failed to fetch media %s: %v", mediaID, err)
+		}
+		return nil, err
+	}
+
+	tasks.SignMediaURLs(media, s.Policy, time.Now())
+	return media, nil
+}
+
+// ResolveMedia returns fresh signed URLs for the media IDs stored on bookings and sessions.
+// It fails unless actor may see every one of them.
+func (s *MediaService) ResolveMedia(ctx context.Context, mediaIDs []string, actor string) (*types.ResolveMediaResponse, error) {
+	var list []types.Media
+	if err := s.withTx(ctx, func(tx pgx.Tx) error {
+		var err error
+		list, err = s.Tasks.FetchMediaList(ctx, tx, mediaIDs)
+		if err != nil {
+			return err
+		}
+		return s.checkAccess(ctx, tx, actor, mediaIDs)
+	}); err != nil {
+		if !errors.Is(err, tasks.ErrMediaNotFound) && !errors.Is(err, tasks.ErrMediaDenied) {
+			s.Logger.Error("Failed to resolve media: %v", err)
+		}
+		return nil, err
+	}
+
+	now := time.Now()
+	for i := range list {
+		tasks.SignMediaURLs(&list[i], s.Policy, now)
+	}
+	return &types.ResolveMediaResponse{Media: list}, nil
+}
+
+// OpenMedia checks a signed URL and opens the file it points to. The caller closes it.
+func (s *MediaService) OpenMedia(
+	ctx context.Context,
+	mediaID string,
+	variant types.MediaVariant,
+	expires, signature string,
+) (io.ReadCloser, *types.Media, error) {
+	if err := tasks.VerifyMediaSignature(mediaID, variant, expires, signature, s.Policy, time.Now()); err != nil {
+		return nil, nil, err
+	}
+
+	// The signature already grants access, so no viewer is checked here.
+	var media *types.Media
+	if err := s.withTx(ctx, func(tx pgx.Tx) error {
+		var err error
+		media, err = s.Tasks.FetchMedia(ctx, tx, mediaID)
+		return err
+	}); err != nil {
+		if !errors.Is(err, tasks.ErrMediaNotFound) {
+			s.Logger.Error("Failed to fetch media %s: %v", mediaID, err)
+		}
+		return nil, nil, err
+	}
+
+	file, err := s.Storage.Open(ctx, tasks.MediaObjectKey(media, variant))
+	if err != nil {
+		if errors.Is(err, tasks.ErrMediaObjectNotFound) {
+			s.Logger.Error("Media %s has no stored %s", mediaID, variant)
+			return nil, nil, tasks.ErrMediaNotFound
+		}
+		s.Logger.Error("Failed to open media %s: %v", mediaID, err)
+		return nil, nil, err
+	}
+	return file, media, nil
+}
+
+func (s *MediaService) resolveViewer(ctx context.Context, actor string) (*tasks.AccessViewer, error) {
+	var viewer *tasks.AccessViewer
+	if err := s.withTx(ctx, func(tx pgx.Tx) error {
+		var err error
+		viewer, err = (&tasks.BookingTasks{}).ResolveAccessViewer(ctx, tx, actor)
+		return err
+	}); err != nil {
+		return nil, err
+	}
+	return viewer, nil
+}
+
+func (s *MediaService) checkAccess(ctx context.Context, tx pgx.Tx, actor string, mediaIDs []string) error {
+	viewer, err := (&tasks.BookingTasks{}).ResolveAccessViewer(ctx, tx, actor)
+	if err != nil {
+		return err
+	}
+	return s.Tasks.CheckMediaAccess(ctx, tx, viewer, mediaIDs)
+}
@@ -0,0 +1,200 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"handworks-api/tasks"
+	"handworks-api/types"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// MarkLateBookings moves approved bookings whose session has not started LateAfter past
+// startSched to LATE and tells admins, the customer and the cleaners.
+func (s *BookingService) MarkLateBookings(ctx context.Context) error {
+	ids, err := s.overdueBookings(ctx, tasks.BookingStatusNotStarted, s.NoShowPolicy.LateAfter)
+	if err != nil {
+		return err
+	}
+
+	late := 0
+	for _, bookingID := range ids {
+		if err := s.withTx(ctx, func(tx pgx.Tx) error {
+			snap, err := s.Tasks.FetchBookingSnapshot(ctx, tx, bookingID)
+			if err != nil {
+				return err
+			}
+			minutesLate := int(time.Since(snap.StartSched).Minutes())
+			if _, err := s.Tasks.TransitionBooking(ctx, tx, bookingID, tasks.BookingEventMarkLate, tasks.SystemActor,
+				fmt.Sprintf("session not started %d minutes after start", minutesLate)); err != nil {
+				return err
+			}
+			return s.Tasks.PublishBookingEvent(ctx, tx, "booking_late", map[string]any{
+				"event":       "booking_late",
+				"bookingId":   bookingID,
+				"customerId":  snap.CustID,
+				"cleanerIds":  snap.CleanerIDs,
+				"startSched":  snap.StartSched,
+				"minutesLate": minutesLate,
+			})
+		}); err != nil {
+			// Started or cancelled since it was listed.
+			if errors.Is(err, tasks.ErrInvalidTransition) {
+				continue
+			}
+			s.Logger.Error("failed to mark booking %s late: %v", bookingID, err)
+			continue
+		}
+		late++
+	}
+
+	if late > 0 {
+		s.Logger.Info("Marked %d bookings late", late)
+	}
+	return nil
+}
+
+// ClassifyCleanerNoShows treats LATE bookings still not started NoShowAfter past
+// startSched as cleaner no-shows. The crew is dropped and the booking goes back to
+// PENDING so admins can reschedule it, which assigns new cleaners.
+func (s *BookingService) ClassifyCleanerNoShows(ctx context.Context) error {
+	ids, err := s.overdueBookings(ctx, tasks.BookingStatusLate, s.NoShowPolicy.NoShowAfter)
+	if err != nil {
+		return err
+	}
+
+	noShows := 0
+	for _, bookingID := range ids {
+		if err := s.withTx(ctx, func(tx pgx.Tx) error {
+			snap, err := s.Tasks.FetchBookingSnapshot(ctx, tx, bookingID)
+			if err != nil {
+				return err
+			}
+			if _, err := s.Tasks.TransitionBooking(ctx, tx, bookingID, tasks.BookingEventCleanerNoShow, tasks.SystemActor,
+				"no cleaner started the session"); err != nil {
+				return err
+			}
+			if err := s.Tasks.RecordNoShow(ctx, tx, bookingID, types.NoShowCleaner, snap.CleanerIDs, tasks.SystemActor, nil, ""); err != nil {
+				return err
+			}
+			if err := s.Tasks.ReplaceBookingCleaners(ctx, tx, bookingID, nil); err != nil {
+				return err
+			}
+			return s.Tasks.PublishBookingEvent(ctx, tx, "booking_no_show", map[string]any{
+				"event":      "booking_no_show",
+				"kind":       types.NoShowCleaner,
+				"bookingId":  bookingID,
+				"customerId": snap.CustID,
+				"cleanerIds": snap.CleanerIDs,
+			})
+		}); err != nil {
+			if errors.Is(err, tasks.ErrInvalidTransition) {
+				continue
+			}
+			s.Logger.Error("failed to record cleaner no-show for booking %s: %v", bookingID, err)
+			continue
+		}
+		noShows++
+	}
+
+	if noShows > 0 {
+		s.Logger.Info("Recorded %d cleaner no-shows", noShows)
+	}
+	return nil
+}
+
+func (s *BookingService) overdueBookings(ctx context.Context, status string, after time.Duration) ([]string, error) {
+	var ids []string
+	if err := s.withTx(ctx, func(tx pgx.Tx) error {
+		var err error
+		now := time.Now()
+		ids, err = s.Tasks.FetchOverdueBookingIDs(ctx, tx, status, now.Add(-s.NoShowPolicy.Lookback), now.Add(-after))
+		return err
+	}); err != nil {
+		s.Logger.Error("failed to list overdue %s bookings: %v", strings.ToLower(status), err)
+		return nil, err
+	}
+	return ids, nil
+}
+
+// RunNoShowMonitor marks late bookings and classifies cleaner no-shows every interval
+// until ctx is done.
+func (s *BookingService) RunNoShowMonitor(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		s.runExclusive(ctx, "no-show-monitor", func() {
+			if err := s.MarkLateBookings(ctx); err != nil {
+				s.Logger.Error("late booking run failed: %v", err)
+			}
+			if err := s.ClassifyCleanerNoShows(ctx); err != nil {
+				s.Logger.Error("cleaner no-show run failed: %v", err)
+			}
+		})
+
+		select {
+		case <-ctx.Done():
+			s.Logger.Info("Stopping no-show monitor")
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// ReportCustomerNoShow closes a booking the customer was not there for. It is settled
+// like a cancellation at the start time, so the cancellation policy decides the fee, and
+// the remaining days of a multi-day job are cancelled with it.
+func (s *BookingService) ReportCustomerNoShow(ctx context.Context, req types.ReportCustomerNoShowRequest, actor string) (*types.CancelBookingResponse, error) {
+	var res *types.CancelBookingResponse
+
+	if err := s.withTx(ctx, func(tx pgx.Tx) error {
+		snap, err := s.Tasks.FetchBookingSnapshot(ctx, tx, req.BookingID)
+		if err != nil {
+			return err
+		}
+		viewer, err := s.Tasks.ResolveAccessViewer(ctx, tx, actor)
+		if err != nil {
+			return err
+		}
+		if err := tasks.CanReportCustomerNoShow(viewer, snap, s.NoShowPolicy, time.Now()); err != nil {
+			return err
+		}
+		if viewer.Role != tasks.ViewerRoleAdmin {
+			if req.Location == nil {
+				return tasks.ErrNoShowLocation
+			}
+			if _, err := tasks.CheckGeofence(s.GeofencePolicy, snap.Address, req.Location); err != nil {
+				return err
+			}
+		}
+
+		reason := "customer no-show"
+		if notes := strings.TrimSpace(req.Notes); notes != "" {
+			reason += ": " + notes
+		}
+		res, err = s.cancelBooking(ctx, tx, snap, tasks.BookingEventCustomerNoShow, reason, actor, 0)
+		if err != nil {
+			return err
+		}
+		if err := s.Tasks.RecordNoShow(ctx, tx, req.BookingID, types.NoShowCustomer, snap.CleanerIDs, actor, req.Location, req.Notes); err != nil {
+			return err
+		}
+
+		return s.Tasks.PublishBookingEvent(ctx, tx, "booking_no_show", map[string]any{
+			"event":      "booking_no_show",
+			"kind":       types.NoShowCustomer,
+			"bookingId":  req.BookingID,
+			"customerId": snap.CustID,
+			"cleanerIds": snap.CleanerIDs,
+		})
+	}); err != nil {
+		s.Logger.Error("failed to report customer no-show for booking %s: %v", req.BookingID, err)
+		return nil, err
+	}
+
+	return res, nil
+}
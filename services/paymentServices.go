@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"handworks-api/tasks"
 	"handworks-api/types"
 	"math"
 	"strings"
@@ -79,21 +80,36 @@ func (s *PaymentService) GetQuotePrices(ctx context.Context, quoteId string) (*t
 
 func (s *PaymentService) MakePublicQuotation(ctx context.Context, req types.QuoteRequest) (*types.QuoteResponse, error) {
 	s.Logger.Info("Generating Quote Preview")
-	quotePrev, err := s.Tasks.CalculateQuotePreview(ctx, &req)
+	var zone *types.ServiceZone
+	if err := s.withTx(ctx, func(tx pgx.Tx) error {
+		var err error
+		zone, err = quoteServiceZone(ctx, tx, req)
+		return err
+	}); err != nil {
+		return nil, err
+	}
+	quotePrev, err := s.Tasks.CalculateQuotePreview(ctx, &req, s.DirtyScalePolicy, zone)
 	if err != nil {
 		s.Logger.Error("Failed to genearte Quote Preview: %v", err)
-		return nil, fmt.Errorf("failed to genearte Quote Preview: %v", err)
+		return nil, fmt.Errorf("failed to genearte Quote Preview: %w", err)
 	}
 	addonsBreakdown := s.Tasks.MapAddonstoAddonBreakdown(&quotePrev.Addons)
 	return &types.QuoteResponse{
-		QuoteId:           quotePrev.ID,
-		MainServiceName:   quotePrev.MainService,
-		MainServiceTotal:  quotePrev.Subtotal,
-		MainServiceHours:  quotePrev.MainServiceHours,
-		TotalServiceHours: quotePrev.TotalServiceHours,
-		TotalPrice:        quotePrev.TotalPrice,
-		AddonTotal:        quotePrev.AddonTotal,
-		Addons:            addonsBreakdown,
+		QuoteId:             quotePrev.ID,
+		MainServiceName:     quotePrev.MainService,
+		MainServiceTotal:    quotePrev.Subtotal,
+		MainServiceHours:    quotePrev.MainServiceHours,
+		TotalServiceHours:   quotePrev.TotalServiceHours,
+		TotalPrice:          quotePrev.TotalPrice,
+		AddonTotal:          quotePrev.AddonTotal,
+		DirtyScale:          quotePrev.DirtyScale,
+		DirtyScaleSurcharge: quotePrev.DirtyScaleSurcharge,
+		DirtyScaleHours:     quotePrev.DirtyScaleHours,
+		ServiceZoneID:       quotePrev.ServiceZoneID,
+		TravelFee:           quotePrev.TravelFee,
+		ServiceDays:         quotePrev.ServiceDays,
+		DayPlan:             quotePrev.DayPlan,
+		Addons:              addonsBreakdown,
 	}, nil
 
 }
@@ -101,15 +117,27 @@ func (s *PaymentService) MakePublicQuotation(ctx context.Context, req types.Quot
 func (s *PaymentService) MakeQuotation(ctx context.Context, req types.QuoteRequest) (*types.QuoteResponse, error) {
 	var quoteResponse types.QuoteResponse
 	if err := s.withTx(ctx, func(tx pgx.Tx) error {
-		quote, err := s.Tasks.CreateQuote(ctx, tx, &req)
+		zone, err := quoteServiceZone(ctx, tx, req)
 		if err != nil {
-			return fmt.Errorf("failed to create Quote: %v", err)
+			return err
+		}
+		quote, err := s.Tasks.CreateQuote(ctx, tx, &req, s.DirtyScalePolicy, zone)
+		if err != nil {
+			return fmt.Errorf("failed to create Quote: %w", err)
 		}
 		quoteResponse.QuoteId = quote.ID
 		quoteResponse.MainServiceName = quote.MainService
-		quoteResponse.MainServiceTotal = quote.TotalPrice
+		quoteResponse.MainServiceTotal = quote.Subtotal
 		quoteResponse.AddonTotal = quote.AddonTotal
 		quoteResponse.TotalPrice = quote.TotalPrice
+		quoteResponse.DirtyScale = quote.DirtyScale
+		quoteResponse.DirtyScaleSurcharge = quote.DirtyScaleSurcharge
+		quoteResponse.DirtyScaleHours = quote.DirtyScaleHours
+		quoteResponse.ServiceZoneID = quote.ServiceZoneID
+		quoteResponse.TravelFee = quote.TravelFee
+		quoteResponse.TotalServiceHours = quote.TotalServiceHours
+		quoteResponse.ServiceDays = quote.ServiceDays
+		quoteResponse.DayPlan = quote.DayPlan
 		quoteResponse.Addons = s.Tasks.MapAddonstoAddonBreakdown(&quote.Addons)
 		return nil
 	}); err != nil {
@@ -119,6 +147,22 @@ func (s *PaymentService) MakeQuotation(ctx context.Context, req types.QuoteReque
 	return &quoteResponse, nil
 }
 
+// quoteServiceZone resolves the zone a quote is priced for. The address is only required
+// once service zones are configured.
+func quoteServiceZone(ctx context.Context, tx pgx.Tx, req types.QuoteRequest) (*types.ServiceZone, error) {
+	if req.Address == nil {
+		configured, err := tasks.ServiceZonesConfigured(ctx, tx)
+		if err != nil {
+			return nil, err
+		}
+		if configured {
+			return nil, fmt.Errorf("%w: address is required", tasks.ErrOutsideServiceArea)
+		}
+		return nil, nil
+	}
+	return tasks.ResolveServiceZone(ctx, tx, *req.Address)
+}
+
 func (s *PaymentService) GetAllQuotesFromCustomer(
 	ctx context.Context,
 	customerId, startDate, endDate string,
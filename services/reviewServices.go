@@ -0,0 +1,89 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"handworks-api/tasks"
+	"handworks-api/types"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// SubmitReview stores a customer's review of their completed booking and updates the
+// performance score of every cleaner who worked it.
+func (s *BookingService) SubmitReview(ctx context.Context, req types.SubmitReviewRequest, actor string) (*types.BookingReview, error) {
+	var review *types.BookingReview
+	if err := s.withTx(ctx, func(tx pgx.Tx) error {
+		snap, err := s.Tasks.FetchBookingSnapshot(ctx, tx, req.BookingID)
+		if err != nil {
+			return err
+		}
+		viewer, err := s.Tasks.ResolveAccessViewer(ctx, tx, actor)
+		if err != nil {
+			return err
+		}
+
+		review, err = s.Tasks.InsertReview(ctx, tx, viewer, snap, &req)
+		if err != nil {
+			return err
+		}
+
+		return s.Tasks.PublishBookingEvent(ctx, tx, "review_submitted", map[string]any{
+			"event":     "review_submitted",
+			"reviewId":  review.ID,
+			"bookingId": review.BookingID,
+		})
+	}); err != nil {
+		if !errors.Is(err, tasks.ErrBookingAlreadyReviewed) && !errors.Is(err, tasks.ErrBookingNotReviewable) &&
+			!errors.Is(err, tasks.ErrReviewNotAllowed) {
+			s.Logger.Error("failed to submit review for booking %s: %v", req.BookingID, err)
+		}
+		return nil, err
+	}
+
+	return review, nil
+}
+
+func (s *BookingService) GetBookingReview(ctx context.Context, bookingID string) (*types.BookingReview, error) {
+	var review *types.BookingReview
+	if err := s.withTx(ctx, func(tx pgx.Tx) error {
+		var err error
+		review, err = s.Tasks.FetchBookingReview(ctx, tx, bookingID)
+		return err
+	}); err != nil {
+		if !errors.Is(err, tasks.ErrReviewNotFound) {
+			s.Logger.Error("failed to fetch review for booking %s: %v", bookingID, err)
+		}
+		return nil, err
+	}
+
+	return review, nil
+}
+
+func (s *AdminService) GetReviews(ctx context.Context, status string, page, limit int) (*types.FetchReviewsResponse, error) {
+	var res *types.FetchReviewsResponse
+	if err := s.withTx(ctx, func(tx pgx.Tx) error {
+		var err error
+		res, err = s.Tasks.FetchReviews(ctx, tx, status, page, limit)
+		return err
+	}); err != nil {
+		s.Logger.Error("Failed to fetch reviews: %v", err)
+		return nil, err
+	}
+
+	return res, nil
+}
+
+func (s *AdminService) ModerateReview(ctx context.Context, reviewID string, req *types.ModerateReviewRequest, actor string) (*types.BookingReview, error) {
+	var review *types.BookingReview
+	if err := s.withTx(ctx, func(tx pgx.Tx) error {
+		var err error
+		review, err = s.Tasks.ModerateReview(ctx, tx, reviewID, req.Status, req.Note, actor)
+		return err
+	}); err != nil {
+		s.Logger.Error("Failed to moderate review %s: %v", reviewID, err)
+		return nil, err
+	}
+
+	return review, nil
+}
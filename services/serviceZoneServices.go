@@ -0,0 +1,61 @@
+package services
+
+import (
+	"context"
+	"handworks-api/types"
+
+	"github.com/jackc/pgx/v5"
+)
+
+func (s *AdminService) GetServiceZones(ctx context.Context) ([]types.ServiceZone, error) {
+	var zones []types.ServiceZone
+	if err := s.withTx(ctx, func(tx pgx.Tx) error {
+		var err error
+		zones, err = s.Tasks.FetchServiceZones(ctx, tx)
+		return err
+	}); err != nil {
+		s.Logger.Error("Failed to fetch service zones: %v", err)
+		return nil, err
+	}
+
+	return zones, nil
+}
+
+func (s *AdminService) CreateServiceZone(ctx context.Context, req *types.ServiceZoneRequest) (*types.ServiceZone, error) {
+	var zone *types.ServiceZone
+	if err := s.withTx(ctx, func(tx pgx.Tx) error {
+		var err error
+		zone, err = s.Tasks.CreateServiceZone(ctx, tx, req)
+		return err
+	}); err != nil {
+		s.Logger.Error("Failed to create service zone: %v", err)
+		return nil, err
+	}
+
+	return zone, nil
+}
+
+func (s *AdminService) UpdateServiceZone(ctx context.Context, zoneID string, req *types.ServiceZoneRequest) (*types.ServiceZone, error) {
+	var zone *types.ServiceZone
+	if err := s.withTx(ctx, func(tx pgx.Tx) error {
+		var err error
+		zone, err = s.Tasks.UpdateServiceZone(ctx, tx, zoneID, req)
+		return err
+	}); err != nil {
+		s.Logger.Error("Failed to update service zone %s: %v", zoneID, err)
+		return nil, err
+	}
+
+	return zone, nil
+}
+
+func (s *AdminService) DeleteServiceZone(ctx context.Context, zoneID string) error {
+	if err := s.withTx(ctx, func(tx pgx.Tx) error {
+		return s.Tasks.DeactivateServiceZone(ctx, tx, zoneID)
+	}); err != nil {
+		s.Logger.Error("Failed to delete service zone %s: %v", zoneID, err)
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,108 @@
+package services
+
+import (
+	"context"
+	"handworks-api/tasks"
+	"handworks-api/types"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+)
+
+func (s *BookingService) PauseSession(ctx context.Context, bookingID, reason, actor string) (*types.SessionSummary, error) {
+	return s.toggleSessionPause(ctx, bookingID, types.SessionEventPause, reason, actor)
+}
+
+func (s *BookingService) ResumeSession(ctx context.Context, bookingID, actor string) (*types.SessionSummary, error) {
+	return s.toggleSessionPause(ctx, bookingID, types.SessionEventResume, "", actor)
+}
+
+func (s *BookingService) toggleSessionPause(
+	ctx context.Context,
+	bookingID string,
+	event types.SessionEventType,
+	reason, actor string,
+) (*types.SessionSummary, error) {
+	var summary *types.SessionSummary
+	if err := s.withTx(ctx, func(tx pgx.Tx) error {
+		snap, err := s.Tasks.FetchBookingSnapshot(ctx, tx, bookingID)
+		if err != nil {
+			return err
+		}
+		if snap.Status != tasks.BookingStatusOngoing {
+			return tasks.ErrSessionNotStarted
+		}
+
+		events, err := s.Tasks.FetchSessionEvents(ctx, tx, bookingID)
+		if err != nil {
+			return err
+		}
+		paused := tasks.SessionIsPaused(events)
+		if event == types.SessionEventPause && paused {
+			return tasks.ErrSessionPaused
+		}
+		if event == types.SessionEventResume && !paused {
+			return tasks.ErrSessionNotPaused
+		}
+
+		if err := s.Tasks.RecordSessionEvent(ctx, tx, bookingID, event, actor, reason); err != nil {
+			return err
+		}
+
+		summary, err = s.sessionSummary(ctx, tx, snap)
+		return err
+	}); err != nil {
+		s.Logger.Error("failed to %s session for booking %s: %v", strings.ToLower(string(event)), bookingID, err)
+		return nil, err
+	}
+
+	return summary, nil
+}
+
+func (s *BookingService) GetSessionSummary(ctx context.Context, bookingID string) (*types.SessionSummary, error) {
+	var summary *types.SessionSummary
+	if err := s.withTx(ctx, func(tx pgx.Tx) error {
+		snap, err := s.Tasks.FetchBookingSnapshot(ctx, tx, bookingID)
+		if err != nil {
+			return err
+		}
+		summary, err = s.sessionSummary(ctx, tx, snap)
+		return err
+	}); err != nil {
+		s.Logger.Error("failed to fetch session summary for booking %s: %v", bookingID, err)
+		return nil, err
+	}
+
+	return summary, nil
+}
+
+// sessionSummary measures the session from its events. While the session is running the
+// overtime fields are a projection of what ending it now would bill.
+func (s *BookingService) sessionSummary(ctx context.Context, tx pgx.Tx, snap *tasks.BookingSnapshot) (*types.SessionSummary, error) {
+	events, err := s.Tasks.FetchSessionEvents(ctx, tx, snap.BookingID)
+	if err != nil {
+		return nil, err
+	}
+	quoted, err := s.Tasks.QuotedSessionHours(ctx, tx, snap)
+	if err != nil {
+		return nil, err
+	}
+
+	worked, paused := tasks.WorkedDuration(events, time.Now())
+	overtime, billed, charge := tasks.CalculateOvertime(worked, quoted, len(snap.CleanerIDs), s.OvertimePolicy)
+
+	return &types.SessionSummary{
+		BookingID:           snap.BookingID,
+		Status:              snap.Status,
+		Paused:              tasks.SessionIsPaused(events),
+		WorkedMinutes:       worked.Minutes(),
+		PausedMinutes:       paused.Minutes(),
+		QuotedHours:         quoted,
+		OvertimeHours:       overtime,
+		BilledOvertimeHours: billed,
+		OvertimeCharge:      charge,
+		Cleaners:            len(snap.CleanerIDs),
+		Events:              events,
+	}, nil
+}
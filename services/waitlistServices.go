@@ -0,0 +1,234 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"handworks-api/tasks"
+	"handworks-api/types"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// JoinWaitlist queues a booking request for a slot that currently has no free cleaners.
+// The customer's order is kept and booked as soon as a promotion finds enough cleaners.
+func (s *BookingService) JoinWaitlist(ctx context.Context, req types.CreateBookingRequest) (*types.WaitlistEntry, error) {
+	if err := validateExtraHours(req); err != nil {
+		return nil, err
+	}
+	if !req.Base.EndSched.After(req.Base.StartSched) {
+		return nil, fmt.Errorf("%w: endSched must be after startSched", tasks.ErrInvalidSchedule)
+	}
+	if !req.Base.StartSched.After(time.Now()) {
+		return nil, fmt.Errorf("%w: startSched must be in the future", tasks.ErrInvalidSchedule)
+	}
+
+	order, prices, err := s.Tasks.FetchOrderAndPrices(ctx, s.PaymentPort, req.Base.OrderId)
+	if err != nil {
+		s.Logger.Error("Failed to fetch waitlist order: %v", err)
+		return nil, err
+	}
+	if err := tasks.CheckQuotedDirtyScale(req.Base.DirtyScale, prices.DirtyScale); err != nil {
+		return nil, err
+	}
+
+	expiresAt := time.Now().Add(s.WaitlistExpiry)
+	if req.Base.StartSched.Before(expiresAt) {
+		expiresAt = req.Base.StartSched
+	}
+
+	var entry *types.WaitlistEntry
+	if err := s.withTx(ctx, func(tx pgx.Tx) error {
+		booked, err := s.Tasks.OrderHasActiveBooking(ctx, tx, order.ID)
+		if err != nil {
+			return err
+		}
+		if booked {
+			return tasks.ErrOrderAlreadyBooked
+		}
+		if err := s.Tasks.ValidateMediaIDs(ctx, tx, req.Base.Photos, types.MediaPurposeBooking); err != nil {
+			return err
+		}
+		if err := tasks.CheckBookingServiceZone(ctx, tx, req.Base.Address, prices.ServiceZoneID); err != nil {
+			return err
+		}
+
+		req.Base.OrderId = order.ID
+		entry, err = s.Tasks.InsertWaitlistEntry(ctx, tx, &req, order.CustomerID, expiresAt)
+		if err != nil {
+			return err
+		}
+		if req.Base.AccessInstructions == nil {
+			return nil
+		}
+		return s.Tasks.SaveHeldAccessInstructions(ctx, tx, s.AccessVault, tasks.WaitlistInstructionsOwner(entry.ID), req.Base.AccessInstructions)
+	}); err != nil {
+		s.Logger.Error("failed to join waitlist for order %s: %v", req.Base.OrderId, err)
+		return nil, err
+	}
+
+	return entry, nil
+}
+
+func (s *BookingService) GetWaitlistEntry(ctx context.Context, entryID string) (*types.WaitlistEntry, error) {
+	var entry *types.WaitlistEntry
+
+	if err := s.withTx(ctx, func(tx pgx.Tx) error {
+		var err error
+		entry, err = s.Tasks.FetchWaitlistEntry(ctx, tx, entryID)
+		return err
+	}); err != nil {
+		s.Logger.Error("failed to fetch waitlist entry %s: %v", entryID, err)
+		return nil, err
+	}
+
+	return entry, nil
+}
+
+func (s *BookingService) LeaveWaitlist(ctx context.Context, entryID string) (*types.WaitlistEntry, error) {
+	if err := s.withTx(ctx, func(tx pgx.Tx) error {
+		if _, err := s.Tasks.FetchWaitlistEntry(ctx, tx, entryID); err != nil {
+			return err
+		}
+		return s.Tasks.CancelWaitlistEntry(ctx, tx, entryID)
+	}); err != nil {
+		s.Logger.Error("failed to leave waitlist %s: %v", entryID, err)
+		return nil, err
+	}
+
+	return s.GetWaitlistEntry(ctx, entryID)
+}
+
+// PromoteWaitlist tries to book every waiting entry, oldest first. Entries that still
+// cannot get enough cleaners stay on the waitlist.
+func (s *BookingService) PromoteWaitlist(ctx context.Context) error {
+	var entryIDs []string
+	if err := s.withTx(ctx, func(tx pgx.Tx) error {
+		var err error
+		entryIDs, err = s.Tasks.FetchPromotableWaitlistIDs(ctx, tx)
+		return err
+	}); err != nil {
+		s.Logger.Error("failed to list waitlist entries: %v", err)
+		return err
+	}
+
+	for _, entryID := range entryIDs {
+		s.promoteWaitlistEntry(ctx, entryID)
+	}
+	return nil
+}
+
+func (s *BookingService) promoteWaitlistEntry(ctx context.Context, entryID string) {
+	entry, err := s.GetWaitlistEntry(ctx, entryID)
+	if err != nil {
+		return
+	}
+
+	_, prices, err := s.Tasks.FetchOrderAndPrices(ctx, s.PaymentPort, entry.OrderID)
+	if err != nil {
+		s.Logger.Error("failed to fetch prices for waitlist entry %s: %v", entryID, err)
+		return
+	}
+
+	var bookingID string
+	err = s.withTx(ctx, func(tx pgx.Tx) error {
+		locked, err := s.Tasks.LockWaitlistEntry(ctx, tx, entryID)
+		if err != nil || locked == nil {
+			return err
+		}
+
+		booked, err := s.Tasks.OrderHasActiveBooking(ctx, tx, locked.OrderID)
+		if err != nil {
+			return err
+		}
+		if booked {
+			s.Logger.Info("Order %s was booked directly, closing waitlist entry %s", locked.OrderID, entryID)
+			return s.Tasks.CancelWaitlistEntry(ctx, tx, entryID)
+		}
+
+		owner := tasks.WaitlistInstructionsOwner(entryID)
+		req := locked.Request
+		if req.Base.AccessInstructions, err = s.Tasks.FetchHeldAccessInstructions(ctx, tx, s.AccessVault, owner); err != nil {
+			return err
+		}
+
+		// Booking the order closes its waiting entries; the entry is marked promoted
+		// right after, in the same transaction.
+		booking, err := s.bookOrderChecked(ctx, tx, req, locked.OrderID, prices, tasks.SystemActor)
+		if err != nil {
+			return err
+		}
+		bookingID = booking.ID
+
+		if err := s.Tasks.DeleteHeldAccessInstructions(ctx, tx, owner); err != nil {
+			return err
+		}
+		return s.Tasks.MarkWaitlistPromoted(ctx, tx, locked, booking.ID)
+	})
+	switch {
+	case err == nil:
+		if bookingID != "" {
+			s.Logger.Info("Promoted waitlist entry %s to booking %s", entryID, bookingID)
+		}
+	case errors.Is(err, tasks.ErrNoAvailableCleaners), errors.Is(err, tasks.ErrInsufficientCleaners):
+		// Still no room for this slot; keep waiting.
+	case errors.Is(err, tasks.ErrOverlappingBooking):
+		s.Logger.Info("Waitlist entry %s still overlaps another booking: %v", entryID, err)
+	default:
+		s.Logger.Error("failed to promote waitlist entry %s: %v", entryID, err)
+	}
+}
+
+// RequestWaitlistPromotion asks the waitlist monitor to run a promotion pass as soon as it
+// can. It never blocks, so event handlers can call it freely.
+func (s *BookingService) RequestWaitlistPromotion() {
+	select {
+	case s.waitlistWake <- struct{}{}:
+	default:
+	}
+}
+
+func (s *BookingService) ExpireWaitlist(ctx context.Context) error {
+	var expired int
+	if err := s.withTx(ctx, func(tx pgx.Tx) error {
+		var err error
+		expired, err = s.Tasks.ExpireWaitlistEntries(ctx, tx)
+		return err
+	}); err != nil {
+		s.Logger.Error("failed to expire waitlist entries: %v", err)
+		return err
+	}
+
+	if expired > 0 {
+		s.Logger.Info("Expired %d waitlist entries", expired)
+	}
+	return nil
+}
+
+// RunWaitlistMonitor expires stale entries and retries promotion every interval, and
+// whenever RequestWaitlistPromotion is called, until ctx is done. The timed retry catches
+// cleaners freed by changes that do not raise an event.
+func (s *BookingService) RunWaitlistMonitor(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		s.runExclusive(ctx, "waitlist-monitor", func() {
+			if err := s.ExpireWaitlist(ctx); err != nil {
+				s.Logger.Error("waitlist expiry run failed: %v", err)
+			}
+			if err := s.PromoteWaitlist(ctx); err != nil {
+				s.Logger.Error("waitlist promotion run failed: %v", err)
+			}
+		})
+
+		select {
+		case <-ctx.Done():
+			s.Logger.Info("Stopping waitlist monitor")
+			return
+		case <-ticker.C:
+		case <-s.waitlistWake:
+		}
+	}
+}
@@ -0,0 +1,380 @@
+package tasks
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"handworks-api/types"
+	"handworks-api/utils"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+)
+
+var (
+	ErrAccessInstructionsDisabled   = errors.New("access instructions are not configured on this server")
+	ErrAccessInstructionsNotFound   = errors.New("booking has no access instructions")
+	ErrAccessInstructionsDenied     = errors.New("access instructions are not available to this user")
+	ErrAccessInstructionsNotYet     = errors.New("access instructions are not available yet")
+	ErrInvalidAccessInstructions    = errors.New("invalid access instructions")
+	ErrAccessInstructionsKeyChanged = errors.New("access instructions were encrypted with a different key")
+)
+
+const (
+	ViewerRoleAdmin    = "admin"
+	ViewerRoleEmployee = "employee"
+	ViewerRoleCustomer = "customer"
+	ViewerRoleOther    = "other"
+)
+
+// AccessInstructionsVault encrypts booking access instructions. CleanerWindow is how long
+// before a booking starts its cleaners can read them.
+type AccessInstructionsVault struct {
+	Box           *utils.SecretBox
+	CleanerWindow time.Duration
+}
+
+// NewAccessInstructionsVault builds the vault for policy. Without a key it is disabled.
+func NewAccessInstructionsVault(policy types.AccessInstructionsPolicy) (*AccessInstructionsVault, error) {
+	vault := &AccessInstructionsVault{CleanerWindow: policy.CleanerWindow}
+	if len(policy.Key) == 0 {
+		return vault, nil
+	}
+	box, err := utils.NewSecretBox(policy.Key)
+	if err != nil {
+		return nil, fmt.Errorf("invalid access instructions key: %w", err)
+	}
+	vault.Box = box
+	return vault, nil
+}
+
+func (v *AccessInstructionsVault) enabled() bool {
+	return v != nil && v.Box != nil
+}
+
+// AccessViewer is who is asking for access instructions, resolved from their Clerk ID.
+type AccessViewer struct {
+	Actor      string
+	Role       string
+	EmployeeID string
+	CustomerID string
+}
+
+func (t *BookingTasks) ResolveAccessViewer(ctx context.Context, tx pgx.Tx, actor string) (*AccessViewer, error) {
+	viewer := &AccessViewer{Actor: actor, Role: ViewerRoleOther}
+	var (
+		isAdmin                bool
+		employeeID, customerID string
+	)
+	err := tx.QueryRow(ctx, `
+		SELECT
+			EXISTS (SELECT 1 FROM account.admins ad WHERE ad.account_id = a.id),
+			COALESCE((SELECT e.id::text FROM account.employees e WHERE e.account_id = a.id), ''),
+			COALESCE((SELECT c.id::text FROM account.customers c WHERE c.account_id = a.id), '')
+		FROM account.accounts a
+		WHERE a.clerk_id = $1
+	`, actor).Scan(&isAdmin, &employeeID, &customerID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return viewer, nil
+		}
+		return nil, fmt.Errorf("failed to resolve viewer: %w", err)
+	}
+
+	switch {
+	case isAdmin:
+		viewer.Role = ViewerRoleAdmin
+	case employeeID != "":
+		viewer.Role, viewer.EmployeeID = ViewerRoleEmployee, employeeID
+	case customerID != "":
+		viewer.Role, viewer.CustomerID = ViewerRoleCustomer, customerID
+	}
+	return viewer, nil
+}
+
+// CanWriteAccessInstructions lets admins and the booking's customer replace the
+// instructions of a booking that has not finished.
+func CanWriteAccessInstructions(viewer *AccessViewer, snap *BookingSnapshot) error {
+	if viewer.Role != ViewerRoleAdmin && (viewer.Role != ViewerRoleCustomer || viewer.CustomerID != snap.CustID) {
+		return fmt.Errorf("%w: only admins and the booking's customer can change them", ErrAccessInstructionsDenied)
+	}
+	switch snap.Status {
+	case BookingStatusCompleted, BookingStatusCancelled, BookingStatusNoShow:
+		return fmt.Errorf("%w: booking is %s", ErrInvalidAccessInstructions, strings.ToLower(snap.Status))
+	}
+	return nil
+}
+
+// CanReadAccessInstructions decides whether viewer may read the booking's instructions.
+// Admins always can. Assigned cleaners can from CleanerWindow before the start until the
+// booking is finished or cancelled. Customers can replace them but not read them back.
+func (v *AccessInstructionsVault) CanReadAccessInstructions(viewer *AccessViewer, snap *BookingSnapshot, now time.Time) error {
+	switch viewer.Role {
+	case ViewerRoleAdmin:
+		return nil
+	case ViewerRoleEmployee:
+		if !slices.Contains(snap.CleanerIDs, viewer.EmployeeID) {
+			return ErrAccessInstructionsDenied
+		}
+		switch snap.Status {
+		case BookingStatusCompleted, BookingStatusCancelled, BookingStatusNoShow:
+			return fmt.Errorf("%w: booking is %s", ErrAccessInstructionsDenied, strings.ToLower(snap.Status))
+		}
+		if opens := snap.StartSched.Add(-v.CleanerWindow); now.Before(opens) {
+			return fmt.Errorf("%w: cleaners can view them from %s", ErrAccessInstructionsNotYet, opens.Format(time.RFC3339))
+		}
+		return nil
+	default:
+		return ErrAccessInstructionsDenied
+	}
+}
+
+func validateAccessInstructions(instr *types.AccessInstructions) error {
+	if instr == nil {
+		return fmt.Errorf("%w: instructions are required", ErrInvalidAccessInstructions)
+	}
+	fields := []string{instr.GateCode, instr.LobbyInstructions, instr.KeyLocation, instr.ParkingNotes, instr.Notes}
+	total := 0
+	for _, f := range fields {
+		total += len(strings.TrimSpace(f))
+	}
+	if total == 0 {
+		return fmt.Errorf("%w: at least one field must be filled in", ErrInvalidAccessInstructions)
+	}
+	if total > 4000 {
+		return fmt.Errorf("%w: instructions are too long", ErrInvalidAccessInstructions)
+	}
+	return nil
+}
+
+// SaveAccessInstructions encrypts and stores the booking's instructions, replacing any
+// already there. The booking ID is bound into the ciphertext so it cannot be copied onto
+// another booking.
+func (t *BookingTasks) SaveAccessInstructions(
+	ctx context.Context,
+	tx pgx.Tx,
+	vault *AccessInstructionsVault,
+	bookingID string,
+	instr *types.AccessInstructions,
+	actor string,
+) error {
+	if !vault.enabled() {
+		return ErrAccessInstructionsDisabled
+	}
+	if err := validateAccessInstructions(instr); err != nil {
+		return err
+	}
+
+	plaintext, err := json.Marshal(instr)
+	if err != nil {
+		return fmt.Errorf("marshal access instructions: %w", err)
+	}
+	sealed, err := vault.Box.Seal(plaintext, []byte(bookingID))
+	if err != nil {
+		return err
+	}
+
+	if _, err := tx.Exec(ctx, `
+		INSERT INTO booking.access_instructions (booking_id, ciphertext, key_id, updated_by, updated_at)
+		VALUES ($1, $2, $3, $4, NOW())
+		ON CONFLICT (booking_id) DO UPDATE
+		SET ciphertext = EXCLUDED.ciphertext,
+		    key_id = EXCLUDED.key_id,
+		    updated_by = EXCLUDED.updated_by,
+		    updated_at = NOW()
+	`, bookingID, sealed, vault.Box.KeyID(), actor); err != nil {
+		return fmt.Errorf("failed to save access instructions: %w", err)
+	}
+
+	return t.RecordAccessLog(ctx, tx, bookingID, actor, "", types.AccessLogWrite, "")
+}
+
+func (t *BookingTasks) FetchAccessInstructions(
+	ctx context.Context,
+	tx pgx.Tx,
+	vault *AccessInstructionsVault,
+	bookingID string,
+) (*types.AccessInstructionsResponse, error) {
+	if !vault.enabled() {
+		return nil, ErrAccessInstructionsDisabled
+	}
+
+	var (
+		sealed []byte
+		keyID  string
+		res    = &types.AccessInstructionsResponse{BookingID: bookingID}
+	)
+	err := tx.QueryRow(ctx, `
+		SELECT ciphertext, key_id, updated_by, updated_at
+		FROM booking.access_instructions
+		WHERE booking_id = $1
+	`, bookingID).Scan(&sealed, &keyID, &res.UpdatedBy, &res.UpdatedAt)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrAccessInstructionsNotFound
+		}
+		return nil, fmt.Errorf("failed to fetch access instructions: %w", err)
+	}
+	if keyID != vault.Box.KeyID() {
+		return nil, ErrAccessInstructionsKeyChanged
+	}
+
+	plaintext, err := vault.Box.Open(sealed, []byte(bookingID))
+	if err != nil {
+		return nil, fmt.Errorf("access instructions for booking %s: %w", bookingID, err)
+	}
+	if err := json.Unmarshal(plaintext, &res.Instructions); err != nil {
+		return nil, fmt.Errorf("unmarshal access instructions: %w", err)
+	}
+	return res, nil
+}
+
+// withoutAccessInstructions copies a request that is stored in plain JSON until it is
+// booked, such as a waitlist entry's or a series template. Its access instructions are
+// held encrypted by SaveHeldAccessInstructions instead.
+func withoutAccessInstructions(req types.CreateBookingRequest) types.CreateBookingRequest {
+	req.Base.AccessInstructions = nil
+	return req
+}
+
+func WaitlistInstructionsOwner(entryID string) string {
+	return "waitlist:" + entryID
+}
+
+func SeriesInstructionsOwner(seriesID string) string {
+	return "series:" + seriesID
+}
+
+// SaveHeldAccessInstructions encrypts instructions given for bookings that do not exist
+// yet and keeps them under owner, a waitlist entry or a series, until they are booked.
+func (t *BookingTasks) SaveHeldAccessInstructions(
+	ctx context.Context,
+	tx pgx.Tx,
+	vault *AccessInstructionsVault,
+	owner string,
+	instr *types.AccessInstructions,
+) error {
+	if !vault.enabled() {
+		return ErrAccessInstructionsDisabled
+	}
+	if err := validateAccessInstructions(instr); err != nil {
+		return err
+	}
+
+	plaintext, err := json.Marshal(instr)
+	if err != nil {
+		return fmt.Errorf("marshal access instructions: %w", err)
+	}
+	sealed, err := vault.Box.Seal(plaintext, []byte(owner))
+	if err != nil {
+		return err
+	}
+
+	if _, err := tx.Exec(ctx, `
+		INSERT INTO booking.held_access_instructions (owner, ciphertext, key_id, updated_at)
+		VALUES ($1, $2, $3, NOW())
+		ON CONFLICT (owner) DO UPDATE
+		SET ciphertext = EXCLUDED.ciphertext,
+		    key_id = EXCLUDED.key_id,
+		    updated_at = NOW()
+	`, owner, sealed, vault.Box.KeyID()); err != nil {
+		return fmt.Errorf("failed to hold access instructions: %w", err)
+	}
+	return nil
+}
+
+// FetchHeldAccessInstructions returns the instructions held for owner, or nil when none
+// were given.
+func (t *BookingTasks) FetchHeldAccessInstructions(
+	ctx context.Context,
+	tx pgx.Tx,
+	vault *AccessInstructionsVault,
+	owner string,
+) (*types.AccessInstructions, error) {
+	var (
+		sealed []byte
+		keyID  string
+	)
+	err := tx.QueryRow(ctx, `
+		SELECT ciphertext, key_id
+		FROM booking.held_access_instructions
+		WHERE owner = $1
+	`, owner).Scan(&sealed, &keyID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to fetch held access instructions: %w", err)
+	}
+	if !vault.enabled() {
+		return nil, ErrAccessInstructionsDisabled
+	}
+	if keyID != vault.Box.KeyID() {
+		return nil, ErrAccessInstructionsKeyChanged
+	}
+
+	plaintext, err := vault.Box.Open(sealed, []byte(owner))
+	if err != nil {
+		return nil, fmt.Errorf("access instructions for %s: %w", owner, err)
+	}
+	var instr types.AccessInstructions
+	if err := json.Unmarshal(plaintext, &instr); err != nil {
+		return nil, fmt.Errorf("unmarshal access instructions: %w", err)
+	}
+	return &instr, nil
+}
+
+func (t *BookingTasks) DeleteHeldAccessInstructions(ctx context.Context, tx pgx.Tx, owner string) error {
+	if _, err := tx.Exec(ctx, `
+		DELETE FROM booking.held_access_instructions
+		WHERE owner = $1
+	`, owner); err != nil {
+		return fmt.Errorf("failed to delete held access instructions: %w", err)
+	}
+	return nil
+}
+
+func (t *BookingTasks) RecordAccessLog(
+	ctx context.Context,
+	tx pgx.Tx,
+	bookingID, actor, viewerRole string,
+	action types.AccessLogAction,
+	reason string,
+) error {
+	if _, err := tx.Exec(ctx, `
+		INSERT INTO booking.access_instruction_logs (booking_id, actor, viewer_role, action, reason, created_at)
+		VALUES ($1, $2, NULLIF($3, ''), $4, NULLIF($5, ''), NOW())
+	`, bookingID, actor, viewerRole, string(action), reason); err != nil {
+		return fmt.Errorf("failed to record access instructions %s: %w", strings.ToLower(string(action)), err)
+	}
+	return nil
+}
+
+func (t *AdminTasks) FetchAccessLog(ctx context.Context, tx pgx.Tx, bookingID string) ([]types.AccessLogEntry, error) {
+	rows, err := tx.Query(ctx, `
+		SELECT id, booking_id, actor, COALESCE(viewer_role, ''), action, COALESCE(reason, ''), created_at
+		FROM booking.access_instruction_logs
+		WHERE booking_id = $1
+		ORDER BY created_at DESC, id DESC
+	`, bookingID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch access log: %w", err)
+	}
+	defer rows.Close()
+
+	entries := make([]types.AccessLogEntry, 0)
+	for rows.Next() {
+		var e types.AccessLogEntry
+		if err := rows.Scan(&e.ID, &e.BookingID, &e.Actor, &e.ViewerRole, &e.Action, &e.Reason, &e.At); err != nil {
+			return nil, fmt.Errorf("failed to scan access log entry: %w", err)
+		}
+		entries = append(entries, e)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed iterating access log: %w", err)
+	}
+	return entries, nil
+}
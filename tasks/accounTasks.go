@@ -499,6 +499,32 @@ func (t *AccountTasks) AddPerformanceScore(c context.Context, tx pgx.Tx, newScor
 	}
 	return nil
 }
+
+// RemovePerformanceScore takes one rating back out of the running average. Removing the
+// last rating restores the score new employees start with.
+func (t *AccountTasks) RemovePerformanceScore(c context.Context, tx pgx.Tx, score float32, empId string) error {
+	args := pgx.NamedArgs{
+		"score": score,
+		"id":    empId,
+	}
+	cmdTag, err := tx.Exec(c,
+		`UPDATE account.employees
+	 SET performance_score = CASE
+	         WHEN num_ratings <= 1 THEN 5.0
+	         ELSE ((performance_score * num_ratings) - @score) / (num_ratings - 1)
+	     END,
+	     num_ratings = GREATEST(num_ratings - 1, 0), updated_at = NOW()
+	 WHERE id = @id::uuid`,
+		args,
+	)
+	if err != nil {
+		return err
+	}
+	if cmdTag.RowsAffected() == 0 {
+		return fmt.Errorf("no employee found with id %s", empId)
+	}
+	return nil
+}
 func (a *AccountTasks) UpdateStatus(c context.Context, tx pgx.Tx, status, empId string) error {
 	args := pgx.NamedArgs{
 		"newStatus": status,
@@ -512,6 +538,13 @@ func (a *AccountTasks) UpdateStatus(c context.Context, tx pgx.Tx, status, empId
 	if err != nil {
 		return err
 	}
+	// A cleaner coming back on shift may free up a waitlisted slot.
+	if status == "ACTIVE" {
+		return publishEvent(c, tx, "employee_activated", map[string]any{
+			"event":      "employee_activated",
+			"employeeId": empId,
+		})
+	}
 	return nil
 }
 func (a *AccountTasks) UpdateCustomerMetadata(c context.Context, tx pgx.Tx, customerId, accId, clerkId string) error {
@@ -568,12 +601,15 @@ func (a *AccountTasks) EmployeeTimeIn(c context.Context, tx pgx.Tx, status strin
 
 	query := `
 	INSERT INTO account.employee_timesheet 
-	(employee_id, work_date, time_in, status, created_at, updated_at)
-	VALUES ($1, CURRENT_DATE, $2, $3, NOW(), NOW())
+	(employee_id, work_date, time_in, status, time_in_latitude, time_in_longitude, time_in_accuracy_m, created_at, updated_at)
+	VALUES ($1, CURRENT_DATE, $2, $3, $4, $5, $6, NOW(), NOW())
 	RETURNING id, employee_id, work_date, time_in, time_out, status, created_at, updated_at
 	`
 
-	err := tx.QueryRow(c, query, req.EmployeeId, req.TimeIn, status).Scan(
+	err := tx.QueryRow(c, query,
+		req.EmployeeId, req.TimeIn, status,
+		req.Location.Latitude, req.Location.Longitude, req.Location.AccuracyMeters,
+	).Scan(
 		&timesheet.TimesheetId,
 		&timesheet.EmployeeId,
 		&timesheet.WorkDate,
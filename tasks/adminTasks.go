@@ -17,7 +17,18 @@ import (
 	"github.com/jackc/pgx/v5"
 )
 
-type AdminTasks struct{}
+// AdminTasks runs admin queries. Location is the business time zone employee calendars
+// are read in.
+type AdminTasks struct {
+	Location *time.Location
+}
+
+func (t *AdminTasks) location() *time.Location {
+	if t.Location == nil {
+		return time.Local
+	}
+	return t.Location
+}
 
 var (
 	ErrBookingNotFound            = errors.New("booking not found")
@@ -441,104 +452,64 @@ func (t *AdminTasks) CreateClerkUser(ctx context.Context, req *types.OnboardEmpl
 	}
 	return newUser, nil
 }
-func (t *AdminTasks) AcceptBooking(ctx context.Context, tx pgx.Tx, bookingID string) error {
-	_, err := tx.Exec(ctx,
-		`UPDATE booking.basebookings bb
-		SET reviewstatus = 'SCHEDULED'
-		FROM booking.bookings b
-		WHERE bb.id = b.base_booking_id
-		AND b.id = $1`,
-		bookingID,
-	)
+func (t *AdminTasks) AssignResourcesToBooking(ctx context.Context, tx pgx.Tx, bookingID string, resources []types.ItemQuantity) error {
+	_, err := assignInventoryToBooking(ctx, tx, bookingID, types.ItemTypeResource, resources)
 	return err
 }
 
-func (t *AdminTasks) AssignResourcesToBooking(ctx context.Context, tx pgx.Tx, bookingID string, resources []types.ItemQuantity) error {
-	// Remove existing resource usage records for this booking
-	_, err := tx.Exec(ctx,
-		`UPDATE booking.bookings
-		 SET resource_ids = '{}'::uuid[]
-		 WHERE id = $1`,
-		bookingID,
-	)
-	if err != nil {
-		return fmt.Errorf("failed to clear resource_ids: %w", err)
-	}
-
-	var usedIDs []string
-	for _, r := range resources {
-		var usedID string
-		err = tx.QueryRow(ctx,
-			`INSERT INTO booking.booking_inventory_used (item_id, item_type, quantity_used)
-			 VALUES ($1, 'RESOURCE', $2)
-			 RETURNING id`,
-			r.ItemID, r.Quantity,
-		).Scan(&usedID)
-		if err != nil {
-			return fmt.Errorf("failed to insert resource usage for item %s: %w", r.ItemID, err)
-		}
-
-		result, updateErr := tx.Exec(ctx,
-			`UPDATE inventory.items
-			 SET quantity = quantity - $2
-			 WHERE id = $1`,
-			r.ItemID, r.Quantity,
-		)
-		if updateErr != nil {
-			return fmt.Errorf("failed to decrement resource inventory for item %s: %w", r.ItemID, updateErr)
-		}
-		if result.RowsAffected() == 0 {
-			return fmt.Errorf("resource inventory item not found: %s", r.ItemID)
-		}
-
-		usedIDs = append(usedIDs, usedID)
-	}
-
-	_, err = tx.Exec(ctx,
-		`UPDATE booking.bookings
-		 SET resource_ids = $2::uuid[]
-		 WHERE id = $1`,
-		bookingID, usedIDs,
-	)
+func (t *AdminTasks) AssignEquipmentToBooking(ctx context.Context, tx pgx.Tx, bookingID string, equipment []types.ItemQuantity) error {
+	_, err := assignInventoryToBooking(ctx, tx, bookingID, types.ItemTypeEquipment, equipment)
 	return err
 }
 
-func (t *AdminTasks) AssignEquipmentToBooking(ctx context.Context, tx pgx.Tx, bookingID string, equipment []types.ItemQuantity) error {
-	// Remove existing equipment usage records for this booking
+// assignInventoryToBooking records the items in booking_inventory_used and replaces the
+// booking's resource_ids or equipment_ids with the new usage records. Resources are used up
+// and taken out of stock; equipment only stays reserved for the booking's schedule.
+func assignInventoryToBooking(ctx context.Context, tx pgx.Tx, bookingID string, itemType types.ItemType, items []types.ItemQuantity) ([]string, error) {
+	column := "resource_ids"
+	label := "resource"
+	if itemType == types.ItemTypeEquipment {
+		column = "equipment_ids"
+		label = "equipment"
+	}
+
+	// Remove existing usage records of this type for the booking
 	_, err := tx.Exec(ctx,
 		`UPDATE booking.bookings
-		 SET equipment_ids = '{}'::uuid[]
+		 SET `+column+` = '{}'::uuid[]
 		 WHERE id = $1`,
 		bookingID,
 	)
 	if err != nil {
-		return fmt.Errorf("failed to clear equipment_ids: %w", err)
+		return nil, fmt.Errorf("failed to clear %s: %w", column, err)
 	}
 
-	var usedIDs []string
-	for _, e := range equipment {
+	usedIDs := make([]string, 0, len(items))
+	for _, item := range items {
 		var usedID string
 		err = tx.QueryRow(ctx,
 			`INSERT INTO booking.booking_inventory_used (item_id, item_type, quantity_used)
-			 VALUES ($1, 'EQUIPMENT', $2)
+			 VALUES ($1, $2, $3)
 			 RETURNING id`,
-			e.ItemID, e.Quantity,
+			item.ItemID, string(itemType), item.Quantity,
 		).Scan(&usedID)
 		if err != nil {
-			return fmt.Errorf("failed to insert equipment usage for item %s: %w", e.ItemID, err)
+			return nil, fmt.Errorf("failed to insert %s usage for item %s: %w", label, item.ItemID, err)
 		}
 
-		result, updateErr := tx.Exec(ctx,
-			`UPDATE inventory.items
-			 SET quantity = quantity - $2
-			 WHERE id = $1`,
-			e.ItemID, e.Quantity,
-		)
-		if updateErr != nil {
-			return fmt.Errorf("failed to decrement equipment inventory for item %s: %w", e.ItemID, updateErr)
-		}
-		if result.RowsAffected() == 0 {
-			return fmt.Errorf("equipment inventory item not found: %s", e.ItemID)
+		if itemType == types.ItemTypeResource {
+			result, updateErr := tx.Exec(ctx,
+				`UPDATE inventory.items
+				 SET quantity = quantity - $2
+				 WHERE id = $1`,
+				item.ItemID, item.Quantity,
+			)
+			if updateErr != nil {
+				return nil, fmt.Errorf("failed to decrement %s inventory for item %s: %w", label, item.ItemID, updateErr)
+			}
+			if result.RowsAffected() == 0 {
+				return nil, fmt.Errorf("%s inventory item not found: %s", label, item.ItemID)
+			}
 		}
 
 		usedIDs = append(usedIDs, usedID)
@@ -546,11 +517,14 @@ func (t *AdminTasks) AssignEquipmentToBooking(ctx context.Context, tx pgx.Tx, bo
 
 	_, err = tx.Exec(ctx,
 		`UPDATE booking.bookings
-		 SET equipment_ids = $2::uuid[]
+		 SET `+column+` = $2::uuid[]
 		 WHERE id = $1`,
 		bookingID, usedIDs,
 	)
-	return err
+	if err != nil {
+		return nil, fmt.Errorf("failed to set %s: %w", column, err)
+	}
+	return usedIDs, nil
 }
 
 func (t *AdminTasks) AddToClerkOrganization(ctx context.Context, clerkUserID, organizationID, role string) (*clerk.OrganizationMembership, error) {
@@ -722,6 +696,17 @@ func (t *AdminTasks) CleanerHasScheduleConflict(
 	employeeID string,
 	startSched, endSched time.Time,
 	excludeBookingID string,
+) (bool, error) {
+	return cleanerHasScheduleConflict(ctx, tx, employeeID, startSched, endSched, excludeBookingID)
+}
+
+// cleanerHasScheduleConflict is shared by admin assignment and booking rescheduling.
+func cleanerHasScheduleConflict(
+	ctx context.Context,
+	tx pgx.Tx,
+	employeeID string,
+	startSched, endSched time.Time,
+	excludeBookingID string,
 ) (bool, error) {
 	var count int
 	err := tx.QueryRow(ctx, `
@@ -750,6 +735,7 @@ func (t *AdminTasks) FetchAvailableCleanersByBooking(
 	tx pgx.Tx,
 	bookingID string,
 	startSched, endSched time.Time,
+	serviceTypes []types.MainServiceType,
 ) ([]types.AvailableCleaner, error) {
 	rows, err := tx.Query(ctx, `
 		SELECT
@@ -771,8 +757,10 @@ func (t *AdminTasks) FetchAvailableCleanersByBooking(
 			  AND UPPER(COALESCE(bb.reviewstatus, '')) NOT IN ('CANCELLED', 'REJECTED')
 			  AND UPPER(COALESCE(bb.status, '')) <> 'CANCELLED'
 		  )
+		  AND `+qualifiedCleanerFilter("e.id", "$4")+`
+		  AND `+availableOnCalendarFilter("e.id", "$1", "$2", 5)+`
 		ORDER BY a.last_name ASC, a.first_name ASC
-	`, startSched, endSched, bookingID)
+	`, append([]any{startSched, endSched, bookingID, qualificationsFor(serviceTypes)}, calendarClockArgs(t.location(), startSched, endSched)...)...)
 	if err != nil {
 		return nil, fmt.Errorf("failed to fetch available cleaners: %w", err)
 	}
@@ -849,3 +837,55 @@ func (t *AdminTasks) AssignEmployeeToBooking(
 		return fmt.Errorf("invalid action: %s", action)
 	}
 }
+
+func (t *AdminTasks) FetchSeriesConflicts(ctx context.Context, tx pgx.Tx) (*types.SeriesConflictsResponse, error) {
+	rows, err := tx.Query(ctx, `
+		SELECT
+			so.id::text,
+			s.id::text,
+			s.customer_id::text,
+			COALESCE(s.template->'base'->>'customerFirstName', ''),
+			COALESCE(s.template->'base'->>'customerLastName', ''),
+			so.start_sched,
+			so.end_sched,
+			COALESCE(so.conflict_reason, ''),
+			so.updated_at
+		FROM booking.series_occurrences so
+		JOIN booking.booking_series s ON s.id = so.series_id
+		WHERE so.status = 'CONFLICT'
+		  AND s.status = 'ACTIVE'
+		  AND so.start_sched > NOW()
+		ORDER BY so.start_sched ASC
+	`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch series conflicts: %w", err)
+	}
+	defer rows.Close()
+
+	conflicts := make([]types.SeriesConflict, 0)
+	for rows.Next() {
+		var c types.SeriesConflict
+		if err := rows.Scan(
+			&c.OccurrenceID,
+			&c.SeriesID,
+			&c.CustomerID,
+			&c.CustomerFirstName,
+			&c.CustomerLastName,
+			&c.StartSched,
+			&c.EndSched,
+			&c.Reason,
+			&c.UpdatedAt,
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan series conflict: %w", err)
+		}
+		conflicts = append(conflicts, c)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("series conflict rows error: %w", err)
+	}
+
+	return &types.SeriesConflictsResponse{
+		Conflicts: conflicts,
+		Total:     len(conflicts),
+	}, nil
+}
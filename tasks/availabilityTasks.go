@@ -0,0 +1,319 @@
+package tasks
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"handworks-api/types"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// MaxAvailabilityRangeDays caps how many days one availability search may cover.
+const MaxAvailabilityRangeDays = 14
+
+var ErrInvalidService = errors.New("invalid service request")
+
+// EstimateServiceHours prices the main service and addons the same way a quote does and
+// returns the total hours of work, including the extra hours of the dirty scale.
+func (t *BookingTasks) EstimateServiceHours(
+	req *types.AvailabilityRequest,
+	dirtyScale types.DirtyScalePolicy,
+	largeAreaRate float32,
+) (int32, error) {
+	pricing := &PaymentTasks{LargeAreaRate: largeAreaRate}
+
+	_, total, err := pricing.CalculatePriceByServiceType(&req.Service)
+	if err != nil {
+		return 0, fmt.Errorf("%w: %v", ErrInvalidService, err)
+	}
+	for i, addon := range req.Addons {
+		_, hours, err := pricing.CalculatePriceByServiceType(&addon.ServiceDetail)
+		if err != nil {
+			return 0, fmt.Errorf("%w: addon %d (%s): %v", ErrInvalidService, i+1, addon.ServiceDetail.ServiceType, err)
+		}
+		total += hours
+	}
+	_, dirtyHours, err := ApplyDirtyScale(dirtyScale, req.DirtyScale, 0, total)
+	if err != nil {
+		return 0, fmt.Errorf("%w: %w", ErrInvalidService, err)
+	}
+	total += dirtyHours
+	if total <= 0 {
+		total = 1
+	}
+	return total, nil
+}
+
+// FindAvailableSlots walks every slot start inside business hours between from and to
+// (inclusive dates) and keeps the ones where enough cleaners are free, qualified and on
+// their calendar. Multi-day jobs need every day of their plan to fit. The cleaners'
+// bookings and calendars are loaded once for the whole range and each slot is checked in
+// memory; travel between jobs is left to the allocation made when booking.
+func (t *BookingTasks) FindAvailableSlots(
+	ctx context.Context,
+	tx pgx.Tx,
+	req *types.AvailabilityRequest,
+	serviceHours int32,
+	hours types.BusinessHours,
+	from, to time.Time,
+) (*types.AvailabilityResponse, error) {
+	dayPlan, err := PlanServiceDays(serviceHours, 0)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidService, err)
+	}
+
+	res := &types.AvailabilityResponse{
+		ServiceHours:  serviceHours,
+		ServiceDays:   len(dayPlan),
+		BusinessHours: hours,
+		Slots:         []types.AvailableSlot{},
+	}
+
+	needed := make([]int, len(dayPlan))
+	for i, day := range dayPlan {
+		needed[i] = t.calculateCleanersNeeded(availabilityRequest(req, time.Time{}, time.Duration(day.Hours)*time.Hour))
+		if needed[i] > res.CleanersNeeded {
+			res.CleanersNeeded = needed[i]
+		}
+	}
+
+	rangeStart := time.Date(from.Year(), from.Month(), from.Day(), hours.OpenHour, 0, 0, 0, from.Location())
+	rangeEnd := time.Date(to.Year(), to.Month(), to.Day(), hours.CloseHour, 0, 0, 0, to.Location()).
+		AddDate(0, 0, len(dayPlan)-1)
+	calendar, err := loadCleanerCalendar(ctx, tx, t.location(), requiredQualifications(availabilityRequest(req, time.Time{}, 0)), rangeStart, rangeEnd)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	step := time.Duration(hours.SlotMinutes) * time.Minute
+
+	for date := from; !date.After(to); date = date.AddDate(0, 0, 1) {
+		open := time.Date(date.Year(), date.Month(), date.Day(), hours.OpenHour, 0, 0, 0, date.Location())
+		closing := time.Date(date.Year(), date.Month(), date.Day(), hours.CloseHour, 0, 0, 0, date.Location())
+
+		for start := open; start.Before(closing); start = start.Add(step) {
+			if !start.After(now) {
+				continue
+			}
+			if slot, ok := planFits(calendar, dayPlan, needed, start, closing); ok {
+				res.Slots = append(res.Slots, *slot)
+			}
+		}
+	}
+
+	return res, nil
+}
+
+// planFits checks every day of the plan starting at start, and returns the slot it spans.
+func planFits(
+	calendar *cleanerCalendar,
+	dayPlan []types.ServiceDay,
+	needed []int,
+	start, closing time.Time,
+) (*types.AvailableSlot, bool) {
+	slot := &types.AvailableSlot{StartSched: start}
+
+	for i, day := range dayPlan {
+		dayStart := start.AddDate(0, 0, int(day.DayIndex))
+		dayEnd := dayStart.Add(time.Duration(day.Hours) * time.Hour)
+		if dayEnd.After(closing.AddDate(0, 0, int(day.DayIndex))) {
+			return nil, false
+		}
+		if calendar.freeCleaners(dayStart, dayEnd) < needed[i] {
+			return nil, false
+		}
+		slot.EndSched = dayEnd
+	}
+
+	return slot, true
+}
+
+// cleanerCalendar holds what the slot search needs to know about every cleaner who could
+// take a job: when they are booked or on time off, and their approved weekly windows.
+// Cleaners without an approved schedule are not in weekly.
+type cleanerCalendar struct {
+	loc      *time.Location
+	cleaners []string
+	busy     map[string][]BookingScheduleWindow
+	weekly   map[string][]weeklyWindow
+}
+
+// weeklyWindow is an approved availability window in seconds after midnight.
+type weeklyWindow struct {
+	weekday, start, end int
+}
+
+// loadCleanerCalendar reads the active cleaners qualified for required, with their live
+// bookings and approved time off between rangeStart and rangeEnd and their approved
+// weekly windows, in four queries.
+func loadCleanerCalendar(
+	ctx context.Context,
+	tx pgx.Tx,
+	loc *time.Location,
+	required []string,
+	rangeStart, rangeEnd time.Time,
+) (*cleanerCalendar, error) {
+	calendar := &cleanerCalendar{
+		loc:    loc,
+		busy:   make(map[string][]BookingScheduleWindow),
+		weekly: make(map[string][]weeklyWindow),
+	}
+
+	rows, err := tx.Query(ctx, `
+		SELECT e.id::text
+		FROM account.employees e
+		WHERE e.position = 'cleaner'
+		  AND e.status IN ('ACTIVE', 'ONDUTY')
+		  AND `+qualifiedCleanerFilter("e.id", "$1"),
+		required,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch cleaners: %w", err)
+	}
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			rows.Close()
+			return nil, fmt.Errorf("failed to scan cleaner: %w", err)
+		}
+		calendar.cleaners = append(calendar.cleaners, id)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed iterating cleaners: %w", err)
+	}
+	if len(calendar.cleaners) == 0 {
+		return calendar, nil
+	}
+
+	busyQueries := []struct {
+		label string
+		query string
+	}{
+		{"bookings", `
+			SELECT c.id::text, bb.startsched, bb.endsched
+			FROM booking.bookings b
+			JOIN booking.basebookings bb ON bb.id = b.base_booking_id
+			CROSS JOIN LATERAL unnest(b.cleaner_ids) AS c(id)
+			WHERE c.id = ANY($1::uuid[])
+			  AND bb.startsched < $3
+			  AND bb.endsched > $2
+			  AND UPPER(COALESCE(bb.reviewstatus, '')) NOT IN ('CANCELLED', 'REJECTED')
+			  AND UPPER(COALESCE(bb.status, '')) <> 'CANCELLED'`},
+		{"time off", `
+			SELECT o.employee_id::text, o.start_at, o.end_at
+			FROM account.employee_time_off o
+			WHERE o.employee_id = ANY($1::uuid[])
+			  AND o.status = 'APPROVED'
+			  AND o.start_at < $3
+			  AND o.end_at > $2`},
+	}
+	for _, q := range busyQueries {
+		rows, err := tx.Query(ctx, q.query, calendar.cleaners, rangeStart, rangeEnd)
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch cleaner %s: %w", q.label, err)
+		}
+		for rows.Next() {
+			var (
+				id     string
+				window BookingScheduleWindow
+			)
+			if err := rows.Scan(&id, &window.StartSched, &window.EndSched); err != nil {
+				rows.Close()
+				return nil, fmt.Errorf("failed to scan cleaner %s: %w", q.label, err)
+			}
+			calendar.busy[id] = append(calendar.busy[id], window)
+		}
+		rows.Close()
+		if err := rows.Err(); err != nil {
+			return nil, fmt.Errorf("failed iterating cleaner %s: %w", q.label, err)
+		}
+	}
+
+	rows, err = tx.Query(ctx, `
+		SELECT
+			s.employee_id::text,
+			w.weekday,
+			EXTRACT(EPOCH FROM w.start_time)::int,
+			EXTRACT(EPOCH FROM w.end_time)::int
+		FROM account.employee_availability_schedules s
+		LEFT JOIN account.employee_availability_windows w ON w.schedule_id = s.id
+		WHERE s.employee_id = ANY($1::uuid[])
+		  AND s.status = 'APPROVED'
+	`, calendar.cleaners)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch cleaner schedules: %w", err)
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var (
+			id                  string
+			weekday, start, end *int
+		)
+		if err := rows.Scan(&id, &weekday, &start, &end); err != nil {
+			return nil, fmt.Errorf("failed to scan cleaner schedule: %w", err)
+		}
+		windows := calendar.weekly[id]
+		if weekday != nil && start != nil && end != nil {
+			windows = append(windows, weeklyWindow{weekday: *weekday, start: *start, end: *end})
+		}
+		// An approved schedule without windows still means the cleaner is never available.
+		if windows == nil {
+			windows = []weeklyWindow{}
+		}
+		calendar.weekly[id] = windows
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed iterating cleaner schedules: %w", err)
+	}
+
+	return calendar, nil
+}
+
+// freeCleaners counts the cleaners who could work the whole window.
+func (c *cleanerCalendar) freeCleaners(start, end time.Time) int {
+	weekday, from, to := calendarClock(c.loc, start, end)
+
+	free := 0
+	for _, id := range c.cleaners {
+		if windows, ok := c.weekly[id]; ok && !coveredByWeeklyWindow(windows, weekday, from, to) {
+			continue
+		}
+		busy := false
+		for _, w := range c.busy[id] {
+			if w.StartSched.Before(end) && w.EndSched.After(start) {
+				busy = true
+				break
+			}
+		}
+		if !busy {
+			free++
+		}
+	}
+	return free
+}
+
+func coveredByWeeklyWindow(windows []weeklyWindow, weekday, from, to int) bool {
+	for _, w := range windows {
+		if w.weekday == weekday && w.start <= from && w.end >= to {
+			return true
+		}
+	}
+	return false
+}
+
+func availabilityRequest(req *types.AvailabilityRequest, start time.Time, duration time.Duration) *types.CreateBookingRequest {
+	return &types.CreateBookingRequest{
+		Base: types.BaseBookingDetailsRequest{
+			StartSched: start,
+			EndSched:   start.Add(duration),
+			DirtyScale: req.DirtyScale,
+		},
+		MainService:       req.Service,
+		Addons:            req.Addons,
+		TotalServiceHours: float32(duration.Hours()),
+	}
+}
@@ -0,0 +1,138 @@
+package tasks
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"handworks-api/types"
+
+	"github.com/jackc/pgx/v5"
+)
+
+var (
+	ErrBookAgainDenied        = errors.New("only admins and the booking's customer can book it again")
+	ErrBookAgainPaymentMethod = errors.New("payment method is required to book again")
+)
+
+// CanBookAgain lets admins and the booking's customer repeat a booking.
+func CanBookAgain(viewer *AccessViewer, custID string) error {
+	if viewer.Role == ViewerRoleAdmin || (viewer.Role == ViewerRoleCustomer && viewer.CustomerID == custID) {
+		return nil
+	}
+	return ErrBookAgainDenied
+}
+
+// FetchBookAgainTemplate rebuilds the booking request a past booking was made from, with
+// its stored main service and add-on details, and returns the payment method of its order.
+// The schedule, order and quote are left for the caller to fill in.
+func (t *BookingTasks) FetchBookAgainTemplate(ctx context.Context, tx pgx.Tx, bookingID string) (*types.CreateBookingRequest, string, error) {
+	var (
+		req           types.CreateBookingRequest
+		mainType      string
+		mainDetail    []byte
+		paymentMethod string
+	)
+	err := tx.QueryRow(ctx, `
+		SELECT
+			bb.custid::text,
+			bb.customerfirstname,
+			bb.customerlastname,
+			COALESCE(bb.customer_phone_no, ''),
+			bb.address,
+			bb.dirtyscale,
+			s.service_type,
+			s.details,
+			COALESCE(o.full_payment_method, '')
+		FROM booking.bookings b
+		JOIN booking.basebookings bb ON bb.id = b.base_booking_id
+		JOIN booking.services s ON s.id = b.main_service_id
+		LEFT JOIN payment.orders o ON o.id = bb.orderid
+		WHERE b.id::text = $1
+	`, bookingID).Scan(
+		&req.Base.CustID,
+		&req.Base.CustomerFirstName,
+		&req.Base.CustomerLastName,
+		&req.Base.CustomerPhoneNo,
+		&req.Base.Address,
+		&req.Base.DirtyScale,
+		&mainType,
+		&mainDetail,
+		&paymentMethod,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, "", ErrBookingNotFound
+		}
+		return nil, "", fmt.Errorf("failed to fetch booking services: %w", err)
+	}
+	if req.MainService, err = serviceRequestFromRow(mainType, mainDetail); err != nil {
+		return nil, "", err
+	}
+
+	rows, err := tx.Query(ctx, `
+		SELECT s.service_type, s.details
+		FROM booking.bookings b
+		JOIN booking.addons a ON a.id = ANY(b.addon_ids)
+		JOIN booking.services s ON s.id = a.service_id
+		WHERE b.id::text = $1
+		ORDER BY array_position(b.addon_ids, a.id)
+	`, bookingID)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to fetch booking add-ons: %w", err)
+	}
+	defer rows.Close()
+
+	req.Addons = []types.AddOnRequest{}
+	for rows.Next() {
+		var (
+			svcType string
+			raw     []byte
+		)
+		if err := rows.Scan(&svcType, &raw); err != nil {
+			return nil, "", fmt.Errorf("failed to scan booking add-on: %w", err)
+		}
+		svc, err := serviceRequestFromRow(svcType, raw)
+		if err != nil {
+			return nil, "", err
+		}
+		req.Addons = append(req.Addons, types.AddOnRequest{ServiceDetail: svc})
+	}
+	if err := rows.Err(); err != nil {
+		return nil, "", fmt.Errorf("failed iterating booking add-ons: %w", err)
+	}
+
+	return &req, paymentMethod, nil
+}
+
+// serviceRequestFromRow turns a booking.services row, whose details hold only the
+// service's own detail struct, back into the request shape quotes are priced from.
+func serviceRequestFromRow(svcType string, raw []byte) (types.ServicesRequest, error) {
+	svc := types.ServicesRequest{ServiceType: types.MainServiceType(svcType)}
+
+	var target any
+	switch svc.ServiceType {
+	case types.GeneralCleaning:
+		svc.Details.General = &types.GeneralCleaningDetails{}
+		target = svc.Details.General
+	case types.CouchCleaning:
+		svc.Details.Couch = &types.CouchCleaningDetails{}
+		target = svc.Details.Couch
+	case types.MattressCleaning:
+		svc.Details.Mattress = &types.MattressCleaningDetails{}
+		target = svc.Details.Mattress
+	case types.CarCleaning:
+		svc.Details.Car = &types.CarCleaningDetails{}
+		target = svc.Details.Car
+	case types.PostCleaning:
+		svc.Details.Post = &types.PostConstructionDetails{}
+		target = svc.Details.Post
+	default:
+		return svc, fmt.Errorf("%w: %s", ErrInvalidService, svcType)
+	}
+
+	if err := json.Unmarshal(raw, target); err != nil {
+		return svc, fmt.Errorf("failed to unmarshal %s service details: %w", svcType, err)
+	}
+	return svc, nil
+}
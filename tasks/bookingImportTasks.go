@@ -0,0 +1,423 @@
+package tasks
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"handworks-api/types"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+)
+
+const (
+	MaxBookingImportRows   = 200
+	BookingImportBatchSize = 10
+	// BookingImportBatchTimeout bounds the creation of one batch of bookings.
+	BookingImportBatchTimeout = 2 * time.Minute
+	// BookingImportStaleAfter is how long a running import may go without saving progress
+	// before another confirm may take it over. It must exceed BookingImportBatchTimeout.
+	BookingImportStaleAfter = 3 * BookingImportBatchTimeout
+)
+
+var (
+	ErrBookingImportDenied   = errors.New("only admins can import bookings")
+	ErrInvalidBookingImport  = errors.New("invalid booking import file")
+	ErrInvalidImportRow      = errors.New("invalid import row")
+	ErrImportCustomerMissing = errors.New("customer not found")
+	ErrNoSlotInWindow        = errors.New("no available slot in the preferred window")
+	ErrBookingImportNotFound = errors.New("booking import not found")
+	ErrBookingImportStarted  = errors.New("booking import is already running or finished")
+)
+
+// bookingImportColumns are the CSV header names, matched case-insensitively in any order.
+// serviceDetails holds the JSON detail object of serviceType, the same shape bookings
+// store, and addons an optional JSON array of {"serviceType", "details"} objects.
+var bookingImportColumns = struct {
+	required, optional []string
+}{
+	required: []string{"customerid", "address", "lat", "lng", "servicetype", "servicedetails", "windowstart", "windowend"},
+	optional: []string{"unit", "addons", "dirtyscale", "paymentmethod"},
+}
+
+// BookingImportRow is one parsed CSV line. Err is set when the line cannot be booked as
+// written; the other fields are then incomplete.
+type BookingImportRow struct {
+	Line          int
+	Request       types.CreateBookingRequest
+	WindowStart   time.Time
+	WindowEnd     time.Time
+	PaymentMethod string
+	Err           error
+}
+
+// ParseBookingImportCSV reads the header and every row of an import file. Problems with
+// the file itself are returned as ErrInvalidBookingImport; problems with a single row are
+// kept on that row so the rest of the file can still be checked.
+func ParseBookingImportCSV(r io.Reader) ([]BookingImportRow, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err != nil {
+		return nil, fmt.Errorf("%w: could not read header: %v", ErrInvalidBookingImport, err)
+	}
+	index := make(map[string]int, len(header))
+	for i, name := range header {
+		index[strings.ToLower(strings.TrimSpace(strings.TrimPrefix(name, "\ufeff")))] = i
+	}
+	for _, name := range bookingImportColumns.required {
+		if _, ok := index[name]; !ok {
+			return nil, fmt.Errorf("%w: missing column %s", ErrInvalidBookingImport, name)
+		}
+	}
+
+	var rows []BookingImportRow
+	for {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrInvalidBookingImport, err)
+		}
+		if len(rows) == MaxBookingImportRows {
+			return nil, fmt.Errorf("%w: at most %d rows can be imported at once", ErrInvalidBookingImport, MaxBookingImportRows)
+		}
+
+		line, _ := reader.FieldPos(0)
+		field := func(name string) string {
+			if i, ok := index[name]; ok && i < len(record) {
+				return strings.TrimSpace(record[i])
+			}
+			return ""
+		}
+		row := BookingImportRow{Line: line}
+		if row.Err = parseBookingImportRow(&row, field); row.Err != nil {
+			row.Err = fmt.Errorf("%w: %w", ErrInvalidImportRow, row.Err)
+		}
+		rows = append(rows, row)
+	}
+
+	if len(rows) == 0 {
+		return nil, fmt.Errorf("%w: no rows to import", ErrInvalidBookingImport)
+	}
+	return rows, nil
+}
+
+func parseBookingImportRow(row *BookingImportRow, field func(string) string) error {
+	for _, name := range bookingImportColumns.required {
+		if field(name) == "" {
+			return fmt.Errorf("%s is required", name)
+		}
+	}
+
+	base := &row.Request.Base
+	base.CustID = field("customerid")
+	base.Address.AddressHuman = field("address")
+	base.Address.Unit = field("unit")
+
+	var err error
+	if base.Address.AddressLat, err = strconv.ParseFloat(field("lat"), 64); err != nil {
+		return fmt.Errorf("lat must be a number")
+	}
+	if base.Address.AddressLng, err = strconv.ParseFloat(field("lng"), 64); err != nil {
+		return fmt.Errorf("lng must be a number")
+	}
+	if raw := field("dirtyscale"); raw != "" {
+		scale, err := strconv.ParseInt(raw, 10, 32)
+		if err != nil {
+			return fmt.Errorf("dirtyScale must be a whole number")
+		}
+		base.DirtyScale = int32(scale)
+	}
+
+	if row.Request.MainService, err = serviceRequestFromRow(field("servicetype"), []byte(field("servicedetails"))); err != nil {
+		return err
+	}
+	row.Request.Addons = []types.AddOnRequest{}
+	if raw := field("addons"); raw != "" {
+		var addons []struct {
+			ServiceType string          `json:"serviceType"`
+			Details     json.RawMessage `json:"details"`
+		}
+		if err := json.Unmarshal([]byte(raw), &addons); err != nil {
+			return fmt.Errorf("addons must be a JSON array of {serviceType, details}: %v", err)
+		}
+		for i, addon := range addons {
+			svc, err := serviceRequestFromRow(addon.ServiceType, addon.Details)
+			if err != nil {
+				return fmt.Errorf("addon %d: %w", i+1, err)
+			}
+			row.Request.Addons = append(row.Request.Addons, types.AddOnRequest{ServiceDetail: svc})
+		}
+	}
+
+	if row.WindowStart, err = time.Parse(time.RFC3339, field("windowstart")); err != nil {
+		return fmt.Errorf("windowStart must be an RFC3339 time")
+	}
+	if row.WindowEnd, err = time.Parse(time.RFC3339, field("windowend")); err != nil {
+		return fmt.Errorf("windowEnd must be an RFC3339 time")
+	}
+	switch {
+	case !row.WindowEnd.After(row.WindowStart):
+		return fmt.Errorf("windowEnd must be after windowStart")
+	case row.WindowStart.Before(time.Now()):
+		return fmt.Errorf("windowStart is in the past")
+	case row.WindowEnd.Sub(row.WindowStart) > MaxAvailabilityRangeDays*24*time.Hour:
+		return fmt.Errorf("window cannot exceed %d days", MaxAvailabilityRangeDays)
+	}
+
+	row.PaymentMethod = field("paymentmethod")
+	return nil
+}
+
+// FetchImportCustomer fills in the customer's name and first phone number for an
+// imported booking.
+func (t *BookingTasks) FetchImportCustomer(ctx context.Context, tx pgx.Tx, base *types.BaseBookingDetailsRequest) error {
+	err := tx.QueryRow(ctx, `
+		SELECT a.first_name, a.last_name, COALESCE(a.phone_numbers[1], '')
+		FROM account.customers c
+		JOIN account.accounts a ON a.id = c.account_id
+		WHERE c.id::text = $1
+	`, base.CustID).Scan(&base.CustomerFirstName, &base.CustomerLastName, &base.CustomerPhoneNo)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return fmt.Errorf("%w: %s", ErrImportCustomerMissing, base.CustID)
+		}
+		return fmt.Errorf("failed to fetch customer %s: %w", base.CustID, err)
+	}
+	return nil
+}
+
+// SlotInWindow reports whether the whole slot, every day of a multi-day job included,
+// falls inside the preferred window.
+func SlotInWindow(slot types.AvailableSlot, start, end time.Time) bool {
+	return !slot.StartSched.Before(start) && !slot.EndSched.After(end)
+}
+
+// BookingImportClaims remembers the schedules earlier rows of an import were given, so
+// later rows for the same customer or address are not planned on top of them.
+type BookingImportClaims struct {
+	claims []importClaim
+}
+
+type importClaim struct {
+	custID  string
+	address types.Address
+	windows []BookingScheduleWindow
+}
+
+func (c *BookingImportClaims) Add(custID string, address types.Address, windows []BookingScheduleWindow) {
+	c.claims = append(c.claims, importClaim{custID: custID, address: address, windows: windows})
+}
+
+// Overlaps applies the same rule as FindOverlappingBookings: same unit, and either the
+// same customer or the same coordinates.
+func (c *BookingImportClaims) Overlaps(custID string, address types.Address, windows []BookingScheduleWindow) bool {
+	for _, claim := range c.claims {
+		if claim.address.Unit != address.Unit {
+			continue
+		}
+		if claim.custID != custID && !sameCoordinates(claim.address, address) {
+			continue
+		}
+		for _, a := range claim.windows {
+			for _, b := range windows {
+				if a.StartSched.Before(b.EndSched) && a.EndSched.After(b.StartSched) {
+					return true
+				}
+			}
+		}
+	}
+	return false
+}
+
+func sameCoordinates(a, b types.Address) bool {
+	return hasCoordinates(a) && hasCoordinates(b) && coordinateKey(a) == coordinateKey(b)
+}
+
+// coordinateKey rounds coordinates to about a metre.
+func coordinateKey(a types.Address) string {
+	return fmt.Sprintf("%.5f,%.5f", a.AddressLat, a.AddressLng)
+}
+
+// addressKey is coordinateKey within the address's unit.
+func addressKey(a types.Address) string {
+	return coordinateKey(a) + ":unit:" + a.Unit
+}
+
+// BookingImportJobRow is what an import keeps of a row: the request ready to book in the
+// slot the dry run chose, left out for invalid rows, and the row's report.
+type BookingImportJobRow struct {
+	Request       *types.CreateBookingRequest  `json:"request,omitempty"`
+	PaymentMethod string                       `json:"paymentMethod,omitempty"`
+	Result        types.BookingImportRowResult `json:"result"`
+}
+
+// BookingImportJob is a validated import file and the progress of creating its bookings.
+type BookingImportJob struct {
+	ID        string
+	CreatedBy string
+	Status    types.BookingImportJobStatus
+	Rows      []BookingImportJobRow
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// Report counts the rows of the job by status.
+func (j *BookingImportJob) Report() *types.BookingImportResponse {
+	res := &types.BookingImportResponse{
+		JobID:     j.ID,
+		Status:    j.Status,
+		Total:     len(j.Rows),
+		Rows:      make([]types.BookingImportRowResult, 0, len(j.Rows)),
+		CreatedAt: j.CreatedAt,
+		UpdatedAt: j.UpdatedAt,
+	}
+	for _, row := range j.Rows {
+		switch row.Result.Status {
+		case types.BookingImportValid:
+			res.Valid++
+		case types.BookingImportInvalid:
+			res.Invalid++
+		case types.BookingImportCreating:
+			res.Creating++
+		case types.BookingImportCreated:
+			res.Created++
+		case types.BookingImportFailed:
+			res.Failed++
+		}
+		res.Rows = append(res.Rows, row.Result)
+	}
+	return res
+}
+
+const bookingImportJobColumns = `
+	id::text,
+	created_by,
+	status,
+	rows,
+	created_at,
+	updated_at`
+
+func scanBookingImportJob(row pgx.Row) (*BookingImportJob, error) {
+	var (
+		job    BookingImportJob
+		status string
+		rows   []byte
+	)
+	if err := row.Scan(&job.ID, &job.CreatedBy, &status, &rows, &job.CreatedAt, &job.UpdatedAt); err != nil {
+		return nil, err
+	}
+	job.Status = types.BookingImportJobStatus(status)
+	if err := json.Unmarshal(rows, &job.Rows); err != nil {
+		return nil, fmt.Errorf("unmarshal booking import rows: %w", err)
+	}
+	return &job, nil
+}
+
+// InsertBookingImportJob stores a dry run so it can be confirmed later.
+func (t *BookingTasks) InsertBookingImportJob(
+	ctx context.Context,
+	tx pgx.Tx,
+	createdBy string,
+	rows []BookingImportJobRow,
+) (*BookingImportJob, error) {
+	rowsJSON, err := json.Marshal(rows)
+	if err != nil {
+		return nil, fmt.Errorf("marshal booking import rows: %w", err)
+	}
+
+	job, err := scanBookingImportJob(tx.QueryRow(ctx, `
+		INSERT INTO booking.booking_imports (created_by, status, rows, created_at, updated_at)
+		VALUES ($1, $2, $3, NOW(), NOW())
+		RETURNING `+bookingImportJobColumns,
+		createdBy,
+		string(types.BookingImportJobValidated),
+		rowsJSON,
+	))
+	if err != nil {
+		return nil, fmt.Errorf("failed to insert booking import: %w", err)
+	}
+	return job, nil
+}
+
+func (t *BookingTasks) FetchBookingImportJob(ctx context.Context, tx pgx.Tx, jobID string) (*BookingImportJob, error) {
+	job, err := scanBookingImportJob(tx.QueryRow(ctx, `
+		SELECT `+bookingImportJobColumns+`
+		FROM booking.booking_imports
+		WHERE id::text = $1
+	`, jobID))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrBookingImportNotFound
+		}
+		return nil, fmt.Errorf("failed to fetch booking import: %w", err)
+	}
+	return job, nil
+}
+
+// ClaimBookingImportJob marks the import RUNNING for the caller. Only a validated import,
+// or a running one that has not saved progress for BookingImportStaleAfter because its
+// worker died, can be claimed, so two confirms never create the same bookings.
+func (t *BookingTasks) ClaimBookingImportJob(ctx context.Context, tx pgx.Tx, jobID string) (*BookingImportJob, error) {
+	job, err := scanBookingImportJob(tx.QueryRow(ctx, `
+		UPDATE booking.booking_imports
+		SET status = $2,
+		    updated_at = NOW()
+		WHERE id::text = $1
+		  AND (
+			status = $3
+			OR (status = $2 AND updated_at < NOW() - make_interval(secs => $4))
+		  )
+		RETURNING `+bookingImportJobColumns,
+		jobID,
+		string(types.BookingImportJobRunning),
+		string(types.BookingImportJobValidated),
+		BookingImportStaleAfter.Seconds(),
+	))
+	if err == nil {
+		return job, nil
+	}
+	if !errors.Is(err, pgx.ErrNoRows) {
+		return nil, fmt.Errorf("failed to claim booking import: %w", err)
+	}
+	if _, err := t.FetchBookingImportJob(ctx, tx, jobID); err != nil {
+		return nil, err
+	}
+	return nil, ErrBookingImportStarted
+}
+
+// SaveBookingImportJob stores the status and rows of a running import. Saving also tells
+// ClaimBookingImportJob that the worker is still alive. It fails with
+// ErrBookingImportStarted when the import was saved by someone else since job was read,
+// which means another worker took it over and this one must stop.
+func (t *BookingTasks) SaveBookingImportJob(ctx context.Context, tx pgx.Tx, job *BookingImportJob) error {
+	rowsJSON, err := json.Marshal(job.Rows)
+	if err != nil {
+		return fmt.Errorf("marshal booking import rows: %w", err)
+	}
+	err = tx.QueryRow(ctx, `
+		UPDATE booking.booking_imports
+		SET status = $3,
+		    rows = $4,
+		    updated_at = NOW()
+		WHERE id::text = $1
+		  AND updated_at = $2
+		RETURNING updated_at
+	`, job.ID, job.UpdatedAt, string(job.Status), rowsJSON).Scan(&job.UpdatedAt)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return ErrBookingImportStarted
+		}
+		return fmt.Errorf("failed to save booking import: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,283 @@
+package tasks
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// Booking status values stored in basebookings.status. LATE is a scheduled booking whose
+// session has not started well past startSched and can still be started; NO_SHOW is one
+// the customer was not there for.
+const (
+	BookingStatusNotStarted = "NOT_STARTED"
+	BookingStatusOngoing    = "ONGOING"
+	BookingStatusCompleted  = "COMPLETED"
+	BookingStatusCancelled  = "CANCELLED"
+	BookingStatusLate       = "LATE"
+	BookingStatusNoShow     = "NO_SHOW"
+)
+
+// Review status values stored in basebookings.reviewstatus.
+const (
+	ReviewStatusPending   = "PENDING"
+	ReviewStatusRequested = "REQUESTED"
+	ReviewStatusScheduled = "SCHEDULED"
+	ReviewStatusRejected  = "REJECTED"
+	ReviewStatusCancelled = "CANCELLED"
+)
+
+// SystemActor is recorded for transitions made by background jobs rather than a user.
+const SystemActor = "system"
+
+// BookingEvent is what moves a booking between states. MARK_LATE and CLEANER_NO_SHOW come
+// from the no-show monitor; CUSTOMER_NO_SHOW is reported by a cleaner at the address.
+type BookingEvent string
+
+const (
+	BookingEventCreate         BookingEvent = "CREATE"
+	BookingEventApprove        BookingEvent = "APPROVE"
+	BookingEventReschedule     BookingEvent = "RESCHEDULE"
+	BookingEventRequeue        BookingEvent = "REQUEUE"
+	BookingEventStart          BookingEvent = "START"
+	BookingEventComplete       BookingEvent = "COMPLETE"
+	BookingEventCancel         BookingEvent = "CANCEL"
+	BookingEventMarkLate       BookingEvent = "MARK_LATE"
+	BookingEventCleanerNoShow  BookingEvent = "CLEANER_NO_SHOW"
+	BookingEventCustomerNoShow BookingEvent = "CUSTOMER_NO_SHOW"
+)
+
+var (
+	ErrInvalidTransition   = errors.New("invalid booking state transition")
+	ErrSessionNotStartable = errors.New("booking cannot be started in its current state")
+	ErrSessionNotStarted   = errors.New("booking session has not been started")
+	ErrNoShowNotReportable = errors.New("booking cannot be reported as a no-show in its current state")
+)
+
+// BookingState is the pair of columns that together describe where a booking is in its lifecycle.
+type BookingState struct {
+	Status       string
+	ReviewStatus string
+}
+
+func (s BookingState) String() string {
+	return s.Status + "/" + s.ReviewStatus
+}
+
+// InvalidTransitionError is returned when an event is not allowed from the booking's current state.
+// It matches ErrInvalidTransition and, where one exists, the event's own sentinel
+// (e.g. ErrBookingNotCancellable for CANCEL) with errors.Is.
+type InvalidTransitionError struct {
+	BookingID string
+	Event     BookingEvent
+	From      BookingState
+}
+
+func (e *InvalidTransitionError) Error() string {
+	return fmt.Sprintf("booking %s cannot %s from %s", e.BookingID, strings.ToLower(string(e.Event)), e.From)
+}
+
+func (e *InvalidTransitionError) Is(target error) bool {
+	if target == ErrInvalidTransition {
+		return true
+	}
+	sentinel, ok := bookingEventErrors[e.Event]
+	return ok && target == sentinel
+}
+
+var bookingEventErrors = map[BookingEvent]error{
+	BookingEventReschedule:     ErrBookingNotReschedulable,
+	BookingEventCancel:         ErrBookingNotCancellable,
+	BookingEventStart:          ErrSessionNotStartable,
+	BookingEventComplete:       ErrSessionNotStarted,
+	BookingEventCustomerNoShow: ErrNoShowNotReportable,
+}
+
+// bookingTransition lists the states an event may be applied from and what it changes.
+// An empty Next field leaves that column as it is.
+type bookingTransition struct {
+	FromStatus []string
+	FromReview []string
+	NextStatus string
+	NextReview string
+}
+
+var openReviewStatuses = []string{ReviewStatusPending, ReviewStatusRequested, ReviewStatusScheduled}
+
+var bookingTransitions = map[BookingEvent]bookingTransition{
+	BookingEventApprove: {
+		FromStatus: []string{BookingStatusNotStarted},
+		FromReview: []string{ReviewStatusPending, ReviewStatusRequested},
+		NextReview: ReviewStatusScheduled,
+	},
+	BookingEventReschedule: {
+		FromStatus: []string{BookingStatusNotStarted},
+		FromReview: openReviewStatuses,
+	},
+	BookingEventRequeue: {
+		FromStatus: []string{BookingStatusNotStarted},
+		FromReview: []string{ReviewStatusScheduled},
+		NextReview: ReviewStatusPending,
+	},
+	BookingEventStart: {
+		FromStatus: []string{BookingStatusNotStarted, BookingStatusLate},
+		FromReview: []string{ReviewStatusScheduled},
+		NextStatus: BookingStatusOngoing,
+	},
+	BookingEventComplete: {
+		FromStatus: []string{BookingStatusOngoing},
+		NextStatus: BookingStatusCompleted,
+	},
+	BookingEventCancel: {
+		FromStatus: []string{BookingStatusNotStarted, BookingStatusLate},
+		FromReview: openReviewStatuses,
+		NextStatus: BookingStatusCancelled,
+		NextReview: ReviewStatusCancelled,
+	},
+	BookingEventMarkLate: {
+		FromStatus: []string{BookingStatusNotStarted},
+		FromReview: []string{ReviewStatusScheduled},
+		NextStatus: BookingStatusLate,
+	},
+	// A cleaner no-show sends the booking back for approval so it can be rescheduled
+	// with a new crew.
+	BookingEventCleanerNoShow: {
+		FromStatus: []string{BookingStatusLate},
+		FromReview: []string{ReviewStatusScheduled},
+		NextStatus: BookingStatusNotStarted,
+		NextReview: ReviewStatusPending,
+	},
+	BookingEventCustomerNoShow: {
+		FromStatus: []string{BookingStatusNotStarted, BookingStatusLate},
+		FromReview: []string{ReviewStatusScheduled},
+		NextStatus: BookingStatusNoShow,
+		NextReview: ReviewStatusCancelled,
+	},
+}
+
+// NextBookingState applies event to from using the transition table without touching the database.
+func NextBookingState(bookingID string, from BookingState, event BookingEvent) (BookingState, error) {
+	from = BookingState{Status: strings.ToUpper(from.Status), ReviewStatus: strings.ToUpper(from.ReviewStatus)}
+
+	transition, ok := bookingTransitions[event]
+	if !ok || !allowsState(transition.FromStatus, from.Status) || !allowsState(transition.FromReview, from.ReviewStatus) {
+		return from, &InvalidTransitionError{BookingID: bookingID, Event: event, From: from}
+	}
+
+	next := from
+	if transition.NextStatus != "" {
+		next.Status = transition.NextStatus
+	}
+	if transition.NextReview != "" {
+		next.ReviewStatus = transition.NextReview
+	}
+	return next, nil
+}
+
+func allowsState(allowed []string, state string) bool {
+	if len(allowed) == 0 {
+		return true
+	}
+	for _, s := range allowed {
+		if s == state {
+			return true
+		}
+	}
+	return false
+}
+
+func (t *BookingTasks) TransitionBooking(ctx context.Context, tx pgx.Tx, bookingID string, event BookingEvent, actor, note string) (*BookingState, error) {
+	return transitionBooking(ctx, tx, bookingID, event, actor, note)
+}
+
+func (t *AdminTasks) TransitionBooking(ctx context.Context, tx pgx.Tx, bookingID string, event BookingEvent, actor, note string) (*BookingState, error) {
+	return transitionBooking(ctx, tx, bookingID, event, actor, note)
+}
+
+// transitionBooking is the only place booking status and review status change after creation.
+// It locks the booking, checks the transition table, applies the change and records it in
+// booking.booking_status_history.
+func transitionBooking(ctx context.Context, tx pgx.Tx, bookingID string, event BookingEvent, actor, note string) (*BookingState, error) {
+	var (
+		baseBookingID string
+		from          BookingState
+	)
+	err := tx.QueryRow(ctx, `
+		SELECT bb.id::text, COALESCE(bb.status, ''), COALESCE(bb.reviewstatus, '')
+		FROM booking.bookings b
+		JOIN booking.basebookings bb ON bb.id = b.base_booking_id
+		WHERE b.id = $1
+		FOR UPDATE OF bb
+	`, bookingID).Scan(&baseBookingID, &from.Status, &from.ReviewStatus)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrBookingNotFound
+		}
+		return nil, fmt.Errorf("failed to load booking state: %w", err)
+	}
+
+	next, err := NextBookingState(bookingID, from, event)
+	if err != nil {
+		return nil, err
+	}
+
+	if next != from {
+		if _, err := tx.Exec(ctx, `
+			UPDATE booking.basebookings
+			SET status = $2,
+			    reviewstatus = $3,
+			    updatedat = NOW()
+			WHERE id = $1
+		`, baseBookingID, next.Status, next.ReviewStatus); err != nil {
+			return nil, fmt.Errorf("failed to update booking state: %w", err)
+		}
+	}
+
+	if err := recordBookingTransition(ctx, tx, bookingID, event, from, next, actor, note); err != nil {
+		return nil, err
+	}
+
+	return &next, nil
+}
+
+// RecordBookingCreated writes the first history entry for a newly inserted booking.
+func (t *BookingTasks) RecordBookingCreated(ctx context.Context, tx pgx.Tx, bookingID, actor string) error {
+	return recordBookingTransition(ctx, tx, bookingID, BookingEventCreate,
+		BookingState{},
+		BookingState{Status: BookingStatusNotStarted, ReviewStatus: ReviewStatusPending},
+		actor, "")
+}
+
+func recordBookingTransition(
+	ctx context.Context,
+	tx pgx.Tx,
+	bookingID string,
+	event BookingEvent,
+	from, to BookingState,
+	actor, note string,
+) error {
+	if actor == "" {
+		actor = SystemActor
+	}
+	_, err := tx.Exec(ctx, `
+		INSERT INTO booking.booking_status_history (
+			booking_id,
+			event,
+			from_status,
+			to_status,
+			from_review_status,
+			to_review_status,
+			actor,
+			note,
+			created_at
+		)
+		VALUES ($1, $2, NULLIF($3, ''), $4, NULLIF($5, ''), $6, $7, NULLIF($8, ''), NOW())
+	`, bookingID, string(event), from.Status, to.Status, from.ReviewStatus, to.ReviewStatus, actor, note)
+	if err != nil {
+		return fmt.Errorf("failed to record booking transition: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,186 @@
+package tasks
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestNextBookingState(t *testing.T) {
+	state := func(status, review string) BookingState {
+		return BookingState{Status: status, ReviewStatus: review}
+	}
+
+	tests := []struct {
+		name    string
+		from    BookingState
+		event   BookingEvent
+		want    BookingState
+		wantErr error
+	}{
+		{
+			name:  "approve a pending booking",
+			from:  state(BookingStatusNotStarted, ReviewStatusPending),
+			event: BookingEventApprove,
+			want:  state(BookingStatusNotStarted, ReviewStatusScheduled),
+		},
+		{
+			name:  "approve a requested booking",
+			from:  state(BookingStatusNotStarted, ReviewStatusRequested),
+			event: BookingEventApprove,
+			want:  state(BookingStatusNotStarted, ReviewStatusScheduled),
+		},
+		{
+			name:    "approve an already scheduled booking",
+			from:    state(BookingStatusNotStarted, ReviewStatusScheduled),
+			event:   BookingEventApprove,
+			wantErr: ErrInvalidTransition,
+		},
+		{
+			name:  "reschedule keeps the state",
+			from:  state(BookingStatusNotStarted, ReviewStatusScheduled),
+			event: BookingEventReschedule,
+			want:  state(BookingStatusNotStarted, ReviewStatusScheduled),
+		},
+		{
+			name:    "reschedule an ongoing booking",
+			from:    state(BookingStatusOngoing, ReviewStatusScheduled),
+			event:   BookingEventReschedule,
+			wantErr: ErrBookingNotReschedulable,
+		},
+		{
+			name:    "reschedule a rejected booking",
+			from:    state(BookingStatusNotStarted, ReviewStatusRejected),
+			event:   BookingEventReschedule,
+			wantErr: ErrBookingNotReschedulable,
+		},
+		{
+			name:  "requeue a scheduled booking",
+			from:  state(BookingStatusNotStarted, ReviewStatusScheduled),
+			event: BookingEventRequeue,
+			want:  state(BookingStatusNotStarted, ReviewStatusPending),
+		},
+		{
+			name:  "start a scheduled booking",
+			from:  state(BookingStatusNotStarted, ReviewStatusScheduled),
+			event: BookingEventStart,
+			want:  state(BookingStatusOngoing, ReviewStatusScheduled),
+		},
+		{
+			name:  "start a late booking",
+			from:  state(BookingStatusLate, ReviewStatusScheduled),
+			event: BookingEventStart,
+			want:  state(BookingStatusOngoing, ReviewStatusScheduled),
+		},
+		{
+			name:    "start an unapproved booking",
+			from:    state(BookingStatusNotStarted, ReviewStatusPending),
+			event:   BookingEventStart,
+			wantErr: ErrSessionNotStartable,
+		},
+		{
+			name:  "complete an ongoing booking",
+			from:  state(BookingStatusOngoing, ReviewStatusScheduled),
+			event: BookingEventComplete,
+			want:  state(BookingStatusCompleted, ReviewStatusScheduled),
+		},
+		{
+			name:    "complete a booking that never started",
+			from:    state(BookingStatusNotStarted, ReviewStatusScheduled),
+			event:   BookingEventComplete,
+			wantErr: ErrSessionNotStarted,
+		},
+		{
+			name:  "cancel a pending booking",
+			from:  state(BookingStatusNotStarted, ReviewStatusPending),
+			event: BookingEventCancel,
+			want:  state(BookingStatusCancelled, ReviewStatusCancelled),
+		},
+		{
+			name:  "cancel a late booking",
+			from:  state(BookingStatusLate, ReviewStatusScheduled),
+			event: BookingEventCancel,
+			want:  state(BookingStatusCancelled, ReviewStatusCancelled),
+		},
+		{
+			name:    "cancel a completed booking",
+			from:    state(BookingStatusCompleted, ReviewStatusScheduled),
+			event:   BookingEventCancel,
+			wantErr: ErrBookingNotCancellable,
+		},
+		{
+			name:    "cancel a cancelled booking",
+			from:    state(BookingStatusCancelled, ReviewStatusCancelled),
+			event:   BookingEventCancel,
+			wantErr: ErrBookingNotCancellable,
+		},
+		{
+			name:  "mark a scheduled booking late",
+			from:  state(BookingStatusNotStarted, ReviewStatusScheduled),
+			event: BookingEventMarkLate,
+			want:  state(BookingStatusLate, ReviewStatusScheduled),
+		},
+		{
+			name:    "mark an unapproved booking late",
+			from:    state(BookingStatusNotStarted, ReviewStatusPending),
+			event:   BookingEventMarkLate,
+			wantErr: ErrInvalidTransition,
+		},
+		{
+			name:  "cleaner no-show goes back for approval",
+			from:  state(BookingStatusLate, ReviewStatusScheduled),
+			event: BookingEventCleanerNoShow,
+			want:  state(BookingStatusNotStarted, ReviewStatusPending),
+		},
+		{
+			name:    "cleaner no-show before the booking is late",
+			from:    state(BookingStatusNotStarted, ReviewStatusScheduled),
+			event:   BookingEventCleanerNoShow,
+			wantErr: ErrInvalidTransition,
+		},
+		{
+			name:  "customer no-show",
+			from:  state(BookingStatusLate, ReviewStatusScheduled),
+			event: BookingEventCustomerNoShow,
+			want:  state(BookingStatusNoShow, ReviewStatusCancelled),
+		},
+		{
+			name:    "customer no-show on an ongoing booking",
+			from:    state(BookingStatusOngoing, ReviewStatusScheduled),
+			event:   BookingEventCustomerNoShow,
+			wantErr: ErrNoShowNotReportable,
+		},
+		{
+			name:  "stored states are matched case-insensitively",
+			from:  state("not_started", "pending"),
+			event: BookingEventApprove,
+			want:  state(BookingStatusNotStarted, ReviewStatusScheduled),
+		},
+		{
+			name:    "create is not a transition",
+			from:    state(BookingStatusNotStarted, ReviewStatusPending),
+			event:   BookingEventCreate,
+			wantErr: ErrInvalidTransition,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NextBookingState("booking-1", tt.from, tt.event)
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("error = %v, want %v", err, tt.wantErr)
+				}
+				if !errors.Is(err, ErrInvalidTransition) {
+					t.Errorf("error %v does not match ErrInvalidTransition", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("next state = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,96 @@
+package tasks
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"handworks-api/types"
+	"sort"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+)
+
+var ErrOverlappingBooking = errors.New("an overlapping booking already exists for this customer or address")
+
+// OverlappingBooking is a live booking whose schedule overlaps a requested one.
+type OverlappingBooking struct {
+	BookingID  string
+	OrderID    string
+	StartSched time.Time
+}
+
+// LockBookingSlot serializes booking creation for a customer and an address, each within
+// the address's unit, until the transaction ends, so double-submitted orders cannot both
+// pass the overlap check. Keys are taken in a fixed order to avoid deadlocks between the two.
+func (t *BookingTasks) LockBookingSlot(ctx context.Context, tx pgx.Tx, custID string, address types.Address) error {
+	keys := []string{"booking:customer:" + custID + ":unit:" + address.Unit}
+	if hasCoordinates(address) {
+		keys = append(keys, "booking:address:"+addressKey(address))
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		if _, err := tx.Exec(ctx, `SELECT pg_advisory_xact_lock(hashtext($1))`, key); err != nil {
+			return fmt.Errorf("failed to lock booking slot: %w", err)
+		}
+	}
+	return nil
+}
+
+// FindOverlappingBookings lists bookings that are not cancelled or rejected and overlap
+// any of the windows in the same unit, either for the same customer or at the same
+// coordinates, rounded to about a metre. Bookings without a unit only clash with each
+// other, so one customer can book several units of a building at once. The earliest
+// booking comes first.
+func (t *BookingTasks) FindOverlappingBookings(
+	ctx context.Context,
+	tx pgx.Tx,
+	custID string,
+	address types.Address,
+	windows []BookingScheduleWindow,
+) ([]OverlappingBooking, error) {
+	starts := make([]time.Time, 0, len(windows))
+	ends := make([]time.Time, 0, len(windows))
+	for _, w := range windows {
+		starts = append(starts, w.StartSched)
+		ends = append(ends, w.EndSched)
+	}
+
+	rows, err := tx.Query(ctx, `
+		SELECT DISTINCT b.id::text, COALESCE(bb.orderid::text, ''), bb.startsched
+		FROM booking.bookings b
+		JOIN booking.basebookings bb ON bb.id = b.base_booking_id
+		JOIN unnest($1::timestamptz[], $2::timestamptz[]) AS w(startsched, endsched)
+		  ON bb.startsched < w.endsched AND bb.endsched > w.startsched
+		WHERE UPPER(COALESCE(bb.reviewstatus, '')) NOT IN ('CANCELLED', 'REJECTED')
+		  AND UPPER(COALESCE(bb.status, '')) NOT IN ('CANCELLED', 'NO_SHOW')
+		  AND COALESCE(bb.address->>'unit', '') = $7
+		  AND (
+			bb.custid::text = $3
+			OR (
+				$4
+				AND round((bb.address->>'addressLat')::numeric, 5) = round($5::numeric, 5)
+				AND round((bb.address->>'addressLng')::numeric, 5) = round($6::numeric, 5)
+			)
+		  )
+		ORDER BY bb.startsched
+	`, starts, ends, custID, hasCoordinates(address), address.AddressLat, address.AddressLng, address.Unit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check overlapping bookings: %w", err)
+	}
+	defer rows.Close()
+
+	var overlaps []OverlappingBooking
+	for rows.Next() {
+		var o OverlappingBooking
+		if err := rows.Scan(&o.BookingID, &o.OrderID, &o.StartSched); err != nil {
+			return nil, fmt.Errorf("failed to scan overlapping booking: %w", err)
+		}
+		overlaps = append(overlaps, o)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed iterating overlapping bookings: %w", err)
+	}
+	return overlaps, nil
+}
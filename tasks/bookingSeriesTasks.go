@@ -0,0 +1,469 @@
+package tasks
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"handworks-api/types"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// SeriesGenerationHorizonDays is how far ahead series occurrences are turned into bookings.
+const SeriesGenerationHorizonDays = 28
+
+// maxSeriesLookahead bounds how many occurrences are walked when resolving a date.
+const maxSeriesLookahead = 520
+
+var (
+	ErrSeriesNotFound         = errors.New("booking series not found")
+	ErrSeriesNotActive        = errors.New("booking series is no longer active")
+	ErrInvalidRecurrence      = errors.New("invalid recurrence rule")
+	ErrOccurrenceNotInSeries  = errors.New("date is not an occurrence of this series")
+	ErrOccurrenceNotSkippable = errors.New("occurrence can no longer be skipped")
+	ErrSeriesDenied           = errors.New("only admins and the series' customer can change it")
+)
+
+func (t *BookingTasks) ValidateRecurrence(req *types.CreateBookingSeriesRequest) error {
+	switch req.Frequency {
+	case types.RecurrenceWeekly, types.RecurrenceBiWeekly, types.RecurrenceMonthly:
+	default:
+		return fmt.Errorf("%w: frequency must be WEEKLY, BIWEEKLY or MONTHLY", ErrInvalidRecurrence)
+	}
+	if req.MaxOccurrences < 0 {
+		return fmt.Errorf("%w: maxOccurrences cannot be negative", ErrInvalidRecurrence)
+	}
+	if req.EndDate != nil && req.EndDate.Before(req.Booking.Base.StartSched) {
+		return fmt.Errorf("%w: endDate is before the first occurrence", ErrInvalidRecurrence)
+	}
+	return nil
+}
+
+// SeriesOccurrenceWindow returns the schedule of the index-th occurrence. Monthly
+// series keep the anchor's day of month, clamped to the last day of shorter months.
+func (t *BookingTasks) SeriesOccurrenceWindow(series *types.BookingSeries, index int) (time.Time, time.Time) {
+	loc := t.location()
+	anchor := series.AnchorStart.In(loc)
+	duration := series.AnchorEnd.Sub(series.AnchorStart)
+
+	var start time.Time
+	switch series.Frequency {
+	case types.RecurrenceBiWeekly:
+		start = anchor.AddDate(0, 0, 14*index)
+	case types.RecurrenceMonthly:
+		firstOfMonth := time.Date(anchor.Year(), anchor.Month()+time.Month(index), 1,
+			anchor.Hour(), anchor.Minute(), anchor.Second(), 0, loc)
+		lastDay := firstOfMonth.AddDate(0, 1, -1).Day()
+		day := anchor.Day()
+		if day > lastDay {
+			day = lastDay
+		}
+		start = firstOfMonth.AddDate(0, 0, day-1)
+	default:
+		start = anchor.AddDate(0, 0, 7*index)
+	}
+
+	return start, start.Add(duration)
+}
+
+// seriesAllowsIndex reports whether the index-th occurrence is inside the series' end rules.
+func (t *BookingTasks) seriesAllowsIndex(series *types.BookingSeries, index int, start time.Time) bool {
+	if series.MaxOccurrences > 0 && index >= int(series.MaxOccurrences) {
+		return false
+	}
+	if series.EndDate != nil && start.After(*series.EndDate) {
+		return false
+	}
+	return true
+}
+
+// ResolveSeriesOccurrenceIndex maps a calendar date (YYYY-MM-DD) to the occurrence on that day.
+func (t *BookingTasks) ResolveSeriesOccurrenceIndex(series *types.BookingSeries, date string) (int, error) {
+	loc := t.location()
+	target, err := time.ParseInLocation("2006-01-02", date, loc)
+	if err != nil {
+		return 0, fmt.Errorf("%w: occurrenceDate must be YYYY-MM-DD", ErrOccurrenceNotInSeries)
+	}
+
+	for i := 0; i < maxSeriesLookahead; i++ {
+		start, _ := t.SeriesOccurrenceWindow(series, i)
+		if !t.seriesAllowsIndex(series, i, start) {
+			break
+		}
+		day := time.Date(start.Year(), start.Month(), start.Day(), 0, 0, 0, 0, loc)
+		if day.Equal(target) {
+			return i, nil
+		}
+		if day.After(target) {
+			break
+		}
+	}
+
+	return 0, ErrOccurrenceNotInSeries
+}
+
+func (t *BookingTasks) InsertBookingSeries(
+	ctx context.Context,
+	tx pgx.Tx,
+	req *types.CreateBookingSeriesRequest,
+	order *types.Order,
+) (*types.BookingSeries, error) {
+	template := withoutAccessInstructions(req.Booking)
+	template.Base.OrderId = ""
+	templateJSON, err := json.Marshal(template)
+	if err != nil {
+		return nil, fmt.Errorf("marshal series template: %w", err)
+	}
+
+	series := types.BookingSeries{
+		CustomerID:     order.CustomerID,
+		QuoteID:        order.QuoteID,
+		PaymentMethod:  order.PaymentMethod,
+		Frequency:      req.Frequency,
+		Status:         "ACTIVE",
+		AnchorStart:    req.Booking.Base.StartSched,
+		AnchorEnd:      req.Booking.Base.EndSched,
+		EndDate:        req.EndDate,
+		MaxOccurrences: req.MaxOccurrences,
+		Subtotal:       order.Subtotal,
+		AddonTotal:     order.AddonTotal,
+		TotalAmount:    order.TotalAmount,
+		Template:       template,
+	}
+
+	err = tx.QueryRow(ctx, `
+		INSERT INTO booking.booking_series (
+			customer_id,
+			quote_id,
+			payment_method,
+			frequency,
+			status,
+			anchor_start,
+			anchor_end,
+			end_date,
+			max_occurrences,
+			subtotal,
+			addon_total,
+			total_amount,
+			template,
+			created_at,
+			updated_at
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, NOW(), NOW())
+		RETURNING id::text, created_at
+	`,
+		series.CustomerID,
+		series.QuoteID,
+		series.PaymentMethod,
+		string(series.Frequency),
+		series.Status,
+		series.AnchorStart,
+		series.AnchorEnd,
+		series.EndDate,
+		series.MaxOccurrences,
+		series.Subtotal,
+		series.AddonTotal,
+		series.TotalAmount,
+		templateJSON,
+	).Scan(&series.ID, &series.CreatedAt)
+	if err != nil {
+		return nil, fmt.Errorf("failed to insert booking series: %w", err)
+	}
+
+	return &series, nil
+}
+
+func (t *BookingTasks) FetchBookingSeries(ctx context.Context, tx pgx.Tx, seriesID string) (*types.BookingSeries, error) {
+	var (
+		series    types.BookingSeries
+		frequency string
+		template  []byte
+	)
+	err := tx.QueryRow(ctx, `
+		SELECT
+			id::text,
+			customer_id::text,
+			quote_id::text,
+			COALESCE(payment_method, ''),
+			frequency,
+			status,
+			anchor_start,
+			anchor_end,
+			end_date,
+			COALESCE(max_occurrences, 0),
+			subtotal,
+			addon_total,
+			total_amount,
+			template,
+			created_at,
+			ended_at
+		FROM booking.booking_series
+		WHERE id = $1
+	`, seriesID).Scan(
+		&series.ID,
+		&series.CustomerID,
+		&series.QuoteID,
+		&series.PaymentMethod,
+		&frequency,
+		&series.Status,
+		&series.AnchorStart,
+		&series.AnchorEnd,
+		&series.EndDate,
+		&series.MaxOccurrences,
+		&series.Subtotal,
+		&series.AddonTotal,
+		&series.TotalAmount,
+		&template,
+		&series.CreatedAt,
+		&series.EndedAt,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrSeriesNotFound
+		}
+		return nil, fmt.Errorf("failed to load booking series: %w", err)
+	}
+
+	series.Frequency = types.RecurrenceFrequency(frequency)
+	if err := json.Unmarshal(template, &series.Template); err != nil {
+		return nil, fmt.Errorf("unmarshal series template: %w", err)
+	}
+
+	return &series, nil
+}
+
+func (t *BookingTasks) FetchActiveSeriesIDs(ctx context.Context, tx pgx.Tx) ([]string, error) {
+	rows, err := tx.Query(ctx, `
+		SELECT id::text
+		FROM booking.booking_series
+		WHERE status = 'ACTIVE'
+		ORDER BY created_at ASC
+	`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list active series: %w", err)
+	}
+	defer rows.Close()
+
+	ids := make([]string, 0)
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			return nil, fmt.Errorf("failed to scan series id: %w", err)
+		}
+		ids = append(ids, id)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed iterating series rows: %w", err)
+	}
+	return ids, nil
+}
+
+func (t *BookingTasks) EndBookingSeries(ctx context.Context, tx pgx.Tx, seriesID string) error {
+	result, err := tx.Exec(ctx, `
+		UPDATE booking.booking_series
+		SET status = 'ENDED',
+		    ended_at = NOW(),
+		    updated_at = NOW()
+		WHERE id = $1
+		  AND status = 'ACTIVE'
+	`, seriesID)
+	if err != nil {
+		return fmt.Errorf("failed to end booking series: %w", err)
+	}
+	if result.RowsAffected() == 0 {
+		return ErrSeriesNotActive
+	}
+	return nil
+}
+
+const seriesOccurrenceColumns = `
+	id::text,
+	series_id::text,
+	occurrence_index,
+	start_sched,
+	end_sched,
+	status,
+	booking_id::text,
+	order_id::text,
+	conflict_reason,
+	updated_at`
+
+func scanSeriesOccurrences(rows pgx.Rows) ([]types.SeriesOccurrence, error) {
+	occurrences := make([]types.SeriesOccurrence, 0)
+	for rows.Next() {
+		var (
+			occ    types.SeriesOccurrence
+			status string
+		)
+		if err := rows.Scan(
+			&occ.ID,
+			&occ.SeriesID,
+			&occ.OccurrenceIndex,
+			&occ.StartSched,
+			&occ.EndSched,
+			&status,
+			&occ.BookingID,
+			&occ.OrderID,
+			&occ.ConflictReason,
+			&occ.UpdatedAt,
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan series occurrence: %w", err)
+		}
+		occ.Status = types.OccurrenceStatus(status)
+		occurrences = append(occurrences, occ)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed iterating series occurrences: %w", err)
+	}
+	return occurrences, nil
+}
+
+func (t *BookingTasks) FetchSeriesOccurrences(ctx context.Context, tx pgx.Tx, seriesID string) ([]types.SeriesOccurrence, error) {
+	rows, err := tx.Query(ctx, `
+		SELECT `+seriesOccurrenceColumns+`
+		FROM booking.series_occurrences
+		WHERE series_id = $1
+		ORDER BY occurrence_index ASC
+	`, seriesID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch series occurrences: %w", err)
+	}
+	defer rows.Close()
+
+	return scanSeriesOccurrences(rows)
+}
+
+// FetchOccurrencesToMaterialize returns future occurrences that still need a booking:
+// freshly generated ones and earlier conflicts that may now find free cleaners.
+func (t *BookingTasks) FetchOccurrencesToMaterialize(ctx context.Context, tx pgx.Tx, seriesID string) ([]types.SeriesOccurrence, error) {
+	rows, err := tx.Query(ctx, `
+		SELECT `+seriesOccurrenceColumns+`
+		FROM booking.series_occurrences
+		WHERE series_id = $1
+		  AND status IN ('PENDING', 'CONFLICT')
+		  AND start_sched > NOW()
+		ORDER BY occurrence_index ASC
+	`, seriesID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch pending series occurrences: %w", err)
+	}
+	defer rows.Close()
+
+	return scanSeriesOccurrences(rows)
+}
+
+func (t *BookingTasks) FetchSeriesOccurrenceByIndex(ctx context.Context, tx pgx.Tx, seriesID string, index int) (*types.SeriesOccurrence, error) {
+	rows, err := tx.Query(ctx, `
+		SELECT `+seriesOccurrenceColumns+`
+		FROM booking.series_occurrences
+		WHERE series_id = $1
+		  AND occurrence_index = $2
+		FOR UPDATE
+	`, seriesID, index)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch series occurrence: %w", err)
+	}
+	defer rows.Close()
+
+	occurrences, err := scanSeriesOccurrences(rows)
+	if err != nil {
+		return nil, err
+	}
+	if len(occurrences) == 0 {
+		return nil, nil
+	}
+	return &occurrences[0], nil
+}
+
+// EnsureSeriesOccurrences inserts PENDING rows for every occurrence that falls inside the
+// generation horizon and has not been generated yet.
+func (t *BookingTasks) EnsureSeriesOccurrences(ctx context.Context, tx pgx.Tx, series *types.BookingSeries, horizon time.Time) error {
+	var lastIndex int
+	if err := tx.QueryRow(ctx, `
+		SELECT COALESCE(MAX(occurrence_index), -1)
+		FROM booking.series_occurrences
+		WHERE series_id = $1
+	`, series.ID).Scan(&lastIndex); err != nil {
+		return fmt.Errorf("failed to load last series occurrence: %w", err)
+	}
+
+	for i := lastIndex + 1; i < maxSeriesLookahead; i++ {
+		start, end := t.SeriesOccurrenceWindow(series, i)
+		if start.After(horizon) || !t.seriesAllowsIndex(series, i, start) {
+			break
+		}
+		if err := t.InsertSeriesOccurrence(ctx, tx, series.ID, i, start, end, types.OccurrencePending, ""); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (t *BookingTasks) InsertSeriesOccurrence(
+	ctx context.Context,
+	tx pgx.Tx,
+	seriesID string,
+	index int,
+	startSched, endSched time.Time,
+	status types.OccurrenceStatus,
+	orderID string,
+) error {
+	_, err := tx.Exec(ctx, `
+		INSERT INTO booking.series_occurrences (
+			series_id,
+			occurrence_index,
+			start_sched,
+			end_sched,
+			status,
+			order_id,
+			created_at,
+			updated_at
+		) VALUES ($1, $2, $3, $4, $5, NULLIF($6, '')::uuid, NOW(), NOW())
+		ON CONFLICT (series_id, occurrence_index) DO NOTHING
+	`, seriesID, index, startSched, endSched, string(status), orderID)
+	if err != nil {
+		return fmt.Errorf("failed to insert series occurrence: %w", err)
+	}
+	return nil
+}
+
+func (t *BookingTasks) UpdateSeriesOccurrence(
+	ctx context.Context,
+	tx pgx.Tx,
+	occurrenceID string,
+	status types.OccurrenceStatus,
+	bookingID, orderID, reason string,
+) error {
+	_, err := tx.Exec(ctx, `
+		UPDATE booking.series_occurrences
+		SET status = $2,
+		    booking_id = COALESCE(NULLIF($3, '')::uuid, booking_id),
+		    order_id = COALESCE(NULLIF($4, '')::uuid, order_id),
+		    conflict_reason = NULLIF($5, ''),
+		    updated_at = NOW()
+		WHERE id = $1
+	`, occurrenceID, string(status), bookingID, orderID, reason)
+	if err != nil {
+		return fmt.Errorf("failed to update series occurrence: %w", err)
+	}
+	return nil
+}
+
+// CancelUnusedOrder closes an order that was created for an occurrence that never got a booking.
+func (t *BookingTasks) CancelUnusedOrder(ctx context.Context, tx pgx.Tx, orderID string) error {
+	_, err := tx.Exec(ctx, `
+		UPDATE payment.orders o
+		SET payment_status = 'cancelled',
+		    remaining_balance = 0,
+		    updated_at = NOW()
+		WHERE o.id = $1
+		  AND NOT EXISTS (
+			SELECT 1 FROM booking.basebookings bb WHERE bb.orderid = o.id
+		  )
+	`, orderID)
+	if err != nil {
+		return fmt.Errorf("failed to cancel unused order: %w", err)
+	}
+	return nil
+}
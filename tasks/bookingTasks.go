@@ -8,17 +8,108 @@ import (
 	"handworks-api/types"
 	"handworks-api/utils"
 	"math"
+	"slices"
+	"sort"
 	"time"
 
 	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgconn"
 )
 
-type BookingTasks struct{}
+// BookingTasks runs booking queries. Location is the business time zone schedules and
+// employee calendars are read in.
+type BookingTasks struct {
+	Location *time.Location
+}
+
+func (t *BookingTasks) location() *time.Location {
+	if t.Location == nil {
+		return time.Local
+	}
+	return t.Location
+}
+
+var (
+	ErrInvalidSchedule         = errors.New("invalid schedule window")
+	ErrBookingNotReschedulable = errors.New("booking can no longer be rescheduled")
+	ErrScheduleSlotUnavailable = errors.New("selected schedule overlaps an occupied booking slot")
+	ErrNoAvailableCleaners     = errors.New("no available cleaners found for selected schedule")
+	ErrInsufficientCleaners    = errors.New("insufficient available cleaners for selected schedule")
+	ErrBookingNotCancellable   = errors.New("booking can no longer be cancelled")
+	ErrBookingChangeDenied     = errors.New("only admins and the booking's customer can change it")
+)
+
+// OrderPaymentSummary is what the cancellation policy needs to know about an order.
+type OrderPaymentSummary struct {
+	OrderID             string
+	Currency            string
+	Provider            string
+	TotalAmount         float32
+	DownpaymentRequired float32
+	AmountPaid          float32
+}
+
+// BookingSnapshot is the locked, flattened view of a booking used by flows
+// that mutate an existing booking (reschedule, cancel).
+type BookingSnapshot struct {
+	BookingID         string
+	BaseBookingID     string
+	CustID            string
+	OrderID           string
+	Address           types.Address
+	StartSched        time.Time
+	EndSched          time.Time
+	DirtyScale        int32
+	ExtraHours        float32
+	TotalServiceHours float32
+	Status            string
+	ReviewStatus      string
+	CleanerIDs        []string
+	DayIndex          int32
+}
+
 type PaymentPort interface {
 	FetchOrderAndPrices(ctx context.Context, orderId string) (*types.Order, *types.CleaningPrices, error)
+	CreateOrder(ctx context.Context, req types.CreateOrderRequest) (*types.CreateOrderResponse, error)
+	MakeQuotation(ctx context.Context, req types.QuoteRequest) (*types.QuoteResponse, error)
+	MakePublicQuotation(ctx context.Context, req types.QuoteRequest) (*types.QuoteResponse, error)
+}
+
+// TravelEstimator estimates how long a cleaner needs to get from one job address to the next.
+type TravelEstimator interface {
+	EstimateTravel(from, to types.Address) time.Duration
+}
+
+// HaversineTravelEstimator assumes a straight-line trip at a fixed average speed.
+// MinBuffer is always kept between jobs and is used alone when an address has no coordinates.
+type HaversineTravelEstimator struct {
+	AverageSpeedKPH float64
+	MinBuffer       time.Duration
+}
+
+func (e *HaversineTravelEstimator) EstimateTravel(from, to types.Address) time.Duration {
+	if !hasCoordinates(from) || !hasCoordinates(to) || e.AverageSpeedKPH <= 0 {
+		return e.MinBuffer
+	}
+
+	km := utils.HaversineKm(from.AddressLat, from.AddressLng, to.AddressLat, to.AddressLng)
+	travel := time.Duration(km / e.AverageSpeedKPH * float64(time.Hour))
+	if travel < e.MinBuffer {
+		return e.MinBuffer
+	}
+	return travel
+}
+
+func hasCoordinates(a types.Address) bool {
+	return a.AddressLat != 0 || a.AddressLng != 0
 }
 
+// Allocation looks at this many candidates per needed cleaner before ranking them by travel.
+const cleanerCandidatePoolFactor = 3
+
+// Jobs further apart than this are not considered adjacent for travel purposes.
+const adjacentJobWindow = 12 * time.Hour
+
 func (t *BookingTasks) FetchOrderAndPrices(ctx context.Context, paymentPort PaymentPort, orderId string) (*types.Order, *types.CleaningPrices, error) {
 	order, prices, err := paymentPort.FetchOrderAndPrices(ctx, orderId)
 	if err != nil {
@@ -27,23 +118,279 @@ func (t *BookingTasks) FetchOrderAndPrices(ctx context.Context, paymentPort Paym
 	return order, prices, nil
 }
 
+// AllocateEquipmentAndResources applies the inventory.allocation_rules of the booked services
+// to work out which items the crew should take. Items that are unavailable or short on stock
+// are left out and reported in Warnings so an admin can assign them by hand. Equipment comes
+// back after every job, so its stock is what is not already reserved by bookings that
+// overlap this one rather than what is left in inventory.items.
 func (t *BookingTasks) AllocateEquipmentAndResources(ctx context.Context, tx pgx.Tx, req *types.CreateBookingRequest) (*types.CleaningAllocation, error) {
-	// FOR TESTING PA NI, I HAVE NOT IMPLEMENTED THE REAL LOGIC YET
-	// TODO: Automation logic for resource and equipment allocation
-	equipments := []types.CleaningEquipment{
-		{ID: "7849f478-f70b-42a7-82d2-aadc81d3e6d6", Name: "Vacuum Cleaner", Type: "Electrical", PhotoURL: "https://example.com/vacuum.jpg"},
-		{ID: "a4cd7e23-787b-4344-80d7-c50199d85ecd", Name: "Mop", Type: "Manual", PhotoURL: "https://example.com/mop.jpg"},
+	allocation := &types.CleaningAllocation{
+		CleaningEquipment: []types.CleaningEquipment{},
+		CleaningResources: []types.CleaningResources{},
+	}
+	if req == nil {
+		return allocation, nil
 	}
-	resources := []types.CleaningResources{
-		{ID: "d1e94940-838d-4916-bf2b-bb09b77d7c46", Name: "Detergent", Type: "Chemical", PhotoURL: "https://example.com/detergent.jpg"},
+
+	services := []types.ServicesRequest{req.MainService}
+	for _, addon := range req.Addons {
+		services = append(services, addon.ServiceDetail)
 	}
-	return &types.CleaningAllocation{
-		CleaningEquipment: equipments,
-		CleaningResources: resources,
-	}, nil
+
+	serviceTypes := make([]string, 0, len(services))
+	for _, svc := range services {
+		serviceTypes = append(serviceTypes, string(svc.ServiceType))
+	}
+
+	rows, err := tx.Query(ctx, `
+		SELECT
+			r.service_type,
+			i.id::text,
+			i.name,
+			i.type,
+			COALESCE(i.image_url, ''),
+			i.quantity,
+			i.is_available,
+			r.base_quantity,
+			r.per_sqm,
+			r.per_unit,
+			r.dirty_scale_factor
+		FROM inventory.allocation_rules r
+		JOIN inventory.items i ON i.id = r.item_id
+		WHERE r.service_type = ANY($1)
+		ORDER BY i.name
+		FOR UPDATE OF i
+	`, serviceTypes)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch allocation rules: %w", err)
+	}
+	defer rows.Close()
+
+	type itemNeed struct {
+		name      string
+		itemType  types.ItemType
+		photoURL  string
+		inStock   int32
+		available bool
+		required  float64
+	}
+	needs := make(map[string]*itemNeed)
+	var order []string
+
+	for rows.Next() {
+		var (
+			serviceType string
+			itemID      string
+			item        itemNeed
+			rule        types.AllocationRule
+		)
+		if err := rows.Scan(
+			&serviceType,
+			&itemID,
+			&item.name,
+			&item.itemType,
+			&item.photoURL,
+			&item.inStock,
+			&item.available,
+			&rule.BaseQuantity,
+			&rule.PerSQM,
+			&rule.PerUnit,
+			&rule.DirtyScaleFactor,
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan allocation rule: %w", err)
+		}
+
+		for _, svc := range services {
+			if string(svc.ServiceType) != serviceType {
+				continue
+			}
+			sqm, units := serviceMeasures(svc)
+			quantity := (rule.BaseQuantity + rule.PerSQM*float64(sqm) + rule.PerUnit*float64(units)) *
+				(1 + rule.DirtyScaleFactor*float64(max(req.Base.DirtyScale-1, 0)))
+
+			existing, ok := needs[itemID]
+			if !ok {
+				item.required = quantity
+				needs[itemID] = &item
+				order = append(order, itemID)
+				continue
+			}
+			// Equipment is shared between services on the same visit, consumables add up
+			if existing.itemType == types.ItemTypeEquipment {
+				existing.required = math.Max(existing.required, quantity)
+			} else {
+				existing.required += quantity
+			}
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed iterating allocation rules: %w", err)
+	}
+	rows.Close()
+
+	reserved, err := reservedEquipment(ctx, tx, req.Base.StartSched, req.Base.EndSched)
+	if err != nil {
+		return nil, err
+	}
+	for itemID, quantity := range reserved {
+		if need, ok := needs[itemID]; ok && need.itemType == types.ItemTypeEquipment {
+			need.inStock -= int32(math.Ceil(quantity))
+		}
+	}
+
+	for _, itemID := range order {
+		need := needs[itemID]
+		quantity := math.Ceil(need.required)
+		if quantity <= 0 {
+			continue
+		}
+
+		switch {
+		case !need.available:
+			allocation.Warnings = append(allocation.Warnings,
+				fmt.Sprintf("%s is marked unavailable, %.0f needed", need.name, quantity))
+			continue
+		case float64(need.inStock) < quantity:
+			allocation.Warnings = append(allocation.Warnings,
+				fmt.Sprintf("insufficient stock for %s: %.0f needed, %d in stock", need.name, quantity, need.inStock))
+			continue
+		}
+
+		if need.itemType == types.ItemTypeEquipment {
+			allocation.CleaningEquipment = append(allocation.CleaningEquipment, types.CleaningEquipment{
+				ItemID:       itemID,
+				Name:         need.name,
+				Type:         string(need.itemType),
+				PhotoURL:     need.photoURL,
+				QuantityUsed: quantity,
+			})
+		} else {
+			allocation.CleaningResources = append(allocation.CleaningResources, types.CleaningResources{
+				ItemID:       itemID,
+				Name:         need.name,
+				Type:         string(need.itemType),
+				PhotoURL:     need.photoURL,
+				QuantityUsed: quantity,
+			})
+		}
+	}
+
+	return allocation, nil
+}
+
+// reservedEquipment sums, per item, the equipment assigned to live bookings whose schedule
+// overlaps the window.
+func reservedEquipment(ctx context.Context, tx pgx.Tx, startSched, endSched time.Time) (map[string]float64, error) {
+	rows, err := tx.Query(ctx, `
+		SELECT biu.item_id::text, SUM(biu.quantity_used)
+		FROM booking.bookings b
+		JOIN booking.basebookings bb ON bb.id = b.base_booking_id
+		JOIN booking.booking_inventory_used biu ON biu.id = ANY(COALESCE(b.equipment_ids, ARRAY[]::uuid[]))
+		WHERE bb.startsched < $2
+		  AND bb.endsched > $1
+		  AND UPPER(COALESCE(bb.reviewstatus, '')) NOT IN ('CANCELLED', 'REJECTED')
+		  AND UPPER(COALESCE(bb.status, '')) NOT IN ('CANCELLED', 'NO_SHOW')
+		GROUP BY biu.item_id
+	`, startSched, endSched)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch reserved equipment: %w", err)
+	}
+	defer rows.Close()
+
+	reserved := make(map[string]float64)
+	for rows.Next() {
+		var (
+			itemID   string
+			quantity float64
+		)
+		if err := rows.Scan(&itemID, &quantity); err != nil {
+			return nil, fmt.Errorf("failed to scan reserved equipment: %w", err)
+		}
+		reserved[itemID] = quantity
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed iterating reserved equipment: %w", err)
+	}
+	return reserved, nil
 }
 
-func (t *BookingTasks) AllocateCleaners(ctx context.Context, tx pgx.Tx, req *types.CreateBookingRequest) ([]types.CleanerAssigned, error) {
+// serviceMeasures returns the floor area and the number of items to clean for a service.
+func serviceMeasures(svc types.ServicesRequest) (int32, int32) {
+	var sqm, units int32
+	details := svc.Details
+
+	switch svc.ServiceType {
+	case types.GeneralCleaning:
+		if details.General != nil {
+			sqm = details.General.SQM
+		}
+	case types.PostCleaning:
+		if details.Post != nil {
+			sqm = details.Post.SQM
+		}
+	case types.CouchCleaning:
+		if details.Couch != nil {
+			for _, spec := range details.Couch.CleaningSpecs {
+				units += spec.Quantity
+			}
+		}
+	case types.MattressCleaning:
+		if details.Mattress != nil {
+			for _, spec := range details.Mattress.CleaningSpecs {
+				units += spec.Quantity
+			}
+		}
+	case types.CarCleaning:
+		if details.Car != nil {
+			for _, spec := range details.Car.CleaningSpecs {
+				units += spec.Quantity
+			}
+		}
+	}
+
+	return sqm, units
+}
+
+// AssignAllocatedInventory writes the allocation to booking_inventory_used the same way the
+// admin assign endpoints do and fills in the usage record ids.
+func (t *BookingTasks) AssignAllocatedInventory(ctx context.Context, tx pgx.Tx, bookingID string, allocation *types.CleaningAllocation) error {
+	if allocation == nil {
+		return nil
+	}
+
+	equipment := make([]types.ItemQuantity, 0, len(allocation.CleaningEquipment))
+	for _, e := range allocation.CleaningEquipment {
+		equipment = append(equipment, types.ItemQuantity{ItemID: e.ItemID, Quantity: e.QuantityUsed})
+	}
+	equipmentIDs, err := assignInventoryToBooking(ctx, tx, bookingID, types.ItemTypeEquipment, equipment)
+	if err != nil {
+		return err
+	}
+	for i := range allocation.CleaningEquipment {
+		allocation.CleaningEquipment[i].ID = equipmentIDs[i]
+	}
+
+	resources := make([]types.ItemQuantity, 0, len(allocation.CleaningResources))
+	for _, r := range allocation.CleaningResources {
+		resources = append(resources, types.ItemQuantity{ItemID: r.ItemID, Quantity: r.QuantityUsed})
+	}
+	resourceIDs, err := assignInventoryToBooking(ctx, tx, bookingID, types.ItemTypeResource, resources)
+	if err != nil {
+		return err
+	}
+	for i := range allocation.CleaningResources {
+		allocation.CleaningResources[i].ID = resourceIDs[i]
+	}
+
+	return nil
+}
+
+func (t *BookingTasks) AllocateCleaners(
+	ctx context.Context,
+	tx pgx.Tx,
+	req *types.CreateBookingRequest,
+	travel TravelEstimator,
+) ([]types.CleanerAssigned, error) {
 	if req == nil {
 		return nil, fmt.Errorf("booking request is required for cleaner allocation")
 	}
@@ -53,28 +400,196 @@ func (t *BookingTasks) AllocateCleaners(ctx context.Context, tx pgx.Tx, req *typ
 		cleanersNeeded = 1
 	}
 
-	cleaners, err := t.allocateCleanersViaSproc(ctx, tx, req.Base.StartSched, req.Base.EndSched, cleanersNeeded, req.Base.DirtyScale)
-	if err != nil {
-		var pgErr *pgconn.PgError
-		if errors.As(err, &pgErr) && pgErr.Code == "42883" {
-			cleaners, err = t.allocateCleanersFallback(ctx, tx, req.Base.StartSched, req.Base.EndSched, cleanersNeeded)
+	poolSize := cleanersNeeded
+	if travel != nil {
+		poolSize = cleanersNeeded * cleanerCandidatePoolFactor
+	}
+
+	var (
+		cleaners []types.CleanerAssigned
+		err      error
+	)
+	required := requiredQualifications(req)
+	if len(required) > 0 {
+		// booking.allocate_cleaners knows nothing about qualifications, so specialist
+		// work goes straight to the query that filters on them.
+		cleaners, err = t.allocateCleanersFallback(ctx, tx, req.Base.StartSched, req.Base.EndSched, poolSize, required)
+		if err != nil {
+			return nil, fmt.Errorf("failed to allocate qualified cleaners: %w", err)
+		}
+	} else {
+		cleaners, err = t.allocateCleanersViaSproc(ctx, tx, req.Base.StartSched, req.Base.EndSched, poolSize, req.Base.DirtyScale)
+		if err != nil {
+			var pgErr *pgconn.PgError
+			if !errors.As(err, &pgErr) || pgErr.Code != "42883" {
+				return nil, fmt.Errorf("failed to allocate cleaners via sproc: %w", err)
+			}
+			cleaners, err = t.allocateCleanersFallback(ctx, tx, req.Base.StartSched, req.Base.EndSched, poolSize, nil)
 			if err != nil {
 				return nil, fmt.Errorf("failed to allocate cleaners via fallback query: %w", err)
 			}
 		} else {
-			return nil, fmt.Errorf("failed to allocate cleaners via sproc: %w", err)
+			// The sproc does not know the employee calendar either. If it picked anyone who
+			// is off, let the fallback query, which does, choose the whole pool.
+			onCalendar, err := filterCleanersOnCalendar(ctx, tx, t.location(), cleaners, req.Base.StartSched, req.Base.EndSched)
+			if err != nil {
+				return nil, err
+			}
+			if len(onCalendar) < len(cleaners) {
+				cleaners, err = t.allocateCleanersFallback(ctx, tx, req.Base.StartSched, req.Base.EndSched, poolSize, nil)
+				if err != nil {
+					return nil, fmt.Errorf("failed to allocate cleaners via fallback query: %w", err)
+				}
+			}
+		}
+	}
+
+	if travel != nil {
+		cleaners, err = rankCleanersByTravel(ctx, tx, travel, cleaners, req.Base.Address, req.Base.StartSched, req.Base.EndSched, "")
+		if err != nil {
+			return nil, err
 		}
 	}
 
 	if len(cleaners) == 0 {
-		return nil, fmt.Errorf("no available cleaners found for selected schedule")
+		return nil, ErrNoAvailableCleaners
 	}
 
 	if len(cleaners) < cleanersNeeded {
-		return nil, fmt.Errorf("insufficient available cleaners for selected schedule: need %d, got %d", cleanersNeeded, len(cleaners))
+		return nil, fmt.Errorf("%w: need %d, got %d", ErrInsufficientCleaners, cleanersNeeded, len(cleaners))
 	}
 
-	return cleaners, nil
+	return cleaners[:cleanersNeeded], nil
+}
+
+// rankCleanersByTravel drops candidates who could not get to the job from their previous one,
+// or on to their next one, in time. The rest are ordered by total travel, keeping the
+// allocator's own ranking between cleaners with equal travel.
+func rankCleanersByTravel(
+	ctx context.Context,
+	tx pgx.Tx,
+	travel TravelEstimator,
+	cleaners []types.CleanerAssigned,
+	address types.Address,
+	startSched, endSched time.Time,
+	excludeBookingID string,
+) ([]types.CleanerAssigned, error) {
+	type ranked struct {
+		cleaner types.CleanerAssigned
+		travel  time.Duration
+	}
+	feasible := make([]ranked, 0, len(cleaners))
+
+	for _, cleaner := range cleaners {
+		ok, cost, err := cleanerCanTravel(ctx, tx, travel, cleaner.ID, address, startSched, endSched, excludeBookingID)
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			feasible = append(feasible, ranked{cleaner: cleaner, travel: cost})
+		}
+	}
+
+	sort.SliceStable(feasible, func(i, j int) bool {
+		return feasible[i].travel < feasible[j].travel
+	})
+
+	result := make([]types.CleanerAssigned, 0, len(feasible))
+	for _, r := range feasible {
+		result = append(result, r.cleaner)
+	}
+	return result, nil
+}
+
+type adjacentJob struct {
+	Address    types.Address
+	StartSched time.Time
+	EndSched   time.Time
+}
+
+// cleanerCanTravel checks the cleaner's jobs right before and after the window and returns
+// whether both legs fit, along with the travel time they add.
+func cleanerCanTravel(
+	ctx context.Context,
+	tx pgx.Tx,
+	travel TravelEstimator,
+	cleanerID string,
+	address types.Address,
+	startSched, endSched time.Time,
+	excludeBookingID string,
+) (bool, time.Duration, error) {
+	prev, next, err := fetchAdjacentJobs(ctx, tx, cleanerID, startSched, endSched, excludeBookingID)
+	if err != nil {
+		return false, 0, err
+	}
+
+	var total time.Duration
+	if prev != nil {
+		leg := travel.EstimateTravel(prev.Address, address)
+		if prev.EndSched.Add(leg).After(startSched) {
+			return false, 0, nil
+		}
+		total += leg
+	}
+	if next != nil {
+		leg := travel.EstimateTravel(address, next.Address)
+		if endSched.Add(leg).After(next.StartSched) {
+			return false, 0, nil
+		}
+		total += leg
+	}
+	return true, total, nil
+}
+
+func fetchAdjacentJobs(
+	ctx context.Context,
+	tx pgx.Tx,
+	cleanerID string,
+	startSched, endSched time.Time,
+	excludeBookingID string,
+) (*adjacentJob, *adjacentJob, error) {
+	const base = `
+		SELECT bb.address, bb.startsched, bb.endsched
+		FROM booking.bookings b
+		JOIN booking.basebookings bb ON bb.id = b.base_booking_id
+		WHERE $1 = ANY(b.cleaner_ids)
+		  AND (
+			NULLIF($4, '')::uuid IS NULL
+			OR b.id <> NULLIF($4, '')::uuid
+		  )
+		  AND UPPER(COALESCE(bb.reviewstatus, '')) NOT IN ('CANCELLED', 'REJECTED')
+		  AND UPPER(COALESCE(bb.status, '')) <> 'CANCELLED'`
+
+	scan := func(row pgx.Row) (*adjacentJob, error) {
+		var job adjacentJob
+		if err := row.Scan(&job.Address, &job.StartSched, &job.EndSched); err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				return nil, nil
+			}
+			return nil, fmt.Errorf("failed to fetch adjacent job for cleaner %s: %w", cleanerID, err)
+		}
+		return &job, nil
+	}
+
+	prev, err := scan(tx.QueryRow(ctx, base+`
+		  AND bb.endsched <= $2
+		  AND bb.endsched > $3
+		ORDER BY bb.endsched DESC
+		LIMIT 1`, cleanerID, startSched, startSched.Add(-adjacentJobWindow), excludeBookingID))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	next, err := scan(tx.QueryRow(ctx, base+`
+		  AND bb.startsched >= $2
+		  AND bb.startsched < $3
+		ORDER BY bb.startsched ASC
+		LIMIT 1`, cleanerID, endSched, endSched.Add(adjacentJobWindow), excludeBookingID))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return prev, next, nil
 }
 
 func (t *BookingTasks) allocateCleanersViaSproc(
@@ -105,7 +620,11 @@ func (t *BookingTasks) allocateCleanersFallback(
 	tx pgx.Tx,
 	startSched, endSched time.Time,
 	cleanersNeeded int,
+	qualifications []string,
 ) ([]types.CleanerAssigned, error) {
+	if qualifications == nil {
+		qualifications = []string{}
+	}
 	query := `
 		WITH candidates AS (
 			SELECT
@@ -124,6 +643,8 @@ func (t *BookingTasks) allocateCleanersFallback(
 			LEFT JOIN booking.basebookings bb2 ON bb2.id = b2.base_booking_id
 			WHERE e.position = 'cleaner'
 			  AND e.status IN ('ACTIVE', 'ONDUTY')
+			  AND ` + qualifiedCleanerFilter("e.id", "$4") + `
+			  AND ` + availableOnCalendarFilter("e.id", "$1", "$2", 5) + `
 			GROUP BY e.id, a.first_name, a.last_name, e.performance_score
 		)
 		SELECT c.id, c.first_name, c.last_name
@@ -141,7 +662,8 @@ func (t *BookingTasks) allocateCleanersFallback(
 		ORDER BY c.upcoming_assignments ASC, c.performance_score DESC, c.last_name ASC, c.first_name ASC
 		LIMIT $3`
 
-	rows, err := tx.Query(ctx, query, startSched, endSched, cleanersNeeded)
+	args := append([]any{startSched, endSched, cleanersNeeded, qualifications}, calendarClockArgs(t.location(), startSched, endSched)...)
+	rows, err := tx.Query(ctx, query, args...)
 	if err != nil {
 		return nil, fmt.Errorf("failed to query available cleaners: %w", err)
 	}
@@ -247,8 +769,8 @@ func (t *BookingTasks) MakeBaseBooking(
 		startSched,
 		endSched,
 		dirtyScale,
-		"NOT_STARTED",
-		"PENDING",
+		BookingStatusNotStarted,
+		ReviewStatusPending,
 		photos,
 		time.Now(),
 		time.Now(),
@@ -452,6 +974,56 @@ func (t *BookingTasks) SaveBooking(
 	return id, nil
 }
 
+// LinkBookingDays groups the bookings of a multi-day job under the first day's booking id.
+func (t *BookingTasks) LinkBookingDays(ctx context.Context, tx pgx.Tx, bookingIDs []string) error {
+	if len(bookingIDs) == 0 {
+		return nil
+	}
+
+	_, err := tx.Exec(ctx, `
+		UPDATE booking.bookings b
+		SET day_group_id = $1::uuid,
+		    day_index = d.idx - 1,
+		    day_count = $2
+		FROM unnest($3::uuid[]) WITH ORDINALITY AS d(id, idx)
+		WHERE b.id = d.id
+	`, bookingIDs[0], len(bookingIDs), bookingIDs)
+	if err != nil {
+		return fmt.Errorf("failed to link booking days: %w", err)
+	}
+	return nil
+}
+
+// FetchLinkedBookingIDs returns the other days of the multi-day job bookingID belongs to.
+func (t *BookingTasks) FetchLinkedBookingIDs(ctx context.Context, tx pgx.Tx, bookingID string) ([]string, error) {
+	rows, err := tx.Query(ctx, `
+		SELECT sibling.id::text
+		FROM booking.bookings b
+		JOIN booking.bookings sibling ON sibling.day_group_id = b.day_group_id
+		WHERE b.id = $1
+		  AND b.day_group_id IS NOT NULL
+		  AND sibling.id <> b.id
+		ORDER BY sibling.day_index
+	`, bookingID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch linked bookings: %w", err)
+	}
+	defer rows.Close()
+
+	var ids []string
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			return nil, fmt.Errorf("failed to scan linked booking: %w", err)
+		}
+		ids = append(ids, id)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed iterating linked bookings: %w", err)
+	}
+	return ids, nil
+}
+
 func (t *BookingTasks) FetchBookingByID(ctx context.Context, tx pgx.Tx, bookingID string, logger *utils.Logger) (*types.Booking, error) {
 	var rawJSON []byte
 	err := tx.QueryRow(ctx, `SELECT booking.get_booking_by_id($1)`, bookingID).Scan(&rawJSON)
@@ -571,26 +1143,6 @@ func (t *BookingTasks) FetchAllEmployeeAssignedBookings(
 	return &response, nil
 }
 
-func (t *BookingTasks) ValidateSessionStart(ctx context.Context, tx pgx.Tx, bookingID string) (bool, time.Time, error) {
-	query := `SELECT bb.startsched
-		FROM booking.basebookings bb
-		JOIN booking.bookings b ON b.base_booking_id = bb.id
-		WHERE b.id = $1
-		AND bb.status = 'NOT_STARTED'
-		AND bb.reviewstatus = 'SCHEDULED'`
-
-	var startSched time.Time
-	err := tx.QueryRow(ctx, query, bookingID).Scan(&startSched)
-	if err != nil {
-		if err == pgx.ErrNoRows {
-			return false, time.Time{}, nil
-		}
-		return false, time.Time{}, fmt.Errorf("failed to validate session start: %w", err)
-	}
-
-	return true, startSched, nil
-}
-
 func (t *BookingTasks) StartSession(ctx context.Context, tx pgx.Tx, bookingID string, startPhotos []string) error {
 	if _, err := tx.Exec(ctx,
 		`INSERT INTO booking.sessions (booking_id, start_photos, created_at, updated_at)
@@ -604,14 +1156,7 @@ func (t *BookingTasks) StartSession(ctx context.Context, tx pgx.Tx, bookingID st
 	); err != nil {
 		return err
 	}
-
-	_, err := tx.Exec(ctx,
-		`UPDATE booking.basebookings
-		 SET status = 'ONGOING', updatedat = now()
-		 WHERE id = (SELECT base_booking_id FROM booking.bookings WHERE id = $1)`,
-		bookingID,
-	)
-	return err
+	return nil
 }
 
 func (t *BookingTasks) EndSession(ctx context.Context, tx pgx.Tx, bookingID string, endPhotos []string) error {
@@ -627,14 +1172,7 @@ func (t *BookingTasks) EndSession(ctx context.Context, tx pgx.Tx, bookingID stri
 	); err != nil {
 		return err
 	}
-
-	_, err := tx.Exec(ctx,
-		`UPDATE booking.basebookings
-		 SET status = 'COMPLETED', updatedat = now()
-		 WHERE id = (SELECT base_booking_id FROM booking.bookings WHERE id = $1)`,
-		bookingID,
-	)
-	return err
+	return nil
 }
 
 func (t *BookingTasks) UpdateCleanerStatusesForBooking(ctx context.Context, tx pgx.Tx, bookingID, status string) error {
@@ -651,7 +1189,16 @@ func (t *BookingTasks) UpdateCleanerStatusesForBooking(ctx context.Context, tx p
 		status,
 		bookingID,
 	)
-	return err
+	if err != nil {
+		return err
+	}
+	if status == "ACTIVE" {
+		return publishEvent(ctx, tx, "employee_activated", map[string]any{
+			"event":     "employee_activated",
+			"bookingId": bookingID,
+		})
+	}
+	return nil
 }
 
 func (t *BookingTasks) FetchBookingSlots(
@@ -737,3 +1284,448 @@ func (t *BookingTasks) FetchBookingsToday(ctx context.Context, tx pgx.Tx, logger
 	}
 	return &response, nil
 }
+
+func (t *BookingTasks) FetchBookingSnapshot(ctx context.Context, tx pgx.Tx, bookingID string) (*BookingSnapshot, error) {
+	var snap BookingSnapshot
+	err := tx.QueryRow(ctx, `
+		SELECT
+			b.id::text,
+			bb.id::text,
+			bb.custid::text,
+			COALESCE(bb.orderid::text, ''),
+			bb.address,
+			bb.startsched,
+			bb.endsched,
+			bb.dirtyscale,
+			COALESCE(bb.extra_hours, 0),
+			COALESCE(q.total_service_hours, 0)::real,
+			bb.status,
+			bb.reviewstatus,
+			COALESCE(b.cleaner_ids, ARRAY[]::uuid[])::text[],
+			COALESCE(b.day_index, 0)
+		FROM booking.bookings b
+		JOIN booking.basebookings bb ON bb.id = b.base_booking_id
+		LEFT JOIN payment.orders o ON o.id = bb.orderid
+		LEFT JOIN payment.quotes q ON q.id = o.quote_id
+		WHERE b.id = $1
+		FOR UPDATE OF b, bb
+	`, bookingID).Scan(
+		&snap.BookingID,
+		&snap.BaseBookingID,
+		&snap.CustID,
+		&snap.OrderID,
+		&snap.Address,
+		&snap.StartSched,
+		&snap.EndSched,
+		&snap.DirtyScale,
+		&snap.ExtraHours,
+		&snap.TotalServiceHours,
+		&snap.Status,
+		&snap.ReviewStatus,
+		&snap.CleanerIDs,
+		&snap.DayIndex,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrBookingNotFound
+		}
+		return nil, fmt.Errorf("failed to load booking snapshot: %w", err)
+	}
+
+	return &snap, nil
+}
+
+// IsScheduleSlotAvailable checks the window against the occupied slots reported by
+// booking.get_daily_booking_slots for every day it touches, ignoring the booking itself.
+func (t *BookingTasks) IsScheduleSlotAvailable(
+	ctx context.Context,
+	tx pgx.Tx,
+	bookingID string,
+	startSched, endSched time.Time,
+	logger *utils.Logger,
+) (bool, error) {
+	loc := t.location()
+	start := startSched.In(loc)
+	end := endSched.In(loc)
+
+	day := time.Date(start.Year(), start.Month(), start.Day(), 0, 0, 0, 0, loc)
+	for day.Before(end) {
+		slots, err := t.FetchBookingSlots(ctx, tx, day.Format("2006-01-02"), logger)
+		if err != nil {
+			return false, err
+		}
+
+		for _, slot := range slots.OccupiedSlots {
+			if slot.BookingID == bookingID {
+				continue
+			}
+			if slot.StartSched.Before(endSched) && slot.EndSched.After(startSched) {
+				return false, nil
+			}
+		}
+
+		day = day.AddDate(0, 0, 1)
+	}
+
+	return true, nil
+}
+
+// FindConflictingCleaners returns the cleaners who are busy or off during the window or,
+// when travel is set, could not travel between it and their adjacent jobs.
+func (t *BookingTasks) FindConflictingCleaners(
+	ctx context.Context,
+	tx pgx.Tx,
+	cleanerIDs []string,
+	address types.Address,
+	startSched, endSched time.Time,
+	excludeBookingID string,
+	travel TravelEstimator,
+) ([]string, error) {
+	conflicts := make([]string, 0)
+	for _, cleanerID := range cleanerIDs {
+		hasConflict, err := cleanerHasScheduleConflict(ctx, tx, cleanerID, startSched, endSched, excludeBookingID)
+		if err != nil {
+			return nil, err
+		}
+		if !hasConflict {
+			available, err := cleanerOnCalendar(ctx, tx, t.location(), cleanerID, startSched, endSched)
+			if err != nil {
+				return nil, err
+			}
+			hasConflict = !available
+		}
+		if !hasConflict && travel != nil {
+			canTravel, _, err := cleanerCanTravel(ctx, tx, travel, cleanerID, address, startSched, endSched, excludeBookingID)
+			if err != nil {
+				return nil, err
+			}
+			hasConflict = !canTravel
+		}
+		if hasConflict {
+			conflicts = append(conflicts, cleanerID)
+		}
+	}
+	return conflicts, nil
+}
+
+func (t *BookingTasks) ReplaceBookingCleaners(ctx context.Context, tx pgx.Tx, bookingID string, cleanerIDs []string) error {
+	if cleanerIDs == nil {
+		cleanerIDs = []string{}
+	}
+	_, err := tx.Exec(ctx,
+		`UPDATE booking.bookings
+		 SET cleaner_ids = $2::uuid[]
+		 WHERE id = $1`,
+		bookingID, cleanerIDs,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to replace booking cleaners: %w", err)
+	}
+	return nil
+}
+
+func (t *BookingTasks) UpdateBookingSchedule(
+	ctx context.Context,
+	tx pgx.Tx,
+	baseBookingID string,
+	startSched, endSched, originalEndSched time.Time,
+) error {
+	_, err := tx.Exec(ctx,
+		`UPDATE booking.basebookings
+		 SET startsched = $2,
+		     endsched = $3,
+		     original_end_sched = $4,
+		     updatedat = NOW()
+		 WHERE id = $1`,
+		baseBookingID, startSched, endSched, originalEndSched,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to update booking schedule: %w", err)
+	}
+	return nil
+}
+
+// PublishBookingEvent queues a NOTIFY on the given channel. Postgres only delivers it
+// once the surrounding transaction commits, so listeners never see rolled back changes.
+func (t *BookingTasks) PublishBookingEvent(ctx context.Context, tx pgx.Tx, channel string, payload any) error {
+	return publishEvent(ctx, tx, channel, payload)
+}
+
+func publishEvent(ctx context.Context, tx pgx.Tx, channel string, payload any) error {
+	raw, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("marshal %s event: %w", channel, err)
+	}
+	if _, err := tx.Exec(ctx, `SELECT pg_notify($1, $2)`, channel, string(raw)); err != nil {
+		return fmt.Errorf("failed to publish %s event: %w", channel, err)
+	}
+	return nil
+}
+
+func (t *BookingTasks) FetchOrderPaymentSummary(ctx context.Context, tx pgx.Tx, orderID string) (*OrderPaymentSummary, error) {
+	var summary OrderPaymentSummary
+	err := tx.QueryRow(ctx, `
+		SELECT
+			o.id::text,
+			COALESCE(o.currency, 'PHP'),
+			COALESCE((
+				SELECT p.provider
+				FROM payment.payments p
+				WHERE p.order_id = o.id
+				  AND UPPER(p.type) <> 'REFUND'
+				ORDER BY p.created_at DESC
+				LIMIT 1
+			), 'MANUAL'),
+			o.total_amount,
+			o.downpayment_required,
+			(
+				COALESCE((
+					SELECT SUM(p.amount)
+					FROM payment.payments p
+					WHERE p.order_id = o.id
+					  AND UPPER(p.type) <> 'REFUND'
+					  AND LOWER(p.status) IN ('paid', 'succeeded')
+				), 0)
+				- COALESCE((
+					SELECT SUM(p.amount)
+					FROM payment.payments p
+					WHERE p.order_id = o.id
+					  AND UPPER(p.type) = 'REFUND'
+				), 0)
+			)::real
+		FROM payment.orders o
+		WHERE o.id = $1
+		FOR UPDATE OF o
+	`, orderID).Scan(
+		&summary.OrderID,
+		&summary.Currency,
+		&summary.Provider,
+		&summary.TotalAmount,
+		&summary.DownpaymentRequired,
+		&summary.AmountPaid,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, fmt.Errorf("order not found")
+		}
+		return nil, fmt.Errorf("failed to load order payment summary: %w", err)
+	}
+
+	if summary.AmountPaid < 0 {
+		summary.AmountPaid = 0
+	}
+	return &summary, nil
+}
+
+// ResolveCancellationOutcome applies the policy tiers to what the customer has paid so far.
+func (t *BookingTasks) ResolveCancellationOutcome(
+	policy types.CancellationPolicy,
+	hoursBeforeStart float64,
+	summary *OrderPaymentSummary,
+) (types.CancellationOutcome, float32) {
+	switch {
+	case hoursBeforeStart >= policy.FullRefundHours:
+		return types.CancellationFullRefund, summary.AmountPaid
+	case hoursBeforeStart >= policy.DownpaymentForfeitHours:
+		refund := summary.AmountPaid - summary.DownpaymentRequired
+		if refund < 0 {
+			refund = 0
+		}
+		return types.CancellationDownpaymentForfeited, refund
+	default:
+		return types.CancellationNoRefund, 0
+	}
+}
+
+// FetchOrderDayPlan returns the day plan of the quote the order was paid for, or nil when
+// the job is done in one day.
+func (t *BookingTasks) FetchOrderDayPlan(ctx context.Context, tx pgx.Tx, orderID string) ([]types.ServiceDay, error) {
+	var raw []byte
+	err := tx.QueryRow(ctx, `
+		SELECT COALESCE(q.day_plan, '[]'::jsonb)
+		FROM payment.orders o
+		JOIN payment.quotes q ON q.id = o.quote_id
+		WHERE o.id = $1
+	`, orderID).Scan(&raw)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to fetch order day plan: %w", err)
+	}
+
+	var plan []types.ServiceDay
+	if err := json.Unmarshal(raw, &plan); err != nil {
+		return nil, fmt.Errorf("unmarshal day plan: %w", err)
+	}
+	return plan, nil
+}
+
+// DayPlanValue returns what the given days of a multi-day job are priced at in its day plan.
+func DayPlanValue(plan []types.ServiceDay, dayIndexes []int32) float32 {
+	var value float32
+	for _, day := range plan {
+		if slices.Contains(dayIndexes, day.DayIndex) {
+			value += day.Price
+		}
+	}
+	return value
+}
+
+// ScopeCancelledDays narrows the order's payment summary to the days being cancelled, which
+// are priced at cancelledValue. Payments go to the days that stay on the order first, so
+// only what was paid beyond them counts toward the cancelled days. It also returns the
+// balance still owed for the days that stay.
+func ScopeCancelledDays(summary *OrderPaymentSummary, cancelledValue float32) (*OrderPaymentSummary, float32) {
+	if summary.TotalAmount <= 0 || cancelledValue >= summary.TotalAmount {
+		return summary, 0
+	}
+
+	keptValue := summary.TotalAmount - cancelledValue
+	paidForKept := min(summary.AmountPaid, keptValue)
+	scoped := *summary
+	scoped.TotalAmount = cancelledValue
+	scoped.DownpaymentRequired = summary.DownpaymentRequired * cancelledValue / summary.TotalAmount
+	scoped.AmountPaid = summary.AmountPaid - paidForKept
+	return &scoped, keptValue - paidForKept
+}
+
+// ReleaseBookingInventory puts the resources taken for the booking back into stock and
+// drops the booking_inventory_used records, which also frees its equipment reservation.
+func (t *BookingTasks) ReleaseBookingInventory(ctx context.Context, tx pgx.Tx, bookingID string) error {
+	_, err := tx.Exec(ctx, `
+		WITH used AS (
+			SELECT biu.item_id, SUM(biu.quantity_used) AS quantity
+			FROM booking.bookings b
+			JOIN booking.booking_inventory_used biu
+			  ON biu.id = ANY(COALESCE(b.resource_ids, ARRAY[]::uuid[]))
+			WHERE b.id = $1
+			GROUP BY biu.item_id
+		)
+		UPDATE inventory.items i
+		SET quantity = i.quantity + used.quantity,
+		    updated_at = NOW()
+		FROM used
+		WHERE i.id = used.item_id
+	`, bookingID)
+	if err != nil {
+		return fmt.Errorf("failed to restock booking inventory: %w", err)
+	}
+
+	_, err = tx.Exec(ctx, `
+		DELETE FROM booking.booking_inventory_used biu
+		USING booking.bookings b
+		WHERE b.id = $1
+		  AND biu.id = ANY(COALESCE(b.resource_ids, ARRAY[]::uuid[]) || COALESCE(b.equipment_ids, ARRAY[]::uuid[]))
+	`, bookingID)
+	if err != nil {
+		return fmt.Errorf("failed to delete booking inventory usage: %w", err)
+	}
+
+	_, err = tx.Exec(ctx,
+		`UPDATE booking.bookings
+		 SET resource_ids = '{}'::uuid[],
+		     equipment_ids = '{}'::uuid[]
+		 WHERE id = $1`,
+		bookingID,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to clear booking inventory ids: %w", err)
+	}
+	return nil
+}
+
+func (t *BookingTasks) RecordCancellation(
+	ctx context.Context,
+	tx pgx.Tx,
+	bookingID string,
+	cleanerIDs []string,
+	reason string,
+	outcome types.CancellationOutcome,
+	hoursBeforeStart float64,
+	amountPaid, refundAmount float32,
+) error {
+	if cleanerIDs == nil {
+		cleanerIDs = []string{}
+	}
+	_, err := tx.Exec(ctx, `
+		INSERT INTO booking.cancellations (
+			booking_id,
+			cleaner_ids,
+			reason,
+			outcome,
+			hours_before_start,
+			amount_paid,
+			refund_amount,
+			cancellation_fee,
+			created_at
+		) VALUES ($1, $2::uuid[], NULLIF($3, ''), $4, $5, $6, $7, $8, NOW())
+	`, bookingID, cleanerIDs, reason, string(outcome), hoursBeforeStart, amountPaid, refundAmount, amountPaid-refundAmount)
+	if err != nil {
+		return fmt.Errorf("failed to record cancellation: %w", err)
+	}
+	return nil
+}
+
+// SettleCancelledOrder leaves keptBalance owed on the order, which is 0 once the whole
+// order is cancelled, and, when money goes back to the customer, queues a pending REFUND
+// payment for finance to process. An order with days still going ahead keeps its payment
+// status unless a refund is queued or nothing more is owed on it.
+func (t *BookingTasks) SettleCancelledOrder(
+	ctx context.Context,
+	tx pgx.Tx,
+	summary *OrderPaymentSummary,
+	outcome types.CancellationOutcome,
+	refundAmount, keptBalance float32,
+	wholeOrder bool,
+) error {
+	var paymentStatus string
+	switch {
+	case refundAmount > 0:
+		paymentStatus = "refund_pending"
+	case wholeOrder:
+		paymentStatus = "cancelled"
+	case keptBalance <= 0:
+		paymentStatus = "paid"
+	}
+
+	_, err := tx.Exec(ctx,
+		`UPDATE payment.orders
+		 SET payment_status = COALESCE(NULLIF($2, ''), payment_status),
+		     remaining_balance = $3,
+		     updated_at = NOW()
+		 WHERE id = $1`,
+		summary.OrderID, paymentStatus, keptBalance,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to update cancelled order: %w", err)
+	}
+
+	if refundAmount <= 0 {
+		return nil
+	}
+
+	rawResponse, err := json.Marshal(map[string]any{"outcome": outcome})
+	if err != nil {
+		return fmt.Errorf("marshal refund outcome: %w", err)
+	}
+
+	_, err = tx.Exec(ctx, `
+		INSERT INTO payment.payments (
+			order_id,
+			client_key,
+			amount,
+			currency,
+			status,
+			type,
+			provider,
+			raw_response,
+			created_at,
+			updated_at
+		) VALUES ($1, '', $2, $3, 'pending', 'REFUND', $4, $5, NOW(), NOW())
+	`, summary.OrderID, refundAmount, summary.Currency, summary.Provider, rawResponse)
+	if err != nil {
+		return fmt.Errorf("failed to store refund payment: %w", err)
+	}
+	return nil
+}
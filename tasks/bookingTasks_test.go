@@ -0,0 +1,187 @@
+package tasks
+
+import (
+	"handworks-api/types"
+	"testing"
+)
+
+func TestResolveCancellationOutcome(t *testing.T) {
+	policy := types.CancellationPolicy{FullRefundHours: 48, DownpaymentForfeitHours: 24}
+
+	tests := []struct {
+		name             string
+		hoursBeforeStart float64
+		summary          OrderPaymentSummary
+		wantOutcome      types.CancellationOutcome
+		wantRefund       float32
+	}{
+		{
+			name:             "well ahead refunds everything paid",
+			hoursBeforeStart: 72,
+			summary:          OrderPaymentSummary{TotalAmount: 5000, DownpaymentRequired: 1000, AmountPaid: 5000},
+			wantOutcome:      types.CancellationFullRefund,
+			wantRefund:       5000,
+		},
+		{
+			name:             "exactly at the full refund cutoff",
+			hoursBeforeStart: 48,
+			summary:          OrderPaymentSummary{TotalAmount: 5000, DownpaymentRequired: 1000, AmountPaid: 1000},
+			wantOutcome:      types.CancellationFullRefund,
+			wantRefund:       1000,
+		},
+		{
+			name:             "inside the full refund window forfeits the downpayment",
+			hoursBeforeStart: 30,
+			summary:          OrderPaymentSummary{TotalAmount: 5000, DownpaymentRequired: 1000, AmountPaid: 5000},
+			wantOutcome:      types.CancellationDownpaymentForfeited,
+			wantRefund:       4000,
+		},
+		{
+			name:             "only the downpayment paid gets nothing back",
+			hoursBeforeStart: 24,
+			summary:          OrderPaymentSummary{TotalAmount: 5000, DownpaymentRequired: 1000, AmountPaid: 1000},
+			wantOutcome:      types.CancellationDownpaymentForfeited,
+			wantRefund:       0,
+		},
+		{
+			name:             "less than the downpayment paid never refunds a negative amount",
+			hoursBeforeStart: 36,
+			summary:          OrderPaymentSummary{TotalAmount: 5000, DownpaymentRequired: 1000, AmountPaid: 400},
+			wantOutcome:      types.CancellationDownpaymentForfeited,
+			wantRefund:       0,
+		},
+		{
+			name:             "last minute keeps everything paid",
+			hoursBeforeStart: 2,
+			summary:          OrderPaymentSummary{TotalAmount: 5000, DownpaymentRequired: 1000, AmountPaid: 5000},
+			wantOutcome:      types.CancellationNoRefund,
+			wantRefund:       0,
+		},
+		{
+			name:             "after the start keeps everything paid",
+			hoursBeforeStart: -3,
+			summary:          OrderPaymentSummary{TotalAmount: 5000, DownpaymentRequired: 1000, AmountPaid: 5000},
+			wantOutcome:      types.CancellationNoRefund,
+			wantRefund:       0,
+		},
+	}
+
+	bt := &BookingTasks{}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			outcome, refund := bt.ResolveCancellationOutcome(policy, tt.hoursBeforeStart, &tt.summary)
+			if outcome != tt.wantOutcome {
+				t.Errorf("outcome = %s, want %s", outcome, tt.wantOutcome)
+			}
+			if refund != tt.wantRefund {
+				t.Errorf("refund = %v, want %v", refund, tt.wantRefund)
+			}
+		})
+	}
+}
+
+func TestDayPlanValue(t *testing.T) {
+	plan := []types.ServiceDay{
+		{DayIndex: 0, Hours: 8, Price: 1200},
+		{DayIndex: 1, Hours: 8, Price: 1200},
+		{DayIndex: 2, Hours: 7, Price: 1050},
+	}
+
+	tests := []struct {
+		name       string
+		dayIndexes []int32
+		want       float32
+	}{
+		{name: "no days", dayIndexes: nil, want: 0},
+		{name: "one day", dayIndexes: []int32{2}, want: 1050},
+		{name: "several days", dayIndexes: []int32{1, 2}, want: 2250},
+		{name: "days outside the plan are ignored", dayIndexes: []int32{0, 5}, want: 1200},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := DayPlanValue(plan, tt.dayIndexes); got != tt.want {
+				t.Errorf("DayPlanValue = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestScopeCancelledDays(t *testing.T) {
+	// Three days priced at 1000 each with a 20% downpayment.
+	order := OrderPaymentSummary{OrderID: "order-1", TotalAmount: 3000, DownpaymentRequired: 600}
+
+	tests := []struct {
+		name           string
+		amountPaid     float32
+		cancelledValue float32
+		wantTotal      float32
+		wantDown       float32
+		wantPaid       float32
+		wantKept       float32
+	}{
+		{
+			name:           "downpayment only stays with the remaining days",
+			amountPaid:     600,
+			cancelledValue: 1000,
+			wantTotal:      1000,
+			wantDown:       200,
+			wantPaid:       0,
+			wantKept:       1400,
+		},
+		{
+			name:           "fully paid refunds only the cancelled day",
+			amountPaid:     3000,
+			cancelledValue: 1000,
+			wantTotal:      1000,
+			wantDown:       200,
+			wantPaid:       1000,
+			wantKept:       0,
+		},
+		{
+			name:           "overpayment beyond the remaining day counts toward the cancelled days",
+			amountPaid:     2500,
+			cancelledValue: 2000,
+			wantTotal:      2000,
+			wantDown:       400,
+			wantPaid:       1500,
+			wantKept:       0,
+		},
+		{
+			name:           "cancelling every day keeps the whole order",
+			amountPaid:     600,
+			cancelledValue: 3000,
+			wantTotal:      3000,
+			wantDown:       600,
+			wantPaid:       600,
+			wantKept:       0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			summary := order
+			summary.AmountPaid = tt.amountPaid
+
+			scoped, kept := ScopeCancelledDays(&summary, tt.cancelledValue)
+			if scoped.OrderID != summary.OrderID {
+				t.Errorf("order id = %q, want %q", scoped.OrderID, summary.OrderID)
+			}
+			if scoped.TotalAmount != tt.wantTotal {
+				t.Errorf("total = %v, want %v", scoped.TotalAmount, tt.wantTotal)
+			}
+			if scoped.DownpaymentRequired != tt.wantDown {
+				t.Errorf("downpayment = %v, want %v", scoped.DownpaymentRequired, tt.wantDown)
+			}
+			if scoped.AmountPaid != tt.wantPaid {
+				t.Errorf("amount paid = %v, want %v", scoped.AmountPaid, tt.wantPaid)
+			}
+			if kept != tt.wantKept {
+				t.Errorf("kept balance = %v, want %v", kept, tt.wantKept)
+			}
+			if summary.AmountPaid != tt.amountPaid || summary.TotalAmount != order.TotalAmount {
+				t.Errorf("the order's summary was modified: %+v", summary)
+			}
+		})
+	}
+}
@@ -0,0 +1,266 @@
+package tasks
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"handworks-api/types"
+	"handworks-api/utils"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+)
+
+var (
+	ErrCalendarFeedNotFound      = errors.New("calendar feed not found")
+	ErrCalendarFeedDenied        = errors.New("calendar feeds can only be managed by admins and their owner")
+	ErrCalendarFeedOwnerNotFound = errors.New("calendar feed owner not found")
+)
+
+const (
+	// CalendarFeedPath is where feed tokens are served; it must stay in main.go's public paths.
+	CalendarFeedPath = "/api/booking/calendar/ics/"
+
+	calendarFeedPast     = 90 * 24 * time.Hour
+	calendarFeedFuture   = 365 * 24 * time.Hour
+	calendarFeedPageSize = 200
+	calendarFeedMaxPages = 10
+)
+
+// NewCalendarFeedToken returns a random token for a feed URL and the hash that is stored
+// in its place.
+func NewCalendarFeedToken() (token, hash string, err error) {
+	raw := make([]byte, 32)
+	if _, err := rand.Read(raw); err != nil {
+		return "", "", fmt.Errorf("could not generate calendar feed token: %w", err)
+	}
+	token = base64.RawURLEncoding.EncodeToString(raw)
+	return token, hashCalendarFeedToken(token), nil
+}
+
+func hashCalendarFeedToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+// CanManageCalendarFeed lets admins manage any feed, and employees and customers manage
+// their own.
+func CanManageCalendarFeed(viewer *AccessViewer, ownerType types.CalendarFeedOwner, ownerID string) error {
+	switch {
+	case viewer.Role == ViewerRoleAdmin:
+		return nil
+	case ownerType == types.CalendarFeedEmployee && viewer.Role == ViewerRoleEmployee && viewer.EmployeeID == ownerID:
+		return nil
+	case ownerType == types.CalendarFeedCustomer && viewer.Role == ViewerRoleCustomer && viewer.CustomerID == ownerID:
+		return nil
+	default:
+		return ErrCalendarFeedDenied
+	}
+}
+
+func (t *BookingTasks) InsertCalendarFeed(
+	ctx context.Context,
+	tx pgx.Tx,
+	req types.CreateCalendarFeedRequest,
+	tokenHash, actor string,
+) (*types.CalendarFeed, error) {
+	ownerTable := "account.customers"
+	if req.OwnerType == types.CalendarFeedEmployee {
+		ownerTable = "account.employees"
+	}
+	var exists bool
+	if err := tx.QueryRow(ctx,
+		`SELECT EXISTS (SELECT 1 FROM `+ownerTable+` WHERE id::text = $1)`, req.OwnerID,
+	).Scan(&exists); err != nil {
+		return nil, fmt.Errorf("failed to check calendar feed owner: %w", err)
+	}
+	if !exists {
+		return nil, ErrCalendarFeedOwnerNotFound
+	}
+
+	feed := &types.CalendarFeed{OwnerType: req.OwnerType, OwnerID: req.OwnerID, CreatedBy: actor}
+	if err := tx.QueryRow(ctx, `
+		INSERT INTO booking.calendar_feeds (owner_type, owner_id, token_hash, created_by, created_at)
+		VALUES ($1, $2, $3, $4, NOW())
+		RETURNING id, created_at
+	`, string(req.OwnerType), req.OwnerID, tokenHash, actor).Scan(&feed.ID, &feed.CreatedAt); err != nil {
+		return nil, fmt.Errorf("failed to insert calendar feed: %w", err)
+	}
+	return feed, nil
+}
+
+const calendarFeedColumns = `id, owner_type, owner_id, created_by, created_at, last_accessed_at, revoked_at`
+
+func scanCalendarFeed(row pgx.Row) (*types.CalendarFeed, error) {
+	var f types.CalendarFeed
+	if err := row.Scan(&f.ID, &f.OwnerType, &f.OwnerID, &f.CreatedBy, &f.CreatedAt, &f.LastAccessedAt, &f.RevokedAt); err != nil {
+		return nil, err
+	}
+	return &f, nil
+}
+
+func (t *BookingTasks) FetchCalendarFeed(ctx context.Context, tx pgx.Tx, feedID string) (*types.CalendarFeed, error) {
+	feed, err := scanCalendarFeed(tx.QueryRow(ctx,
+		`SELECT `+calendarFeedColumns+` FROM booking.calendar_feeds WHERE id::text = $1`, feedID,
+	))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrCalendarFeedNotFound
+		}
+		return nil, fmt.Errorf("failed to fetch calendar feed: %w", err)
+	}
+	return feed, nil
+}
+
+func (t *BookingTasks) FetchCalendarFeeds(
+	ctx context.Context,
+	tx pgx.Tx,
+	ownerType types.CalendarFeedOwner,
+	ownerID string,
+) ([]types.CalendarFeed, error) {
+	rows, err := tx.Query(ctx, `
+		SELECT `+calendarFeedColumns+`
+		FROM booking.calendar_feeds
+		WHERE owner_type = $1 AND owner_id = $2
+		ORDER BY created_at DESC
+	`, string(ownerType), ownerID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch calendar feeds: %w", err)
+	}
+	defer rows.Close()
+
+	feeds := make([]types.CalendarFeed, 0)
+	for rows.Next() {
+		feed, err := scanCalendarFeed(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan calendar feed: %w", err)
+		}
+		feeds = append(feeds, *feed)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed iterating calendar feeds: %w", err)
+	}
+	return feeds, nil
+}
+
+// RevokeCalendarFeed stops the feed's URL from working. Revoking twice is not an error.
+func (t *BookingTasks) RevokeCalendarFeed(ctx context.Context, tx pgx.Tx, feedID string) error {
+	if _, err := tx.Exec(ctx, `
+		UPDATE booking.calendar_feeds
+		SET revoked_at = NOW()
+		WHERE id::text = $1 AND revoked_at IS NULL
+	`, feedID); err != nil {
+		return fmt.Errorf("failed to revoke calendar feed: %w", err)
+	}
+	return nil
+}
+
+// UseCalendarFeedToken looks up the live feed a token belongs to and records the access.
+// Unknown and revoked tokens both give ErrCalendarFeedNotFound.
+func (t *BookingTasks) UseCalendarFeedToken(ctx context.Context, tx pgx.Tx, token string) (*types.CalendarFeed, error) {
+	feed, err := scanCalendarFeed(tx.QueryRow(ctx, `
+		UPDATE booking.calendar_feeds
+		SET last_accessed_at = NOW()
+		WHERE token_hash = $1 AND revoked_at IS NULL
+		RETURNING `+calendarFeedColumns,
+		hashCalendarFeedToken(token),
+	))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrCalendarFeedNotFound
+		}
+		return nil, fmt.Errorf("failed to fetch calendar feed: %w", err)
+	}
+	return feed, nil
+}
+
+// FetchCalendarFeedBookings lists the owner's bookings from calendarFeedPast ago to
+// calendarFeedFuture ahead, using the same queries as the customer and employee booking
+// lists.
+func (t *BookingTasks) FetchCalendarFeedBookings(
+	ctx context.Context,
+	tx pgx.Tx,
+	feed *types.CalendarFeed,
+	now time.Time,
+	logger *utils.Logger,
+) ([]types.Booking, error) {
+	from := now.Add(-calendarFeedPast).Format("2006-01-02")
+	to := now.Add(calendarFeedFuture).Format("2006-01-02")
+
+	var (
+		bookings []types.Booking
+		seen     = make(map[string]bool)
+	)
+	for page := 0; page < calendarFeedMaxPages; page++ {
+		var (
+			res *types.FetchAllBookingsResponse
+			err error
+		)
+		if feed.OwnerType == types.CalendarFeedEmployee {
+			res, err = t.FetchAllEmployeeAssignedBookings(ctx, tx, feed.OwnerID, from, to, page, calendarFeedPageSize, logger)
+		} else {
+			res, err = t.FetchAllCustomerBookings(ctx, tx, feed.OwnerID, from, to, "", page, calendarFeedPageSize, logger)
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		added := 0
+		for _, b := range res.Bookings {
+			if seen[b.ID] {
+				continue
+			}
+			seen[b.ID] = true
+			bookings = append(bookings, b)
+			added++
+		}
+		if added == 0 || len(res.Bookings) < calendarFeedPageSize {
+			break
+		}
+	}
+	return bookings, nil
+}
+
+// BookingCalendarEvents turns bookings into calendar events keyed by booking ID, so a
+// rescheduled booking moves and a cancelled one is marked cancelled in subscribers'
+// calendars.
+func BookingCalendarEvents(bookings []types.Booking) []utils.ICSEvent {
+	events := make([]utils.ICSEvent, 0, len(bookings))
+	for _, b := range bookings {
+		modified := b.Base.CreatedAt
+		if b.Base.UpdatedAt != nil {
+			modified = *b.Base.UpdatedAt
+		}
+		service := serviceDisplayName(b.MainService.ServiceType)
+
+		events = append(events, utils.ICSEvent{
+			UID:          b.ID + "@handworks",
+			Sequence:     int64(modified.Sub(b.Base.CreatedAt).Seconds()),
+			Start:        b.Base.StartSched,
+			End:          b.Base.EndSched,
+			LastModified: modified,
+			Summary:      "Handworks " + service,
+			Location:     b.Base.Address.AddressHuman,
+			Latitude:     b.Base.Address.AddressLat,
+			Longitude:    b.Base.Address.AddressLng,
+			Description: fmt.Sprintf("Booking ID: %s\nService: %s\nStatus: %s",
+				b.ID, service, strings.ReplaceAll(b.Base.Status, "_", " ")),
+			Cancelled: b.Base.Status == BookingStatusCancelled || b.Base.Status == BookingStatusNoShow,
+		})
+	}
+	return events
+}
+
+// serviceDisplayName turns a service type such as GENERAL_CLEANING into "General cleaning".
+func serviceDisplayName(serviceType string) string {
+	name := strings.ToLower(strings.ReplaceAll(serviceType, "_", " "))
+	if name == "" {
+		return "cleaning"
+	}
+	return strings.ToUpper(name[:1]) + name[1:]
+}
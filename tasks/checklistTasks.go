@@ -0,0 +1,278 @@
+package tasks
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"handworks-api/types"
+	"strings"
+
+	"github.com/jackc/pgx/v5"
+)
+
+var (
+	ErrInvalidChecklist          = errors.New("invalid checklist request")
+	ErrChecklistTemplateNotFound = errors.New("checklist template not found")
+	ErrChecklistItemNotFound     = errors.New("checklist item not found")
+	ErrSkipReasonRequired        = errors.New("a reason is required to skip a checklist item")
+	ErrChecklistIncomplete       = errors.New("required checklist items are not done or skipped")
+	ErrChecklistDenied           = errors.New("only the booking's cleaners and admins can update its checklist")
+)
+
+const checklistTemplateColumns = `
+	id, service_type, scope, title, COALESCE(description, ''), required, position, active`
+
+func scanChecklistTemplate(row pgx.Row) (*types.ChecklistTemplate, error) {
+	var tpl types.ChecklistTemplate
+	if err := row.Scan(
+		&tpl.ID, &tpl.ServiceType, &tpl.Scope, &tpl.Title,
+		&tpl.Description, &tpl.Required, &tpl.Position, &tpl.Active,
+	); err != nil {
+		return nil, err
+	}
+	return &tpl, nil
+}
+
+func validateChecklistTemplate(req *types.ChecklistTemplateRequest) error {
+	if !qualifiableServiceTypes[req.ServiceType] {
+		return fmt.Errorf("%w: unknown service type %q", ErrInvalidChecklist, req.ServiceType)
+	}
+	if strings.TrimSpace(req.Title) == "" {
+		return fmt.Errorf("%w: checklist title is required", ErrInvalidChecklist)
+	}
+	return nil
+}
+
+// FetchChecklistTemplates lists active templates, optionally for one service type.
+func (t *AdminTasks) FetchChecklistTemplates(ctx context.Context, tx pgx.Tx, serviceType string) ([]types.ChecklistTemplate, error) {
+	rows, err := tx.Query(ctx, `
+		SELECT `+checklistTemplateColumns+`
+		FROM booking.checklist_templates
+		WHERE active
+		  AND (NULLIF($1, '') IS NULL OR service_type = $1)
+		ORDER BY service_type ASC, scope ASC, position ASC, title ASC
+	`, serviceType)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch checklist templates: %w", err)
+	}
+	defer rows.Close()
+
+	templates := make([]types.ChecklistTemplate, 0)
+	for rows.Next() {
+		tpl, err := scanChecklistTemplate(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan checklist template: %w", err)
+		}
+		templates = append(templates, *tpl)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed iterating checklist templates: %w", err)
+	}
+	return templates, nil
+}
+
+func (t *AdminTasks) CreateChecklistTemplate(ctx context.Context, tx pgx.Tx, req *types.ChecklistTemplateRequest) (*types.ChecklistTemplate, error) {
+	if err := validateChecklistTemplate(req); err != nil {
+		return nil, err
+	}
+
+	tpl, err := scanChecklistTemplate(tx.QueryRow(ctx, `
+		INSERT INTO booking.checklist_templates
+			(service_type, scope, title, description, required, position, active, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, TRUE, NOW(), NOW())
+		RETURNING `+checklistTemplateColumns,
+		string(req.ServiceType), string(req.Scope), strings.TrimSpace(req.Title), req.Description, req.Required, req.Position,
+	))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create checklist template: %w", err)
+	}
+	return tpl, nil
+}
+
+func (t *AdminTasks) UpdateChecklistTemplate(ctx context.Context, tx pgx.Tx, templateID string, req *types.ChecklistTemplateRequest) (*types.ChecklistTemplate, error) {
+	if err := validateChecklistTemplate(req); err != nil {
+		return nil, err
+	}
+
+	tpl, err := scanChecklistTemplate(tx.QueryRow(ctx, `
+		UPDATE booking.checklist_templates
+		SET service_type = $2,
+		    scope = $3,
+		    title = $4,
+		    description = $5,
+		    required = $6,
+		    position = $7,
+		    updated_at = NOW()
+		WHERE id = $1
+		  AND active
+		RETURNING `+checklistTemplateColumns,
+		templateID, string(req.ServiceType), string(req.Scope), strings.TrimSpace(req.Title), req.Description, req.Required, req.Position,
+	))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrChecklistTemplateNotFound
+		}
+		return nil, fmt.Errorf("failed to update checklist template: %w", err)
+	}
+	return tpl, nil
+}
+
+// DeactivateChecklistTemplate stops a template from being added to new sessions. Items
+// already copied onto bookings are kept.
+func (t *AdminTasks) DeactivateChecklistTemplate(ctx context.Context, tx pgx.Tx, templateID string) error {
+	tag, err := tx.Exec(ctx, `
+		UPDATE booking.checklist_templates
+		SET active = FALSE, updated_at = NOW()
+		WHERE id = $1
+		  AND active
+	`, templateID)
+	if err != nil {
+		return fmt.Errorf("failed to deactivate checklist template: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return ErrChecklistTemplateNotFound
+	}
+	return nil
+}
+
+// InstantiateChecklist copies the active templates for the booking's main service and
+// add-ons onto the booking. Running it again leaves existing items alone.
+func (t *BookingTasks) InstantiateChecklist(ctx context.Context, tx pgx.Tx, bookingID string) error {
+	_, err := tx.Exec(ctx, `
+		INSERT INTO booking.booking_checklist_items (
+			booking_id, template_id, service_type, scope, title, description,
+			required, position, status, created_at
+		)
+		SELECT $1, t.id, t.service_type, t.scope, t.title, t.description,
+		       t.required, t.position, 'PENDING', NOW()
+		FROM booking.checklist_templates t
+		WHERE t.active
+		  AND (
+			(t.scope = 'MAIN' AND t.service_type IN (
+				SELECT s.service_type
+				FROM booking.bookings b
+				JOIN booking.services s ON s.id = b.main_service_id
+				WHERE b.id = $1
+			))
+			OR (t.scope = 'ADDON' AND t.service_type IN (
+				SELECT s.service_type
+				FROM booking.bookings b
+				JOIN booking.addons a ON a.id = ANY(b.addon_ids)
+				JOIN booking.services s ON s.id = a.service_id
+				WHERE b.id = $1
+			))
+		  )
+		ON CONFLICT (booking_id, template_id) DO NOTHING
+	`, bookingID)
+	if err != nil {
+		return fmt.Errorf("failed to create booking checklist: %w", err)
+	}
+	return nil
+}
+
+func (t *BookingTasks) FetchBookingChecklist(ctx context.Context, tx pgx.Tx, bookingID string) (*types.BookingChecklist, error) {
+	rows, err := tx.Query(ctx, `
+		SELECT `+bookingChecklistItemColumns+`
+		FROM booking.booking_checklist_items
+		WHERE booking_id = $1
+		ORDER BY scope DESC, service_type ASC, position ASC, title ASC
+	`, bookingID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch booking checklist: %w", err)
+	}
+	defer rows.Close()
+
+	checklist := &types.BookingChecklist{BookingID: bookingID, Items: []types.BookingChecklistItem{}}
+	for rows.Next() {
+		item, err := scanBookingChecklistItem(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan booking checklist item: %w", err)
+		}
+		if item.Required && item.Status == types.ChecklistItemPending {
+			checklist.Outstanding++
+		}
+		checklist.Items = append(checklist.Items, *item)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed iterating booking checklist: %w", err)
+	}
+	checklist.Complete = checklist.Outstanding == 0
+	return checklist, nil
+}
+
+// UpdateChecklistItem ticks, skips or reopens an item. Skipping needs a reason.
+func (t *BookingTasks) UpdateChecklistItem(
+	ctx context.Context,
+	tx pgx.Tx,
+	bookingID, itemID string,
+	req *types.UpdateChecklistItemRequest,
+	actor string,
+) (*types.BookingChecklistItem, error) {
+	reason := strings.TrimSpace(req.SkipReason)
+	switch req.Status {
+	case types.ChecklistItemSkipped:
+		if reason == "" {
+			return nil, ErrSkipReasonRequired
+		}
+	case types.ChecklistItemDone, types.ChecklistItemPending:
+		reason = ""
+	default:
+		return nil, fmt.Errorf("%w: unknown checklist status %q", ErrInvalidChecklist, req.Status)
+	}
+
+	item, err := scanBookingChecklistItem(tx.QueryRow(ctx, `
+		UPDATE booking.booking_checklist_items
+		SET status = $3,
+		    skip_reason = NULLIF($4, ''),
+		    updated_by = $5,
+		    updated_at = NOW()
+		WHERE id = $2
+		  AND booking_id = $1
+		RETURNING `+bookingChecklistItemColumns,
+		bookingID, itemID, string(req.Status), reason, actor,
+	))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrChecklistItemNotFound
+		}
+		return nil, fmt.Errorf("failed to update checklist item: %w", err)
+	}
+	return item, nil
+}
+
+// EnsureChecklistComplete returns ErrChecklistIncomplete, naming the outstanding items,
+// while any required item is still pending.
+func (t *BookingTasks) EnsureChecklistComplete(ctx context.Context, tx pgx.Tx, bookingID string) error {
+	checklist, err := t.FetchBookingChecklist(ctx, tx, bookingID)
+	if err != nil {
+		return err
+	}
+	if checklist.Complete {
+		return nil
+	}
+
+	titles := make([]string, 0, checklist.Outstanding)
+	for _, item := range checklist.Items {
+		if item.Required && item.Status == types.ChecklistItemPending {
+			titles = append(titles, item.Title)
+		}
+	}
+	return fmt.Errorf("%w: %s", ErrChecklistIncomplete, strings.Join(titles, ", "))
+}
+
+const bookingChecklistItemColumns = `
+	id, booking_id, COALESCE(template_id::text, ''), service_type, scope, title,
+	COALESCE(description, ''), required, position, status, COALESCE(skip_reason, ''),
+	COALESCE(updated_by, ''), updated_at`
+
+func scanBookingChecklistItem(row pgx.Row) (*types.BookingChecklistItem, error) {
+	var item types.BookingChecklistItem
+	if err := row.Scan(
+		&item.ID, &item.BookingID, &item.TemplateID, &item.ServiceType, &item.Scope, &item.Title,
+		&item.Description, &item.Required, &item.Position, &item.Status, &item.SkipReason,
+		&item.UpdatedBy, &item.UpdatedAt,
+	); err != nil {
+		return nil, err
+	}
+	return &item, nil
+}
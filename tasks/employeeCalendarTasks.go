@@ -0,0 +1,644 @@
+package tasks
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"handworks-api/types"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+)
+
+var (
+	ErrInvalidAvailability       = errors.New("invalid availability schedule")
+	ErrInvalidTimeOff            = errors.New("invalid time off request")
+	ErrCalendarRequestNotFound   = errors.New("calendar request not found")
+	ErrCalendarRequestNotPending = errors.New("calendar request has already been reviewed")
+	ErrCleanerUnavailable        = errors.New("cleaner is not available at the booking time")
+)
+
+const minutesPerDay = 24 * 60
+
+// availableOnCalendarFilter is a WHERE condition that holds when the employee in column has
+// no approved time off overlapping [startParam, endParam) and, if they have an approved
+// weekly schedule, one of its windows covers the whole job. The three parameters from first
+// onwards must be bound to calendarClockArgs.
+func availableOnCalendarFilter(column, startParam, endParam string, first int) string {
+	weekday := "$" + strconv.Itoa(first)
+	from := "$" + strconv.Itoa(first+1)
+	to := "$" + strconv.Itoa(first+2)
+
+	return `NOT EXISTS (
+			SELECT 1
+			FROM account.employee_time_off o
+			WHERE o.employee_id = ` + column + `
+			  AND o.status = 'APPROVED'
+			  AND o.start_at < ` + endParam + `
+			  AND o.end_at > ` + startParam + `
+		  )
+		  AND (
+			NOT EXISTS (
+				SELECT 1
+				FROM account.employee_availability_schedules s
+				WHERE s.employee_id = ` + column + `
+				  AND s.status = 'APPROVED'
+			)
+			OR EXISTS (
+				SELECT 1
+				FROM account.employee_availability_schedules s
+				JOIN account.employee_availability_windows w ON w.schedule_id = s.id
+				WHERE s.employee_id = ` + column + `
+				  AND s.status = 'APPROVED'
+				  AND w.weekday = ` + weekday + `::int
+				  AND w.start_time <= ` + from + `::text::time
+				  AND w.end_time >= ` + to + `::text::time
+			)
+		  )`
+}
+
+// calendarClockArgs returns the weekday and times of day of a job in the business time
+// zone loc for availableOnCalendarFilter. A job that runs past midnight cannot fit a weekly
+// window, so it gets a weekday no window has.
+func calendarClockArgs(loc *time.Location, startSched, endSched time.Time) []any {
+	weekday, from, to := calendarClock(loc, startSched, endSched)
+	clock := func(seconds int) string {
+		return fmt.Sprintf("%02d:%02d:%02d", seconds/3600, seconds/60%60, seconds%60)
+	}
+	return []any{weekday, clock(from), clock(to)}
+}
+
+// calendarClock returns the weekday of a job in loc and its start and end in seconds after
+// midnight, the end being 24:00 for a job that finishes at midnight. Jobs that run past
+// midnight get weekday -1.
+func calendarClock(loc *time.Location, startSched, endSched time.Time) (int, int, int) {
+	start := startSched.In(loc)
+	end := endSched.In(loc)
+
+	weekday := int(start.Weekday())
+	from := start.Hour()*3600 + start.Minute()*60 + start.Second()
+	to := end.Hour()*3600 + end.Minute()*60 + end.Second()
+
+	nextMidnight := time.Date(start.Year(), start.Month(), start.Day()+1, 0, 0, 0, 0, loc)
+	switch {
+	case end.Equal(nextMidnight):
+		to = minutesPerDay * 60
+	case !end.Before(nextMidnight):
+		weekday = -1
+	}
+
+	return weekday, from, to
+}
+
+// cleanerOnCalendar reports whether the employee's approved calendar allows them to work
+// the whole window.
+func cleanerOnCalendar(ctx context.Context, tx pgx.Tx, loc *time.Location, employeeID string, startSched, endSched time.Time) (bool, error) {
+	args := append([]any{employeeID, startSched, endSched}, calendarClockArgs(loc, startSched, endSched)...)
+
+	var available bool
+	err := tx.QueryRow(ctx, `
+		SELECT `+availableOnCalendarFilter("$1::uuid", "$2", "$3", 4),
+		args...,
+	).Scan(&available)
+	if err != nil {
+		return false, fmt.Errorf("failed to check employee calendar: %w", err)
+	}
+	return available, nil
+}
+
+// filterCleanersOnCalendar keeps the cleaners whose calendar allows the window, in order.
+func filterCleanersOnCalendar(
+	ctx context.Context,
+	tx pgx.Tx,
+	loc *time.Location,
+	cleaners []types.CleanerAssigned,
+	startSched, endSched time.Time,
+) ([]types.CleanerAssigned, error) {
+	if len(cleaners) == 0 {
+		return cleaners, nil
+	}
+
+	ids := make([]string, 0, len(cleaners))
+	for _, cleaner := range cleaners {
+		ids = append(ids, cleaner.ID)
+	}
+
+	args := append([]any{ids, startSched, endSched}, calendarClockArgs(loc, startSched, endSched)...)
+	rows, err := tx.Query(ctx, `
+		SELECT c.id::text
+		FROM unnest($1::uuid[]) AS c(id)
+		WHERE `+availableOnCalendarFilter("c.id", "$2", "$3", 4),
+		args...,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check cleaner calendars: %w", err)
+	}
+	defer rows.Close()
+
+	available := make(map[string]bool, len(ids))
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			return nil, fmt.Errorf("failed to scan cleaner calendar row: %w", err)
+		}
+		available[id] = true
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed iterating cleaner calendar rows: %w", err)
+	}
+
+	out := make([]types.CleanerAssigned, 0, len(cleaners))
+	for _, cleaner := range cleaners {
+		if available[cleaner.ID] {
+			out = append(out, cleaner)
+		}
+	}
+	return out, nil
+}
+
+// ValidateCleanerOnCalendar checks that the employee's approved calendar allows the window.
+func (t *AdminTasks) ValidateCleanerOnCalendar(ctx context.Context, tx pgx.Tx, employeeID string, startSched, endSched time.Time) error {
+	available, err := cleanerOnCalendar(ctx, tx, t.location(), employeeID, startSched, endSched)
+	if err != nil {
+		return err
+	}
+	if !available {
+		return ErrCleanerUnavailable
+	}
+	return nil
+}
+
+func ensureEmployeeExists(ctx context.Context, tx pgx.Tx, employeeID string) error {
+	var exists bool
+	if err := tx.QueryRow(ctx, `SELECT EXISTS(SELECT 1 FROM account.employees WHERE id = $1)`, employeeID).Scan(&exists); err != nil {
+		return fmt.Errorf("failed to check employee: %w", err)
+	}
+	if !exists {
+		return ErrEmployeeNotFoundOrInactive
+	}
+	return nil
+}
+
+// parseClock reads an HH:MM time of day as minutes after midnight. 24:00 is accepted so a
+// window can run to the end of the day.
+func parseClock(value string) (int, error) {
+	parts := strings.Split(strings.TrimSpace(value), ":")
+	if len(parts) != 2 || len(parts[1]) != 2 {
+		return 0, fmt.Errorf("%w: time %q must be HH:MM", ErrInvalidAvailability, value)
+	}
+	hours, err := strconv.Atoi(parts[0])
+	if err != nil {
+		return 0, fmt.Errorf("%w: time %q must be HH:MM", ErrInvalidAvailability, value)
+	}
+	minutes, err := strconv.Atoi(parts[1])
+	if err != nil || minutes < 0 || minutes > 59 {
+		return 0, fmt.Errorf("%w: time %q must be HH:MM", ErrInvalidAvailability, value)
+	}
+	total := hours*60 + minutes
+	if hours < 0 || total > minutesPerDay {
+		return 0, fmt.Errorf("%w: time %q is outside the day", ErrInvalidAvailability, value)
+	}
+	return total, nil
+}
+
+func formatClock(minutes int) string {
+	return fmt.Sprintf("%02d:%02d", minutes/60, minutes%60)
+}
+
+// normalizeWindows validates the windows and merges the ones that touch or overlap on the
+// same weekday, so a job spanning two back-to-back windows still fits.
+func normalizeWindows(windows []types.AvailabilityWindow) ([]types.AvailabilityWindow, error) {
+	type span struct{ weekday, start, end int }
+
+	spans := make([]span, 0, len(windows))
+	for _, w := range windows {
+		if w.Weekday < 0 || w.Weekday > 6 {
+			return nil, fmt.Errorf("%w: weekday %d must be between 0 (Sunday) and 6", ErrInvalidAvailability, w.Weekday)
+		}
+		start, err := parseClock(w.StartTime)
+		if err != nil {
+			return nil, err
+		}
+		end, err := parseClock(w.EndTime)
+		if err != nil {
+			return nil, err
+		}
+		if end <= start {
+			return nil, fmt.Errorf("%w: window %s-%s ends before it starts", ErrInvalidAvailability, w.StartTime, w.EndTime)
+		}
+		spans = append(spans, span{weekday: w.Weekday, start: start, end: end})
+	}
+	if len(spans) == 0 {
+		return nil, fmt.Errorf("%w: at least one window is required", ErrInvalidAvailability)
+	}
+
+	sort.Slice(spans, func(i, j int) bool {
+		if spans[i].weekday != spans[j].weekday {
+			return spans[i].weekday < spans[j].weekday
+		}
+		return spans[i].start < spans[j].start
+	})
+
+	merged := []span{spans[0]}
+	for _, s := range spans[1:] {
+		last := &merged[len(merged)-1]
+		if s.weekday == last.weekday && s.start <= last.end {
+			if s.end > last.end {
+				last.end = s.end
+			}
+			continue
+		}
+		merged = append(merged, s)
+	}
+
+	out := make([]types.AvailabilityWindow, 0, len(merged))
+	for _, s := range merged {
+		out = append(out, types.AvailabilityWindow{
+			Weekday:   s.weekday,
+			StartTime: formatClock(s.start),
+			EndTime:   formatClock(s.end),
+		})
+	}
+	return out, nil
+}
+
+// SubmitWeeklyAvailability stores a new weekly schedule for admin review. It replaces any
+// schedule of the employee's that is still pending; the approved one stays in force until
+// the new one is approved.
+func (t *AccountTasks) SubmitWeeklyAvailability(
+	ctx context.Context,
+	tx pgx.Tx,
+	employeeID string,
+	windows []types.AvailabilityWindow,
+) (*types.WeeklyAvailability, error) {
+	normalized, err := normalizeWindows(windows)
+	if err != nil {
+		return nil, err
+	}
+	if err := ensureEmployeeExists(ctx, tx, employeeID); err != nil {
+		return nil, err
+	}
+
+	if _, err := tx.Exec(ctx, `
+		UPDATE account.employee_availability_schedules
+		SET status = 'CANCELLED'
+		WHERE employee_id = $1
+		  AND status = 'PENDING'
+	`, employeeID); err != nil {
+		return nil, fmt.Errorf("failed to cancel pending availability schedule: %w", err)
+	}
+
+	schedule := types.WeeklyAvailability{
+		EmployeeID: employeeID,
+		Status:     types.CalendarRequestPending,
+		Windows:    normalized,
+	}
+	if err := tx.QueryRow(ctx, `
+		INSERT INTO account.employee_availability_schedules (employee_id, status, submitted_at)
+		VALUES ($1, 'PENDING', NOW())
+		RETURNING id, submitted_at
+	`, employeeID).Scan(&schedule.ID, &schedule.SubmittedAt); err != nil {
+		return nil, fmt.Errorf("failed to save availability schedule: %w", err)
+	}
+
+	for _, w := range normalized {
+		if _, err := tx.Exec(ctx, `
+			INSERT INTO account.employee_availability_windows (schedule_id, weekday, start_time, end_time)
+			VALUES ($1, $2, $3::text::time, $4::text::time)
+		`, schedule.ID, w.Weekday, w.StartTime, w.EndTime); err != nil {
+			return nil, fmt.Errorf("failed to save availability window: %w", err)
+		}
+	}
+
+	return &schedule, nil
+}
+
+// RequestTimeOff records a stretch of unavailability for admin review.
+func (t *AccountTasks) RequestTimeOff(ctx context.Context, tx pgx.Tx, employeeID string, req *types.RequestTimeOffRequest) (*types.TimeOff, error) {
+	if !req.EndAt.After(req.StartAt) {
+		return nil, fmt.Errorf("%w: endAt must be after startAt", ErrInvalidTimeOff)
+	}
+	if !req.EndAt.After(time.Now()) {
+		return nil, fmt.Errorf("%w: time off must end in the future", ErrInvalidTimeOff)
+	}
+	if err := ensureEmployeeExists(ctx, tx, employeeID); err != nil {
+		return nil, err
+	}
+
+	var id string
+	if err := tx.QueryRow(ctx, `
+		INSERT INTO account.employee_time_off (employee_id, start_at, end_at, reason, status, requested_at)
+		VALUES ($1, $2, $3, $4, 'PENDING', NOW())
+		RETURNING id
+	`, employeeID, req.StartAt, req.EndAt, strings.TrimSpace(req.Reason)).Scan(&id); err != nil {
+		return nil, fmt.Errorf("failed to save time off request: %w", err)
+	}
+
+	return fetchTimeOff(ctx, tx, id)
+}
+
+// CancelTimeOff withdraws a pending request, or approved time off that has not ended yet.
+func (t *AccountTasks) CancelTimeOff(ctx context.Context, tx pgx.Tx, employeeID, timeOffID string) (*types.TimeOff, error) {
+	timeOff, err := fetchTimeOff(ctx, tx, timeOffID)
+	if err != nil {
+		return nil, err
+	}
+	if timeOff.EmployeeID != employeeID {
+		return nil, ErrCalendarRequestNotFound
+	}
+
+	switch timeOff.Status {
+	case types.CalendarRequestPending:
+	case types.CalendarRequestApproved:
+		if !timeOff.EndAt.After(time.Now()) {
+			return nil, fmt.Errorf("%w: time off has already ended", ErrCalendarRequestNotPending)
+		}
+	default:
+		return nil, fmt.Errorf("%w: time off is %s", ErrCalendarRequestNotPending, timeOff.Status)
+	}
+
+	if _, err := tx.Exec(ctx, `
+		UPDATE account.employee_time_off
+		SET status = 'CANCELLED'
+		WHERE id = $1
+	`, timeOffID); err != nil {
+		return nil, fmt.Errorf("failed to cancel time off: %w", err)
+	}
+
+	timeOff.Status = types.CalendarRequestCancelled
+	return timeOff, nil
+}
+
+func (t *AccountTasks) FetchEmployeeCalendar(ctx context.Context, tx pgx.Tx, employeeID string) (*types.EmployeeCalendar, error) {
+	if err := ensureEmployeeExists(ctx, tx, employeeID); err != nil {
+		return nil, err
+	}
+
+	calendar := &types.EmployeeCalendar{EmployeeID: employeeID}
+
+	schedules, err := fetchWeeklyAvailability(ctx, tx, `
+		WHERE s.employee_id = $1
+		  AND s.status IN ('APPROVED', 'PENDING')
+	`, employeeID)
+	if err != nil {
+		return nil, err
+	}
+	for i := range schedules {
+		switch schedules[i].Status {
+		case types.CalendarRequestApproved:
+			calendar.WeeklySchedule = &schedules[i]
+		case types.CalendarRequestPending:
+			calendar.PendingSchedule = &schedules[i]
+		}
+	}
+
+	calendar.UpcomingTimeOff, err = fetchTimeOffs(ctx, tx, `
+		WHERE o.employee_id = $1
+		  AND o.status IN ('APPROVED', 'PENDING')
+		  AND o.end_at > NOW()
+	`, employeeID)
+	if err != nil {
+		return nil, err
+	}
+
+	return calendar, nil
+}
+
+func (t *AdminTasks) FetchPendingCalendarRequests(ctx context.Context, tx pgx.Tx) (*types.PendingCalendarRequestsResponse, error) {
+	schedules, err := fetchWeeklyAvailability(ctx, tx, `WHERE s.status = 'PENDING'`)
+	if err != nil {
+		return nil, err
+	}
+	timeOff, err := fetchTimeOffs(ctx, tx, `WHERE o.status = 'PENDING'`)
+	if err != nil {
+		return nil, err
+	}
+	return &types.PendingCalendarRequestsResponse{Schedules: schedules, TimeOff: timeOff}, nil
+}
+
+// ReviewWeeklyAvailability approves or rejects a pending schedule. An approved schedule
+// supersedes the employee's previous one.
+func (t *AdminTasks) ReviewWeeklyAvailability(
+	ctx context.Context,
+	tx pgx.Tx,
+	scheduleID string,
+	approve bool,
+	actor string,
+) (*types.WeeklyAvailability, error) {
+	var employeeID, status string
+	err := tx.QueryRow(ctx, `
+		SELECT employee_id, status
+		FROM account.employee_availability_schedules
+		WHERE id = $1
+		FOR UPDATE
+	`, scheduleID).Scan(&employeeID, &status)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrCalendarRequestNotFound
+		}
+		return nil, fmt.Errorf("failed to fetch availability schedule: %w", err)
+	}
+	if types.CalendarRequestStatus(status) != types.CalendarRequestPending {
+		return nil, fmt.Errorf("%w: schedule is %s", ErrCalendarRequestNotPending, status)
+	}
+
+	next := types.CalendarRequestRejected
+	if approve {
+		next = types.CalendarRequestApproved
+		if _, err := tx.Exec(ctx, `
+			UPDATE account.employee_availability_schedules
+			SET status = 'SUPERSEDED'
+			WHERE employee_id = $1
+			  AND status = 'APPROVED'
+		`, employeeID); err != nil {
+			return nil, fmt.Errorf("failed to supersede availability schedule: %w", err)
+		}
+	}
+
+	if _, err := tx.Exec(ctx, `
+		UPDATE account.employee_availability_schedules
+		SET status = $2, reviewed_by = $3, reviewed_at = NOW()
+		WHERE id = $1
+	`, scheduleID, string(next), actor); err != nil {
+		return nil, fmt.Errorf("failed to review availability schedule: %w", err)
+	}
+
+	schedules, err := fetchWeeklyAvailability(ctx, tx, `WHERE s.id = $1`, scheduleID)
+	if err != nil {
+		return nil, err
+	}
+	if len(schedules) == 0 {
+		return nil, ErrCalendarRequestNotFound
+	}
+	return &schedules[0], nil
+}
+
+func (t *AdminTasks) ReviewTimeOff(ctx context.Context, tx pgx.Tx, timeOffID string, approve bool, actor string) (*types.TimeOff, error) {
+	var status string
+	err := tx.QueryRow(ctx, `
+		SELECT status
+		FROM account.employee_time_off
+		WHERE id = $1
+		FOR UPDATE
+	`, timeOffID).Scan(&status)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrCalendarRequestNotFound
+		}
+		return nil, fmt.Errorf("failed to fetch time off: %w", err)
+	}
+	if types.CalendarRequestStatus(status) != types.CalendarRequestPending {
+		return nil, fmt.Errorf("%w: time off is %s", ErrCalendarRequestNotPending, status)
+	}
+
+	next := types.CalendarRequestRejected
+	if approve {
+		next = types.CalendarRequestApproved
+	}
+	if _, err := tx.Exec(ctx, `
+		UPDATE account.employee_time_off
+		SET status = $2, reviewed_by = $3, reviewed_at = NOW()
+		WHERE id = $1
+	`, timeOffID, string(next), actor); err != nil {
+		return nil, fmt.Errorf("failed to review time off: %w", err)
+	}
+
+	return fetchTimeOff(ctx, tx, timeOffID)
+}
+
+// FetchBookingsDuringTimeOff returns the live bookings the employee is assigned to that
+// overlap the window.
+func (t *AdminTasks) FetchBookingsDuringTimeOff(ctx context.Context, tx pgx.Tx, employeeID string, startAt, endAt time.Time) ([]string, error) {
+	rows, err := tx.Query(ctx, `
+		SELECT b.id::text
+		FROM booking.bookings b
+		JOIN booking.basebookings bb ON bb.id = b.base_booking_id
+		WHERE $1 = ANY(b.cleaner_ids)
+		  AND bb.startsched < $3
+		  AND bb.endsched > $2
+		  AND UPPER(COALESCE(bb.reviewstatus, '')) NOT IN ('CANCELLED', 'REJECTED')
+		  AND UPPER(COALESCE(bb.status, '')) NOT IN ('CANCELLED', 'COMPLETED')
+		ORDER BY bb.startsched ASC
+	`, employeeID, startAt, endAt)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch bookings during time off: %w", err)
+	}
+	defer rows.Close()
+
+	bookingIDs := make([]string, 0)
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			return nil, fmt.Errorf("failed to scan booking during time off: %w", err)
+		}
+		bookingIDs = append(bookingIDs, id)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed iterating bookings during time off: %w", err)
+	}
+	return bookingIDs, nil
+}
+
+func fetchWeeklyAvailability(ctx context.Context, tx pgx.Tx, where string, args ...any) ([]types.WeeklyAvailability, error) {
+	rows, err := tx.Query(ctx, `
+		SELECT
+			s.id,
+			s.employee_id,
+			s.status,
+			s.submitted_at,
+			COALESCE(s.reviewed_by, ''),
+			s.reviewed_at,
+			w.weekday,
+			to_char(w.start_time, 'HH24:MI'),
+			to_char(w.end_time, 'HH24:MI')
+		FROM account.employee_availability_schedules s
+		JOIN account.employee_availability_windows w ON w.schedule_id = s.id
+		`+where+`
+		ORDER BY s.submitted_at ASC, s.id, w.weekday ASC, w.start_time ASC
+	`, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch availability schedules: %w", err)
+	}
+	defer rows.Close()
+
+	schedules := make([]types.WeeklyAvailability, 0)
+	for rows.Next() {
+		var (
+			s types.WeeklyAvailability
+			w types.AvailabilityWindow
+		)
+		if err := rows.Scan(
+			&s.ID, &s.EmployeeID, &s.Status, &s.SubmittedAt, &s.ReviewedBy, &s.ReviewedAt,
+			&w.Weekday, &w.StartTime, &w.EndTime,
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan availability window: %w", err)
+		}
+		// A window only ends at 00:00 when it runs to the end of the day.
+		if w.EndTime == "00:00" {
+			w.EndTime = "24:00"
+		}
+
+		if n := len(schedules); n > 0 && schedules[n-1].ID == s.ID {
+			schedules[n-1].Windows = append(schedules[n-1].Windows, w)
+			continue
+		}
+		s.Windows = []types.AvailabilityWindow{w}
+		schedules = append(schedules, s)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed iterating availability windows: %w", err)
+	}
+	return schedules, nil
+}
+
+func fetchTimeOff(ctx context.Context, tx pgx.Tx, timeOffID string) (*types.TimeOff, error) {
+	timeOffs, err := fetchTimeOffs(ctx, tx, `WHERE o.id = $1`, timeOffID)
+	if err != nil {
+		return nil, err
+	}
+	if len(timeOffs) == 0 {
+		return nil, ErrCalendarRequestNotFound
+	}
+	return &timeOffs[0], nil
+}
+
+func fetchTimeOffs(ctx context.Context, tx pgx.Tx, where string, args ...any) ([]types.TimeOff, error) {
+	rows, err := tx.Query(ctx, `
+		SELECT
+			o.id,
+			o.employee_id,
+			o.start_at,
+			o.end_at,
+			COALESCE(o.reason, ''),
+			o.status,
+			o.requested_at,
+			COALESCE(o.reviewed_by, ''),
+			o.reviewed_at
+		FROM account.employee_time_off o
+		`+where+`
+		ORDER BY o.start_at ASC
+	`, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch time off: %w", err)
+	}
+	defer rows.Close()
+
+	timeOffs := make([]types.TimeOff, 0)
+	for rows.Next() {
+		var o types.TimeOff
+		if err := rows.Scan(
+			&o.ID, &o.EmployeeID, &o.StartAt, &o.EndAt, &o.Reason,
+			&o.Status, &o.RequestedAt, &o.ReviewedBy, &o.ReviewedAt,
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan time off: %w", err)
+		}
+		timeOffs = append(timeOffs, o)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed iterating time off: %w", err)
+	}
+	return timeOffs, nil
+}
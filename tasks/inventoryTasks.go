@@ -3,6 +3,7 @@ package tasks
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"handworks-api/types"
 
@@ -11,6 +12,12 @@ import (
 
 type InventoryTasks struct{}
 
+var (
+	ErrAllocationRuleNotFound = errors.New("allocation rule not found")
+	ErrInventoryItemNotFound  = errors.New("inventory item not found")
+	ErrInvalidAllocationRule  = errors.New("invalid allocation rule")
+)
+
 func (t *InventoryTasks) CreateInventoryItem(
 	c context.Context,
 	tx pgx.Tx,
@@ -180,6 +187,147 @@ func (t *InventoryTasks) DeleteInventoryItem(
 	return &item, nil
 }
 
+func (t *InventoryTasks) ValidateAllocationRule(serviceType types.MainServiceType, quantities ...float64) error {
+	switch serviceType {
+	case types.GeneralCleaning, types.CouchCleaning, types.MattressCleaning, types.CarCleaning, types.PostCleaning:
+	default:
+		return fmt.Errorf("%w: unsupported service type %s", ErrInvalidAllocationRule, serviceType)
+	}
+	for _, q := range quantities {
+		if q < 0 {
+			return fmt.Errorf("%w: quantities and factors cannot be negative", ErrInvalidAllocationRule)
+		}
+	}
+	return nil
+}
+
+const allocationRuleColumns = `
+	r.id::text,
+	r.service_type,
+	r.item_id::text,
+	i.name,
+	i.type,
+	r.base_quantity,
+	r.per_sqm,
+	r.per_unit,
+	r.dirty_scale_factor,
+	r.created_at,
+	r.updated_at`
+
+func scanAllocationRule(row pgx.Row) (*types.AllocationRule, error) {
+	var rule types.AllocationRule
+	if err := row.Scan(
+		&rule.ID,
+		&rule.ServiceType,
+		&rule.ItemID,
+		&rule.ItemName,
+		&rule.ItemType,
+		&rule.BaseQuantity,
+		&rule.PerSQM,
+		&rule.PerUnit,
+		&rule.DirtyScaleFactor,
+		&rule.CreatedAt,
+		&rule.UpdatedAt,
+	); err != nil {
+		return nil, err
+	}
+	return &rule, nil
+}
+
+func (t *InventoryTasks) CreateAllocationRule(ctx context.Context, tx pgx.Tx, in *types.CreateAllocationRuleRequest) (*types.AllocationRule, error) {
+	var ruleID string
+	err := tx.QueryRow(ctx, `
+		INSERT INTO inventory.allocation_rules
+			(service_type, item_id, base_quantity, per_sqm, per_unit, dirty_scale_factor)
+		SELECT $1, i.id, $3, $4, $5, $6
+		FROM inventory.items i
+		WHERE i.id = $2
+		RETURNING id::text
+	`, in.ServiceType, in.ItemID, in.BaseQuantity, in.PerSQM, in.PerUnit, in.DirtyScaleFactor).Scan(&ruleID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrInventoryItemNotFound
+		}
+		return nil, fmt.Errorf("could not create allocation rule: %w", err)
+	}
+
+	return t.FetchAllocationRule(ctx, tx, ruleID)
+}
+
+func (t *InventoryTasks) FetchAllocationRule(ctx context.Context, tx pgx.Tx, id string) (*types.AllocationRule, error) {
+	rule, err := scanAllocationRule(tx.QueryRow(ctx, `
+		SELECT `+allocationRuleColumns+`
+		FROM inventory.allocation_rules r
+		JOIN inventory.items i ON i.id = r.item_id
+		WHERE r.id = $1
+	`, id))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrAllocationRuleNotFound
+		}
+		return nil, fmt.Errorf("could not fetch allocation rule with id %s: %w", id, err)
+	}
+	return rule, nil
+}
+
+func (t *InventoryTasks) FetchAllocationRules(ctx context.Context, tx pgx.Tx, serviceType string) ([]types.AllocationRule, error) {
+	rows, err := tx.Query(ctx, `
+		SELECT `+allocationRuleColumns+`
+		FROM inventory.allocation_rules r
+		JOIN inventory.items i ON i.id = r.item_id
+		WHERE NULLIF($1, '') IS NULL OR r.service_type = $1
+		ORDER BY r.service_type, i.name
+	`, serviceType)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch allocation rules: %w", err)
+	}
+	defer rows.Close()
+
+	rules := make([]types.AllocationRule, 0)
+	for rows.Next() {
+		rule, err := scanAllocationRule(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan allocation rule: %w", err)
+		}
+		rules = append(rules, *rule)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("allocation rule rows error: %w", err)
+	}
+	return rules, nil
+}
+
+func (t *InventoryTasks) UpdateAllocationRule(ctx context.Context, tx pgx.Tx, id string, in *types.UpdateAllocationRuleRequest) (*types.AllocationRule, error) {
+	result, err := tx.Exec(ctx, `
+		UPDATE inventory.allocation_rules
+		SET base_quantity = COALESCE($2, base_quantity),
+		    per_sqm = COALESCE($3, per_sqm),
+		    per_unit = COALESCE($4, per_unit),
+		    dirty_scale_factor = COALESCE($5, dirty_scale_factor),
+		    updated_at = NOW()
+		WHERE id = $1
+	`, id, in.BaseQuantity, in.PerSQM, in.PerUnit, in.DirtyScaleFactor)
+	if err != nil {
+		return nil, fmt.Errorf("could not update allocation rule: %w", err)
+	}
+	if result.RowsAffected() == 0 {
+		return nil, ErrAllocationRuleNotFound
+	}
+
+	return t.FetchAllocationRule(ctx, tx, id)
+}
+
+func (t *InventoryTasks) DeleteAllocationRule(ctx context.Context, tx pgx.Tx, id string) error {
+	result, err := tx.Exec(ctx, `DELETE FROM inventory.allocation_rules WHERE id = $1`, id)
+	if err != nil {
+		return fmt.Errorf("could not delete allocation rule with id %s: %w", id, err)
+	}
+	if result.RowsAffected() == 0 {
+		return ErrAllocationRuleNotFound
+	}
+	return nil
+}
+
 // for the assignment logic
 // func (s *InventoryTasks) resolveEquipmentTypes(serviceType string) []string {
 // 	switch serviceType {
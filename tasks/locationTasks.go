@@ -0,0 +1,138 @@
+package tasks
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"handworks-api/types"
+	"handworks-api/utils"
+	"slices"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+)
+
+var (
+	ErrLocationNotTracked = errors.New("booking is not en route or in progress")
+	ErrLocationDenied     = errors.New("cleaner locations are only shown to admins and the booking's customer")
+)
+
+// CanTrackLocation accepts pings from a cleaner assigned to the booking while it is
+// ongoing or late, or while it has not started yet and starts within the en-route window.
+func CanTrackLocation(policy types.LocationPolicy, snap *BookingSnapshot, employeeID string, now time.Time) error {
+	if !slices.Contains(snap.CleanerIDs, employeeID) {
+		return ErrCleanerNotAssigned
+	}
+	switch snap.Status {
+	case BookingStatusOngoing, BookingStatusLate:
+		return nil
+	case BookingStatusNotStarted:
+		if now.Before(snap.StartSched.Add(-policy.EnRouteWindow)) || !now.Before(snap.EndSched) {
+			return ErrLocationNotTracked
+		}
+		return nil
+	default:
+		return ErrLocationNotTracked
+	}
+}
+
+// EstimateArrival fills in the distance to the booking address and a straight-line ETA at
+// the policy's average speed. A cleaner inside the geofence radius has arrived.
+func EstimateArrival(
+	policy types.LocationPolicy,
+	geofence types.GeofencePolicy,
+	address types.Address,
+	loc *types.CleanerLocation,
+) {
+	if !hasCoordinates(address) {
+		return
+	}
+	meters := utils.HaversineKm(address.AddressLat, address.AddressLng, loc.Latitude, loc.Longitude) * 1000
+	loc.DistanceMeters = &meters
+	loc.Arrived = meters <= geofence.RadiusMeters
+
+	var eta int64
+	if !loc.Arrived {
+		eta = int64(meters / 1000 / policy.AverageSpeedKPH * 3600)
+	}
+	loc.ETASeconds = &eta
+}
+
+// SaveEmployeeLocation replaces the employee's latest position. A ping for the same
+// booking less than minInterval after the stored one is dropped and reported as false.
+func (t *BookingTasks) SaveEmployeeLocation(
+	ctx context.Context,
+	tx pgx.Tx,
+	loc *types.CleanerLocation,
+	minInterval time.Duration,
+) (bool, error) {
+	var employeeID string
+	err := tx.QueryRow(ctx, `
+		INSERT INTO account.employee_locations
+			(employee_id, booking_id, latitude, longitude, accuracy_m, distance_m, eta_seconds, arrived, recorded_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+		ON CONFLICT (employee_id) DO UPDATE
+		SET booking_id = EXCLUDED.booking_id,
+		    latitude = EXCLUDED.latitude,
+		    longitude = EXCLUDED.longitude,
+		    accuracy_m = EXCLUDED.accuracy_m,
+		    distance_m = EXCLUDED.distance_m,
+		    eta_seconds = EXCLUDED.eta_seconds,
+		    arrived = EXCLUDED.arrived,
+		    recorded_at = EXCLUDED.recorded_at
+		WHERE account.employee_locations.booking_id <> EXCLUDED.booking_id
+		   OR account.employee_locations.recorded_at <= EXCLUDED.recorded_at - make_interval(secs => $10)
+		RETURNING employee_id
+	`,
+		loc.EmployeeID, loc.BookingID, loc.Latitude, loc.Longitude, loc.AccuracyMeters,
+		loc.DistanceMeters, loc.ETASeconds, loc.Arrived, loc.RecordedAt, minInterval.Seconds(),
+	).Scan(&employeeID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return false, nil
+		}
+		return false, fmt.Errorf("failed to save employee location: %w", err)
+	}
+	return true, nil
+}
+
+// FetchBookingLocations returns the latest positions recorded for the booking since the
+// given time, one per cleaner.
+func (t *BookingTasks) FetchBookingLocations(ctx context.Context, tx pgx.Tx, bookingID string, since time.Time) ([]types.CleanerLocation, error) {
+	rows, err := tx.Query(ctx, `
+		SELECT employee_id::text, booking_id::text, latitude, longitude, accuracy_m, distance_m, eta_seconds, arrived, recorded_at
+		FROM account.employee_locations
+		WHERE booking_id::text = $1 AND recorded_at >= $2
+		ORDER BY recorded_at DESC
+	`, bookingID, since)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch booking locations: %w", err)
+	}
+	defer rows.Close()
+
+	locations := make([]types.CleanerLocation, 0)
+	for rows.Next() {
+		var l types.CleanerLocation
+		if err := rows.Scan(
+			&l.EmployeeID, &l.BookingID, &l.Latitude, &l.Longitude, &l.AccuracyMeters,
+			&l.DistanceMeters, &l.ETASeconds, &l.Arrived, &l.RecordedAt,
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan booking location: %w", err)
+		}
+		locations = append(locations, l)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed iterating booking locations: %w", err)
+	}
+	return locations, nil
+}
+
+// PurgeEmployeeLocations deletes positions recorded before the cutoff and returns how many
+// were removed.
+func (t *BookingTasks) PurgeEmployeeLocations(ctx context.Context, tx pgx.Tx, before time.Time) (int64, error) {
+	tag, err := tx.Exec(ctx, `DELETE FROM account.employee_locations WHERE recorded_at < $1`, before)
+	if err != nil {
+		return 0, fmt.Errorf("failed to purge employee locations: %w", err)
+	}
+	return tag.RowsAffected(), nil
+}
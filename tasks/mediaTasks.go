@@ -0,0 +1,240 @@
+package tasks
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"handworks-api/types"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+)
+
+type MediaTasks struct{}
+
+var (
+	ErrMediaNotFound          = errors.New("media not found")
+	ErrMediaTooLarge          = errors.New("file is larger than the upload limit")
+	ErrUnsupportedMediaType   = errors.New("unsupported media type")
+	ErrInvalidMediaPurpose    = errors.New("invalid media purpose")
+	ErrInvalidMediaSignature  = errors.New("media link is invalid or has expired")
+	ErrMediaPurposeMismatched = errors.New("media was not uploaded for this use")
+	ErrMediaDenied            = errors.New("media is not available to this user")
+)
+
+// MediaDownloadPath is where signed media URLs point. It is public; the signature is the
+// only thing that grants access.
+const MediaDownloadPath = "/api/media/files/"
+
+func ValidateMediaPurpose(purpose types.MediaPurpose) error {
+	switch purpose {
+	case types.MediaPurposeBooking, types.MediaPurposeSession, types.MediaPurposeProfile:
+		return nil
+	default:
+		return fmt.Errorf("%w: %q", ErrInvalidMediaPurpose, purpose)
+	}
+}
+
+// MediaObjectKey is the storage key of one variant of a media item.
+func MediaObjectKey(media *types.Media, variant types.MediaVariant) string {
+	ext := ".jpg"
+	if media.ContentType == "image/png" {
+		ext = ".png"
+	}
+	return fmt.Sprintf("%s/%s/%s%s", strings.ToLower(string(media.Purpose)), media.ID, variant, ext)
+}
+
+const mediaColumns = `
+	id, purpose, COALESCE(owner_id, ''), content_type, size_bytes, width, height, uploaded_by, created_at`
+
+func scanMedia(row pgx.Row) (*types.Media, error) {
+	var m types.Media
+	if err := row.Scan(
+		&m.ID, &m.Purpose, &m.OwnerID, &m.ContentType, &m.SizeBytes,
+		&m.Width, &m.Height, &m.UploadedBy, &m.CreatedAt,
+	); err != nil {
+		return nil, err
+	}
+	return &m, nil
+}
+
+func (t *MediaTasks) InsertMedia(ctx context.Context, tx pgx.Tx, media *types.Media) (*types.Media, error) {
+	created, err := scanMedia(tx.QueryRow(ctx, `
+		INSERT INTO booking.media
+			(purpose, owner_id, content_type, size_bytes, width, height, uploaded_by, created_at)
+		VALUES ($1, NULLIF($2, ''), $3, $4, $5, $6, $7, NOW())
+		RETURNING `+mediaColumns,
+		string(media.Purpose), media.OwnerID, media.ContentType, media.SizeBytes,
+		media.Width, media.Height, media.UploadedBy,
+	))
+	if err != nil {
+		return nil, fmt.Errorf("failed to save media: %w", err)
+	}
+	return created, nil
+}
+
+func (t *MediaTasks) FetchMedia(ctx context.Context, tx pgx.Tx, mediaID string) (*types.Media, error) {
+	media, err := scanMedia(tx.QueryRow(ctx, `
+		SELECT `+mediaColumns+`
+		FROM booking.media
+		WHERE id::text = $1
+	`, mediaID))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrMediaNotFound
+		}
+		return nil, fmt.Errorf("failed to fetch media: %w", err)
+	}
+	return media, nil
+}
+
+// FetchMediaList returns the media with the given IDs in the order they were asked for.
+// Unknown IDs are an error.
+func (t *MediaTasks) FetchMediaList(ctx context.Context, tx pgx.Tx, mediaIDs []string) ([]types.Media, error) {
+	rows, err := tx.Query(ctx, `
+		SELECT `+mediaColumns+`
+		FROM booking.media
+		WHERE id::text = ANY($1::text[])
+	`, mediaIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch media: %w", err)
+	}
+	defer rows.Close()
+
+	byID := make(map[string]types.Media, len(mediaIDs))
+	for rows.Next() {
+		m, err := scanMedia(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan media: %w", err)
+		}
+		byID[m.ID] = *m
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed iterating media: %w", err)
+	}
+
+	list := make([]types.Media, 0, len(mediaIDs))
+	for _, id := range mediaIDs {
+		m, ok := byID[id]
+		if !ok {
+			return nil, fmt.Errorf("%w: %s", ErrMediaNotFound, id)
+		}
+		list = append(list, m)
+	}
+	return list, nil
+}
+
+// CheckMediaAccess fails with ErrMediaDenied unless viewer may see every one of the media.
+// Admins see everything. Others see what they uploaded, their own profile picture, and
+// the booking and session photos of bookings they are the customer or a cleaner of.
+func (t *MediaTasks) CheckMediaAccess(ctx context.Context, tx pgx.Tx, viewer *AccessViewer, mediaIDs []string) error {
+	if viewer.Role == ViewerRoleAdmin {
+		return nil
+	}
+
+	var denied []string
+	rows, err := tx.Query(ctx, `
+		SELECT m.id::text
+		FROM booking.media m
+		WHERE m.id::text = ANY($1::text[])
+		  AND m.uploaded_by <> $2
+		  AND NOT (m.owner_id IS NOT NULL AND m.owner_id::text IN ($3, $4))
+		  AND NOT EXISTS (
+			SELECT 1
+			FROM booking.bookings b
+			JOIN booking.basebookings bb ON bb.id = b.base_booking_id
+			LEFT JOIN booking.sessions se ON se.booking_id = b.id
+			WHERE (
+				m.id::text = ANY(bb.photos::text[])
+				OR m.id::text = ANY(se.start_photos::text[])
+				OR m.id::text = ANY(se.end_photos::text[])
+			  )
+			  AND (
+				bb.custid::text = NULLIF($3, '')
+				OR NULLIF($4, '') = ANY(b.cleaner_ids::text[])
+			  )
+		  )
+	`, mediaIDs, viewer.Actor, viewer.CustomerID, viewer.EmployeeID)
+	if err != nil {
+		return fmt.Errorf("failed to check media access: %w", err)
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			return fmt.Errorf("failed to scan media: %w", err)
+		}
+		denied = append(denied, id)
+	}
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("failed iterating media: %w", err)
+	}
+
+	if len(denied) > 0 {
+		return fmt.Errorf("%w: %s", ErrMediaDenied, strings.Join(denied, ", "))
+	}
+	return nil
+}
+
+// ValidateMediaIDs checks that every ID is media uploaded for the given purpose, so
+// bookings and sessions only ever reference files that went through the upload checks.
+func (t *BookingTasks) ValidateMediaIDs(ctx context.Context, tx pgx.Tx, mediaIDs []string, purpose types.MediaPurpose) error {
+	if len(mediaIDs) == 0 {
+		return nil
+	}
+	media, err := (&MediaTasks{}).FetchMediaList(ctx, tx, mediaIDs)
+	if err != nil {
+		return err
+	}
+	for _, m := range media {
+		if m.Purpose != purpose {
+			return fmt.Errorf("%w: %s is %s media", ErrMediaPurposeMismatched, m.ID, strings.ToLower(string(m.Purpose)))
+		}
+	}
+	return nil
+}
+
+// SignMediaURLs fills in the media's download URLs, valid for policy.URLTTL from now.
+func SignMediaURLs(media *types.Media, policy types.MediaPolicy, now time.Time) {
+	expires := now.Add(policy.URLTTL).Truncate(time.Second)
+	media.URL = signedMediaURL(media.ID, types.MediaVariantOriginal, expires, policy)
+	media.ThumbnailURL = signedMediaURL(media.ID, types.MediaVariantThumbnail, expires, policy)
+	media.URLExpiresAt = expires
+}
+
+func signedMediaURL(mediaID string, variant types.MediaVariant, expires time.Time, policy types.MediaPolicy) string {
+	unix := expires.Unix()
+	q := url.Values{}
+	q.Set("variant", string(variant))
+	q.Set("expires", strconv.FormatInt(unix, 10))
+	q.Set("signature", mediaSignature(mediaID, variant, unix, policy.URLSecret))
+	return policy.BaseURL + MediaDownloadPath + url.PathEscape(mediaID) + "?" + q.Encode()
+}
+
+func mediaSignature(mediaID string, variant types.MediaVariant, expires int64, secret []byte) string {
+	mac := hmac.New(sha256.New, secret)
+	fmt.Fprintf(mac, "%s\n%s\n%d", mediaID, variant, expires)
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
+
+// VerifyMediaSignature checks a signed URL's parameters.
+func VerifyMediaSignature(mediaID string, variant types.MediaVariant, expires, signature string, policy types.MediaPolicy, now time.Time) error {
+	if variant != types.MediaVariantOriginal && variant != types.MediaVariantThumbnail {
+		return ErrInvalidMediaSignature
+	}
+	unix, err := strconv.ParseInt(expires, 10, 64)
+	if err != nil || now.Unix() > unix {
+		return ErrInvalidMediaSignature
+	}
+	want := mediaSignature(mediaID, variant, unix, policy.URLSecret)
+	if !hmac.Equal([]byte(want), []byte(signature)) {
+		return ErrInvalidMediaSignature
+	}
+	return nil
+}
@@ -0,0 +1,110 @@
+package tasks
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"handworks-api/types"
+	"slices"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+)
+
+var (
+	ErrNoShowTooEarly     = errors.New("customer no-show cannot be reported yet")
+	ErrNoShowReportDenied = errors.New("only the booking's cleaners and admins can report a customer no-show")
+	ErrNoShowLocation     = errors.New("location is required to report a customer no-show")
+)
+
+// noShowBatchSize caps how many bookings one monitor pass moves, so a backlog after
+// downtime is worked through over a few passes instead of one long run.
+const noShowBatchSize = 100
+
+// FetchOverdueBookingIDs lists approved bookings in status that were due to start between
+// startedAfter and startedBefore, oldest first. The lower bound keeps bookings that were
+// overdue long before the monitor ran from being moved.
+func (t *BookingTasks) FetchOverdueBookingIDs(
+	ctx context.Context,
+	tx pgx.Tx,
+	status string,
+	startedAfter time.Time,
+	startedBefore time.Time,
+) ([]string, error) {
+	rows, err := tx.Query(ctx, `
+		SELECT b.id::text
+		FROM booking.bookings b
+		JOIN booking.basebookings bb ON bb.id = b.base_booking_id
+		WHERE UPPER(COALESCE(bb.status, '')) = $1
+		  AND UPPER(COALESCE(bb.reviewstatus, '')) = $2
+		  AND bb.startsched >= $3
+		  AND bb.startsched < $4
+		ORDER BY bb.startsched
+		LIMIT $5
+	`, status, ReviewStatusScheduled, startedAfter, startedBefore, noShowBatchSize)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch overdue bookings: %w", err)
+	}
+	defer rows.Close()
+
+	var ids []string
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			return nil, fmt.Errorf("failed to scan overdue booking: %w", err)
+		}
+		ids = append(ids, id)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed iterating overdue bookings: %w", err)
+	}
+	return ids, nil
+}
+
+// CanReportCustomerNoShow lets an admin, or a cleaner assigned to the booking who has
+// waited the policy's CustomerWait past startSched, report the customer absent.
+func CanReportCustomerNoShow(viewer *AccessViewer, snap *BookingSnapshot, policy types.NoShowPolicy, now time.Time) error {
+	switch viewer.Role {
+	case ViewerRoleAdmin:
+		return nil
+	case ViewerRoleEmployee:
+		if !slices.Contains(snap.CleanerIDs, viewer.EmployeeID) {
+			return ErrNoShowReportDenied
+		}
+		if from := snap.StartSched.Add(policy.CustomerWait); now.Before(from) {
+			return fmt.Errorf("%w: wait until %s", ErrNoShowTooEarly, from.Format(time.RFC3339))
+		}
+		return nil
+	default:
+		return ErrNoShowReportDenied
+	}
+}
+
+// RecordNoShow keeps who missed the booking and, for a reported customer no-show, where
+// the reporting cleaner was.
+func (t *BookingTasks) RecordNoShow(
+	ctx context.Context,
+	tx pgx.Tx,
+	bookingID string,
+	kind types.NoShowKind,
+	cleanerIDs []string,
+	reportedBy string,
+	loc *types.DeviceLocation,
+	notes string,
+) error {
+	if cleanerIDs == nil {
+		cleanerIDs = []string{}
+	}
+	var lat, lng, accuracy *float64
+	if loc != nil {
+		lat, lng, accuracy = loc.Latitude, loc.Longitude, &loc.AccuracyMeters
+	}
+	if _, err := tx.Exec(ctx, `
+		INSERT INTO booking.no_shows (
+			booking_id, kind, cleaner_ids, reported_by, latitude, longitude, accuracy_m, notes, created_at
+		) VALUES ($1, $2, $3::uuid[], $4, $5, $6, $7, NULLIF($8, ''), NOW())
+	`, bookingID, string(kind), cleanerIDs, reportedBy, lat, lng, accuracy, notes); err != nil {
+		return fmt.Errorf("failed to record no-show: %w", err)
+	}
+	return nil
+}
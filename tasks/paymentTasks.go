@@ -8,18 +8,26 @@ import (
 	"handworks-api/types"
 	"handworks-api/utils"
 	"log"
+	"math"
 	"strings"
 	"time"
 
 	"github.com/jackc/pgx/v5"
 )
 
-type PaymentTasks struct{}
+// PaymentTasks prices quotes. LargeAreaRate is the configured price per square metre of
+// general cleaning above 100 SQM; without one such areas are rejected.
+type PaymentTasks struct {
+	LargeAreaRate float32
+}
 
 // Maximum daily hours limit
 const MaxDailyHours = 11
 
-func CalculateGeneralCleaning(details *types.GeneralCleaningDetails) (float32, int32, error) {
+// Longest multi-day plan a single quote may produce
+const MaxServiceDays = 7
+
+func CalculateGeneralCleaning(details *types.GeneralCleaningDetails, largeAreaRate float32) (float32, int32, error) {
 	if details == nil {
 		return 0.0, 0, fmt.Errorf("general cleaning details cannot be nil")
 	}
@@ -43,18 +51,17 @@ func CalculateGeneralCleaning(details *types.GeneralCleaningDetails) (float32, i
 	case sqm > 50 && sqm <= 100:
 		price = 5000.00
 		hours = 8
+	case largeAreaRate > 0:
+		// Areas above 100 SQM are charged the configured rate per extra SQM and split
+		// across days by the quote's day plan
+		price = 5000.00 + float32(sqm-100)*largeAreaRate
+		hours = calculateHoursForLargeArea(sqm)
 	default:
 		// For areas above 100 SQM, return error to encourage splitting
 		return 0.0, 0, fmt.Errorf("areas above 100 SQM require %d hours which exceeds our daily limit of %d hours. Please divide your cleaning into multiple bookings (e.g., book different floors/areas on separate days)",
 			calculateHoursForLargeArea(sqm), MaxDailyHours)
 	}
 
-	// Individual service validation
-	if hours > MaxDailyHours {
-		return price, hours, fmt.Errorf("this general cleaning requires %d hours which exceeds our daily limit of %d hours. Please divide your cleaning into multiple bookings",
-			hours, MaxDailyHours)
-	}
-
 	return price, hours, nil
 }
 
@@ -65,11 +72,7 @@ func calculateHoursForLargeArea(sqm int32) int32 {
 	}
 	additionalSQM := sqm - 100
 	additionalHours := (additionalSQM + 12) / 13
-	hours := 8 + int32(additionalHours)
-	if hours > 24 {
-		hours = 24
-	}
-	return hours
+	return 8 + int32(additionalHours)
 }
 
 func CalculateCarCleaning(details *types.CarCleaningDetails) (float32, int32, error) {
@@ -127,12 +130,6 @@ func CalculateCarCleaning(details *types.CarCleaningDetails) (float32, int32, er
 
 	finalHours := int32(totalHours*2+0.5) / 2
 
-	// Individual service validation
-	if finalHours > MaxDailyHours {
-		return total, finalHours, fmt.Errorf("this car cleaning requires %d hours which exceeds our daily limit of %d hours. Please divide your car cleaning into multiple bookings (e.g., clean different vehicles on separate days)",
-			finalHours, MaxDailyHours)
-	}
-
 	return total, finalHours, nil
 }
 
@@ -205,11 +202,6 @@ func CalculateCouchCleaning(details *types.CouchCleaningDetails) (float32, int32
 
 	finalHours := int32(totalHours*2+0.5) / 2
 
-	if finalHours > MaxDailyHours {
-		return total, finalHours, fmt.Errorf("this couch cleaning requires %d hours which exceeds our daily limit of %d hours. Please divide your couch cleaning into multiple bookings (e.g., clean different rooms on separate days)",
-			finalHours, MaxDailyHours)
-	}
-
 	return total, finalHours, nil
 }
 
@@ -256,11 +248,6 @@ func CalculateMattressCleaning(details *types.MattressCleaningDetails) (float32,
 
 	finalHours := int32(totalHours*2+0.5) / 2
 
-	if finalHours > MaxDailyHours {
-		return total, finalHours, fmt.Errorf("this mattress cleaning requires %d hours which exceeds our daily limit of %d hours. Please divide your mattress cleaning into multiple bookings (e.g., clean different mattresses on separate days)",
-			finalHours, MaxDailyHours)
-	}
-
 	return total, finalHours, nil
 }
 
@@ -297,13 +284,87 @@ func CalculatePostConstructionCleaning(details *types.PostConstructionDetails) (
 		}
 	}
 
-	if hours > MaxDailyHours {
-		daysNeeded := (hours + int32(MaxDailyHours) - 1) / int32(MaxDailyHours)
-		return price, hours, fmt.Errorf("this post-construction cleaning requires %d hours, which exceeds our daily limit of %d hours. Please divide into %d separate day bookings (e.g., book %d hours on day 1 and %d hours on day 2)",
-			hours, MaxDailyHours, daysNeeded, min(int32(MaxDailyHours), hours), hours-int32(MaxDailyHours))
+	return price, hours, nil
+}
+
+var (
+	ErrInvalidDirtyScale  = errors.New("invalid dirty scale")
+	ErrDirtyScaleMismatch = errors.New("dirty scale differs from the one quoted")
+)
+
+// DirtyScaleLevel treats an unset dirty scale as level 1.
+func DirtyScaleLevel(dirtyScale int32) int32 {
+	if dirtyScale < 1 {
+		return 1
 	}
+	return dirtyScale
+}
 
-	return price, hours, nil
+// ApplyDirtyScale returns the surcharge and extra hours the policy adds at dirtyScale to a
+// job of the given price and hours.
+func ApplyDirtyScale(policy types.DirtyScalePolicy, dirtyScale int32, price float32, hours int32) (float32, int32, error) {
+	levels := min(len(policy.PriceMultipliers), len(policy.HourMultipliers))
+	level := DirtyScaleLevel(dirtyScale)
+	if dirtyScale < 0 || int(level) > levels {
+		return 0, 0, fmt.Errorf("%w: %d, must be between 1 and %d", ErrInvalidDirtyScale, dirtyScale, levels)
+	}
+
+	surcharge := math.Round(float64(price)*(policy.PriceMultipliers[level-1]-1)*100) / 100
+	// Round away float noise first so 10 hours at 1.1 adds one hour, not two.
+	extraHours := math.Ceil(math.Round(float64(hours)*(policy.HourMultipliers[level-1]-1)*1e6) / 1e6)
+	return float32(surcharge), int32(extraHours), nil
+}
+
+// CheckQuotedDirtyScale rejects a booking whose dirty scale is not the one its quote was
+// priced at. Quotes store the level they were priced at, so a quote without one was saved
+// before dirty scales were priced and takes any booking.
+func CheckQuotedDirtyScale(requested, quoted int32) error {
+	if quoted < 1 {
+		return nil
+	}
+	if DirtyScaleLevel(requested) != quoted {
+		return fmt.Errorf("%w: booking has %d, quote has %d", ErrDirtyScaleMismatch, requested, quoted)
+	}
+	return nil
+}
+
+// PlanServiceDays splits a job into consecutive days of at most MaxDailyHours.
+// Hours are spread as evenly as possible and the price follows each day's share of the hours.
+func PlanServiceDays(totalHours int32, totalPrice float32) ([]types.ServiceDay, error) {
+	if totalHours <= 0 {
+		return nil, fmt.Errorf("total service hours must be greater than 0")
+	}
+
+	days := (totalHours + MaxDailyHours - 1) / MaxDailyHours
+	if days > MaxServiceDays {
+		return nil, fmt.Errorf("total service hours (%d) would need %d days, but a single booking can span at most %d days. Please split your request into separate bookings",
+			totalHours, days, MaxServiceDays)
+	}
+
+	plan := make([]types.ServiceDay, 0, days)
+	base, extra := totalHours/days, totalHours%days
+	var allocated float32
+	for i := int32(0); i < days; i++ {
+		hours := base
+		if i < extra {
+			hours++
+		}
+
+		price := float32(math.Round(float64(totalPrice)*float64(hours)/float64(totalHours)*100) / 100)
+		if i == days-1 {
+			// The last day absorbs rounding so the plan always sums to the quoted total
+			price = totalPrice - allocated
+		}
+		allocated += price
+
+		plan = append(plan, types.ServiceDay{
+			DayIndex: i,
+			Hours:    hours,
+			Price:    price,
+		})
+	}
+
+	return plan, nil
 }
 
 // Updated CalculatePriceByServiceType to return errors
@@ -318,7 +379,7 @@ func (t *PaymentTasks) CalculatePriceByServiceType(service *types.ServicesReques
 
 	switch service.ServiceType {
 	case types.GeneralCleaning:
-		calculatedPrice, calculatedHours, err = CalculateGeneralCleaning(service.Details.General)
+		calculatedPrice, calculatedHours, err = CalculateGeneralCleaning(service.Details.General, t.LargeAreaRate)
 	case types.CouchCleaning:
 		calculatedPrice, calculatedHours, err = CalculateCouchCleaning(service.Details.Couch)
 	case types.MattressCleaning:
@@ -338,7 +399,12 @@ func (t *PaymentTasks) CalculatePriceByServiceType(service *types.ServicesReques
 	return calculatedPrice, calculatedHours, nil
 }
 
-func (t *PaymentTasks) CalculateQuotePreview(c context.Context, in *types.QuoteRequest) (*types.Quote, error) {
+func (t *PaymentTasks) CalculateQuotePreview(
+	c context.Context,
+	in *types.QuoteRequest,
+	dirtyScale types.DirtyScalePolicy,
+	zone *types.ServiceZone,
+) (*types.Quote, error) {
 	var dbQuote types.Quote
 	var dbAddons []*types.QuoteAddon
 
@@ -375,13 +441,6 @@ func (t *PaymentTasks) CalculateQuotePreview(c context.Context, in *types.QuoteR
 			continue
 		}
 
-		if mainHours+addonTotalHours+addonHours > MaxDailyHours {
-			validationErrors = append(validationErrors,
-				fmt.Sprintf("Addon %d (%s) would exceed daily limit. Current total: %d hours, Addon requires: %d hours, Daily limit: %d hours. Please remove some items or create separate bookings.",
-					i+1, addon.ServiceDetail.ServiceType, mainHours+addonTotalHours, addonHours, MaxDailyHours))
-			continue
-		}
-
 		serviceDetail, err := json.Marshal(addon.ServiceDetail)
 		if err != nil {
 			return nil, fmt.Errorf("failed to marshal addon service: %v", err)
@@ -399,7 +458,16 @@ func (t *PaymentTasks) CalculateQuotePreview(c context.Context, in *types.QuoteR
 		dbAddons = append(dbAddons, dbAddon)
 	}
 
-	totalServiceHours := mainHours + addonTotalHours
+	surcharge, dirtyHours, err := ApplyDirtyScale(dirtyScale, in.DirtyScale, subtotal+addonTotal, mainHours+addonTotalHours)
+	if err != nil {
+		return nil, err
+	}
+	totalServiceHours := mainHours + addonTotalHours + dirtyHours
+	travelFee, err := ZoneTravelFee(zone, subtotal+addonTotal+surcharge)
+	if err != nil {
+		return nil, err
+	}
+	totalPrice := subtotal + addonTotal + surcharge + travelFee
 
 	log.Printf("DEBUG CalculateQuotePreview - totalServiceHours: %d", totalServiceHours)
 
@@ -415,37 +483,36 @@ func (t *PaymentTasks) CalculateQuotePreview(c context.Context, in *types.QuoteR
 		return nil, errors.New(sb.String())
 	}
 
-	if totalServiceHours > MaxDailyHours {
-		return nil, fmt.Errorf("total service hours (%d) exceed daily limit of %d hours. Please divide your cleaning into multiple bookings (e.g., book different services on separate days)",
-			totalServiceHours, MaxDailyHours)
+	dayPlan, err := PlanServiceDays(totalServiceHours, totalPrice)
+	if err != nil {
+		return nil, err
 	}
 
 	dbQuote = types.Quote{
-		ID:                "",
-		CustomerID:        in.CustomerID,
-		MainService:       string(in.Service.ServiceType),
-		MainServiceDetail: mainServiceDetail,
-		MainServiceHours:  mainHours,
-		Subtotal:          subtotal,
-		AddonTotal:        addonTotal,
-		TotalServiceHours: totalServiceHours,
-		TotalPrice:        subtotal + addonTotal,
-		IsValid:           false,
-		CreatedAt:         time.Now(),
-		Addons:            dbAddons,
+		ID:                  "",
+		CustomerID:          in.CustomerID,
+		MainService:         string(in.Service.ServiceType),
+		MainServiceDetail:   mainServiceDetail,
+		MainServiceHours:    mainHours,
+		Subtotal:            subtotal,
+		AddonTotal:          addonTotal,
+		TotalServiceHours:   totalServiceHours,
+		TotalPrice:          totalPrice,
+		DirtyScale:          in.DirtyScale,
+		DirtyScaleSurcharge: surcharge,
+		DirtyScaleHours:     dirtyHours,
+		ServiceZoneID:       ServiceZoneID(zone),
+		TravelFee:           travelFee,
+		ServiceDays:         int32(len(dayPlan)),
+		DayPlan:             dayPlan,
+		IsValid:             false,
+		CreatedAt:           time.Now(),
+		Addons:              dbAddons,
 	}
 
 	return &dbQuote, nil
 }
 
-// Helper function
-func min(a, b int32) int32 {
-	if a < b {
-		return a
-	}
-	return b
-}
-
 func (t *PaymentTasks) MapAddonstoAddonBreakdown(addons *[]*types.QuoteAddon) []types.AddOnBreakdown {
 	var breakdowns []types.AddOnBreakdown
 	if addons != nil && len(*addons) > 0 {
@@ -464,7 +531,13 @@ func (t *PaymentTasks) MapAddonstoAddonBreakdown(addons *[]*types.QuoteAddon) []
 	return []types.AddOnBreakdown{}
 }
 
-func (p *PaymentTasks) CreateQuote(c context.Context, tx pgx.Tx, in *types.QuoteRequest) (*types.Quote, error) {
+func (p *PaymentTasks) CreateQuote(
+	c context.Context,
+	tx pgx.Tx,
+	in *types.QuoteRequest,
+	dirtyScale types.DirtyScalePolicy,
+	zone *types.ServiceZone,
+) (*types.Quote, error) {
 	var dbQuote types.Quote
 	var dbAddons []*types.QuoteAddon
 	var mainServiceDetail []byte
@@ -503,14 +576,6 @@ func (p *PaymentTasks) CreateQuote(c context.Context, tx pgx.Tx, in *types.Quote
 			continue
 		}
 
-		// Check if adding this addon would exceed daily limit
-		if mainHours+addonTotalHours+addonHours > 11 {
-			validationErrors = append(validationErrors,
-				fmt.Sprintf("Addon %d (%s) would exceed daily limit of 11 hours. Current total: %d hours, Addon requires: %d hours",
-					i+1, addon.ServiceDetail.ServiceType, mainHours+addonTotalHours, addonHours))
-			continue
-		}
-
 		serviceDetail, err := json.Marshal(addon.ServiceDetail)
 		if err != nil {
 			return nil, fmt.Errorf("failed to marshal addon service: %v", err)
@@ -541,13 +606,29 @@ func (p *PaymentTasks) CreateQuote(c context.Context, tx pgx.Tx, in *types.Quote
 		return nil, errors.New(sb.String())
 	}
 
-	totalPrice := subtotal + addonTotal
-	totalServiceHours := mainHours + addonTotalHours
+	surcharge, dirtyHours, err := ApplyDirtyScale(dirtyScale, in.DirtyScale, subtotal+addonTotal, mainHours+addonTotalHours)
+	if err != nil {
+		return nil, err
+	}
+	travelFee, err := ZoneTravelFee(zone, subtotal+addonTotal+surcharge)
+	if err != nil {
+		return nil, err
+	}
+	totalPrice := subtotal + addonTotal + surcharge + travelFee
+	totalServiceHours := mainHours + addonTotalHours + dirtyHours
 
-	// Final validation
-	if totalServiceHours > 11 {
-		return nil, fmt.Errorf("total service hours (%d) exceed daily limit of 11 hours. Please divide your cleaning into multiple bookings",
-			totalServiceHours)
+	// Jobs longer than MaxDailyHours are split into consecutive days instead of rejected
+	dayPlan, err := PlanServiceDays(totalServiceHours, totalPrice)
+	if err != nil {
+		return nil, err
+	}
+	dayPlanJSON, err := json.Marshal(dayPlan)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal day plan: %v", err)
+	}
+	var zoneID *string
+	if zone != nil {
+		zoneID = &zone.ID
 	}
 
 	err = tx.QueryRow(c, `
@@ -560,12 +641,21 @@ func (p *PaymentTasks) CreateQuote(c context.Context, tx pgx.Tx, in *types.Quote
 			addon_total,
 			total_service_hours,
 			total_price,
+			service_days,
+			day_plan,
+			dirty_scale,
+			dirty_scale_surcharge,
+			dirty_scale_hours,
+			service_zone_id,
+			travel_fee,
 			is_valid
 		)
-		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, TRUE)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, TRUE)
 		RETURNING id, customer_id, main_service_type, main_service_detail,
 		          main_service_hours, subtotal, addon_total, total_service_hours,
-		          total_price, is_valid, created_at, updated_at
+		          total_price, service_days, dirty_scale, dirty_scale_surcharge,
+		          dirty_scale_hours, COALESCE(service_zone_id::text, ''), travel_fee, is_valid,
+		          created_at, updated_at
 	`,
 		in.CustomerID,
 		in.Service.ServiceType,
@@ -575,6 +665,13 @@ func (p *PaymentTasks) CreateQuote(c context.Context, tx pgx.Tx, in *types.Quote
 		addonTotal,
 		totalServiceHours,
 		totalPrice,
+		len(dayPlan),
+		dayPlanJSON,
+		DirtyScaleLevel(in.DirtyScale),
+		surcharge,
+		dirtyHours,
+		zoneID,
+		travelFee,
 	).Scan(
 		&dbQuote.ID,
 		&dbQuote.CustomerID,
@@ -585,6 +682,12 @@ func (p *PaymentTasks) CreateQuote(c context.Context, tx pgx.Tx, in *types.Quote
 		&dbQuote.AddonTotal,
 		&dbQuote.TotalServiceHours,
 		&dbQuote.TotalPrice,
+		&dbQuote.ServiceDays,
+		&dbQuote.DirtyScale,
+		&dbQuote.DirtyScaleSurcharge,
+		&dbQuote.DirtyScaleHours,
+		&dbQuote.ServiceZoneID,
+		&dbQuote.TravelFee,
 		&dbQuote.IsValid,
 		&dbQuote.CreatedAt,
 		&dbQuote.UpdatedAt,
@@ -614,6 +717,7 @@ func (p *PaymentTasks) CreateQuote(c context.Context, tx pgx.Tx, in *types.Quote
 	}
 
 	dbQuote.Addons = dbAddons
+	dbQuote.DayPlan = dayPlan
 	return &dbQuote, nil
 }
 
@@ -621,13 +725,18 @@ func (t *PaymentTasks) VerifyQuoteAndFetchPrices(ctx context.Context, tx pgx.Tx,
 	var prices types.CleaningPrices
 
 	var dbQuote types.Quote
+	var dayPlan []byte
 	err := tx.QueryRow(ctx, `
-		SELECT total_price, is_valid
+		SELECT total_price, is_valid, COALESCE(day_plan, '[]'::jsonb), COALESCE(dirty_scale, 0),
+		       COALESCE(service_zone_id::text, '')
 		FROM payment.quotes
 		WHERE id = $1
 	`, quoteId).Scan(
 		&dbQuote.TotalPrice,
 		&dbQuote.IsValid,
+		&dayPlan,
+		&dbQuote.DirtyScale,
+		&dbQuote.ServiceZoneID,
 	)
 	if err != nil {
 		return &prices, fmt.Errorf("fetch main quote: %w", err)
@@ -664,6 +773,11 @@ func (t *PaymentTasks) VerifyQuoteAndFetchPrices(ctx context.Context, tx pgx.Tx,
 		})
 	}
 	prices.MainServicePrice = dbQuote.TotalPrice
+	prices.DirtyScale = dbQuote.DirtyScale
+	prices.ServiceZoneID = dbQuote.ServiceZoneID
+	if err := json.Unmarshal(dayPlan, &prices.DayPlan); err != nil {
+		return &prices, fmt.Errorf("unmarshal day plan: %w", err)
+	}
 	return &prices, nil
 }
 
@@ -0,0 +1,217 @@
+package tasks
+
+import (
+	"errors"
+	"handworks-api/types"
+	"math"
+	"testing"
+)
+
+func TestPlanServiceDays(t *testing.T) {
+	tests := []struct {
+		name       string
+		totalHours int32
+		totalPrice float32
+		wantHours  []int32
+		wantPrices []float32
+		wantErr    bool
+	}{
+		{name: "no hours", totalHours: 0, totalPrice: 1000, wantErr: true},
+		{name: "fits in one day", totalHours: 8, totalPrice: 4000, wantHours: []int32{8}, wantPrices: []float32{4000}},
+		{name: "exactly one full day", totalHours: MaxDailyHours, totalPrice: 5500, wantHours: []int32{11}, wantPrices: []float32{5500}},
+		{name: "one hour over splits evenly", totalHours: 12, totalPrice: 6000, wantHours: []int32{6, 6}, wantPrices: []float32{3000, 3000}},
+		{
+			name:       "uneven hours go to the earlier days and the last day absorbs rounding",
+			totalHours: 23,
+			totalPrice: 1000,
+			wantHours:  []int32{8, 8, 7},
+			wantPrices: []float32{347.83, 347.83, 304.34},
+		},
+		{
+			name:       "longest allowed job",
+			totalHours: MaxDailyHours * MaxServiceDays,
+			totalPrice: 7000,
+			wantHours:  []int32{11, 11, 11, 11, 11, 11, 11},
+			wantPrices: []float32{1000, 1000, 1000, 1000, 1000, 1000, 1000},
+		},
+		{name: "longer than the allowed days", totalHours: MaxDailyHours*MaxServiceDays + 1, totalPrice: 7000, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			plan, err := PlanServiceDays(tt.totalHours, tt.totalPrice)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("expected an error, got plan %+v", plan)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if len(plan) != len(tt.wantHours) {
+				t.Fatalf("got %d days, want %d", len(plan), len(tt.wantHours))
+			}
+
+			var sum float64
+			for i, day := range plan {
+				if day.DayIndex != int32(i) {
+					t.Errorf("day %d has index %d", i, day.DayIndex)
+				}
+				if day.Hours != tt.wantHours[i] {
+					t.Errorf("day %d hours = %d, want %d", i, day.Hours, tt.wantHours[i])
+				}
+				if math.Abs(float64(day.Price-tt.wantPrices[i])) > 0.005 {
+					t.Errorf("day %d price = %v, want %v", i, day.Price, tt.wantPrices[i])
+				}
+				sum += float64(day.Price)
+			}
+			if math.Abs(sum-float64(tt.totalPrice)) > 0.005 {
+				t.Errorf("plan sums to %v, want %v", sum, tt.totalPrice)
+			}
+		})
+	}
+}
+
+func TestPlanServiceDaysNeverExceedsDailyLimit(t *testing.T) {
+	for hours := int32(1); hours <= MaxDailyHours*MaxServiceDays; hours++ {
+		plan, err := PlanServiceDays(hours, float32(hours)*500)
+		if err != nil {
+			t.Fatalf("%d hours: unexpected error: %v", hours, err)
+		}
+		var total int32
+		for _, day := range plan {
+			if day.Hours > MaxDailyHours {
+				t.Fatalf("%d hours: day %d has %d hours", hours, day.DayIndex, day.Hours)
+			}
+			total += day.Hours
+		}
+		if total != hours {
+			t.Fatalf("%d hours: plan covers %d hours", hours, total)
+		}
+	}
+}
+
+func TestApplyDirtyScale(t *testing.T) {
+	policy := types.DirtyScalePolicy{
+		PriceMultipliers: []float64{1, 1, 1.15, 1.3, 1.5},
+		HourMultipliers:  []float64{1, 1, 1.1, 1.25, 1.5},
+	}
+
+	tests := []struct {
+		name          string
+		dirtyScale    int32
+		price         float32
+		hours         int32
+		wantSurcharge float32
+		wantHours     int32
+		wantErr       error
+	}{
+		{
+			name:       "unset scale is priced as level 1",
+			dirtyScale: 0,
+			price:      2000,
+			hours:      4,
+		},
+		{
+			name:       "level 2 adds nothing",
+			dirtyScale: 2,
+			price:      2000,
+			hours:      4,
+		},
+		{
+			name:          "level 3 rounds extra hours up",
+			dirtyScale:    3,
+			price:         2000,
+			hours:         4,
+			wantSurcharge: 300,
+			wantHours:     1,
+		},
+		{
+			name:          "level 3 on a whole number of extra hours",
+			dirtyScale:    3,
+			price:         4000,
+			hours:         10,
+			wantSurcharge: 600,
+			wantHours:     1,
+		},
+		{
+			name:          "surcharge is rounded to cents",
+			dirtyScale:    4,
+			price:         1234.56,
+			hours:         3,
+			wantSurcharge: 370.37,
+			wantHours:     1,
+		},
+		{
+			name:          "highest level",
+			dirtyScale:    5,
+			price:         2000,
+			hours:         4,
+			wantSurcharge: 1000,
+			wantHours:     2,
+		},
+		{
+			name:       "above the highest level",
+			dirtyScale: 6,
+			price:      2000,
+			hours:      4,
+			wantErr:    ErrInvalidDirtyScale,
+		},
+		{
+			name:       "negative scale",
+			dirtyScale: -1,
+			price:      2000,
+			hours:      4,
+			wantErr:    ErrInvalidDirtyScale,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			surcharge, hours, err := ApplyDirtyScale(policy, tt.dirtyScale, tt.price, tt.hours)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("err = %v, want %v", err, tt.wantErr)
+			}
+			if surcharge != tt.wantSurcharge {
+				t.Errorf("surcharge = %v, want %v", surcharge, tt.wantSurcharge)
+			}
+			if hours != tt.wantHours {
+				t.Errorf("extra hours = %d, want %d", hours, tt.wantHours)
+			}
+		})
+	}
+}
+
+func TestApplyDirtyScaleUsesTheShorterMultiplierList(t *testing.T) {
+	policy := types.DirtyScalePolicy{
+		PriceMultipliers: []float64{1, 1.2, 1.4},
+		HourMultipliers:  []float64{1, 1.5},
+	}
+	if _, _, err := ApplyDirtyScale(policy, 3, 1000, 2); !errors.Is(err, ErrInvalidDirtyScale) {
+		t.Errorf("err = %v, want %v", err, ErrInvalidDirtyScale)
+	}
+}
+
+func TestCheckQuotedDirtyScale(t *testing.T) {
+	tests := []struct {
+		name      string
+		requested int32
+		quoted    int32
+		wantErr   error
+	}{
+		{name: "quote without a level takes any booking", requested: 5, quoted: 0},
+		{name: "same level", requested: 3, quoted: 3},
+		{name: "unset booking matches a level 1 quote", requested: 0, quoted: 1},
+		{name: "higher than quoted", requested: 4, quoted: 3, wantErr: ErrDirtyScaleMismatch},
+		{name: "lower than quoted", requested: 2, quoted: 3, wantErr: ErrDirtyScaleMismatch},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := CheckQuotedDirtyScale(tt.requested, tt.quoted); !errors.Is(err, tt.wantErr) {
+				t.Errorf("err = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
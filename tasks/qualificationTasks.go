@@ -0,0 +1,204 @@
+package tasks
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"handworks-api/types"
+	"sort"
+
+	"github.com/jackc/pgx/v5"
+)
+
+var (
+	ErrCleanerNotQualified  = errors.New("cleaner is not qualified for the booked services")
+	ErrInvalidQualification = errors.New("invalid qualification")
+)
+
+// baselineServiceType is the work every cleaner is trained for, so it never needs a qualification.
+const baselineServiceType = types.GeneralCleaning
+
+var qualifiableServiceTypes = map[types.MainServiceType]bool{
+	types.GeneralCleaning:  true,
+	types.CouchCleaning:    true,
+	types.MattressCleaning: true,
+	types.CarCleaning:      true,
+	types.PostCleaning:     true,
+}
+
+// requiredQualifications returns the service types a cleaner must be qualified for to take
+// the request's main service and every addon.
+func requiredQualifications(req *types.CreateBookingRequest) []string {
+	serviceTypes := []types.MainServiceType{req.MainService.ServiceType}
+	for _, addon := range req.Addons {
+		serviceTypes = append(serviceTypes, addon.ServiceDetail.ServiceType)
+	}
+	return qualificationsFor(serviceTypes)
+}
+
+func qualificationsFor(serviceTypes []types.MainServiceType) []string {
+	seen := make(map[string]bool, len(serviceTypes))
+	required := make([]string, 0, len(serviceTypes))
+	for _, st := range serviceTypes {
+		if st == "" || st == baselineServiceType || st == types.ServiceTypeUnspecified || seen[string(st)] {
+			continue
+		}
+		seen[string(st)] = true
+		required = append(required, string(st))
+	}
+	sort.Strings(required)
+	return required
+}
+
+// qualifiedCleanerFilter is a WHERE condition that holds when the employee in column has
+// every service type in the text[] parameter param.
+func qualifiedCleanerFilter(column, param string) string {
+	return `NOT EXISTS (
+			SELECT 1
+			FROM unnest(` + param + `::text[]) AS required(service_type)
+			WHERE NOT EXISTS (
+				SELECT 1
+				FROM account.employee_qualifications q
+				WHERE q.employee_id = ` + column + `
+				  AND q.service_type = required.service_type
+			)
+		  )`
+}
+
+func (t *BookingTasks) FetchBookingServiceTypes(ctx context.Context, tx pgx.Tx, bookingID string) ([]types.MainServiceType, error) {
+	return fetchBookingServiceTypes(ctx, tx, bookingID)
+}
+
+func (t *AdminTasks) FetchBookingServiceTypes(ctx context.Context, tx pgx.Tx, bookingID string) ([]types.MainServiceType, error) {
+	return fetchBookingServiceTypes(ctx, tx, bookingID)
+}
+
+// fetchBookingServiceTypes returns the service types of a booking's main service and addons.
+func fetchBookingServiceTypes(ctx context.Context, tx pgx.Tx, bookingID string) ([]types.MainServiceType, error) {
+	rows, err := tx.Query(ctx, `
+		SELECT s.service_type
+		FROM booking.bookings b
+		JOIN booking.services s ON s.id = b.main_service_id
+		WHERE b.id = $1
+		UNION
+		SELECT s.service_type
+		FROM booking.bookings b
+		JOIN booking.addons a ON a.id = ANY(b.addon_ids)
+		JOIN booking.services s ON s.id = a.service_id
+		WHERE b.id = $1
+	`, bookingID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch booking service types: %w", err)
+	}
+	defer rows.Close()
+
+	serviceTypes := make([]types.MainServiceType, 0)
+	for rows.Next() {
+		var st string
+		if err := rows.Scan(&st); err != nil {
+			return nil, fmt.Errorf("failed to scan booking service type: %w", err)
+		}
+		serviceTypes = append(serviceTypes, types.MainServiceType(st))
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed iterating booking service types: %w", err)
+	}
+	return serviceTypes, nil
+}
+
+// ServiceRequestsFor turns a booking's service types back into the shape AllocateCleaners
+// takes. Only the types matter for allocation, so which one becomes the main service does not.
+func ServiceRequestsFor(serviceTypes []types.MainServiceType) (types.ServicesRequest, []types.AddOnRequest) {
+	var main types.ServicesRequest
+	addons := make([]types.AddOnRequest, 0)
+	for i, st := range serviceTypes {
+		if i == 0 {
+			main.ServiceType = st
+			continue
+		}
+		addons = append(addons, types.AddOnRequest{ServiceDetail: types.ServicesRequest{ServiceType: st}})
+	}
+	return main, addons
+}
+
+// ValidateCleanerQualified checks that the employee holds every qualification the booking needs.
+func (t *AdminTasks) ValidateCleanerQualified(ctx context.Context, tx pgx.Tx, employeeID string, serviceTypes []types.MainServiceType) error {
+	var qualified bool
+	err := tx.QueryRow(ctx, `
+		SELECT `+qualifiedCleanerFilter("$1::uuid", "$2"),
+		employeeID, qualificationsFor(serviceTypes),
+	).Scan(&qualified)
+	if err != nil {
+		return fmt.Errorf("failed to check cleaner qualifications: %w", err)
+	}
+	if !qualified {
+		return ErrCleanerNotQualified
+	}
+	return nil
+}
+
+func (t *AdminTasks) FetchEmployeeQualifications(ctx context.Context, tx pgx.Tx, employeeID string) ([]types.MainServiceType, error) {
+	if err := ensureEmployeeExists(ctx, tx, employeeID); err != nil {
+		return nil, err
+	}
+
+	rows, err := tx.Query(ctx, `
+		SELECT service_type
+		FROM account.employee_qualifications
+		WHERE employee_id = $1
+		ORDER BY service_type ASC
+	`, employeeID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch employee qualifications: %w", err)
+	}
+	defer rows.Close()
+
+	qualifications := []types.MainServiceType{baselineServiceType}
+	for rows.Next() {
+		var st string
+		if err := rows.Scan(&st); err != nil {
+			return nil, fmt.Errorf("failed to scan employee qualification: %w", err)
+		}
+		if types.MainServiceType(st) == baselineServiceType {
+			continue
+		}
+		qualifications = append(qualifications, types.MainServiceType(st))
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed iterating employee qualifications: %w", err)
+	}
+	return qualifications, nil
+}
+
+// ReplaceEmployeeQualifications sets the employee's qualifications to exactly serviceTypes.
+func (t *AdminTasks) ReplaceEmployeeQualifications(ctx context.Context, tx pgx.Tx, employeeID string, serviceTypes []types.MainServiceType) error {
+	for _, st := range serviceTypes {
+		if !qualifiableServiceTypes[st] {
+			return fmt.Errorf("%w: unknown service type %q", ErrInvalidQualification, st)
+		}
+	}
+
+	if err := ensureEmployeeExists(ctx, tx, employeeID); err != nil {
+		return err
+	}
+
+	if _, err := tx.Exec(ctx, `
+		DELETE FROM account.employee_qualifications
+		WHERE employee_id = $1
+	`, employeeID); err != nil {
+		return fmt.Errorf("failed to clear employee qualifications: %w", err)
+	}
+
+	required := qualificationsFor(serviceTypes)
+	if len(required) == 0 {
+		return nil
+	}
+	if _, err := tx.Exec(ctx, `
+		INSERT INTO account.employee_qualifications (employee_id, service_type, created_at)
+		SELECT $1, st, NOW()
+		FROM unnest($2::text[]) AS st
+	`, employeeID, required); err != nil {
+		return fmt.Errorf("failed to save employee qualifications: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,299 @@
+package tasks
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"handworks-api/types"
+	"slices"
+	"strings"
+
+	"github.com/jackc/pgx/v5"
+)
+
+var (
+	ErrInvalidReview          = errors.New("invalid review")
+	ErrReviewNotFound         = errors.New("review not found")
+	ErrBookingNotReviewable   = errors.New("only completed bookings can be reviewed")
+	ErrReviewNotAllowed       = errors.New("only the booking's customer can review it")
+	ErrBookingAlreadyReviewed = errors.New("booking has already been reviewed")
+)
+
+// InsertReview stores the customer's review of a completed booking and adds each cleaner's
+// rating to their performance score. Only the booking's own customer may review it.
+func (t *BookingTasks) InsertReview(
+	ctx context.Context,
+	tx pgx.Tx,
+	viewer *AccessViewer,
+	snap *BookingSnapshot,
+	req *types.SubmitReviewRequest,
+) (*types.BookingReview, error) {
+	if viewer.Role != ViewerRoleCustomer || viewer.CustomerID != snap.CustID {
+		return nil, ErrReviewNotAllowed
+	}
+	if snap.Status != BookingStatusCompleted {
+		return nil, ErrBookingNotReviewable
+	}
+
+	ratings, err := cleanerRatingsFor(snap.CleanerIDs, req)
+	if err != nil {
+		return nil, err
+	}
+
+	var reviewID string
+	err = tx.QueryRow(ctx, `
+		INSERT INTO booking.reviews (booking_id, customer_id, overall_rating, comment, status, created_at, updated_at)
+		VALUES ($1, $2, $3, NULLIF($4, ''), 'PUBLISHED', NOW(), NOW())
+		ON CONFLICT (booking_id) DO NOTHING
+		RETURNING id
+	`, snap.BookingID, snap.CustID, req.OverallRating, strings.TrimSpace(req.Comment)).Scan(&reviewID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrBookingAlreadyReviewed
+		}
+		return nil, fmt.Errorf("failed to save review: %w", err)
+	}
+
+	for _, r := range ratings {
+		if _, err := tx.Exec(ctx, `
+			INSERT INTO booking.cleaner_ratings (review_id, booking_id, employee_id, rating, counted, created_at)
+			VALUES ($1, $2, $3, $4, FALSE, NOW())
+		`, reviewID, snap.BookingID, r.EmployeeID, r.Rating); err != nil {
+			return nil, fmt.Errorf("failed to save cleaner rating: %w", err)
+		}
+	}
+	if err := countReviewRatings(ctx, tx, reviewID, true); err != nil {
+		return nil, err
+	}
+
+	return fetchReview(ctx, tx, `WHERE r.id = $1`, reviewID)
+}
+
+// cleanerRatingsFor checks the per-cleaner ratings against the booking's crew and fills in
+// the overall rating for cleaners the customer did not rate separately.
+func cleanerRatingsFor(cleanerIDs []string, req *types.SubmitReviewRequest) ([]types.CleanerRating, error) {
+	given := make(map[string]int32, len(req.CleanerRatings))
+	for _, r := range req.CleanerRatings {
+		if !slices.Contains(cleanerIDs, r.EmployeeID) {
+			return nil, fmt.Errorf("%w: cleaner %s", ErrCleanerNotAssigned, r.EmployeeID)
+		}
+		if _, dup := given[r.EmployeeID]; dup {
+			return nil, fmt.Errorf("%w: cleaner %s is rated more than once", ErrInvalidReview, r.EmployeeID)
+		}
+		if r.Rating < 1 || r.Rating > 5 {
+			return nil, fmt.Errorf("%w: ratings must be between 1 and 5", ErrInvalidReview)
+		}
+		given[r.EmployeeID] = r.Rating
+	}
+	if req.OverallRating < 1 || req.OverallRating > 5 {
+		return nil, fmt.Errorf("%w: ratings must be between 1 and 5", ErrInvalidReview)
+	}
+
+	ratings := make([]types.CleanerRating, 0, len(cleanerIDs))
+	for _, id := range cleanerIDs {
+		rating, ok := given[id]
+		if !ok {
+			rating = req.OverallRating
+		}
+		ratings = append(ratings, types.CleanerRating{EmployeeID: id, Rating: rating})
+	}
+	return ratings, nil
+}
+
+// countReviewRatings adds the review's ratings to, or takes them out of, the cleaners'
+// running averages. Ratings already in the wanted state are left alone.
+func countReviewRatings(ctx context.Context, tx pgx.Tx, reviewID string, count bool) error {
+	rows, err := tx.Query(ctx, `
+		UPDATE booking.cleaner_ratings
+		SET counted = $2
+		WHERE review_id = $1
+		  AND counted <> $2
+		RETURNING employee_id::text, rating
+	`, reviewID, count)
+	if err != nil {
+		return fmt.Errorf("failed to update counted ratings: %w", err)
+	}
+	changed := make([]types.CleanerRating, 0)
+	for rows.Next() {
+		var r types.CleanerRating
+		if err := rows.Scan(&r.EmployeeID, &r.Rating); err != nil {
+			rows.Close()
+			return fmt.Errorf("failed to scan cleaner rating: %w", err)
+		}
+		changed = append(changed, r)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("failed iterating cleaner ratings: %w", err)
+	}
+
+	accounts := &AccountTasks{}
+	for _, r := range changed {
+		if count {
+			err = accounts.AddPerformanceScore(ctx, tx, float32(r.Rating), r.EmployeeID)
+		} else {
+			err = accounts.RemovePerformanceScore(ctx, tx, float32(r.Rating), r.EmployeeID)
+		}
+		if err != nil {
+			return fmt.Errorf("failed to update performance score of %s: %w", r.EmployeeID, err)
+		}
+	}
+	return nil
+}
+
+func (t *BookingTasks) FetchBookingReview(ctx context.Context, tx pgx.Tx, bookingID string) (*types.BookingReview, error) {
+	return fetchReview(ctx, tx, `WHERE r.booking_id = $1`, bookingID)
+}
+
+// FetchReviews lists reviews newest first, optionally only those with one status.
+func (t *AdminTasks) FetchReviews(ctx context.Context, tx pgx.Tx, status string, page, limit int) (*types.FetchReviewsResponse, error) {
+	res := &types.FetchReviewsResponse{}
+	if err := tx.QueryRow(ctx, `
+		SELECT COUNT(1)::int
+		FROM booking.reviews
+		WHERE NULLIF($1, '') IS NULL OR status = $1
+	`, status).Scan(&res.TotalReviews); err != nil {
+		return nil, fmt.Errorf("failed to count reviews: %w", err)
+	}
+
+	reviews, err := fetchReviews(ctx, tx, `
+		WHERE NULLIF($1, '') IS NULL OR r.status = $1
+		ORDER BY r.created_at DESC
+		LIMIT $2 OFFSET $3
+	`, status, limit, page*limit)
+	if err != nil {
+		return nil, err
+	}
+	res.Reviews = reviews
+	return res, nil
+}
+
+// ModerateReview changes a review's status. Rejecting takes its ratings out of the
+// cleaners' performance scores; restoring a rejected review puts them back.
+func (t *AdminTasks) ModerateReview(
+	ctx context.Context,
+	tx pgx.Tx,
+	reviewID string,
+	status types.ReviewStatus,
+	note, actor string,
+) (*types.BookingReview, error) {
+	switch status {
+	case types.ReviewPublished, types.ReviewHidden, types.ReviewRejected:
+	default:
+		return nil, fmt.Errorf("%w: unknown status %q", ErrInvalidReview, status)
+	}
+
+	var exists bool
+	if err := tx.QueryRow(ctx, `
+		SELECT EXISTS(SELECT 1 FROM booking.reviews WHERE id = $1 FOR UPDATE)
+	`, reviewID).Scan(&exists); err != nil {
+		return nil, fmt.Errorf("failed to lock review: %w", err)
+	}
+	if !exists {
+		return nil, ErrReviewNotFound
+	}
+
+	if _, err := tx.Exec(ctx, `
+		UPDATE booking.reviews
+		SET status = $2,
+		    moderation_note = NULLIF($3, ''),
+		    moderated_by = $4,
+		    moderated_at = NOW(),
+		    updated_at = NOW()
+		WHERE id = $1
+	`, reviewID, string(status), strings.TrimSpace(note), actor); err != nil {
+		return nil, fmt.Errorf("failed to moderate review: %w", err)
+	}
+
+	if err := countReviewRatings(ctx, tx, reviewID, status != types.ReviewRejected); err != nil {
+		return nil, err
+	}
+
+	return fetchReview(ctx, tx, `WHERE r.id = $1`, reviewID)
+}
+
+func fetchReview(ctx context.Context, tx pgx.Tx, where string, args ...any) (*types.BookingReview, error) {
+	reviews, err := fetchReviews(ctx, tx, where, args...)
+	if err != nil {
+		return nil, err
+	}
+	if len(reviews) == 0 {
+		return nil, ErrReviewNotFound
+	}
+	return &reviews[0], nil
+}
+
+func fetchReviews(ctx context.Context, tx pgx.Tx, where string, args ...any) ([]types.BookingReview, error) {
+	rows, err := tx.Query(ctx, `
+		SELECT
+			r.id,
+			r.booking_id,
+			r.customer_id,
+			r.overall_rating,
+			COALESCE(r.comment, ''),
+			r.status,
+			COALESCE(r.moderation_note, ''),
+			COALESCE(r.moderated_by, ''),
+			r.moderated_at,
+			r.created_at
+		FROM booking.reviews r
+		`+where, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch reviews: %w", err)
+	}
+
+	reviews := make([]types.BookingReview, 0)
+	index := make(map[string]int)
+	for rows.Next() {
+		var r types.BookingReview
+		if err := rows.Scan(
+			&r.ID, &r.BookingID, &r.CustomerID, &r.OverallRating, &r.Comment,
+			&r.Status, &r.ModerationNote, &r.ModeratedBy, &r.ModeratedAt, &r.CreatedAt,
+		); err != nil {
+			rows.Close()
+			return nil, fmt.Errorf("failed to scan review: %w", err)
+		}
+		r.CleanerRatings = []types.CleanerRating{}
+		index[r.ID] = len(reviews)
+		reviews = append(reviews, r)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed iterating reviews: %w", err)
+	}
+	if len(reviews) == 0 {
+		return reviews, nil
+	}
+
+	ids := make([]string, 0, len(reviews))
+	for _, r := range reviews {
+		ids = append(ids, r.ID)
+	}
+	ratingRows, err := tx.Query(ctx, `
+		SELECT review_id::text, employee_id::text, rating
+		FROM booking.cleaner_ratings
+		WHERE review_id = ANY($1::uuid[])
+		ORDER BY created_at ASC
+	`, ids)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch cleaner ratings: %w", err)
+	}
+	defer ratingRows.Close()
+
+	for ratingRows.Next() {
+		var (
+			reviewID string
+			rating   types.CleanerRating
+		)
+		if err := ratingRows.Scan(&reviewID, &rating.EmployeeID, &rating.Rating); err != nil {
+			return nil, fmt.Errorf("failed to scan cleaner rating: %w", err)
+		}
+		if i, ok := index[reviewID]; ok {
+			reviews[i].CleanerRatings = append(reviews[i].CleanerRatings, rating)
+		}
+	}
+	if err := ratingRows.Err(); err != nil {
+		return nil, fmt.Errorf("failed iterating cleaner ratings: %w", err)
+	}
+	return reviews, nil
+}
@@ -0,0 +1,230 @@
+package tasks
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"handworks-api/types"
+	"handworks-api/utils"
+	"strings"
+
+	"github.com/jackc/pgx/v5"
+)
+
+var (
+	ErrInvalidServiceZone  = errors.New("invalid service zone")
+	ErrServiceZoneNotFound = errors.New("service zone not found")
+	ErrOutsideServiceArea  = errors.New("address is outside our service area")
+	ErrBelowZoneMinimum    = errors.New("order is below the minimum for this service zone")
+	ErrServiceZoneMismatch = errors.New("booking address is in a different service zone than the one quoted")
+)
+
+const serviceZoneColumns = `
+	id, name, boundary, travel_fee, min_order_value, active, created_at, updated_at`
+
+func scanServiceZone(row pgx.Row) (*types.ServiceZone, error) {
+	var zone types.ServiceZone
+	if err := row.Scan(
+		&zone.ID, &zone.Name, &zone.Boundary, &zone.TravelFee,
+		&zone.MinOrderValue, &zone.Active, &zone.CreatedAt, &zone.UpdatedAt,
+	); err != nil {
+		return nil, err
+	}
+	return &zone, nil
+}
+
+func validateServiceZone(req *types.ServiceZoneRequest) error {
+	if strings.TrimSpace(req.Name) == "" {
+		return fmt.Errorf("%w: zone name is required", ErrInvalidServiceZone)
+	}
+	if _, err := utils.ParseGeoJSONPolygons(req.Boundary); err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidServiceZone, err)
+	}
+	return nil
+}
+
+func (t *AdminTasks) FetchServiceZones(ctx context.Context, tx pgx.Tx) ([]types.ServiceZone, error) {
+	rows, err := tx.Query(ctx, `
+		SELECT `+serviceZoneColumns+`
+		FROM booking.service_zones
+		ORDER BY active DESC, name ASC
+	`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch service zones: %w", err)
+	}
+	defer rows.Close()
+
+	zones := make([]types.ServiceZone, 0)
+	for rows.Next() {
+		zone, err := scanServiceZone(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan service zone: %w", err)
+		}
+		zones = append(zones, *zone)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed iterating service zones: %w", err)
+	}
+	return zones, nil
+}
+
+func (t *AdminTasks) CreateServiceZone(ctx context.Context, tx pgx.Tx, req *types.ServiceZoneRequest) (*types.ServiceZone, error) {
+	if err := validateServiceZone(req); err != nil {
+		return nil, err
+	}
+
+	active := req.Active == nil || *req.Active
+	zone, err := scanServiceZone(tx.QueryRow(ctx, `
+		INSERT INTO booking.service_zones
+			(name, boundary, travel_fee, min_order_value, active, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, NOW(), NOW())
+		RETURNING `+serviceZoneColumns,
+		strings.TrimSpace(req.Name), req.Boundary, req.TravelFee, req.MinOrderValue, active,
+	))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create service zone: %w", err)
+	}
+	return zone, nil
+}
+
+// UpdateServiceZone replaces a zone's boundary and fees. Active is only changed when given.
+func (t *AdminTasks) UpdateServiceZone(ctx context.Context, tx pgx.Tx, zoneID string, req *types.ServiceZoneRequest) (*types.ServiceZone, error) {
+	if err := validateServiceZone(req); err != nil {
+		return nil, err
+	}
+
+	zone, err := scanServiceZone(tx.QueryRow(ctx, `
+		UPDATE booking.service_zones
+		SET name = $2,
+		    boundary = $3,
+		    travel_fee = $4,
+		    min_order_value = $5,
+		    active = COALESCE($6, active),
+		    updated_at = NOW()
+		WHERE id::text = $1
+		RETURNING `+serviceZoneColumns,
+		zoneID, strings.TrimSpace(req.Name), req.Boundary, req.TravelFee, req.MinOrderValue, req.Active,
+	))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrServiceZoneNotFound
+		}
+		return nil, fmt.Errorf("failed to update service zone: %w", err)
+	}
+	return zone, nil
+}
+
+// DeactivateServiceZone stops new addresses, quotes and bookings in the zone. Bookings
+// already made there are kept.
+func (t *AdminTasks) DeactivateServiceZone(ctx context.Context, tx pgx.Tx, zoneID string) error {
+	tag, err := tx.Exec(ctx, `
+		UPDATE booking.service_zones
+		SET active = FALSE, updated_at = NOW()
+		WHERE id::text = $1
+		  AND active
+	`, zoneID)
+	if err != nil {
+		return fmt.Errorf("failed to deactivate service zone: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return ErrServiceZoneNotFound
+	}
+	return nil
+}
+
+// ResolveServiceZone returns the active zone the address lies in. Where zones overlap the
+// one with the lowest travel fee is used. While no zone is active the service area is not
+// restricted yet, and it returns a nil zone for every address.
+func ResolveServiceZone(ctx context.Context, tx pgx.Tx, address types.Address) (*types.ServiceZone, error) {
+	rows, err := tx.Query(ctx, `
+		SELECT `+serviceZoneColumns+`
+		FROM booking.service_zones
+		WHERE active
+		ORDER BY travel_fee ASC, created_at ASC
+	`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch service zones: %w", err)
+	}
+	defer rows.Close()
+
+	zones := make([]*types.ServiceZone, 0)
+	for rows.Next() {
+		zone, err := scanServiceZone(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan service zone: %w", err)
+		}
+		zones = append(zones, zone)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed iterating service zones: %w", err)
+	}
+	if len(zones) == 0 {
+		return nil, nil
+	}
+
+	if !hasCoordinates(address) {
+		return nil, fmt.Errorf("%w: address has no coordinates", ErrOutsideServiceArea)
+	}
+	for _, zone := range zones {
+		polygons, err := utils.ParseGeoJSONPolygons(zone.Boundary)
+		if err != nil {
+			// Boundaries are validated on save; skip one that was edited by hand.
+			continue
+		}
+		if utils.PolygonsContain(polygons, address.AddressLat, address.AddressLng) {
+			return zone, nil
+		}
+	}
+	return nil, ErrOutsideServiceArea
+}
+
+// ServiceZonesConfigured reports whether any zone is active, i.e. whether addresses have
+// to be checked against the service area at all.
+func ServiceZonesConfigured(ctx context.Context, tx pgx.Tx) (bool, error) {
+	var exists bool
+	if err := tx.QueryRow(ctx, `
+		SELECT EXISTS (SELECT 1 FROM booking.service_zones WHERE active)
+	`).Scan(&exists); err != nil {
+		return false, fmt.Errorf("failed to check service zones: %w", err)
+	}
+	return exists, nil
+}
+
+// CheckBookingServiceZone rejects a booking address outside every active zone, or in a
+// different zone from the one its quote charged the travel fee of. Quotes made before
+// zones existed carry no zone and only need the address to be served.
+func CheckBookingServiceZone(ctx context.Context, tx pgx.Tx, address types.Address, quotedZoneID string) error {
+	zone, err := ResolveServiceZone(ctx, tx, address)
+	if err != nil {
+		return err
+	}
+	if zone == nil {
+		return nil
+	}
+	if quotedZoneID != "" && zone.ID != quotedZoneID {
+		return fmt.Errorf("%w: quoted for another zone, address is in %s", ErrServiceZoneMismatch, zone.Name)
+	}
+	return nil
+}
+
+// ServiceZoneID returns the zone's ID, or "" for the nil zone of an unrestricted area.
+func ServiceZoneID(zone *types.ServiceZone) string {
+	if zone == nil {
+		return ""
+	}
+	return zone.ID
+}
+
+// ZoneTravelFee returns the zone's travel fee for an order worth orderValue before the fee,
+// or ErrBelowZoneMinimum when the order is too small to travel there for. There is no
+// fee while no zones are configured.
+func ZoneTravelFee(zone *types.ServiceZone, orderValue float32) (float32, error) {
+	if zone == nil {
+		return 0, nil
+	}
+	if orderValue < zone.MinOrderValue {
+		return 0, fmt.Errorf("%w: %s needs at least %.2f, order is %.2f",
+			ErrBelowZoneMinimum, zone.Name, zone.MinOrderValue, orderValue)
+	}
+	return zone.TravelFee, nil
+}
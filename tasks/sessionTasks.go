@@ -0,0 +1,282 @@
+package tasks
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"handworks-api/types"
+	"handworks-api/utils"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// ExtraHourRatePerCleaner is what one cleaner's extra hour costs, whether booked up front
+// as ExtraHours or billed as overtime when a session runs long.
+const ExtraHourRatePerCleaner float32 = 250.00
+
+var (
+	ErrSessionPaused          = errors.New("booking session is paused")
+	ErrSessionNotPaused       = errors.New("booking session is not paused")
+	ErrOutsideGeofence        = errors.New("device is too far from the booking address")
+	ErrGeofenceUnverifiable   = errors.New("booking address has no coordinates to check the device against")
+	ErrGeofenceOverrideDenied = errors.New("only admins can override the geofence")
+)
+
+func (t *BookingTasks) RecordSessionEvent(
+	ctx context.Context,
+	tx pgx.Tx,
+	bookingID string,
+	eventType types.SessionEventType,
+	actor, reason string,
+) error {
+	_, err := tx.Exec(ctx, `
+		INSERT INTO booking.session_events (booking_id, event_type, actor, reason, created_at)
+		VALUES ($1, $2, $3, NULLIF($4, ''), NOW())
+	`, bookingID, string(eventType), actor, strings.TrimSpace(reason))
+	if err != nil {
+		return fmt.Errorf("failed to record session %s event: %w", strings.ToLower(string(eventType)), err)
+	}
+	return nil
+}
+
+// FetchSessionEvents returns the session's events in order. Sessions started before events
+// were recorded get a START at the time their session row was created.
+func (t *BookingTasks) FetchSessionEvents(ctx context.Context, tx pgx.Tx, bookingID string) ([]types.SessionEvent, error) {
+	rows, err := tx.Query(ctx, `
+		SELECT event_type, created_at, COALESCE(actor, ''), COALESCE(reason, '')
+		FROM booking.session_events
+		WHERE booking_id = $1
+		ORDER BY created_at ASC, id ASC
+	`, bookingID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch session events: %w", err)
+	}
+	defer rows.Close()
+
+	events := make([]types.SessionEvent, 0)
+	for rows.Next() {
+		var e types.SessionEvent
+		if err := rows.Scan(&e.Type, &e.At, &e.Actor, &e.Reason); err != nil {
+			return nil, fmt.Errorf("failed to scan session event: %w", err)
+		}
+		events = append(events, e)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed iterating session events: %w", err)
+	}
+
+	if len(events) > 0 && events[0].Type == types.SessionEventStart {
+		return events, nil
+	}
+
+	var startedAt time.Time
+	err = tx.QueryRow(ctx, `SELECT created_at FROM booking.sessions WHERE booking_id = $1`, bookingID).Scan(&startedAt)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return events, nil
+		}
+		return nil, fmt.Errorf("failed to fetch session start: %w", err)
+	}
+	return append([]types.SessionEvent{{Type: types.SessionEventStart, At: startedAt}}, events...), nil
+}
+
+// SessionIsPaused reports whether the last pause or resume in events was a pause.
+func SessionIsPaused(events []types.SessionEvent) bool {
+	paused := false
+	for _, e := range events {
+		switch e.Type {
+		case types.SessionEventPause:
+			paused = true
+		case types.SessionEventResume, types.SessionEventStart, types.SessionEventEnd:
+			paused = false
+		}
+	}
+	return paused
+}
+
+// WorkedDuration adds up the time between each START or RESUME and the PAUSE or END that
+// follows it, and the time spent paused. A session that has not ended counts up to now.
+func WorkedDuration(events []types.SessionEvent, now time.Time) (worked, paused time.Duration) {
+	var (
+		running, onPause bool
+		since            time.Time
+	)
+
+	for _, e := range events {
+		switch e.Type {
+		case types.SessionEventStart:
+			if !running && !onPause {
+				running, since = true, e.At
+			}
+		case types.SessionEventPause:
+			if running {
+				worked += e.At.Sub(since)
+				running, onPause, since = false, true, e.At
+			}
+		case types.SessionEventResume:
+			if onPause {
+				paused += e.At.Sub(since)
+				running, onPause, since = true, false, e.At
+			}
+		case types.SessionEventEnd:
+			if running {
+				worked += e.At.Sub(since)
+			}
+			if onPause {
+				paused += e.At.Sub(since)
+			}
+			return worked, paused
+		}
+	}
+
+	if running {
+		worked += now.Sub(since)
+	}
+	if onPause {
+		paused += now.Sub(since)
+	}
+	return worked, paused
+}
+
+// CalculateOvertime compares the session's wall-clock worked time with the quoted hours,
+// which must be wall-clock hours too, and prices the overtime at ExtraHourRatePerCleaner
+// for every cleaner on the booking.
+func CalculateOvertime(worked time.Duration, quotedHours float32, cleaners int, policy types.OvertimePolicy) (overtimeHours, billedHours, charge float32) {
+	overtime := worked.Minutes() - float64(quotedHours)*60
+	if overtime <= 0 {
+		return 0, 0, 0
+	}
+	overtimeHours = float32(overtime / 60)
+	if overtime <= policy.ToleranceMinutes || cleaners <= 0 {
+		return overtimeHours, 0, 0
+	}
+
+	increment := policy.IncrementMinutes
+	if increment <= 0 {
+		increment = 60
+	}
+	billedHours = float32(math.Ceil(overtime/increment) * increment / 60)
+	charge = billedHours * ExtraHourRatePerCleaner * float32(cleaners)
+	return overtimeHours, billedHours, charge
+}
+
+// CrewSessionHours turns the quote's service hours, which are crew-hours, into how long
+// a crew of the given size is on site. Extra hours are added as they are, since every
+// cleaner works them.
+func CrewSessionHours(serviceHours, extraHours float32, cleaners int) float32 {
+	if cleaners < 1 {
+		cleaners = 1
+	}
+	return serviceHours/float32(cleaners) + extraHours
+}
+
+// QuotedSessionHours is how long, on the clock, the booking's crew was sold to work: the
+// quote's service hours shared across its cleaners plus any extra hours. Days of a
+// multi-day job each carry their own share, so those use the scheduled window instead.
+func (t *BookingTasks) QuotedSessionHours(ctx context.Context, tx pgx.Tx, snap *BookingSnapshot) (float32, error) {
+	linked, err := t.FetchLinkedBookingIDs(ctx, tx, snap.BookingID)
+	if err != nil {
+		return 0, err
+	}
+	if len(linked) > 0 || snap.TotalServiceHours <= 0 {
+		return float32(snap.EndSched.Sub(snap.StartSched).Hours()), nil
+	}
+	return CrewSessionHours(snap.TotalServiceHours, snap.ExtraHours, len(snap.CleanerIDs)), nil
+}
+
+// SaveSessionTotals stores the measured duration and any overtime on the session.
+func (t *BookingTasks) SaveSessionTotals(ctx context.Context, tx pgx.Tx, summary *types.SessionSummary) error {
+	_, err := tx.Exec(ctx, `
+		UPDATE booking.sessions
+		SET worked_minutes = $2,
+		    paused_minutes = $3,
+		    overtime_hours = $4,
+		    overtime_charge = $5,
+		    updated_at = NOW()
+		WHERE booking_id = $1
+	`, summary.BookingID, summary.WorkedMinutes, summary.PausedMinutes, summary.BilledOvertimeHours, summary.OvertimeCharge)
+	if err != nil {
+		return fmt.Errorf("failed to save session totals: %w", err)
+	}
+	return nil
+}
+
+// ChargeOvertime adds the overtime charge to the booking and to its order's balance. A
+// fully paid order goes back to pending_fullpayment for just the overtime.
+func (t *BookingTasks) ChargeOvertime(ctx context.Context, tx pgx.Tx, bookingID, orderID string, charge float32) error {
+	if charge <= 0 {
+		return nil
+	}
+
+	if _, err := tx.Exec(ctx, `
+		UPDATE booking.bookings
+		SET extra_hour_cost = COALESCE(extra_hour_cost, 0) + $2,
+		    total_price = COALESCE(total_price, 0) + $2
+		WHERE id = $1
+	`, bookingID, charge); err != nil {
+		return fmt.Errorf("failed to add overtime to booking: %w", err)
+	}
+
+	if orderID == "" {
+		return nil
+	}
+	if _, err := tx.Exec(ctx, `
+		UPDATE payment.orders
+		SET total_amount = total_amount + $2,
+		    remaining_balance = CASE
+				WHEN LOWER(payment_status) = 'paid' THEN $2
+				ELSE remaining_balance + $2
+			END,
+		    payment_status = CASE
+				WHEN LOWER(payment_status) = 'paid' THEN 'pending_fullpayment'
+				ELSE payment_status
+			END,
+		    updated_at = NOW()
+		WHERE id = $1
+	`, orderID, charge); err != nil {
+		return fmt.Errorf("failed to add overtime to order balance: %w", err)
+	}
+	return nil
+}
+
+// CheckGeofence returns how far, in meters, the device is from the booking address and
+// fails when that is beyond the policy radius.
+func CheckGeofence(policy types.GeofencePolicy, address types.Address, loc *types.DeviceLocation) (float64, error) {
+	if !hasCoordinates(address) {
+		return 0, ErrGeofenceUnverifiable
+	}
+	distance := utils.HaversineKm(address.AddressLat, address.AddressLng, *loc.Latitude, *loc.Longitude) * 1000
+	if distance > policy.RadiusMeters {
+		return distance, fmt.Errorf("%w: %.0f m away, limit is %.0f m", ErrOutsideGeofence, distance, policy.RadiusMeters)
+	}
+	return distance, nil
+}
+
+// RecordSessionStartLocation keeps where the session was started from for disputes.
+// distance is nil when the booking address has no coordinates; overriddenBy is the admin
+// who let the session start outside the geofence, if any.
+func (t *BookingTasks) RecordSessionStartLocation(
+	ctx context.Context,
+	tx pgx.Tx,
+	bookingID string,
+	loc *types.DeviceLocation,
+	distance *float64,
+	overriddenBy string,
+) error {
+	if _, err := tx.Exec(ctx, `
+		UPDATE booking.sessions
+		SET start_latitude = $2,
+		    start_longitude = $3,
+		    start_accuracy_m = $4,
+		    start_distance_m = $5,
+		    geofence_override_by = NULLIF($6, ''),
+		    updated_at = NOW()
+		WHERE booking_id = $1
+	`, bookingID, loc.Latitude, loc.Longitude, loc.AccuracyMeters, distance, overriddenBy); err != nil {
+		return fmt.Errorf("failed to record session start location: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,129 @@
+package tasks
+
+import (
+	"handworks-api/types"
+	"math"
+	"testing"
+	"time"
+)
+
+func TestCalculateOvertime(t *testing.T) {
+	policy := types.OvertimePolicy{ToleranceMinutes: 15, IncrementMinutes: 30}
+
+	tests := []struct {
+		name         string
+		worked       time.Duration
+		quotedHours  float32
+		cleaners     int
+		policy       types.OvertimePolicy
+		wantOvertime float32
+		wantBilled   float32
+		wantCharge   float32
+	}{
+		{name: "finished early", worked: 3 * time.Hour, quotedHours: 4, cleaners: 2, policy: policy},
+		{name: "finished on time", worked: 4 * time.Hour, quotedHours: 4, cleaners: 2, policy: policy},
+		{
+			name:         "within the tolerance is recorded but not billed",
+			worked:       4*time.Hour + 10*time.Minute,
+			quotedHours:  4,
+			cleaners:     2,
+			policy:       policy,
+			wantOvertime: 10.0 / 60,
+		},
+		{
+			name:         "exactly at the tolerance is not billed",
+			worked:       4*time.Hour + 15*time.Minute,
+			quotedHours:  4,
+			cleaners:     2,
+			policy:       policy,
+			wantOvertime: 15.0 / 60,
+		},
+		{
+			name:         "past the tolerance rounds up to the next increment for every cleaner",
+			worked:       4*time.Hour + 20*time.Minute,
+			quotedHours:  4,
+			cleaners:     2,
+			policy:       policy,
+			wantOvertime: 20.0 / 60,
+			wantBilled:   0.5,
+			wantCharge:   0.5 * ExtraHourRatePerCleaner * 2,
+		},
+		{
+			name:         "several increments",
+			worked:       5*time.Hour + 5*time.Minute,
+			quotedHours:  4,
+			cleaners:     3,
+			policy:       policy,
+			wantOvertime: 65.0 / 60,
+			wantBilled:   1.5,
+			wantCharge:   1.5 * ExtraHourRatePerCleaner * 3,
+		},
+		{
+			name:         "no cleaners on the booking is not billed",
+			worked:       5 * time.Hour,
+			quotedHours:  4,
+			cleaners:     0,
+			policy:       policy,
+			wantOvertime: 1,
+		},
+		{
+			name:         "an unset increment bills whole hours",
+			worked:       4*time.Hour + 20*time.Minute,
+			quotedHours:  4,
+			cleaners:     1,
+			policy:       types.OvertimePolicy{ToleranceMinutes: 15},
+			wantOvertime: 20.0 / 60,
+			wantBilled:   1,
+			wantCharge:   ExtraHourRatePerCleaner,
+		},
+		{
+			name:         "a crew of two on an eight crew-hour quote is on site for four hours",
+			worked:       4*time.Hour + 20*time.Minute,
+			quotedHours:  CrewSessionHours(8, 0, 2),
+			cleaners:     2,
+			policy:       policy,
+			wantOvertime: 20.0 / 60,
+			wantBilled:   0.5,
+			wantCharge:   0.5 * ExtraHourRatePerCleaner * 2,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			overtime, billed, charge := CalculateOvertime(tt.worked, tt.quotedHours, tt.cleaners, tt.policy)
+			if math.Abs(float64(overtime-tt.wantOvertime)) > 1e-4 {
+				t.Errorf("overtime hours = %v, want %v", overtime, tt.wantOvertime)
+			}
+			if billed != tt.wantBilled {
+				t.Errorf("billed hours = %v, want %v", billed, tt.wantBilled)
+			}
+			if charge != tt.wantCharge {
+				t.Errorf("charge = %v, want %v", charge, tt.wantCharge)
+			}
+		})
+	}
+}
+
+func TestCrewSessionHours(t *testing.T) {
+	tests := []struct {
+		name         string
+		serviceHours float32
+		extraHours   float32
+		cleaners     int
+		want         float32
+	}{
+		{name: "one cleaner works the quoted hours", serviceHours: 6, cleaners: 1, want: 6},
+		{name: "service hours are shared across the crew", serviceHours: 8, cleaners: 2, want: 4},
+		{name: "extra hours are worked by every cleaner", serviceHours: 8, extraHours: 1, cleaners: 2, want: 5},
+		{name: "uneven split", serviceHours: 6, cleaners: 4, want: 1.5},
+		{name: "no cleaners counts as one", serviceHours: 8, cleaners: 0, want: 8},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := CrewSessionHours(tt.serviceHours, tt.extraHours, tt.cleaners); got != tt.want {
+				t.Errorf("CrewSessionHours = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,303 @@
+package tasks
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"handworks-api/types"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+)
+
+var (
+	ErrWaitlistEntryNotFound  = errors.New("waitlist entry not found")
+	ErrWaitlistEntryClosed    = errors.New("waitlist entry is no longer waiting")
+	ErrOrderAlreadyBooked     = errors.New("order already has a booking")
+	ErrOrderAlreadyWaitlisted = errors.New("order is already on the waitlist")
+)
+
+const waitlistEntryColumns = `
+	id::text,
+	customer_id::text,
+	order_id::text,
+	start_sched,
+	end_sched,
+	status,
+	booking_id::text,
+	request,
+	expires_at,
+	created_at,
+	updated_at`
+
+func scanWaitlistEntries(rows pgx.Rows) ([]types.WaitlistEntry, error) {
+	entries := make([]types.WaitlistEntry, 0)
+	for rows.Next() {
+		var (
+			entry   types.WaitlistEntry
+			status  string
+			request []byte
+		)
+		if err := rows.Scan(
+			&entry.ID,
+			&entry.CustomerID,
+			&entry.OrderID,
+			&entry.StartSched,
+			&entry.EndSched,
+			&status,
+			&entry.BookingID,
+			&request,
+			&entry.ExpiresAt,
+			&entry.CreatedAt,
+			&entry.UpdatedAt,
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan waitlist entry: %w", err)
+		}
+		entry.Status = types.WaitlistStatus(status)
+		if err := json.Unmarshal(request, &entry.Request); err != nil {
+			return nil, fmt.Errorf("unmarshal waitlist request: %w", err)
+		}
+		entries = append(entries, entry)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed iterating waitlist entries: %w", err)
+	}
+	return entries, nil
+}
+
+// OrderHasActiveBooking reports whether an order is already attached to a booking that was not cancelled.
+func (t *BookingTasks) OrderHasActiveBooking(ctx context.Context, tx pgx.Tx, orderID string) (bool, error) {
+	var exists bool
+	err := tx.QueryRow(ctx, `
+		SELECT EXISTS (
+			SELECT 1
+			FROM booking.basebookings
+			WHERE orderid = $1
+			  AND status <> 'CANCELLED'
+		)
+	`, orderID).Scan(&exists)
+	if err != nil {
+		return false, fmt.Errorf("failed to check order bookings: %w", err)
+	}
+	return exists, nil
+}
+
+// InsertWaitlistEntry queues a booking request that could not get cleaners. The request is
+// stored as is so it can be booked unchanged once cleaners free up.
+func (t *BookingTasks) InsertWaitlistEntry(
+	ctx context.Context,
+	tx pgx.Tx,
+	req *types.CreateBookingRequest,
+	customerID string,
+	expiresAt time.Time,
+) (*types.WaitlistEntry, error) {
+	requestJSON, err := json.Marshal(withoutAccessInstructions(*req))
+	if err != nil {
+		return nil, fmt.Errorf("marshal waitlist request: %w", err)
+	}
+
+	rows, err := tx.Query(ctx, `
+		INSERT INTO booking.waitlist_entries (
+			customer_id,
+			order_id,
+			start_sched,
+			end_sched,
+			status,
+			request,
+			expires_at,
+			created_at,
+			updated_at
+		)
+		SELECT $1, $2, $3, $4, $5, $6, $7, NOW(), NOW()
+		WHERE NOT EXISTS (
+			SELECT 1
+			FROM booking.waitlist_entries
+			WHERE order_id = $2
+			  AND status = $5
+		)
+		RETURNING `+waitlistEntryColumns,
+		customerID,
+		req.Base.OrderId,
+		req.Base.StartSched,
+		req.Base.EndSched,
+		string(types.WaitlistWaiting),
+		requestJSON,
+		expiresAt,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to insert waitlist entry: %w", err)
+	}
+	defer rows.Close()
+
+	entries, err := scanWaitlistEntries(rows)
+	if err != nil {
+		return nil, err
+	}
+	if len(entries) == 0 {
+		return nil, ErrOrderAlreadyWaitlisted
+	}
+	return &entries[0], nil
+}
+
+func (t *BookingTasks) FetchWaitlistEntry(ctx context.Context, tx pgx.Tx, entryID string) (*types.WaitlistEntry, error) {
+	rows, err := tx.Query(ctx, `
+		SELECT `+waitlistEntryColumns+`
+		FROM booking.waitlist_entries
+		WHERE id = $1
+	`, entryID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch waitlist entry: %w", err)
+	}
+	defer rows.Close()
+
+	entries, err := scanWaitlistEntries(rows)
+	if err != nil {
+		return nil, err
+	}
+	if len(entries) == 0 {
+		return nil, ErrWaitlistEntryNotFound
+	}
+	return &entries[0], nil
+}
+
+// LockWaitlistEntry locks a waiting entry for promotion. It returns nil when the entry is
+// no longer waiting or another worker is already promoting it.
+func (t *BookingTasks) LockWaitlistEntry(ctx context.Context, tx pgx.Tx, entryID string) (*types.WaitlistEntry, error) {
+	rows, err := tx.Query(ctx, `
+		SELECT `+waitlistEntryColumns+`
+		FROM booking.waitlist_entries
+		WHERE id = $1
+		  AND status = 'WAITING'
+		  AND expires_at > NOW()
+		FOR UPDATE SKIP LOCKED
+	`, entryID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to lock waitlist entry: %w", err)
+	}
+	defer rows.Close()
+
+	entries, err := scanWaitlistEntries(rows)
+	if err != nil {
+		return nil, err
+	}
+	if len(entries) == 0 {
+		return nil, nil
+	}
+	return &entries[0], nil
+}
+
+// FetchPromotableWaitlistIDs lists waiting entries oldest first, so the customer who joined
+// first is offered freed cleaners first.
+func (t *BookingTasks) FetchPromotableWaitlistIDs(ctx context.Context, tx pgx.Tx) ([]string, error) {
+	rows, err := tx.Query(ctx, `
+		SELECT id::text
+		FROM booking.waitlist_entries
+		WHERE status = 'WAITING'
+		  AND expires_at > NOW()
+		ORDER BY created_at ASC
+	`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list waitlist entries: %w", err)
+	}
+	defer rows.Close()
+
+	ids := make([]string, 0)
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			return nil, fmt.Errorf("failed to scan waitlist entry id: %w", err)
+		}
+		ids = append(ids, id)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed iterating waitlist rows: %w", err)
+	}
+	return ids, nil
+}
+
+func (t *BookingTasks) MarkWaitlistPromoted(ctx context.Context, tx pgx.Tx, entry *types.WaitlistEntry, bookingID string) error {
+	_, err := tx.Exec(ctx, `
+		UPDATE booking.waitlist_entries
+		SET status = 'PROMOTED',
+		    booking_id = $2,
+		    updated_at = NOW()
+		WHERE id = $1
+	`, entry.ID, bookingID)
+	if err != nil {
+		return fmt.Errorf("failed to mark waitlist entry promoted: %w", err)
+	}
+
+	return publishEvent(ctx, tx, "waitlist_promoted", map[string]any{
+		"event":      "waitlist_promoted",
+		"entryId":    entry.ID,
+		"bookingId":  bookingID,
+		"customerId": entry.CustomerID,
+	})
+}
+
+func (t *BookingTasks) CancelWaitlistEntry(ctx context.Context, tx pgx.Tx, entryID string) error {
+	result, err := tx.Exec(ctx, `
+		UPDATE booking.waitlist_entries
+		SET status = 'CANCELLED',
+		    updated_at = NOW()
+		WHERE id = $1
+		  AND status = 'WAITING'
+	`, entryID)
+	if err != nil {
+		return fmt.Errorf("failed to cancel waitlist entry: %w", err)
+	}
+	if result.RowsAffected() == 0 {
+		return ErrWaitlistEntryClosed
+	}
+	return nil
+}
+
+// CancelOrderWaitlistEntries closes every waiting entry for an order that has just been
+// booked, so a later promotion cannot book it a second time.
+func (t *BookingTasks) CancelOrderWaitlistEntries(ctx context.Context, tx pgx.Tx, orderID string) error {
+	_, err := tx.Exec(ctx, `
+		UPDATE booking.waitlist_entries
+		SET status = 'CANCELLED',
+		    updated_at = NOW()
+		WHERE order_id = $1
+		  AND status = 'WAITING'
+	`, orderID)
+	if err != nil {
+		return fmt.Errorf("failed to cancel order waitlist entries: %w", err)
+	}
+	return nil
+}
+
+// ExpireWaitlistEntries closes every waiting entry past its expiry and queues a
+// waitlist_expired event for each so the customer hears about it.
+func (t *BookingTasks) ExpireWaitlistEntries(ctx context.Context, tx pgx.Tx) (int, error) {
+	rows, err := tx.Query(ctx, `
+		UPDATE booking.waitlist_entries
+		SET status = 'EXPIRED',
+		    updated_at = NOW()
+		WHERE status = 'WAITING'
+		  AND expires_at <= NOW()
+		RETURNING `+waitlistEntryColumns)
+	if err != nil {
+		return 0, fmt.Errorf("failed to expire waitlist entries: %w", err)
+	}
+	expired, err := scanWaitlistEntries(rows)
+	rows.Close()
+	if err != nil {
+		return 0, err
+	}
+
+	for _, entry := range expired {
+		if err := publishEvent(ctx, tx, "waitlist_expired", map[string]any{
+			"event":      "waitlist_expired",
+			"entryId":    entry.ID,
+			"customerId": entry.CustomerID,
+			"startSched": entry.StartSched,
+			"endSched":   entry.EndSched,
+		}); err != nil {
+			return 0, err
+		}
+	}
+	return len(expired), nil
+}
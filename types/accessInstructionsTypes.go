@@ -0,0 +1,46 @@
+package types
+
+import "time"
+
+// AccessInstructions tell cleaners how to get into the property. They are stored
+// encrypted and only shown to admins and the booking's cleaners.
+type AccessInstructions struct {
+	GateCode          string `json:"gateCode,omitempty"`
+	LobbyInstructions string `json:"lobbyInstructions,omitempty"`
+	KeyLocation       string `json:"keyLocation,omitempty"`
+	ParkingNotes      string `json:"parkingNotes,omitempty"`
+	Notes             string `json:"notes,omitempty"`
+}
+
+// AccessInstructionsPolicy holds the 32-byte key access instructions are encrypted with,
+// if any, and how long before a booking starts its cleaners can read them.
+type AccessInstructionsPolicy struct {
+	Key           []byte
+	CleanerWindow time.Duration
+}
+
+type AccessInstructionsResponse struct {
+	BookingID    string             `json:"bookingId"`
+	Instructions AccessInstructions `json:"instructions"`
+	UpdatedBy    string             `json:"updatedBy"`
+	UpdatedAt    time.Time          `json:"updatedAt"`
+}
+
+type AccessLogAction string
+
+const (
+	AccessLogRead   AccessLogAction = "READ"
+	AccessLogWrite  AccessLogAction = "WRITE"
+	AccessLogDenied AccessLogAction = "DENIED"
+)
+
+// AccessLogEntry records one attempt to read or change a booking's access instructions.
+type AccessLogEntry struct {
+	ID         string          `json:"id"`
+	BookingID  string          `json:"bookingId"`
+	Actor      string          `json:"actor"`
+	ViewerRole string          `json:"viewerRole"`
+	Action     AccessLogAction `json:"action"`
+	Reason     string          `json:"reason,omitempty"`
+	At         time.Time       `json:"at"`
+}
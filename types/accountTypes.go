@@ -238,8 +238,9 @@ type TimesheetToday struct {
 }
 
 type TimeInRequest struct {
-	EmployeeId string    `json:"employee_id"`
-	TimeIn     time.Time `json:"time_in"`
+	EmployeeId string          `json:"employee_id"`
+	TimeIn     time.Time       `json:"time_in"`
+	Location   *DeviceLocation `json:"location" binding:"required"`
 }
 
 type TimeOutRequest struct {
@@ -162,6 +162,17 @@ type AssignEmployeeToBookingResponse struct {
 	Message    string `json:"message"`
 }
 
+// EmployeeQualificationsResponse lists the service types an employee may be allocated to.
+// GENERAL_CLEANING is always included since every cleaner is trained for it.
+type EmployeeQualificationsResponse struct {
+	EmployeeID     string            `json:"employeeId"`
+	Qualifications []MainServiceType `json:"qualifications"`
+}
+
+type UpdateEmployeeQualificationsRequest struct {
+	Qualifications []MainServiceType `json:"qualifications" binding:"required"`
+}
+
 type AvailableCleaner struct {
 	EmployeeID string `json:"employeeId"`
 	FirstName  string `json:"firstName"`
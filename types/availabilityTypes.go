@@ -0,0 +1,38 @@
+package types
+
+import "time"
+
+// BusinessHours bounds every bookable window in the business time zone. A job may start
+// at OpenHour at the earliest and has to be finished by CloseHour. Location is also the
+// zone employee calendars and recurring series are read in.
+type BusinessHours struct {
+	OpenHour    int            `json:"openHour"`
+	CloseHour   int            `json:"closeHour"`
+	SlotMinutes int            `json:"slotMinutes"`
+	TimeZone    string         `json:"timeZone"`
+	Location    *time.Location `json:"-"`
+}
+
+type AvailabilityRequest struct {
+	Service    ServicesRequest `json:"service" binding:"required"`
+	Addons     []AddOnRequest  `json:"addons"`
+	StartDate  string          `json:"startDate" binding:"required"` // YYYY-MM-DD
+	EndDate    string          `json:"endDate" binding:"required"`   // YYYY-MM-DD, inclusive
+	DirtyScale int32           `json:"dirtyScale"`
+	Address    *Address        `json:"address,omitempty"`
+}
+
+// AvailableSlot is a start time that can be booked. For multi-day jobs EndSched is
+// the end of the last day.
+type AvailableSlot struct {
+	StartSched time.Time `json:"startSched"`
+	EndSched   time.Time `json:"endSched"`
+}
+
+type AvailabilityResponse struct {
+	ServiceHours   int32           `json:"serviceHours"`
+	ServiceDays    int             `json:"serviceDays"`
+	CleanersNeeded int             `json:"cleanersNeeded"`
+	BusinessHours  BusinessHours   `json:"businessHours"`
+	Slots          []AvailableSlot `json:"slots"`
+}
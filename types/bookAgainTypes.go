@@ -0,0 +1,10 @@
+package types
+
+// BookAgainResponse.Booking is the past booking's request with the new order and quote
+// filled in; it can be created as soon as startSched and endSched are set.
+type BookAgainResponse struct {
+	SourceBookingID string               `json:"sourceBookingId"`
+	Quote           QuoteResponse        `json:"quote"`
+	Order           Order                `json:"order"`
+	Booking         CreateBookingRequest `json:"booking"`
+}
@@ -0,0 +1,51 @@
+package types
+
+import "time"
+
+type BookingImportStatus string
+
+const (
+	BookingImportValid    BookingImportStatus = "VALID"
+	BookingImportInvalid  BookingImportStatus = "INVALID"
+	BookingImportCreating BookingImportStatus = "CREATING"
+	BookingImportCreated  BookingImportStatus = "CREATED"
+	BookingImportFailed   BookingImportStatus = "FAILED"
+)
+
+// BookingImportJobStatus is where an import is: VALIDATED after the dry run, RUNNING while
+// its bookings are created and COMPLETED once every valid row has been tried.
+type BookingImportJobStatus string
+
+const (
+	BookingImportJobValidated BookingImportJobStatus = "VALIDATED"
+	BookingImportJobRunning   BookingImportJobStatus = "RUNNING"
+	BookingImportJobCompleted BookingImportJobStatus = "COMPLETED"
+)
+
+// BookingImportRowResult reports what happened to one CSV row. Line counts the header as
+// line 1. A row that failed after its order was created still reports the order.
+type BookingImportRowResult struct {
+	Line       int                 `json:"line"`
+	Status     BookingImportStatus `json:"status"`
+	Error      string              `json:"error,omitempty"`
+	CustomerID string              `json:"customerId,omitempty"`
+	StartSched *time.Time          `json:"startSched,omitempty"`
+	EndSched   *time.Time          `json:"endSched,omitempty"`
+	Quote      *QuoteResponse      `json:"quote,omitempty"`
+	OrderID    string              `json:"orderId,omitempty"`
+	BookingID  string              `json:"bookingId,omitempty"`
+}
+
+type BookingImportResponse struct {
+	JobID     string                   `json:"jobId"`
+	Status    BookingImportJobStatus   `json:"status"`
+	Total     int                      `json:"total"`
+	Valid     int                      `json:"valid"`
+	Invalid   int                      `json:"invalid"`
+	Creating  int                      `json:"creating"`
+	Created   int                      `json:"created"`
+	Failed    int                      `json:"failed"`
+	Rows      []BookingImportRowResult `json:"rows"`
+	CreatedAt time.Time                `json:"createdAt"`
+	UpdatedAt time.Time                `json:"updatedAt"`
+}
@@ -0,0 +1,83 @@
+package types
+
+import "time"
+
+type RecurrenceFrequency string
+
+const (
+	RecurrenceWeekly   RecurrenceFrequency = "WEEKLY"
+	RecurrenceBiWeekly RecurrenceFrequency = "BIWEEKLY"
+	RecurrenceMonthly  RecurrenceFrequency = "MONTHLY"
+)
+
+type OccurrenceStatus string
+
+const (
+	OccurrencePending   OccurrenceStatus = "PENDING"
+	OccurrenceScheduled OccurrenceStatus = "SCHEDULED"
+	OccurrenceConflict  OccurrenceStatus = "CONFLICT"
+	OccurrenceFailed    OccurrenceStatus = "FAILED"
+	OccurrenceSkipped   OccurrenceStatus = "SKIPPED"
+	OccurrenceCancelled OccurrenceStatus = "CANCELLED"
+)
+
+type CreateBookingSeriesRequest struct {
+	Frequency      RecurrenceFrequency  `json:"frequency" binding:"required"`
+	EndDate        *time.Time           `json:"endDate,omitempty"`
+	MaxOccurrences int32                `json:"maxOccurrences,omitempty"`
+	Booking        CreateBookingRequest `json:"booking" binding:"required"`
+}
+
+type BookingSeries struct {
+	ID             string               `json:"id"`
+	CustomerID     string               `json:"customerId"`
+	QuoteID        string               `json:"quoteId"`
+	PaymentMethod  string               `json:"paymentMethod"`
+	Frequency      RecurrenceFrequency  `json:"frequency"`
+	Status         string               `json:"status"`
+	AnchorStart    time.Time            `json:"anchorStart"`
+	AnchorEnd      time.Time            `json:"anchorEnd"`
+	EndDate        *time.Time           `json:"endDate,omitempty"`
+	MaxOccurrences int32                `json:"maxOccurrences,omitempty"`
+	Subtotal       float32              `json:"subtotal"`
+	AddonTotal     float32              `json:"addonTotal"`
+	TotalAmount    float32              `json:"totalAmount"`
+	Template       CreateBookingRequest `json:"-"`
+	CreatedAt      time.Time            `json:"createdAt"`
+	EndedAt        *time.Time           `json:"endedAt,omitempty"`
+	Occurrences    []SeriesOccurrence   `json:"occurrences"`
+}
+
+type SeriesOccurrence struct {
+	ID              string           `json:"id"`
+	SeriesID        string           `json:"seriesId"`
+	OccurrenceIndex int32            `json:"occurrenceIndex"`
+	StartSched      time.Time        `json:"startSched"`
+	EndSched        time.Time        `json:"endSched"`
+	Status          OccurrenceStatus `json:"status"`
+	BookingID       *string          `json:"bookingId,omitempty"`
+	OrderID         *string          `json:"orderId,omitempty"`
+	ConflictReason  *string          `json:"conflictReason,omitempty"`
+	UpdatedAt       time.Time        `json:"updatedAt"`
+}
+
+type SkipSeriesOccurrenceRequest struct {
+	OccurrenceDate string `json:"occurrenceDate" binding:"required"` // YYYY-MM-DD
+}
+
+type SeriesConflict struct {
+	OccurrenceID      string    `json:"occurrenceId"`
+	SeriesID          string    `json:"seriesId"`
+	CustomerID        string    `json:"customerId"`
+	CustomerFirstName string    `json:"customerFirstName"`
+	CustomerLastName  string    `json:"customerLastName"`
+	StartSched        time.Time `json:"startSched"`
+	EndSched          time.Time `json:"endSched"`
+	Reason            string    `json:"reason"`
+	UpdatedAt         time.Time `json:"updatedAt"`
+}
+
+type SeriesConflictsResponse struct {
+	Conflicts []SeriesConflict `json:"conflicts"`
+	Total     int              `json:"total"`
+}
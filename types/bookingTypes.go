@@ -15,6 +15,7 @@ type BookingAllocation struct {
 type CleaningAllocation struct {
 	CleaningEquipment []CleaningEquipment
 	CleaningResources []CleaningResources
+	Warnings          []string
 }
 
 type CleaningEquipment struct {
@@ -51,6 +52,9 @@ type CleaningPrices struct {
 	MainServicePrice float32              `json:"mainServicePrice"`
 	AddonPrices      []AddonCleaningPrice `json:"addonPrices"`
 	ExtraHourCost    float32              `json:"extraHourCost,omitempty"` // Added optional field
+	DayPlan          []ServiceDay         `json:"dayPlan,omitempty"`
+	DirtyScale       int32                `json:"dirtyScale"`
+	ServiceZoneID    string               `json:"serviceZoneId,omitempty"`
 }
 
 type ServiceDetail struct {
@@ -156,28 +160,35 @@ type BaseBookingDetails struct {
 	OriginalEndSched  *time.Time `json:"originalEndSched,omitempty" db:"original_end_sched"`
 }
 
+// BaseBookingDetailsRequest.Photos are IDs of BOOKING media uploaded through /media.
+// AccessInstructions are encrypted onto the booking when it is created; waitlist entries
+// and series templates do not keep them, so set them on the booking afterwards.
 type BaseBookingDetailsRequest struct {
-	CustID               string     `json:"custId" db:"custid"`
-	CustomerFirstName    string     `json:"customerFirstName" db:"customerfirstname"`
-	CustomerLastName     string     `json:"customerLastName" db:"customerlastname"`
-	CustomerPhoneNo      string     `json:"customerPhoneNo" db:"customer_phone_no"`
-	Address              Address    `json:"address"`
-	StartSched           time.Time  `json:"startSched" db:"startsched"`
-	EndSched             time.Time  `json:"endSched" db:"endsched"`
-	ServiceDurationHours float64    `json:"serviceDurationHours"`
-	DirtyScale           int32      `json:"dirtyScale" db:"dirtyscale"`
-	Photos               []string   `json:"photos" db:"photos"`
-	CreatedAt            time.Time  `json:"createdAt" db:"createdat"`
-	UpdatedAt            *time.Time `json:"updatedAt,omitempty" db:"updatedat"`
-	OrderId              string     `json:"orderId" db:"orderid"`
-	QuoteId              string     `json:"quoteId" db:"quoteid"`
-	ExtraHours           float32    `json:"extraHours"`
-}
-
+	CustID               string              `json:"custId" db:"custid"`
+	CustomerFirstName    string              `json:"customerFirstName" db:"customerfirstname"`
+	CustomerLastName     string              `json:"customerLastName" db:"customerlastname"`
+	CustomerPhoneNo      string              `json:"customerPhoneNo" db:"customer_phone_no"`
+	Address              Address             `json:"address"`
+	StartSched           time.Time           `json:"startSched" db:"startsched"`
+	EndSched             time.Time           `json:"endSched" db:"endsched"`
+	ServiceDurationHours float64             `json:"serviceDurationHours"`
+	DirtyScale           int32               `json:"dirtyScale" db:"dirtyscale"`
+	Photos               []string            `json:"photos" db:"photos"`
+	CreatedAt            time.Time           `json:"createdAt" db:"createdat"`
+	UpdatedAt            *time.Time          `json:"updatedAt,omitempty" db:"updatedat"`
+	OrderId              string              `json:"orderId" db:"orderid"`
+	QuoteId              string              `json:"quoteId" db:"quoteid"`
+	ExtraHours           float32             `json:"extraHours"`
+	AccessInstructions   *AccessInstructions `json:"accessInstructions,omitempty"`
+}
+
+// Address is where a job takes place. Unit tells apart units that share one building and
+// one pair of coordinates, such as the apartments of a corporate client.
 type Address struct {
 	AddressHuman string  `json:"addressHuman"`
 	AddressLat   float64 `json:"addressLat"`
 	AddressLng   float64 `json:"addressLng"`
+	Unit         string  `json:"unit,omitempty"`
 }
 
 type BookingReply struct {
@@ -224,15 +235,28 @@ type AddOns struct {
 }
 
 type Booking struct {
-	ID            string              `json:"id"`
-	Base          BaseBookingDetails  `json:"base"`
-	MainService   ServiceDetails      `json:"mainService"`
-	Addons        []AddOns            `json:"addons,omitempty"`
-	Equipments    []CleaningEquipment `json:"equipments,omitempty"`
-	Resources     []CleaningResources `json:"resources,omitempty"`
-	Cleaners      []CleanerAssigned   `json:"cleaners,omitempty"`
-	ExtraHourCost float32             `json:"extraHourCost,omitempty"`
-	TotalPrice    float32             `json:"totalPrice"`
+	ID                string              `json:"id"`
+	Base              BaseBookingDetails  `json:"base"`
+	MainService       ServiceDetails      `json:"mainService"`
+	Addons            []AddOns            `json:"addons,omitempty"`
+	Equipments        []CleaningEquipment `json:"equipments,omitempty"`
+	Resources         []CleaningResources `json:"resources,omitempty"`
+	Cleaners          []CleanerAssigned   `json:"cleaners,omitempty"`
+	ExtraHourCost     float32             `json:"extraHourCost,omitempty"`
+	TotalPrice        float32             `json:"totalPrice"`
+	LinkedBookings    []LinkedBookingDay  `json:"linkedBookings,omitempty"`
+	InventoryWarnings []string            `json:"inventoryWarnings,omitempty"`
+}
+
+// LinkedBookingDay is one of the consecutive-day bookings created for a multi-day quote.
+type LinkedBookingDay struct {
+	BookingID  string            `json:"bookingId"`
+	DayIndex   int32             `json:"dayIndex"`
+	StartSched time.Time         `json:"startSched"`
+	EndSched   time.Time         `json:"endSched"`
+	Hours      int32             `json:"hours"`
+	Price      float32           `json:"price"`
+	Cleaners   []CleanerAssigned `json:"cleaners"`
 }
 
 type FetchAllBookingsResponse struct {
@@ -270,12 +294,64 @@ type UsedInventoryItem struct {
 	Quantity float64 `json:"quantity"`
 }
 
+// StartSessionRequest and EndSessionRequest take the IDs of SESSION media uploaded
+// through /media, not URLs. StartSessionRequest.Location must be within the geofence
+// radius of the booking address unless an admin sets OverrideGeofence.
 type StartSessionRequest struct {
-	BookingID   string   `json:"bookingId" binding:"required"`
-	StartPhotos []string `json:"startPhotos" binding:"required,min=1"`
+	BookingID        string          `json:"bookingId" binding:"required"`
+	StartPhotos      []string        `json:"startPhotos" binding:"required,min=1"`
+	Location         *DeviceLocation `json:"location" binding:"required"`
+	OverrideGeofence bool            `json:"overrideGeofence"`
 }
 
 type EndSessionRequest struct {
 	BookingID string   `json:"bookingId" binding:"required"`
 	EndPhotos []string `json:"endPhotos" binding:"required,min=1"`
 }
+
+type RescheduleBookingRequest struct {
+	StartSched time.Time `json:"startSched" binding:"required"`
+	EndSched   time.Time `json:"endSched" binding:"required"`
+}
+
+type RescheduleBookingResponse struct {
+	BookingID          string    `json:"bookingId"`
+	StartSched         time.Time `json:"startSched"`
+	EndSched           time.Time `json:"endSched"`
+	ReviewStatus       string    `json:"reviewStatus"`
+	CleanerIDs         []string  `json:"cleanerIds"`
+	CleanersReassigned bool      `json:"cleanersReassigned"`
+}
+
+type CancellationOutcome string
+
+const (
+	CancellationFullRefund           CancellationOutcome = "FULL_REFUND"
+	CancellationDownpaymentForfeited CancellationOutcome = "DOWNPAYMENT_FORFEITED"
+	CancellationNoRefund             CancellationOutcome = "NO_REFUND"
+)
+
+// CancellationPolicy maps how early a booking is cancelled to a refund outcome.
+// Cancelling at least FullRefundHours before startSched refunds everything paid,
+// at least DownpaymentForfeitHours keeps the downpayment, anything later refunds nothing.
+type CancellationPolicy struct {
+	FullRefundHours         float64 `json:"fullRefundHours"`
+	DownpaymentForfeitHours float64 `json:"downpaymentForfeitHours"`
+}
+
+// TravelPolicy is the straight-line travel model cleaner allocation uses between jobs:
+// trips at AverageSpeedKPH, never shorter than MinBuffer.
+type TravelPolicy struct {
+	AverageSpeedKPH float64       `json:"averageSpeedKph"`
+	MinBuffer       time.Duration `json:"minBuffer"`
+}
+
+type CancelBookingResponse struct {
+	BookingID        string              `json:"bookingId"`
+	Status           string              `json:"status"`
+	Outcome          CancellationOutcome `json:"outcome"`
+	HoursBeforeStart float64             `json:"hoursBeforeStart"`
+	AmountPaid       float32             `json:"amountPaid"`
+	RefundAmount     float32             `json:"refundAmount"`
+	CancellationFee  float32             `json:"cancellationFee"`
+}
@@ -0,0 +1,34 @@
+package types
+
+import "time"
+
+type CalendarFeedOwner string
+
+const (
+	CalendarFeedEmployee CalendarFeedOwner = "EMPLOYEE"
+	CalendarFeedCustomer CalendarFeedOwner = "CUSTOMER"
+)
+
+// CalendarFeed is a read-only ICS subscription for one employee's or customer's bookings.
+// The token itself is only returned when the feed is created.
+type CalendarFeed struct {
+	ID             string            `json:"id"`
+	OwnerType      CalendarFeedOwner `json:"ownerType"`
+	OwnerID        string            `json:"ownerId"`
+	CreatedBy      string            `json:"createdBy"`
+	CreatedAt      time.Time         `json:"createdAt"`
+	LastAccessedAt *time.Time        `json:"lastAccessedAt,omitempty"`
+	RevokedAt      *time.Time        `json:"revokedAt,omitempty"`
+}
+
+type CreateCalendarFeedRequest struct {
+	OwnerType CalendarFeedOwner `json:"ownerType" binding:"required,oneof=EMPLOYEE CUSTOMER"`
+	OwnerID   string            `json:"ownerId" binding:"required"`
+}
+
+// CreateCalendarFeedResponse carries the feed URL to add to a calendar app. Keep it
+// private: anyone with the URL can read the feed until it is revoked.
+type CreateCalendarFeedResponse struct {
+	Feed CalendarFeed `json:"feed"`
+	URL  string       `json:"url"`
+}
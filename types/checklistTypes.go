@@ -0,0 +1,71 @@
+package types
+
+import "time"
+
+// ChecklistScope says whether a template applies when its service type is the booking's
+// main service or when it is booked as an add-on.
+type ChecklistScope string
+
+const (
+	ChecklistScopeMain  ChecklistScope = "MAIN"
+	ChecklistScopeAddon ChecklistScope = "ADDON"
+)
+
+type ChecklistItemStatus string
+
+const (
+	ChecklistItemPending ChecklistItemStatus = "PENDING"
+	ChecklistItemDone    ChecklistItemStatus = "DONE"
+	ChecklistItemSkipped ChecklistItemStatus = "SKIPPED"
+)
+
+type ChecklistTemplate struct {
+	ID          string          `json:"id"`
+	ServiceType MainServiceType `json:"serviceType"`
+	Scope       ChecklistScope  `json:"scope"`
+	Title       string          `json:"title"`
+	Description string          `json:"description"`
+	Required    bool            `json:"required"`
+	Position    int32           `json:"position"`
+	Active      bool            `json:"active"`
+}
+
+type ChecklistTemplateRequest struct {
+	ServiceType MainServiceType `json:"serviceType" binding:"required"`
+	Scope       ChecklistScope  `json:"scope" binding:"required,oneof=MAIN ADDON"`
+	Title       string          `json:"title" binding:"required"`
+	Description string          `json:"description"`
+	Required    bool            `json:"required"`
+	Position    int32           `json:"position"`
+}
+
+// BookingChecklistItem is a template copied onto a booking when its session starts, so
+// later template edits do not change work already in progress.
+type BookingChecklistItem struct {
+	ID          string              `json:"id"`
+	BookingID   string              `json:"bookingId"`
+	TemplateID  string              `json:"templateId"`
+	ServiceType MainServiceType     `json:"serviceType"`
+	Scope       ChecklistScope      `json:"scope"`
+	Title       string              `json:"title"`
+	Description string              `json:"description"`
+	Required    bool                `json:"required"`
+	Position    int32               `json:"position"`
+	Status      ChecklistItemStatus `json:"status"`
+	SkipReason  string              `json:"skipReason,omitempty"`
+	UpdatedBy   string              `json:"updatedBy,omitempty"`
+	UpdatedAt   *time.Time          `json:"updatedAt,omitempty"`
+}
+
+type BookingChecklist struct {
+	BookingID string                 `json:"bookingId"`
+	Items     []BookingChecklistItem `json:"items"`
+	// Outstanding counts required items that are neither done nor skipped.
+	Outstanding int  `json:"outstanding"`
+	Complete    bool `json:"complete"`
+}
+
+type UpdateChecklistItemRequest struct {
+	Status     ChecklistItemStatus `json:"status" binding:"required,oneof=PENDING DONE SKIPPED"`
+	SkipReason string              `json:"skipReason"`
+}
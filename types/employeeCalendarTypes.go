@@ -0,0 +1,83 @@
+package types
+
+import "time"
+
+// CalendarRequestStatus tracks the admin review of a weekly schedule or a time-off request.
+// Only APPROVED entries are used by cleaner allocation.
+type CalendarRequestStatus string
+
+const (
+	CalendarRequestPending    CalendarRequestStatus = "PENDING"
+	CalendarRequestApproved   CalendarRequestStatus = "APPROVED"
+	CalendarRequestRejected   CalendarRequestStatus = "REJECTED"
+	CalendarRequestCancelled  CalendarRequestStatus = "CANCELLED"
+	CalendarRequestSuperseded CalendarRequestStatus = "SUPERSEDED"
+)
+
+// AvailabilityWindow is a recurring block of working time in local time. Weekday follows
+// time.Weekday (0 = Sunday); StartTime and EndTime are HH:MM.
+type AvailabilityWindow struct {
+	Weekday   int    `json:"weekday" binding:"min=0,max=6"`
+	StartTime string `json:"startTime" binding:"required"`
+	EndTime   string `json:"endTime" binding:"required"`
+}
+
+// WeeklyAvailability is the set of windows an employee works every week. An employee
+// without an approved schedule can be allocated at any time.
+type WeeklyAvailability struct {
+	ID          string                `json:"id"`
+	EmployeeID  string                `json:"employeeId"`
+	Status      CalendarRequestStatus `json:"status"`
+	Windows     []AvailabilityWindow  `json:"windows"`
+	SubmittedAt time.Time             `json:"submittedAt"`
+	ReviewedBy  string                `json:"reviewedBy,omitempty"`
+	ReviewedAt  *time.Time            `json:"reviewedAt,omitempty"`
+}
+
+type SubmitWeeklyAvailabilityRequest struct {
+	Windows []AvailabilityWindow `json:"windows" binding:"required,min=1,dive"`
+}
+
+// TimeOff is a one-off stretch of unavailability such as leave or a sick day.
+type TimeOff struct {
+	ID          string                `json:"id"`
+	EmployeeID  string                `json:"employeeId"`
+	StartAt     time.Time             `json:"startAt"`
+	EndAt       time.Time             `json:"endAt"`
+	Reason      string                `json:"reason"`
+	Status      CalendarRequestStatus `json:"status"`
+	RequestedAt time.Time             `json:"requestedAt"`
+	ReviewedBy  string                `json:"reviewedBy,omitempty"`
+	ReviewedAt  *time.Time            `json:"reviewedAt,omitempty"`
+}
+
+type RequestTimeOffRequest struct {
+	StartAt time.Time `json:"startAt" binding:"required"`
+	EndAt   time.Time `json:"endAt" binding:"required"`
+	Reason  string    `json:"reason"`
+}
+
+// EmployeeCalendar is what allocation currently uses for an employee, plus anything
+// still waiting for review.
+type EmployeeCalendar struct {
+	EmployeeID      string              `json:"employeeId"`
+	WeeklySchedule  *WeeklyAvailability `json:"weeklySchedule,omitempty"`
+	PendingSchedule *WeeklyAvailability `json:"pendingSchedule,omitempty"`
+	UpcomingTimeOff []TimeOff           `json:"upcomingTimeOff"`
+}
+
+type ReviewCalendarRequest struct {
+	Approve bool `json:"approve"`
+}
+
+type PendingCalendarRequestsResponse struct {
+	Schedules []WeeklyAvailability `json:"schedules"`
+	TimeOff   []TimeOff            `json:"timeOff"`
+}
+
+// ReviewTimeOffResponse lists the bookings the employee is still assigned to inside an
+// approved time-off, so they can be reassigned.
+type ReviewTimeOffResponse struct {
+	TimeOff            TimeOff  `json:"timeOff"`
+	AffectedBookingIDs []string `json:"affectedBookingIds"`
+}
@@ -76,3 +76,39 @@ type InventoryFilter struct {
 	Page     *int    `json:"page,omitempty" form:"page"`
 	Limit    *int    `json:"limit,omitempty" form:"limit"`
 }
+
+// AllocationRule says how much of an inventory item a service type needs.
+// Required quantity = (BaseQuantity + PerSQM*sqm + PerUnit*units) * (1 + DirtyScaleFactor*(dirtyScale-1)).
+type AllocationRule struct {
+	ID               string          `json:"id" db:"id"`
+	ServiceType      MainServiceType `json:"serviceType" db:"service_type"`
+	ItemID           string          `json:"itemId" db:"item_id"`
+	ItemName         string          `json:"itemName" db:"item_name"`
+	ItemType         ItemType        `json:"itemType" db:"item_type"`
+	BaseQuantity     float64         `json:"baseQuantity" db:"base_quantity"`
+	PerSQM           float64         `json:"perSqm" db:"per_sqm"`
+	PerUnit          float64         `json:"perUnit" db:"per_unit"`
+	DirtyScaleFactor float64         `json:"dirtyScaleFactor" db:"dirty_scale_factor"`
+	CreatedAt        time.Time       `json:"createdAt" db:"created_at"`
+	UpdatedAt        time.Time       `json:"updatedAt" db:"updated_at"`
+}
+
+type CreateAllocationRuleRequest struct {
+	ServiceType      MainServiceType `json:"serviceType" binding:"required"`
+	ItemID           string          `json:"itemId" binding:"required"`
+	BaseQuantity     float64         `json:"baseQuantity"`
+	PerSQM           float64         `json:"perSqm"`
+	PerUnit          float64         `json:"perUnit"`
+	DirtyScaleFactor float64         `json:"dirtyScaleFactor"`
+}
+
+type UpdateAllocationRuleRequest struct {
+	BaseQuantity     *float64 `json:"baseQuantity"`
+	PerSQM           *float64 `json:"perSqm"`
+	PerUnit          *float64 `json:"perUnit"`
+	DirtyScaleFactor *float64 `json:"dirtyScaleFactor"`
+}
+
+type AllocationRulesResponse struct {
+	Rules []AllocationRule `json:"rules"`
+}
@@ -0,0 +1,35 @@
+package types
+
+import "time"
+
+// LocationPolicy controls live cleaner tracking. Positions older than Retention are
+// discarded, pings closer together than MinPingInterval are dropped, and a booking can be
+// tracked from EnRouteWindow before it starts. ETAs assume AverageSpeedKPH.
+type LocationPolicy struct {
+	Retention       time.Duration `json:"retention"`
+	MinPingInterval time.Duration `json:"minPingInterval"`
+	EnRouteWindow   time.Duration `json:"enRouteWindow"`
+	AverageSpeedKPH float64       `json:"averageSpeedKph"`
+}
+
+// LocationPingMessage is the data of a location.ping message sent by a cleaner over the
+// employee websocket.
+type LocationPingMessage struct {
+	BookingID string `json:"bookingId" binding:"required"`
+	DeviceLocation
+}
+
+// CleanerLocation is a cleaner's latest position on a booking. DistanceMeters and
+// ETASeconds are omitted when the booking address has no coordinates.
+type CleanerLocation struct {
+	EmployeeID     string    `json:"employeeId"`
+	BookingID      string    `json:"bookingId"`
+	CustomerID     string    `json:"customerId,omitempty"`
+	Latitude       float64   `json:"latitude"`
+	Longitude      float64   `json:"longitude"`
+	AccuracyMeters float64   `json:"accuracyMeters"`
+	DistanceMeters *float64  `json:"distanceMeters,omitempty"`
+	ETASeconds     *int64    `json:"etaSeconds,omitempty"`
+	Arrived        bool      `json:"arrived"`
+	RecordedAt     time.Time `json:"recordedAt"`
+}
@@ -0,0 +1,58 @@
+package types
+
+import "time"
+
+// MediaPurpose says where an upload may be used. Booking photos, session photos and
+// profile pictures only accept media uploaded for that purpose.
+type MediaPurpose string
+
+const (
+	MediaPurposeBooking MediaPurpose = "BOOKING"
+	MediaPurposeSession MediaPurpose = "SESSION"
+	MediaPurposeProfile MediaPurpose = "PROFILE"
+)
+
+type MediaVariant string
+
+const (
+	MediaVariantOriginal  MediaVariant = "original"
+	MediaVariantThumbnail MediaVariant = "thumbnail"
+)
+
+// MediaPolicy limits uploads and controls the signed URLs media is served from. Uploads
+// are kept on the server's filesystem under StorageDir.
+type MediaPolicy struct {
+	StorageDir     string
+	MaxUploadBytes int64
+	ThumbnailSize  int
+	URLTTL         time.Duration
+	URLSecret      []byte
+	// BaseURL is prefixed to signed download paths, e.g. https://api.example.com.
+	// Empty gives paths relative to this API.
+	BaseURL string
+}
+
+// Media describes a stored upload. URL and ThumbnailURL are signed and stop working
+// at URLExpiresAt; fetch the media again for fresh ones.
+type Media struct {
+	ID           string       `json:"id"`
+	Purpose      MediaPurpose `json:"purpose"`
+	OwnerID      string       `json:"ownerId,omitempty"`
+	ContentType  string       `json:"contentType"`
+	SizeBytes    int64        `json:"sizeBytes"`
+	Width        int          `json:"width"`
+	Height       int          `json:"height"`
+	URL          string       `json:"url"`
+	ThumbnailURL string       `json:"thumbnailUrl"`
+	URLExpiresAt time.Time    `json:"urlExpiresAt"`
+	UploadedBy   string       `json:"uploadedBy"`
+	CreatedAt    time.Time    `json:"createdAt"`
+}
+
+type ResolveMediaRequest struct {
+	IDs []string `json:"ids" binding:"required,min=1,max=50"`
+}
+
+type ResolveMediaResponse struct {
+	Media []Media `json:"media"`
+}
@@ -0,0 +1,30 @@
+package types
+
+import "time"
+
+// NoShowPolicy drives the no-show monitor. A scheduled booking with no session LateAfter
+// past startSched is marked LATE, and one still not started NoShowAfter past startSched is
+// a cleaner no-show. Cleaners can report a customer no-show once they have waited
+// CustomerWait past startSched at the address. The monitor only looks at bookings that
+// started within Lookback, so old bookings that never got a session are left alone.
+type NoShowPolicy struct {
+	LateAfter    time.Duration `json:"lateAfter"`
+	NoShowAfter  time.Duration `json:"noShowAfter"`
+	CustomerWait time.Duration `json:"customerWait"`
+	Lookback     time.Duration `json:"lookback"`
+}
+
+type NoShowKind string
+
+const (
+	NoShowCleaner  NoShowKind = "CLEANER"
+	NoShowCustomer NoShowKind = "CUSTOMER"
+)
+
+// ReportCustomerNoShowRequest is sent by a cleaner at the address. Location must be inside
+// the booking's geofence unless the report comes from an admin.
+type ReportCustomerNoShowRequest struct {
+	BookingID string          `json:"bookingId" binding:"required"`
+	Location  *DeviceLocation `json:"location"`
+	Notes     string          `json:"notes"`
+}
@@ -6,19 +6,33 @@ import (
 )
 
 type Quote struct {
-	ID                string          `json:"id"`
-	CustomerID        string          `json:"customerId"`
-	MainService       string          `json:"mainService"`                            //the main type of service
-	MainServiceDetail json.RawMessage `json:"mainServiceDetail" swaggertype:"object"` //added
-	MainServiceHours  int32           `json:"mainServiceHours"`                       //added
-	Subtotal          float32         `json:"subtotal"`
-	AddonTotal        float32         `json:"addonTotal"`
-	TotalServiceHours int32           `json:"totalServiceHours"` //added
-	TotalPrice        float32         `json:"totalPrice"`
-	IsValid           bool            `json:"isValid"`
-	CreatedAt         time.Time       `json:"createdAt"`
-	UpdatedAt         time.Time       `json:"updatedAt"`
-	Addons            []*QuoteAddon   `json:"addons"`
+	ID                  string          `json:"id"`
+	CustomerID          string          `json:"customerId"`
+	MainService         string          `json:"mainService"`                            //the main type of service
+	MainServiceDetail   json.RawMessage `json:"mainServiceDetail" swaggertype:"object"` //added
+	MainServiceHours    int32           `json:"mainServiceHours"`                       //added
+	Subtotal            float32         `json:"subtotal"`
+	AddonTotal          float32         `json:"addonTotal"`
+	TotalServiceHours   int32           `json:"totalServiceHours"` //added
+	TotalPrice          float32         `json:"totalPrice"`
+	DirtyScale          int32           `json:"dirtyScale"`
+	DirtyScaleSurcharge float32         `json:"dirtyScaleSurcharge"`
+	DirtyScaleHours     int32           `json:"dirtyScaleHours"`
+	ServiceZoneID       string          `json:"serviceZoneId"`
+	TravelFee           float32         `json:"travelFee"`
+	ServiceDays         int32           `json:"serviceDays"`
+	DayPlan             []ServiceDay    `json:"dayPlan"`
+	IsValid             bool            `json:"isValid"`
+	CreatedAt           time.Time       `json:"createdAt"`
+	UpdatedAt           time.Time       `json:"updatedAt"`
+	Addons              []*QuoteAddon   `json:"addons"`
+}
+
+// ServiceDay is one day of a quote whose hours exceed the daily limit.
+type ServiceDay struct {
+	DayIndex int32   `json:"dayIndex"`
+	Hours    int32   `json:"hours"`
+	Price    float32 `json:"price"`
 }
 
 type QuoteAddon struct {
@@ -45,22 +59,43 @@ type QuoteCleaningPrices struct {
 	AddonPrices      []AddonCleaningPrice `json:"addonPrices"`
 }
 type QuoteResponse struct {
-	QuoteId           string           `json:"quoteId" db:"quote_id"`
-	MainServiceName   string           `json:"mainServiceName"`
-	MainServiceDetail json.RawMessage  `json:"mainServiceDetail" swaggertype:"object"`
-	MainServiceTotal  float32          `json:"mainServiceTotal"`
-	MainServiceHours  int32            `json:"mainServiceHours"`
-	AddonTotal        float32          `json:"addonTotal"`
-	TotalPrice        float32          `json:"totalPrice"`
-	TotalServiceHours int32            `json:"totalServiceHours"`
-	Addons            []AddOnBreakdown `json:"addons"`
+	QuoteId           string          `json:"quoteId" db:"quote_id"`
+	MainServiceName   string          `json:"mainServiceName"`
+	MainServiceDetail json.RawMessage `json:"mainServiceDetail" swaggertype:"object"`
+	// Price of the main service alone, before addons, the dirty scale surcharge and the
+	// travel fee. Saved quotes used to report the whole quote here; use totalPrice for that
+	MainServiceTotal    float32          `json:"mainServiceTotal"`
+	MainServiceHours    int32            `json:"mainServiceHours"`
+	AddonTotal          float32          `json:"addonTotal"`
+	DirtyScale          int32            `json:"dirtyScale"`
+	DirtyScaleSurcharge float32          `json:"dirtyScaleSurcharge"`
+	DirtyScaleHours     int32            `json:"dirtyScaleHours"`
+	ServiceZoneID       string           `json:"serviceZoneId"`
+	TravelFee           float32          `json:"travelFee"`
+	TotalPrice          float32          `json:"totalPrice"`
+	TotalServiceHours   int32            `json:"totalServiceHours"`
+	ServiceDays         int32            `json:"serviceDays,omitempty"`
+	DayPlan             []ServiceDay     `json:"dayPlan,omitempty"`
+	Addons              []AddOnBreakdown `json:"addons"`
 }
 
 // QuoteRequest represents the data needed to build a quotation.
+// DirtyScale must match the dirtyScale of the booking made from the quote. Address is
+// required once service zones are configured and must be in the same zone as the
+// booking's, whose travel fee the quote includes.
 type QuoteRequest struct {
 	CustomerID string          `json:"customerId" db:"customer_id"`
 	Service    ServicesRequest `json:"service"` // nested structs usually don't need db tags
 	Addons     []AddOnRequest  `json:"addons"`  // same here
+	DirtyScale int32           `json:"dirtyScale"`
+	Address    *Address        `json:"address"`
+}
+
+// DirtyScalePolicy holds the price and hour multipliers for each dirty scale level, the
+// first entry being level 1. The extra price is quoted as its own surcharge line.
+type DirtyScalePolicy struct {
+	PriceMultipliers []float64 `json:"priceMultipliers"`
+	HourMultipliers  []float64 `json:"hourMultipliers"`
 }
 
 type AddOnBreakdown struct {
@@ -0,0 +1,51 @@
+package types
+
+import "time"
+
+// ReviewStatus is set by moderation. HIDDEN keeps the comment out of public listings but
+// still counts the ratings; REJECTED also takes the ratings out of performance scores.
+type ReviewStatus string
+
+const (
+	ReviewPublished ReviewStatus = "PUBLISHED"
+	ReviewHidden    ReviewStatus = "HIDDEN"
+	ReviewRejected  ReviewStatus = "REJECTED"
+)
+
+type CleanerRating struct {
+	EmployeeID string `json:"employeeId" binding:"required"`
+	Rating     int32  `json:"rating" binding:"required,min=1,max=5"`
+}
+
+// SubmitReviewRequest rates a completed booking. The reviewer is the signed-in customer.
+// Assigned cleaners left out of CleanerRatings are given the overall rating.
+type SubmitReviewRequest struct {
+	BookingID      string          `json:"bookingId" binding:"required"`
+	OverallRating  int32           `json:"overallRating" binding:"required,min=1,max=5"`
+	Comment        string          `json:"comment"`
+	CleanerRatings []CleanerRating `json:"cleanerRatings" binding:"dive"`
+}
+
+type BookingReview struct {
+	ID             string          `json:"id"`
+	BookingID      string          `json:"bookingId"`
+	CustomerID     string          `json:"customerId"`
+	OverallRating  int32           `json:"overallRating"`
+	Comment        string          `json:"comment"`
+	Status         ReviewStatus    `json:"status"`
+	ModerationNote string          `json:"moderationNote,omitempty"`
+	ModeratedBy    string          `json:"moderatedBy,omitempty"`
+	ModeratedAt    *time.Time      `json:"moderatedAt,omitempty"`
+	CleanerRatings []CleanerRating `json:"cleanerRatings"`
+	CreatedAt      time.Time       `json:"createdAt"`
+}
+
+type ModerateReviewRequest struct {
+	Status ReviewStatus `json:"status" binding:"required,oneof=PUBLISHED HIDDEN REJECTED"`
+	Note   string       `json:"note"`
+}
+
+type FetchReviewsResponse struct {
+	TotalReviews int             `json:"totalReviews"`
+	Reviews      []BookingReview `json:"reviews"`
+}
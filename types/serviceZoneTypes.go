@@ -0,0 +1,28 @@
+package types
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// ServiceZone is an area bookings are taken in. Boundary is a GeoJSON Polygon or
+// MultiPolygon geometry with [longitude, latitude] positions. TravelFee is added to quotes
+// for addresses in the zone, and orders below MinOrderValue are not quoted there.
+type ServiceZone struct {
+	ID            string          `json:"id"`
+	Name          string          `json:"name"`
+	Boundary      json.RawMessage `json:"boundary" swaggertype:"object"`
+	TravelFee     float32         `json:"travelFee"`
+	MinOrderValue float32         `json:"minOrderValue"`
+	Active        bool            `json:"active"`
+	CreatedAt     time.Time       `json:"createdAt"`
+	UpdatedAt     time.Time       `json:"updatedAt"`
+}
+
+type ServiceZoneRequest struct {
+	Name          string          `json:"name" binding:"required"`
+	Boundary      json.RawMessage `json:"boundary" binding:"required" swaggertype:"object"`
+	TravelFee     float32         `json:"travelFee" binding:"gte=0"`
+	MinOrderValue float32         `json:"minOrderValue" binding:"gte=0"`
+	Active        *bool           `json:"active"`
+}
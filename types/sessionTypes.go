@@ -0,0 +1,68 @@
+package types
+
+import "time"
+
+type SessionEventType string
+
+const (
+	SessionEventStart  SessionEventType = "START"
+	SessionEventPause  SessionEventType = "PAUSE"
+	SessionEventResume SessionEventType = "RESUME"
+	SessionEventEnd    SessionEventType = "END"
+)
+
+type SessionEvent struct {
+	Type   SessionEventType `json:"type"`
+	At     time.Time        `json:"at"`
+	Actor  string           `json:"actor,omitempty"`
+	Reason string           `json:"reason,omitempty"`
+}
+
+// OvertimePolicy decides when a session that ran past its quoted hours is billed extra.
+// Overtime up to ToleranceMinutes is free; beyond it the whole overtime is billed, rounded
+// up to IncrementMinutes.
+type OvertimePolicy struct {
+	ToleranceMinutes float64 `json:"toleranceMinutes"`
+	IncrementMinutes float64 `json:"incrementMinutes"`
+}
+
+// DeviceLocation is a device's GPS fix. AccuracyMeters is the radius the device reports
+// the true position to be within. The coordinates are pointers so a fix without them is
+// rejected instead of read as (0,0).
+type DeviceLocation struct {
+	Latitude       *float64 `json:"latitude" binding:"required,min=-90,max=90"`
+	Longitude      *float64 `json:"longitude" binding:"required,min=-180,max=180"`
+	AccuracyMeters float64  `json:"accuracyMeters" binding:"gt=0"`
+}
+
+// GeofencePolicy is how far from the booking address, in meters, a session may be started.
+type GeofencePolicy struct {
+	RadiusMeters float64 `json:"radiusMeters"`
+}
+
+type PauseSessionRequest struct {
+	BookingID string `json:"bookingId" binding:"required"`
+	Reason    string `json:"reason"`
+}
+
+type ResumeSessionRequest struct {
+	BookingID string `json:"bookingId" binding:"required"`
+}
+
+// SessionSummary compares the time actually worked on a booking with what was quoted.
+// The overtime fields are only final once the session has ended.
+type SessionSummary struct {
+	BookingID     string  `json:"bookingId"`
+	Status        string  `json:"status"`
+	Paused        bool    `json:"paused"`
+	WorkedMinutes float64 `json:"workedMinutes"`
+	PausedMinutes float64 `json:"pausedMinutes"`
+	// Hours on the clock the crew was quoted for: the quote's service hours, which are
+	// crew-hours, shared across the cleaners, plus any extra hours
+	QuotedHours         float32        `json:"quotedHours"`
+	OvertimeHours       float32        `json:"overtimeHours"`
+	BilledOvertimeHours float32        `json:"billedOvertimeHours"`
+	OvertimeCharge      float32        `json:"overtimeCharge"`
+	Cleaners            int            `json:"cleaners"`
+	Events              []SessionEvent `json:"events"`
+}
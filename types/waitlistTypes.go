@@ -0,0 +1,26 @@
+package types
+
+import "time"
+
+type WaitlistStatus string
+
+const (
+	WaitlistWaiting   WaitlistStatus = "WAITING"
+	WaitlistPromoted  WaitlistStatus = "PROMOTED"
+	WaitlistExpired   WaitlistStatus = "EXPIRED"
+	WaitlistCancelled WaitlistStatus = "CANCELLED"
+)
+
+type WaitlistEntry struct {
+	ID         string               `json:"id"`
+	CustomerID string               `json:"customerId"`
+	OrderID    string               `json:"orderId"`
+	StartSched time.Time            `json:"startSched"`
+	EndSched   time.Time            `json:"endSched"`
+	Status     WaitlistStatus       `json:"status"`
+	BookingID  *string              `json:"bookingId,omitempty"`
+	Request    CreateBookingRequest `json:"-"`
+	ExpiresAt  time.Time            `json:"expiresAt"`
+	CreatedAt  time.Time            `json:"createdAt"`
+	UpdatedAt  time.Time            `json:"updatedAt"`
+}
@@ -0,0 +1,111 @@
+package utils
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+)
+
+const earthRadiusKm = 6371.0
+
+// HaversineKm returns the great-circle distance between two coordinates in kilometres.
+func HaversineKm(lat1, lng1, lat2, lng2 float64) float64 {
+	toRad := func(deg float64) float64 { return deg * math.Pi / 180 }
+
+	dLat := toRad(lat2 - lat1)
+	dLng := toRad(lng2 - lng1)
+	a := math.Sin(dLat/2)*math.Sin(dLat/2) +
+		math.Cos(toRad(lat1))*math.Cos(toRad(lat2))*math.Sin(dLng/2)*math.Sin(dLng/2)
+
+	return earthRadiusKm * 2 * math.Atan2(math.Sqrt(a), math.Sqrt(1-a))
+}
+
+// GeoPolygon is a GeoJSON polygon: an outer ring followed by any holes, each ring a closed
+// list of [longitude, latitude] positions.
+type GeoPolygon [][][2]float64
+
+// ParseGeoJSONPolygons reads a GeoJSON Polygon or MultiPolygon geometry and checks that
+// every ring is closed, has at least three corners and uses valid coordinates.
+func ParseGeoJSONPolygons(raw []byte) ([]GeoPolygon, error) {
+	var geometry struct {
+		Type        string          `json:"type"`
+		Coordinates json.RawMessage `json:"coordinates"`
+	}
+	if err := json.Unmarshal(raw, &geometry); err != nil {
+		return nil, fmt.Errorf("invalid GeoJSON: %w", err)
+	}
+
+	var polygons []GeoPolygon
+	switch geometry.Type {
+	case "Polygon":
+		var polygon GeoPolygon
+		if err := json.Unmarshal(geometry.Coordinates, &polygon); err != nil {
+			return nil, fmt.Errorf("invalid Polygon coordinates: %w", err)
+		}
+		polygons = []GeoPolygon{polygon}
+	case "MultiPolygon":
+		if err := json.Unmarshal(geometry.Coordinates, &polygons); err != nil {
+			return nil, fmt.Errorf("invalid MultiPolygon coordinates: %w", err)
+		}
+	default:
+		return nil, fmt.Errorf("GeoJSON type must be Polygon or MultiPolygon, got %q", geometry.Type)
+	}
+
+	if len(polygons) == 0 {
+		return nil, errors.New("GeoJSON has no polygons")
+	}
+	for _, polygon := range polygons {
+		if len(polygon) == 0 {
+			return nil, errors.New("polygon has no rings")
+		}
+		for _, ring := range polygon {
+			if len(ring) < 4 {
+				return nil, errors.New("polygon ring needs at least four positions")
+			}
+			if ring[0] != ring[len(ring)-1] {
+				return nil, errors.New("polygon ring must end at its first position")
+			}
+			for _, pos := range ring {
+				if pos[0] < -180 || pos[0] > 180 || pos[1] < -90 || pos[1] > 90 {
+					return nil, fmt.Errorf("position [%g, %g] is not a valid longitude and latitude", pos[0], pos[1])
+				}
+			}
+		}
+	}
+	return polygons, nil
+}
+
+// PolygonsContain reports whether the point lies inside any of the polygons and outside
+// that polygon's holes.
+func PolygonsContain(polygons []GeoPolygon, lat, lng float64) bool {
+	for _, polygon := range polygons {
+		if !ringContains(polygon[0], lat, lng) {
+			continue
+		}
+		inHole := false
+		for _, hole := range polygon[1:] {
+			if ringContains(hole, lat, lng) {
+				inHole = true
+				break
+			}
+		}
+		if !inHole {
+			return true
+		}
+	}
+	return false
+}
+
+// ringContains casts a ray from the point and counts the ring edges it crosses.
+func ringContains(ring [][2]float64, lat, lng float64) bool {
+	inside := false
+	for i, j := 0, len(ring)-1; i < len(ring); j, i = i, i+1 {
+		xi, yi := ring[i][0], ring[i][1]
+		xj, yj := ring[j][0], ring[j][1]
+		if (yi > lat) != (yj > lat) && lng < (xj-xi)*(lat-yi)/(yj-yi)+xi {
+			inside = !inside
+		}
+	}
+	return inside
+}
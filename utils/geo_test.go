@@ -0,0 +1,107 @@
+package utils
+
+import "testing"
+
+func TestPolygonsContain(t *testing.T) {
+	// A 10x10 square with a 2x2 hole in the middle, and a separate 1x1 square to the east.
+	polygons, err := ParseGeoJSONPolygons([]byte(`{
+		"type": "MultiPolygon",
+		"coordinates": [
+			[
+				[[120, 10], [130, 10], [130, 20], [120, 20], [120, 10]],
+				[[124, 14], [126, 14], [126, 16], [124, 16], [124, 14]]
+			],
+			[
+				[[140, 10], [141, 10], [141, 11], [140, 11], [140, 10]]
+			]
+		]
+	}`))
+	if err != nil {
+		t.Fatalf("ParseGeoJSONPolygons: %v", err)
+	}
+
+	tests := []struct {
+		name string
+		lat  float64
+		lng  float64
+		want bool
+	}{
+		{name: "inside the outer ring", lat: 12, lng: 122, want: true},
+		{name: "inside the hole", lat: 15, lng: 125, want: false},
+		{name: "between the hole and the outer ring", lat: 15, lng: 127, want: true},
+		{name: "inside the second polygon", lat: 10.5, lng: 140.5, want: true},
+		{name: "between the polygons", lat: 10.5, lng: 135, want: false},
+		{name: "north of the outer ring", lat: 21, lng: 125, want: false},
+		{name: "west of the outer ring", lat: 15, lng: 119.9, want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := PolygonsContain(polygons, tt.lat, tt.lng); got != tt.want {
+				t.Errorf("PolygonsContain(%v, %v) = %v, want %v", tt.lat, tt.lng, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseGeoJSONPolygons(t *testing.T) {
+	tests := []struct {
+		name         string
+		raw          string
+		wantPolygons int
+		wantErr      bool
+	}{
+		{
+			name:         "polygon",
+			raw:          `{"type": "Polygon", "coordinates": [[[120, 10], [121, 10], [121, 11], [120, 10]]]}`,
+			wantPolygons: 1,
+		},
+		{
+			name:         "multi polygon",
+			raw:          `{"type": "MultiPolygon", "coordinates": [[[[120, 10], [121, 10], [121, 11], [120, 10]]], [[[122, 10], [123, 10], [123, 11], [122, 10]]]]}`,
+			wantPolygons: 2,
+		},
+		{
+			name:    "unsupported type",
+			raw:     `{"type": "Point", "coordinates": [120, 10]}`,
+			wantErr: true,
+		},
+		{
+			name:    "ring not closed",
+			raw:     `{"type": "Polygon", "coordinates": [[[120, 10], [121, 10], [121, 11], [120, 11]]]}`,
+			wantErr: true,
+		},
+		{
+			name:    "ring too short",
+			raw:     `{"type": "Polygon", "coordinates": [[[120, 10], [121, 10], [120, 10]]]}`,
+			wantErr: true,
+		},
+		{
+			name:    "latitude out of range",
+			raw:     `{"type": "Polygon", "coordinates": [[[120, 10], [121, 95], [121, 11], [120, 10]]]}`,
+			wantErr: true,
+		},
+		{
+			name:    "no polygons",
+			raw:     `{"type": "MultiPolygon", "coordinates": []}`,
+			wantErr: true,
+		},
+		{
+			name:    "not JSON",
+			raw:     `polygon`,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			polygons, err := ParseGeoJSONPolygons([]byte(tt.raw))
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("err = %v, wantErr %v", err, tt.wantErr)
+			}
+			if len(polygons) != tt.wantPolygons {
+				t.Errorf("got %d polygons, want %d", len(polygons), tt.wantPolygons)
+			}
+		})
+	}
+}
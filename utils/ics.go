@@ -0,0 +1,92 @@
+package utils
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+const icsTimeLayout = "20060102T150405Z"
+
+// ICSEvent is one VEVENT. UID must stay the same across edits of the event and Sequence
+// must grow, so calendar apps replace the old copy instead of adding another.
+type ICSEvent struct {
+	UID          string
+	Sequence     int64
+	Start        time.Time
+	End          time.Time
+	LastModified time.Time
+	Summary      string
+	Location     string
+	Description  string
+	Latitude     float64
+	Longitude    float64
+	Cancelled    bool
+}
+
+// RenderICS writes a VCALENDAR (RFC 5545) holding the given events.
+func RenderICS(name string, events []ICSEvent, now time.Time) []byte {
+	var b strings.Builder
+	line := func(s string) { writeICSLine(&b, s) }
+
+	line("BEGIN:VCALENDAR")
+	line("VERSION:2.0")
+	line("PRODID:-//Handworks//Bookings//EN")
+	line("CALSCALE:GREGORIAN")
+	line("METHOD:PUBLISH")
+	line("X-WR-CALNAME:" + escapeICSText(name))
+	line("X-PUBLISHED-TTL:PT1H")
+	line("REFRESH-INTERVAL;VALUE=DURATION:PT1H")
+	for _, e := range events {
+		line("BEGIN:VEVENT")
+		line("UID:" + escapeICSText(e.UID))
+		line("DTSTAMP:" + now.UTC().Format(icsTimeLayout))
+		line("DTSTART:" + e.Start.UTC().Format(icsTimeLayout))
+		line("DTEND:" + e.End.UTC().Format(icsTimeLayout))
+		line(fmt.Sprintf("SEQUENCE:%d", max(e.Sequence, 0)))
+		if !e.LastModified.IsZero() {
+			line("LAST-MODIFIED:" + e.LastModified.UTC().Format(icsTimeLayout))
+		}
+		line("SUMMARY:" + escapeICSText(e.Summary))
+		if e.Location != "" {
+			line("LOCATION:" + escapeICSText(e.Location))
+		}
+		if e.Latitude != 0 || e.Longitude != 0 {
+			line(fmt.Sprintf("GEO:%.6f;%.6f", e.Latitude, e.Longitude))
+		}
+		if e.Description != "" {
+			line("DESCRIPTION:" + escapeICSText(e.Description))
+		}
+		if e.Cancelled {
+			line("STATUS:CANCELLED")
+		} else {
+			line("STATUS:CONFIRMED")
+		}
+		line("END:VEVENT")
+	}
+	line("END:VCALENDAR")
+	return []byte(b.String())
+}
+
+var icsTextEscaper = strings.NewReplacer(`\`, `\\`, ";", `\;`, ",", `\,`, "\r\n", `\n`, "\n", `\n`, "\r", `\n`)
+
+func escapeICSText(s string) string {
+	return icsTextEscaper.Replace(s)
+}
+
+// writeICSLine folds content lines longer than 75 octets without splitting a UTF-8
+// character, and ends each with CRLF.
+func writeICSLine(b *strings.Builder, s string) {
+	const limit = 75
+	width := 0
+	for _, r := range s {
+		size := len(string(r))
+		if width+size > limit {
+			b.WriteString("\r\n ")
+			width = 1
+		}
+		b.WriteRune(r)
+		width += size
+	}
+	b.WriteString("\r\n")
+}
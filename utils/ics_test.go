@@ -0,0 +1,158 @@
+package utils
+
+import (
+	"strings"
+	"testing"
+	"time"
+	"unicode/utf8"
+)
+
+func TestRenderICS(t *testing.T) {
+	now := time.Date(2026, 3, 1, 9, 30, 0, 0, time.UTC)
+	manila := time.FixedZone("PHT", 8*60*60)
+	start := time.Date(2026, 3, 4, 9, 0, 0, 0, manila)
+
+	booking := ICSEvent{
+		UID:          "b-1@handworks",
+		Sequence:     3600,
+		Start:        start,
+		End:          start.Add(4 * time.Hour),
+		LastModified: time.Date(2026, 3, 1, 8, 0, 0, 0, time.UTC),
+		Summary:      "Handworks General cleaning",
+		Location:     "12 Rizal St., Makati",
+		Description:  "Booking ID: b-1\nService: General cleaning",
+		Latitude:     14.5547,
+		Longitude:    121.0244,
+	}
+
+	tests := []struct {
+		name       string
+		calName    string
+		events     []ICSEvent
+		wantLines  []string
+		wantAbsent []string
+	}{
+		{
+			name:    "empty calendar",
+			calName: "Handworks",
+			wantLines: []string{
+				"BEGIN:VCALENDAR",
+				"VERSION:2.0",
+				"X-WR-CALNAME:Handworks",
+				"END:VCALENDAR",
+			},
+			wantAbsent: []string{"BEGIN:VEVENT"},
+		},
+		{
+			name:    "booking times are written in UTC",
+			calName: "Handworks - Ana",
+			events:  []ICSEvent{booking},
+			wantLines: []string{
+				"BEGIN:VEVENT",
+				"UID:b-1@handworks",
+				"DTSTAMP:20260301T093000Z",
+				"DTSTART:20260304T010000Z",
+				"DTEND:20260304T050000Z",
+				"SEQUENCE:3600",
+				"LAST-MODIFIED:20260301T080000Z",
+				"GEO:14.554700;121.024400",
+				"STATUS:CONFIRMED",
+				"END:VEVENT",
+			},
+			wantAbsent: []string{"STATUS:CANCELLED"},
+		},
+		{
+			name:    "text values are escaped",
+			calName: "Ana; Ben, and Co",
+			events:  []ICSEvent{booking},
+			wantLines: []string{
+				`X-WR-CALNAME:Ana\; Ben\, and Co`,
+				`LOCATION:12 Rizal St.\, Makati`,
+				`DESCRIPTION:Booking ID: b-1\nService: General cleaning`,
+			},
+		},
+		{
+			name:    "cancelled booking without coordinates or details",
+			calName: "Handworks",
+			events: []ICSEvent{{
+				UID:       "b-2@handworks",
+				Sequence:  -1,
+				Start:     start,
+				End:       start.Add(time.Hour),
+				Summary:   "Handworks cleaning",
+				Cancelled: true,
+			}},
+			wantLines: []string{
+				"UID:b-2@handworks",
+				"SEQUENCE:0",
+				"STATUS:CANCELLED",
+			},
+			wantAbsent: []string{"GEO:", "LOCATION:", "DESCRIPTION:", "LAST-MODIFIED:", "STATUS:CONFIRMED"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			out := string(RenderICS(tt.calName, tt.events, now))
+			if !strings.HasSuffix(out, "\r\n") {
+				t.Fatalf("output does not end with CRLF: %q", out)
+			}
+
+			lines := strings.Split(strings.TrimSuffix(strings.ReplaceAll(out, "\r\n ", ""), "\r\n"), "\r\n")
+			for _, want := range tt.wantLines {
+				if !containsLine(lines, want) {
+					t.Errorf("missing line %q in:\n%s", want, out)
+				}
+			}
+			for _, absent := range tt.wantAbsent {
+				for _, l := range lines {
+					if strings.HasPrefix(l, absent) {
+						t.Errorf("unexpected line %q", l)
+					}
+				}
+			}
+			if lines[0] != "BEGIN:VCALENDAR" || lines[len(lines)-1] != "END:VCALENDAR" {
+				t.Errorf("calendar is not wrapped in BEGIN/END:VCALENDAR")
+			}
+		})
+	}
+}
+
+func TestRenderICSFoldsLongLines(t *testing.T) {
+	tests := []struct {
+		name        string
+		description string
+	}{
+		{name: "ascii", description: strings.Repeat("Bring the ladder for the high windows. ", 8)},
+		{name: "multi-byte characters are not split", description: strings.Repeat("Ñ", 100)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			out := string(RenderICS("Handworks", []ICSEvent{{UID: "b-1", Summary: "x", Description: tt.description}}, time.Now()))
+
+			for _, l := range strings.Split(strings.TrimSuffix(out, "\r\n"), "\r\n") {
+				if len(l) > 75 {
+					t.Errorf("line is %d octets: %q", len(l), l)
+				}
+				if !utf8.ValidString(l) {
+					t.Errorf("line splits a UTF-8 character: %q", l)
+				}
+			}
+
+			unfolded := strings.ReplaceAll(out, "\r\n ", "")
+			if !strings.Contains(unfolded, "\r\nDESCRIPTION:"+tt.description+"\r\n") {
+				t.Errorf("unfolded output lost the description:\n%s", out)
+			}
+		})
+	}
+}
+
+func containsLine(lines []string, want string) bool {
+	for _, l := range lines {
+		if l == want {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,303 @@
+package utils
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"image/png"
+	"net/http"
+)
+
+const (
+	maxImagePixels   = 50_000_000
+	imageJPEGQuality = 90
+	thumbJPEGQuality = 80
+)
+
+var (
+	ErrUnsupportedImage = errors.New("only JPEG and PNG images are supported")
+	ErrInvalidImage     = errors.New("file is not a valid image")
+)
+
+// ProcessedImage is an upload with its location and other identifying metadata removed,
+// plus a thumbnail in the same format.
+type ProcessedImage struct {
+	Data        []byte
+	Thumbnail   []byte
+	ContentType string
+	Width       int
+	Height      int
+}
+
+// ProcessImage sniffs the content type rather than trusting the client, strips EXIF, XMP
+// and text metadata, and builds a thumbnail no larger than thumbSize on either side. JPEGs
+// with an EXIF orientation are rotated upright first, since the tag saying how to display
+// them is removed.
+func ProcessImage(data []byte, thumbSize int) (*ProcessedImage, error) {
+	contentType := http.DetectContentType(data)
+
+	var (
+		clean       []byte
+		orientation = 1
+		err         error
+	)
+	switch contentType {
+	case "image/jpeg":
+		clean, orientation, err = stripJPEGMetadata(data)
+	case "image/png":
+		clean, err = stripPNGMetadata(data)
+	default:
+		return nil, fmt.Errorf("%w: got %s", ErrUnsupportedImage, contentType)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	cfg, _, err := image.DecodeConfig(bytes.NewReader(clean))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidImage, err)
+	}
+	if cfg.Width*cfg.Height > maxImagePixels {
+		return nil, fmt.Errorf("%w: image is larger than %d megapixels", ErrInvalidImage, maxImagePixels/1_000_000)
+	}
+
+	img, _, err := image.Decode(bytes.NewReader(clean))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidImage, err)
+	}
+
+	if orientation > 1 {
+		img = orientImage(img, orientation)
+		if clean, err = encodeImage(img, contentType, imageJPEGQuality); err != nil {
+			return nil, err
+		}
+	}
+
+	thumb, err := encodeImage(thumbnail(img, thumbSize), contentType, thumbJPEGQuality)
+	if err != nil {
+		return nil, err
+	}
+
+	b := img.Bounds()
+	return &ProcessedImage{
+		Data:        clean,
+		Thumbnail:   thumb,
+		ContentType: contentType,
+		Width:       b.Dx(),
+		Height:      b.Dy(),
+	}, nil
+}
+
+func encodeImage(img image.Image, contentType string, quality int) ([]byte, error) {
+	var buf bytes.Buffer
+	var err error
+	if contentType == "image/png" {
+		err = png.Encode(&buf, img)
+	} else {
+		err = jpeg.Encode(&buf, img, &jpeg.Options{Quality: quality})
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode image: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+// stripJPEGMetadata drops APP1 (EXIF, XMP) and APP13 (IPTC) segments and returns the EXIF
+// orientation they carried. Image data is copied as is, so nothing is re-compressed.
+func stripJPEGMetadata(data []byte) ([]byte, int, error) {
+	if len(data) < 4 || data[0] != 0xFF || data[1] != 0xD8 {
+		return nil, 0, ErrInvalidImage
+	}
+
+	out := make([]byte, 0, len(data))
+	out = append(out, 0xFF, 0xD8)
+	orientation := 1
+
+	for i := 2; ; {
+		if i+1 >= len(data) || data[i] != 0xFF {
+			return nil, 0, fmt.Errorf("%w: malformed JPEG", ErrInvalidImage)
+		}
+		for i+1 < len(data) && data[i+1] == 0xFF {
+			i++
+		}
+		if i+1 >= len(data) {
+			return nil, 0, fmt.Errorf("%w: malformed JPEG", ErrInvalidImage)
+		}
+
+		marker := data[i+1]
+		switch {
+		case marker == 0xDA, marker == 0xD9:
+			// Start of scan: the rest is compressed image data.
+			return append(out, data[i:]...), orientation, nil
+		case marker == 0x01, marker >= 0xD0 && marker <= 0xD7:
+			out = append(out, data[i:i+2]...)
+			i += 2
+			continue
+		}
+
+		if i+4 > len(data) {
+			return nil, 0, fmt.Errorf("%w: malformed JPEG", ErrInvalidImage)
+		}
+		end := i + 2 + int(binary.BigEndian.Uint16(data[i+2:]))
+		if end > len(data) || end < i+4 {
+			return nil, 0, fmt.Errorf("%w: malformed JPEG", ErrInvalidImage)
+		}
+
+		switch marker {
+		case 0xE1:
+			if o := exifOrientation(data[i+4 : end]); o > 1 {
+				orientation = o
+			}
+		case 0xED:
+		default:
+			out = append(out, data[i:end]...)
+		}
+		i = end
+	}
+}
+
+func exifOrientation(payload []byte) int {
+	const header = "Exif\x00\x00"
+	if !bytes.HasPrefix(payload, []byte(header)) {
+		return 1
+	}
+	tiff := payload[len(header):]
+	if len(tiff) < 8 {
+		return 1
+	}
+
+	var order binary.ByteOrder
+	switch string(tiff[:2]) {
+	case "II":
+		order = binary.LittleEndian
+	case "MM":
+		order = binary.BigEndian
+	default:
+		return 1
+	}
+
+	ifd := int(order.Uint32(tiff[4:]))
+	if ifd < 8 || ifd+2 > len(tiff) {
+		return 1
+	}
+	entries := int(order.Uint16(tiff[ifd:]))
+	for n := 0; n < entries; n++ {
+		entry := ifd + 2 + n*12
+		if entry+12 > len(tiff) {
+			break
+		}
+		if order.Uint16(tiff[entry:]) == 0x0112 {
+			if v := int(order.Uint16(tiff[entry+8:])); v >= 1 && v <= 8 {
+				return v
+			}
+			break
+		}
+	}
+	return 1
+}
+
+// stripPNGMetadata drops the eXIf chunk and text chunks, which is where XMP is kept.
+func stripPNGMetadata(data []byte) ([]byte, error) {
+	const signature = "\x89PNG\r\n\x1a\n"
+	if !bytes.HasPrefix(data, []byte(signature)) {
+		return nil, ErrInvalidImage
+	}
+
+	out := make([]byte, 0, len(data))
+	out = append(out, signature...)
+	for i := len(signature); i < len(data); {
+		if i+8 > len(data) {
+			return nil, fmt.Errorf("%w: malformed PNG", ErrInvalidImage)
+		}
+		end := i + 12 + int(binary.BigEndian.Uint32(data[i:]))
+		if end > len(data) || end < i+12 {
+			return nil, fmt.Errorf("%w: malformed PNG", ErrInvalidImage)
+		}
+
+		switch string(data[i+4 : i+8]) {
+		case "eXIf", "tEXt", "zTXt", "iTXt":
+		default:
+			out = append(out, data[i:end]...)
+		}
+		i = end
+	}
+	return out, nil
+}
+
+// orientImage applies an EXIF orientation (2-8) so the image displays upright without it.
+func orientImage(src image.Image, orientation int) image.Image {
+	b := src.Bounds()
+	w, h := b.Dx(), b.Dy()
+	dw, dh := w, h
+	if orientation >= 5 {
+		dw, dh = h, w
+	}
+
+	dst := image.NewRGBA(image.Rect(0, 0, dw, dh))
+	for y := 0; y < dh; y++ {
+		for x := 0; x < dw; x++ {
+			var sx, sy int
+			switch orientation {
+			case 2:
+				sx, sy = w-1-x, y
+			case 3:
+				sx, sy = w-1-x, h-1-y
+			case 4:
+				sx, sy = x, h-1-y
+			case 5:
+				sx, sy = y, x
+			case 6:
+				sx, sy = y, h-1-x
+			case 7:
+				sx, sy = w-1-y, h-1-x
+			case 8:
+				sx, sy = w-1-y, x
+			default:
+				sx, sy = x, y
+			}
+			dst.Set(x, y, src.At(b.Min.X+sx, b.Min.Y+sy))
+		}
+	}
+	return dst
+}
+
+// thumbnail shrinks img so its longer side is at most size, averaging the source pixels
+// that fall into each thumbnail pixel. Smaller images are returned unchanged.
+func thumbnail(img image.Image, size int) image.Image {
+	b := img.Bounds()
+	w, h := b.Dx(), b.Dy()
+	if size <= 0 || (w <= size && h <= size) {
+		return img
+	}
+
+	tw, th := size, h*size/w
+	if h > w {
+		tw, th = w*size/h, size
+	}
+	tw, th = max(tw, 1), max(th, 1)
+
+	dst := image.NewRGBA(image.Rect(0, 0, tw, th))
+	for ty := 0; ty < th; ty++ {
+		y0, y1 := ty*h/th, max((ty+1)*h/th, ty*h/th+1)
+		for tx := 0; tx < tw; tx++ {
+			x0, x1 := tx*w/tw, max((tx+1)*w/tw, tx*w/tw+1)
+
+			var r, g, bl, a, n uint64
+			for y := y0; y < y1; y++ {
+				for x := x0; x < x1; x++ {
+					pr, pg, pb, pa := img.At(b.Min.X+x, b.Min.Y+y).RGBA()
+					r, g, bl, a = r+uint64(pr), g+uint64(pg), bl+uint64(pb), a+uint64(pa)
+					n++
+				}
+			}
+			dst.Set(tx, ty, color.RGBA64{
+				R: uint16(r / n), G: uint16(g / n), B: uint16(bl / n), A: uint16(a / n),
+			})
+		}
+	}
+	return dst
+}
@@ -0,0 +1,223 @@
+package utils
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"hash/crc32"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"image/png"
+	"testing"
+)
+
+const secretMetadata = "GPS 14.5995N 120.9842E"
+
+func testImage(w, h int) image.Image {
+	img := image.NewRGBA(image.Rect(0, 0, w, h))
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			img.Set(x, y, color.RGBA{R: uint8(40 * x), G: uint8(40 * y), B: 128, A: 255})
+		}
+	}
+	return img
+}
+
+func encodeTestJPEG(t *testing.T, w, h int) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	if err := jpeg.Encode(&buf, testImage(w, h), nil); err != nil {
+		t.Fatalf("encode jpeg: %v", err)
+	}
+	return buf.Bytes()
+}
+
+func encodeTestPNG(t *testing.T, w, h int) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, testImage(w, h)); err != nil {
+		t.Fatalf("encode png: %v", err)
+	}
+	return buf.Bytes()
+}
+
+// exifPayload is a big-endian EXIF block with one IFD entry for the orientation, followed
+// by text standing in for the location tags a phone would add.
+func exifPayload(orientation uint16) []byte {
+	var b bytes.Buffer
+	b.WriteString("Exif\x00\x00MM\x00\x2a")
+	binary.Write(&b, binary.BigEndian, uint32(8))
+	binary.Write(&b, binary.BigEndian, uint16(1))
+	binary.Write(&b, binary.BigEndian, []uint16{0x0112, 3})
+	binary.Write(&b, binary.BigEndian, uint32(1))
+	binary.Write(&b, binary.BigEndian, []uint16{orientation, 0})
+	binary.Write(&b, binary.BigEndian, uint32(0))
+	b.WriteString(secretMetadata)
+	return b.Bytes()
+}
+
+// withJPEGSegments inserts marker segments right after the JPEG's start-of-image marker.
+func withJPEGSegments(data []byte, segments ...[]byte) []byte {
+	out := append([]byte{}, data[:2]...)
+	for _, s := range segments {
+		out = append(out, s...)
+	}
+	return append(out, data[2:]...)
+}
+
+func jpegSegment(marker byte, payload []byte) []byte {
+	seg := []byte{0xFF, marker, 0, 0}
+	binary.BigEndian.PutUint16(seg[2:], uint16(len(payload)+2))
+	return append(seg, payload...)
+}
+
+// withPNGChunks inserts chunks right after the PNG's IHDR chunk.
+func withPNGChunks(data []byte, chunks ...[]byte) []byte {
+	ihdrEnd := 8 + 12 + int(binary.BigEndian.Uint32(data[8:]))
+	out := append([]byte{}, data[:ihdrEnd]...)
+	for _, c := range chunks {
+		out = append(out, c...)
+	}
+	return append(out, data[ihdrEnd:]...)
+}
+
+func pngChunk(kind string, payload []byte) []byte {
+	chunk := make([]byte, 4, 12+len(payload))
+	binary.BigEndian.PutUint32(chunk, uint32(len(payload)))
+	chunk = append(chunk, kind...)
+	chunk = append(chunk, payload...)
+	return binary.BigEndian.AppendUint32(chunk, crc32.ChecksumIEEE(chunk[4:]))
+}
+
+func TestProcessImage(t *testing.T) {
+	tests := []struct {
+		name       string
+		data       func(t *testing.T) []byte
+		wantType   string
+		wantWidth  int
+		wantHeight int
+		wantThumbW int
+		wantThumbH int
+		wantErr    error
+	}{
+		{
+			name: "jpeg exif and iptc are removed",
+			data: func(t *testing.T) []byte {
+				return withJPEGSegments(encodeTestJPEG(t, 8, 4),
+					jpegSegment(0xE1, exifPayload(1)),
+					jpegSegment(0xED, []byte("Photoshop 3.0\x00"+secretMetadata)),
+				)
+			},
+			wantType:   "image/jpeg",
+			wantWidth:  8,
+			wantHeight: 4,
+			wantThumbW: 4,
+			wantThumbH: 2,
+		},
+		{
+			name: "jpeg xmp is removed",
+			data: func(t *testing.T) []byte {
+				return withJPEGSegments(encodeTestJPEG(t, 8, 4),
+					jpegSegment(0xE1, []byte("http://ns.adobe.com/xap/1.0/\x00"+secretMetadata)),
+				)
+			},
+			wantType:   "image/jpeg",
+			wantWidth:  8,
+			wantHeight: 4,
+			wantThumbW: 4,
+			wantThumbH: 2,
+		},
+		{
+			name: "jpeg rotated by its exif orientation is turned upright",
+			data: func(t *testing.T) []byte {
+				return withJPEGSegments(encodeTestJPEG(t, 8, 4), jpegSegment(0xE1, exifPayload(6)))
+			},
+			wantType:   "image/jpeg",
+			wantWidth:  4,
+			wantHeight: 8,
+			wantThumbW: 2,
+			wantThumbH: 4,
+		},
+		{
+			name: "png text and exif chunks are removed",
+			data: func(t *testing.T) []byte {
+				return withPNGChunks(encodeTestPNG(t, 8, 4),
+					pngChunk("tEXt", []byte("Comment\x00"+secretMetadata)),
+					pngChunk("iTXt", []byte("XML:com.adobe.xmp\x00\x00\x00\x00\x00"+secretMetadata)),
+					pngChunk("eXIf", exifPayload(1)[len("Exif\x00\x00"):]),
+				)
+			},
+			wantType:   "image/png",
+			wantWidth:  8,
+			wantHeight: 4,
+			wantThumbW: 4,
+			wantThumbH: 2,
+		},
+		{
+			name:       "small images keep their size as the thumbnail",
+			data:       func(t *testing.T) []byte { return encodeTestPNG(t, 3, 2) },
+			wantType:   "image/png",
+			wantWidth:  3,
+			wantHeight: 2,
+			wantThumbW: 3,
+			wantThumbH: 2,
+		},
+		{
+			name:    "other formats are rejected",
+			data:    func(t *testing.T) []byte { return []byte("GIF89a" + secretMetadata) },
+			wantErr: ErrUnsupportedImage,
+		},
+		{
+			name: "truncated jpeg is rejected",
+			data: func(t *testing.T) []byte {
+				return withJPEGSegments([]byte{0xFF, 0xD8}, []byte{0xFF, 0xE1, 0x40, 0x00})
+			},
+			wantErr: ErrInvalidImage,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			processed, err := ProcessImage(tt.data(t), 4)
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("error = %v, want %v", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if processed.ContentType != tt.wantType {
+				t.Errorf("content type = %s, want %s", processed.ContentType, tt.wantType)
+			}
+			if processed.Width != tt.wantWidth || processed.Height != tt.wantHeight {
+				t.Errorf("size = %dx%d, want %dx%d", processed.Width, processed.Height, tt.wantWidth, tt.wantHeight)
+			}
+			for _, data := range [][]byte{processed.Data, processed.Thumbnail} {
+				for _, leak := range []string{secretMetadata, "Exif", "eXIf", "tEXt", "iTXt", "Photoshop"} {
+					if bytes.Contains(data, []byte(leak)) {
+						t.Errorf("output still contains %q", leak)
+					}
+				}
+			}
+
+			img, _, err := image.Decode(bytes.NewReader(processed.Data))
+			if err != nil {
+				t.Fatalf("processed image does not decode: %v", err)
+			}
+			if b := img.Bounds(); b.Dx() != tt.wantWidth || b.Dy() != tt.wantHeight {
+				t.Errorf("decoded size = %dx%d, want %dx%d", b.Dx(), b.Dy(), tt.wantWidth, tt.wantHeight)
+			}
+			thumb, _, err := image.Decode(bytes.NewReader(processed.Thumbnail))
+			if err != nil {
+				t.Fatalf("thumbnail does not decode: %v", err)
+			}
+			if b := thumb.Bounds(); b.Dx() != tt.wantThumbW || b.Dy() != tt.wantThumbH {
+				t.Errorf("thumbnail size = %dx%d, want %dx%d", b.Dx(), b.Dy(), tt.wantThumbW, tt.wantThumbH)
+			}
+		})
+	}
+}